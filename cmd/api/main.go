@@ -2,16 +2,35 @@
 package main
 
 import (
+	"errors"
 	"log"
+	nethttp "net/http"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/joho/godotenv"
 
+	"github.com/G20-00/task-management-service-go/config"
+	"github.com/G20-00/task-management-service-go/internal/delivery/grpc"
 	"github.com/G20-00/task-management-service-go/internal/delivery/http"
+	"github.com/G20-00/task-management-service-go/internal/infrastructure/broker"
 	"github.com/G20-00/task-management-service-go/internal/infrastructure/db"
 	"github.com/G20-00/task-management-service-go/internal/infrastructure/repository"
+	"github.com/G20-00/task-management-service-go/internal/usecase/archive"
+	"github.com/G20-00/task-management-service-go/internal/usecase/changefeed"
+	"github.com/G20-00/task-management-service-go/internal/usecase/checklist"
+	"github.com/G20-00/task-management-service-go/internal/usecase/history"
+	"github.com/G20-00/task-management-service-go/internal/usecase/idempotency"
+	"github.com/G20-00/task-management-service-go/internal/usecase/outbox"
+	"github.com/G20-00/task-management-service-go/internal/usecase/report"
+	"github.com/G20-00/task-management-service-go/internal/usecase/search"
+	"github.com/G20-00/task-management-service-go/internal/usecase/sprint"
+	"github.com/G20-00/task-management-service-go/internal/usecase/stream"
 	"github.com/G20-00/task-management-service-go/internal/usecase/task"
 	"github.com/G20-00/task-management-service-go/internal/usecase/tasklist"
+	"github.com/G20-00/task-management-service-go/internal/usecase/template"
+	"github.com/G20-00/task-management-service-go/internal/usecase/trash"
+	"github.com/G20-00/task-management-service-go/internal/usecase/view"
+	"github.com/G20-00/task-management-service-go/internal/usecase/webhook"
 )
 
 func main() {
@@ -19,6 +38,8 @@ func main() {
 		log.Println("No .env file found")
 	}
 
+	cfg := config.Load()
+
 	database, err := db.NewPostgresDB()
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -29,21 +50,158 @@ func main() {
 		}
 	}()
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: http.ErrorHandler})
+	http.RegisterRequestIDMiddleware(app)
 
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{"status": "ok"})
 	})
 
+	taskListRepo := repository.NewPostgresTaskListRepository(database)
+
+	webhookRepo := repository.NewPostgresWebhookRepository(database)
+	webhookService := webhook.NewService(webhookRepo, taskListRepo, webhook.NewHTTPClient(cfg.WebhookTimeout))
+	webhookHandler := http.NewWebhookHandler(webhookService)
+	stopWebhookDeliveries := webhookService.StartDeliveryWorker(cfg.WebhookDeliveryInterval)
+	defer stopWebhookDeliveries()
+
+	// Task and list changes record their events in the outbox; the relay hands them to the
+	// webhooks, to the broker and announces them on a PostgreSQL channel. The local broker
+	// stands in for a NATS connection, which satisfies outbox.Broker as is.
+	outboxRepo := repository.NewPostgresOutboxRepository(database)
+	outboxService := outbox.NewService(outboxRepo, cfg.OutboxRetention)
+	outboxService.AddSink("webhooks", webhookService)
+	outboxService.AddSink("broker", outbox.NewBrokerSink(broker.NewLocalBroker(), cfg.EventSubjectPrefix))
+	outboxService.AddSink("notify", repository.NewPostgresEventNotifier(database, stream.NotifyChannel))
+	stopOutboxRelay := outboxService.StartRelay(cfg.OutboxRelayInterval)
+	defer stopOutboxRelay()
+	stopOutboxPurge := outboxService.StartPurgeJob(cfg.OutboxPurgeInterval)
+	defer stopOutboxPurge()
+
+	// Every instance listens on the channel and streams the announced events to its clients,
+	// through the in-process bus.
+	eventBus := outbox.NewBus()
+	streamService := stream.NewService(outboxRepo, taskListRepo, eventBus)
+	streamHandler := http.NewStreamHandler(streamService, cfg.StreamHeartbeat)
+	stopListening, err := db.Listen(stream.NotifyChannel, streamService.Notify)
+	if err != nil {
+		log.Fatalf("Failed to listen for events: %v", err)
+	}
+	defer stopListening()
+
 	taskRepo := repository.NewPostgresTaskRepository(database)
 	taskService := task.NewService(taskRepo)
+
+	historyRepo := repository.NewPostgresHistoryRepository(database)
+	historyService := history.NewService(historyRepo, taskService)
+	historyHandler := http.NewHistoryHandler(historyService)
+
 	taskHandler := http.NewTaskHandler(taskService)
 
-	taskListRepo := repository.NewPostgresTaskListRepository(database)
 	taskListService := tasklist.NewService(taskListRepo)
-	taskListHandler := http.NewTaskListHandler(taskListService, taskService)
+	taskListHandler := http.NewTaskListHandler(taskListService)
+
+	trashRepo := repository.NewPostgresTrashRepository(database)
+	trashService := trash.NewService(trashRepo, cfg.TrashRetention)
+	trashHandler := http.NewTrashHandler(trashService)
+	stopTrashRetention := trashService.StartRetentionJob(cfg.TrashPurgeInterval)
+	defer stopTrashRetention()
+
+	archiveRepo := repository.NewPostgresArchiveRepository(database)
+	archiveService := archive.NewService(archiveRepo, cfg.AutoArchiveAfter)
+	archiveHandler := http.NewArchiveHandler(archiveService)
+	stopAutoArchive := archiveService.StartAutoArchiveJob(cfg.AutoArchiveInterval)
+	defer stopAutoArchive()
+
+	templateRepo := repository.NewPostgresTemplateRepository(database)
+	templateService := template.NewService(templateRepo, taskListRepo, taskRepo)
+	templateHandler := http.NewTemplateHandler(templateService)
+
+	checklistRepo := repository.NewPostgresChecklistRepository(database)
+	checklistService := checklist.NewService(checklistRepo, taskRepo)
+	checklistHandler := http.NewChecklistHandler(checklistService)
+
+	sprintRepo := repository.NewPostgresSprintRepository(database)
+	sprintService := sprint.NewService(sprintRepo)
+	sprintHandler := http.NewSprintHandler(sprintService)
+
+	reportRepo := repository.NewPostgresReportRepository(database)
+	reportService := report.NewService(reportRepo, taskListRepo, sprintRepo)
+	reportHandler := http.NewReportHandler(reportService)
+	stopSnapshots := reportService.StartSnapshotJob(cfg.SnapshotInterval)
+	defer stopSnapshots()
 
-	http.RegisterRoutes(app, taskHandler, taskListHandler)
+	searchRepo := repository.NewPostgresSearchRepository(database)
+	searchService := search.NewService(searchRepo)
+	if err := searchService.ConfigureLanguage(cfg.SearchLanguage); err != nil {
+		log.Fatalf("Failed to configure search language %q: %v", cfg.SearchLanguage, err)
+	}
+	searchHandler := http.NewSearchHandler(searchService)
+
+	viewRepo := repository.NewPostgresViewRepository(database)
+	viewService := view.NewService(viewRepo, taskListRepo, taskService)
+	viewHandler := http.NewViewHandler(viewService)
+
+	syncRepo := repository.NewPostgresSyncRepository(database)
+	syncService := changefeed.NewService(syncRepo, taskService, taskListService, cfg.SyncTombstoneRetention)
+	syncHandler := http.NewSyncHandler(syncService)
+	stopSyncPurge := syncService.StartPurgeJob(cfg.SyncPurgeInterval)
+	defer stopSyncPurge()
+
+	graphqlHandler := http.NewGraphQLHandler(taskService, taskListService, http.GraphQLLimits{
+		MaxDepth:      cfg.GraphQLMaxDepth,
+		MaxComplexity: cfg.GraphQLMaxComplexity,
+	})
+
+	// The gRPC API is served on its own port, with the same use cases and JWTs as the REST API.
+	grpcHandler := grpc.NewHandler(taskService, taskListService, streamService)
+	grpcServer := grpc.NewServer(grpcHandler).NewHTTPServer(":" + cfg.GRPCPort)
+	go func() {
+		if err := grpcServer.ListenAndServe(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+	}()
+	defer func() {
+		if closeErr := grpcServer.Close(); closeErr != nil {
+			log.Printf("Failed to close gRPC server: %v", closeErr)
+		}
+	}()
+
+	idempotencyRepo := repository.NewPostgresIdempotencyRepository(database)
+	idempotencyService := idempotency.NewService(idempotencyRepo, cfg.IdempotencyTTL, cfg.IdempotencyLease)
+	idempotencyMiddleware := http.NewIdempotencyMiddleware(idempotencyService)
+	stopIdempotencyPurge := idempotencyService.StartPurgeJob(cfg.IdempotencyPurgeInterval)
+	defer stopIdempotencyPurge()
+
+	openAPIDocument := http.NewOpenAPIDocument()
+	openAPIHandler, err := http.NewOpenAPIHandler(openAPIDocument)
+	if err != nil {
+		log.Fatalf("Failed to build OpenAPI document: %v", err)
+	}
+
+	// Authentication runs first, so that a request without a valid token gets a 401 before
+	// its body is validated or its Idempotency-Key is looked up.
+	http.RegisterAuthMiddleware(app)
+	http.RegisterRequestValidationMiddleware(app, http.NewRequestValidationMiddleware(openAPIDocument))
+	http.RegisterIdempotencyMiddleware(app, idempotencyMiddleware)
+	http.RegisterRoutes(app, taskHandler, taskListHandler, http.APIVersioning{
+		V1DeprecatedAt: cfg.APIV1DeprecatedAt,
+		V1Sunset:       cfg.APIV1Sunset,
+	})
+	http.RegisterTrashRoutes(app, trashHandler)
+	http.RegisterArchiveRoutes(app, archiveHandler)
+	http.RegisterHistoryRoutes(app, historyHandler)
+	http.RegisterTemplateRoutes(app, templateHandler)
+	http.RegisterChecklistRoutes(app, checklistHandler)
+	http.RegisterSprintRoutes(app, sprintHandler)
+	http.RegisterReportRoutes(app, reportHandler)
+	http.RegisterSearchRoutes(app, searchHandler)
+	http.RegisterViewRoutes(app, viewHandler)
+	http.RegisterWebhookRoutes(app, webhookHandler)
+	http.RegisterStreamRoutes(app, streamHandler)
+	http.RegisterSyncRoutes(app, syncHandler)
+	http.RegisterGraphQLRoutes(app, graphqlHandler)
+	http.RegisterOpenAPIRoutes(app, openAPIHandler)
 
 	if err := app.Listen(":8080"); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
@@ -1,2 +1,112 @@
 // Package config provides configuration management for the application.
 package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config holds the application settings loaded from environment variables.
+type Config struct {
+	// TrashRetention is how long soft deleted items stay in the trash before being purged.
+	TrashRetention time.Duration
+	// TrashPurgeInterval is how often the retention job runs.
+	TrashPurgeInterval time.Duration
+	// AutoArchiveAfter is how long a task stays completed before it is archived automatically.
+	AutoArchiveAfter time.Duration
+	// AutoArchiveInterval is how often the auto-archive job runs.
+	AutoArchiveInterval time.Duration
+	// SnapshotInterval is how often the status of every task is recorded for the daily flow charts.
+	SnapshotInterval time.Duration
+	// IdempotencyTTL is how long the response of a request made with an Idempotency-Key is kept for retries.
+	IdempotencyTTL time.Duration
+	// IdempotencyLease is how long a request in progress holds its Idempotency-Key before a
+	// retry may take it over.
+	IdempotencyLease time.Duration
+	// IdempotencyPurgeInterval is how often expired idempotency keys are deleted.
+	IdempotencyPurgeInterval time.Duration
+	// WebhookDeliveryInterval is how often pending webhook deliveries are sent.
+	WebhookDeliveryInterval time.Duration
+	// WebhookTimeout is how long a webhook receiver has to respond to a delivery.
+	WebhookTimeout time.Duration
+	// OutboxRelayInterval is how often pending outbox events are published.
+	OutboxRelayInterval time.Duration
+	// OutboxRetention is how long published outbox events are kept.
+	OutboxRetention time.Duration
+	// OutboxPurgeInterval is how often expired outbox events are deleted.
+	OutboxPurgeInterval time.Duration
+	// EventSubjectPrefix is the prefix of the broker subjects events are published on.
+	EventSubjectPrefix string
+	// StreamHeartbeat is how often an idle event stream sends a heartbeat.
+	StreamHeartbeat time.Duration
+	// SyncTombstoneRetention is how long deletions are kept in the changes feed.
+	SyncTombstoneRetention time.Duration
+	// SyncPurgeInterval is how often expired sync tombstones are deleted.
+	SyncPurgeInterval time.Duration
+	// GraphQLMaxDepth is how deeply the fields of a GraphQL operation can be nested.
+	GraphQLMaxDepth int
+	// GraphQLMaxComplexity is the highest estimated cost of a GraphQL operation.
+	GraphQLMaxComplexity int
+	// GRPCPort is the port the gRPC API listens on, next to the REST API.
+	GRPCPort string
+	// SearchLanguage is the PostgreSQL text search configuration used for full-text search.
+	SearchLanguage string
+	// APIV1DeprecatedAt is when the v1 REST routes were deprecated in favor of /api/v2.
+	APIV1DeprecatedAt time.Time
+	// APIV1Sunset is when the v1 REST routes stop being served.
+	APIV1Sunset time.Time
+}
+
+// Load reads the configuration from environment variables, falling back to defaults.
+func Load() *Config {
+	return &Config{
+		TrashRetention:           time.Duration(getEnvInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour,
+		TrashPurgeInterval:       time.Duration(getEnvInt("TRASH_PURGE_INTERVAL_MINUTES", 60)) * time.Minute,
+		AutoArchiveAfter:         time.Duration(getEnvInt("AUTO_ARCHIVE_DAYS", 14)) * 24 * time.Hour,
+		AutoArchiveInterval:      time.Duration(getEnvInt("AUTO_ARCHIVE_INTERVAL_MINUTES", 60)) * time.Minute,
+		SnapshotInterval:         time.Duration(getEnvInt("SNAPSHOT_INTERVAL_MINUTES", 60)) * time.Minute,
+		IdempotencyTTL:           time.Duration(getEnvInt("IDEMPOTENCY_TTL_HOURS", 24)) * time.Hour,
+		IdempotencyLease:         time.Duration(getEnvInt("IDEMPOTENCY_LEASE_SECONDS", 60)) * time.Second,
+		IdempotencyPurgeInterval: time.Duration(getEnvInt("IDEMPOTENCY_PURGE_INTERVAL_MINUTES", 60)) * time.Minute,
+		WebhookDeliveryInterval:  time.Duration(getEnvInt("WEBHOOK_DELIVERY_INTERVAL_SECONDS", 5)) * time.Second,
+		WebhookTimeout:           time.Duration(getEnvInt("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second,
+		OutboxRelayInterval:      time.Duration(getEnvInt("OUTBOX_RELAY_INTERVAL_SECONDS", 1)) * time.Second,
+		OutboxRetention:          time.Duration(getEnvInt("OUTBOX_RETENTION_HOURS", 24)) * time.Hour,
+		OutboxPurgeInterval:      time.Duration(getEnvInt("OUTBOX_PURGE_INTERVAL_MINUTES", 60)) * time.Minute,
+		EventSubjectPrefix:       getEnv("EVENT_SUBJECT_PREFIX", "tasks"),
+		StreamHeartbeat:          time.Duration(getEnvInt("STREAM_HEARTBEAT_SECONDS", 15)) * time.Second,
+		SyncTombstoneRetention:   time.Duration(getEnvInt("SYNC_TOMBSTONE_RETENTION_DAYS", 90)) * 24 * time.Hour,
+		SyncPurgeInterval:        time.Duration(getEnvInt("SYNC_PURGE_INTERVAL_MINUTES", 60)) * time.Minute,
+		GraphQLMaxDepth:          getEnvInt("GRAPHQL_MAX_DEPTH", 8),
+		GraphQLMaxComplexity:     getEnvInt("GRAPHQL_MAX_COMPLEXITY", 5000),
+		GRPCPort:                 getEnv("GRPC_PORT", "9090"),
+		SearchLanguage:           getEnv("SEARCH_LANGUAGE", "spanish"),
+		APIV1DeprecatedAt:        getEnvDate("API_V1_DEPRECATED_AT", "2026-10-19"),
+		APIV1Sunset:              getEnvDate("API_V1_SUNSET", "2027-04-30"),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// getEnvDate reads a date in the form YYYY-MM-DD, in UTC.
+func getEnvDate(key, fallback string) time.Time {
+	date, err := time.Parse(time.DateOnly, os.Getenv(key))
+	if err != nil {
+		date, _ = time.Parse(time.DateOnly, fallback) //nolint:errcheck // the fallbacks are valid dates
+	}
+	return date
+}
@@ -3,6 +3,25 @@ CREATE TABLE task_lists (
     name VARCHAR(255) NOT NULL,
     description TEXT NOT NULL DEFAULT '',
     created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP NULL,
+    archived_at TIMESTAMP NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    change_seq BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE sprints (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    goal TEXT NOT NULL DEFAULT '',
+    kind VARCHAR(20) NOT NULL DEFAULT 'sprint',
+    status VARCHAR(20) NOT NULL DEFAULT 'planned',
+    starts_at TIMESTAMP NULL,
+    ends_at TIMESTAMP NULL,
+    started_at TIMESTAMP NULL,
+    closed_at TIMESTAMP NULL,
+    rolled_over INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL
 );
 
@@ -15,9 +34,342 @@ CREATE TABLE tasks (
     priority VARCHAR(20) NOT NULL DEFAULT 'medium',
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL,
-    FOREIGN KEY (list_id) REFERENCES task_lists(id) ON DELETE CASCADE
+    deleted_at TIMESTAMP NULL,
+    archived_at TIMESTAMP NULL,
+    completed_at TIMESTAMP NULL,
+    due_date TIMESTAMP NULL,
+    parent_id VARCHAR(36) NULL REFERENCES tasks(id) ON DELETE SET NULL,
+    labels TEXT[] NOT NULL DEFAULT '{}',
+    sprint_id VARCHAR(36) NULL REFERENCES sprints(id),
+    search_vector TSVECTOR,
+    version INTEGER NOT NULL DEFAULT 1,
+    change_seq BIGINT NOT NULL DEFAULT 0,
+    FOREIGN KEY (list_id) REFERENCES task_lists(id)
 );
 
 CREATE INDEX idx_tasks_list_id ON tasks(list_id);
 CREATE INDEX idx_tasks_status ON tasks(status);
 CREATE INDEX idx_tasks_priority ON tasks(priority);
+CREATE INDEX idx_tasks_deleted_at ON tasks(deleted_at);
+CREATE INDEX idx_task_lists_deleted_at ON task_lists(deleted_at);
+CREATE INDEX idx_tasks_archived_at ON tasks(archived_at);
+CREATE INDEX idx_task_lists_archived_at ON task_lists(archived_at);
+CREATE INDEX idx_tasks_parent_id ON tasks(parent_id);
+CREATE INDEX idx_tasks_sprint_id ON tasks(sprint_id);
+CREATE INDEX idx_tasks_list_id_status ON tasks(list_id, status) WHERE deleted_at IS NULL;
+
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+CREATE FUNCTION immutable_unaccent(TEXT) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+    AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$;
+
+CREATE TABLE search_settings (
+    singleton BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (singleton),
+    language REGCONFIG NOT NULL DEFAULT 'spanish'
+);
+
+INSERT INTO search_settings (singleton) VALUES (TRUE);
+
+CREATE FUNCTION task_search_vector(lang REGCONFIG, title TEXT, description TEXT) RETURNS TSVECTOR
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT setweight(to_tsvector(lang, immutable_unaccent(COALESCE(title, ''))), 'A') ||
+                 setweight(to_tsvector(lang, immutable_unaccent(COALESCE(description, ''))), 'B') $$;
+
+CREATE FUNCTION tasks_search_vector_update() RETURNS TRIGGER
+    LANGUAGE plpgsql AS $$
+BEGIN
+    NEW.search_vector := task_search_vector((SELECT language FROM search_settings), NEW.title, NEW.description);
+    RETURN NEW;
+END
+$$;
+
+CREATE TRIGGER tasks_search_vector_trigger BEFORE INSERT OR UPDATE OF title, description ON tasks
+    FOR EACH ROW EXECUTE FUNCTION tasks_search_vector_update();
+
+CREATE INDEX idx_tasks_search_vector ON tasks USING GIN (search_vector);
+
+CREATE FUNCTION bump_version() RETURNS TRIGGER
+    LANGUAGE plpgsql AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END
+$$;
+
+CREATE TRIGGER task_lists_version_trigger
+    BEFORE UPDATE OF name, description, archived_at, deleted_at ON task_lists
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
+
+CREATE TRIGGER tasks_version_trigger
+    BEFORE UPDATE OF list_id, title, description, status, priority, archived_at, deleted_at, due_date, parent_id, labels, sprint_id ON tasks
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
+
+CREATE TABLE task_revisions (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    changed_by TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMP NOT NULL,
+    changed_fields TEXT[] NOT NULL DEFAULT '{}',
+    list_id VARCHAR(36),
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(50) NOT NULL,
+    priority VARCHAR(20) NOT NULL,
+    task_version INTEGER NOT NULL DEFAULT 0,
+    sprint_id VARCHAR(36),
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE (task_id, revision)
+);
+
+CREATE INDEX idx_task_revisions_task_version ON task_revisions(task_id, task_version);
+
+CREATE TABLE task_templates (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE task_template_items (
+    id VARCHAR(36) PRIMARY KEY,
+    template_id VARCHAR(36) NOT NULL REFERENCES task_templates(id) ON DELETE CASCADE,
+    parent_item_id VARCHAR(36) NULL,
+    position INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    priority VARCHAR(20) NOT NULL,
+    due_offset_days INTEGER NULL,
+    labels TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_task_template_items_template_id ON task_template_items(template_id);
+
+CREATE TABLE task_checklist_items (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_task_checklist_items_task_id ON task_checklist_items(task_id, position);
+
+CREATE TABLE sprint_commitments (
+    sprint_id VARCHAR(36) NOT NULL REFERENCES sprints(id) ON DELETE CASCADE,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    PRIMARY KEY (sprint_id, task_id)
+);
+
+CREATE TABLE task_status_snapshots (
+    snapshot_date DATE NOT NULL,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    list_id VARCHAR(36),
+    sprint_id VARCHAR(36),
+    status VARCHAR(50) NOT NULL,
+    PRIMARY KEY (snapshot_date, task_id)
+);
+
+CREATE INDEX idx_task_status_snapshots_list ON task_status_snapshots(list_id, snapshot_date);
+CREATE INDEX idx_task_status_snapshots_sprint ON task_status_snapshots(sprint_id, snapshot_date);
+
+CREATE TABLE saved_views (
+    id VARCHAR(36) PRIMARY KEY,
+    owner_id TEXT NOT NULL,
+    list_id VARCHAR(36) NULL REFERENCES task_lists(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    filter TEXT NOT NULL DEFAULT '',
+    sort VARCHAR(50) NOT NULL DEFAULT '',
+    group_by VARCHAR(50) NOT NULL DEFAULT '',
+    fields TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_saved_views_owner_id ON saved_views(owner_id);
+CREATE INDEX idx_saved_views_list_id ON saved_views(list_id);
+
+CREATE TABLE idempotency_keys (
+    user_id TEXT NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    status_code INTEGER NULL,
+    headers JSONB NOT NULL DEFAULT '{}',
+    body BYTEA NULL,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP NULL,
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+CREATE TABLE webhook_subscriptions (
+    id VARCHAR(36) PRIMARY KEY,
+    owner_id TEXT NOT NULL,
+    url TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    list_id VARCHAR(36) NULL REFERENCES task_lists(id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_webhook_subscriptions_owner_id ON webhook_subscriptions(owner_id);
+
+CREATE TABLE webhook_deliveries (
+    id VARCHAR(36) PRIMARY KEY,
+    subscription_id VARCHAR(36) NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id VARCHAR(36) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload BYTEA NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL,
+    last_status_code INTEGER NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    delivered_at TIMESTAMP NULL
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, created_at);
+CREATE UNIQUE INDEX idx_webhook_deliveries_event ON webhook_deliveries(subscription_id, event_id);
+
+CREATE TABLE webhook_delivery_attempts (
+    id VARCHAR(36) PRIMARY KEY,
+    delivery_id VARCHAR(36) NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER NULL,
+    error TEXT NOT NULL DEFAULT '',
+    duration_ms INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_webhook_delivery_attempts_delivery_id ON webhook_delivery_attempts(delivery_id);
+
+CREATE TABLE outbox_events (
+    sequence BIGSERIAL PRIMARY KEY,
+    id VARCHAR(36) NOT NULL UNIQUE,
+    aggregate_type VARCHAR(20) NOT NULL,
+    aggregate_id VARCHAR(36) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    list_id VARCHAR(36) NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    available_at TIMESTAMP NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    published_at TIMESTAMP NULL
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events(aggregate_type, aggregate_id, sequence) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_events_published_at ON outbox_events(published_at) WHERE published_at IS NOT NULL;
+
+CREATE TABLE sync_state (
+    singleton BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (singleton),
+    last_sequence BIGINT NOT NULL DEFAULT 0,
+    purged_sequence BIGINT NOT NULL DEFAULT 0
+);
+
+INSERT INTO sync_state (singleton) VALUES (TRUE);
+
+-- Una sola fila: las secuencias se confirman en orden a costa de serializar a los escritores
+CREATE FUNCTION next_change_sequence() RETURNS BIGINT
+    LANGUAGE sql AS $$ UPDATE sync_state SET last_sequence = last_sequence + 1 RETURNING last_sequence $$;
+
+CREATE INDEX idx_task_lists_change_seq ON task_lists(change_seq);
+CREATE INDEX idx_tasks_change_seq ON tasks(change_seq);
+
+CREATE FUNCTION bump_change_sequence() RETURNS TRIGGER
+    LANGUAGE plpgsql AS $$
+BEGIN
+    NEW.change_seq := next_change_sequence();
+    RETURN NEW;
+END
+$$;
+
+CREATE TRIGGER task_lists_change_seq_trigger
+    BEFORE INSERT OR UPDATE OF name, description, archived_at, deleted_at ON task_lists
+    FOR EACH ROW EXECUTE FUNCTION bump_change_sequence();
+
+CREATE TRIGGER tasks_change_seq_trigger
+    BEFORE INSERT OR UPDATE OF list_id, title, description, status, priority, archived_at, deleted_at, due_date, parent_id, labels, sprint_id ON tasks
+    FOR EACH ROW EXECUTE FUNCTION bump_change_sequence();
+
+CREATE TABLE sync_tombstones (
+    entity_type VARCHAR(10) NOT NULL,
+    entity_id VARCHAR(36) NOT NULL,
+    change_seq BIGINT NOT NULL,
+    deleted_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (entity_type, entity_id)
+);
+
+CREATE INDEX idx_sync_tombstones_change_seq ON sync_tombstones(change_seq);
+CREATE INDEX idx_sync_tombstones_deleted_at ON sync_tombstones(deleted_at);
+
+CREATE FUNCTION record_sync_tombstone() RETURNS TRIGGER
+    LANGUAGE plpgsql AS $$
+BEGIN
+    INSERT INTO sync_tombstones (entity_type, entity_id, change_seq, deleted_at)
+    VALUES (TG_ARGV[0], OLD.id, next_change_sequence(), NOW())
+    ON CONFLICT (entity_type, entity_id) DO UPDATE SET change_seq = EXCLUDED.change_seq, deleted_at = EXCLUDED.deleted_at;
+    RETURN OLD;
+END
+$$;
+
+CREATE TRIGGER task_lists_tombstone_trigger
+    AFTER DELETE ON task_lists
+    FOR EACH ROW EXECUTE FUNCTION record_sync_tombstone('list');
+
+CREATE TRIGGER tasks_tombstone_trigger
+    AFTER DELETE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION record_sync_tombstone('task');
+
+CREATE FUNCTION record_task_revision() RETURNS TRIGGER
+    LANGUAGE plpgsql AS $$
+DECLARE
+    previous tasks%ROWTYPE;
+    changed TEXT[] := '{}';
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        previous := OLD;
+    END IF;
+
+    IF COALESCE(NEW.list_id, '') <> COALESCE(previous.list_id, '') THEN changed := changed || 'list_id'::TEXT; END IF;
+    IF NEW.title <> COALESCE(previous.title, '') THEN changed := changed || 'title'::TEXT; END IF;
+    IF NEW.description <> COALESCE(previous.description, '') THEN changed := changed || 'description'::TEXT; END IF;
+    IF NEW.status <> COALESCE(previous.status, '') THEN changed := changed || 'status'::TEXT; END IF;
+    IF NEW.priority <> COALESCE(previous.priority, '') THEN changed := changed || 'priority'::TEXT; END IF;
+    IF COALESCE(NEW.sprint_id, '') <> COALESCE(previous.sprint_id, '') THEN changed := changed || 'sprint_id'::TEXT; END IF;
+    IF (NEW.archived_at IS NOT NULL) <> (previous.archived_at IS NOT NULL) THEN changed := changed || 'archived'::TEXT; END IF;
+    IF (NEW.deleted_at IS NOT NULL) <> (previous.deleted_at IS NOT NULL) THEN changed := changed || 'deleted'::TEXT; END IF;
+
+    IF cardinality(changed) = 0 THEN
+        RETURN NULL;
+    END IF;
+
+    -- La escritura que dispara el trigger tiene bloqueada la fila de la tarea hasta el commit,
+    -- así que dos transacciones nunca calculan el mismo número de revisión para una tarea
+    INSERT INTO task_revisions (id, task_id, revision, task_version, changed_by, changed_at, changed_fields,
+                                list_id, title, description, status, priority, sprint_id, archived, deleted)
+    SELECT gen_random_uuid()::TEXT, NEW.id, COALESCE(MAX(revision), 0) + 1, NEW.version,
+           COALESCE(current_setting('app.user_id', TRUE), ''), NOW(), changed,
+           NEW.list_id, NEW.title, NEW.description, NEW.status, NEW.priority, NEW.sprint_id,
+           NEW.archived_at IS NOT NULL, NEW.deleted_at IS NOT NULL
+    FROM task_revisions WHERE task_id = NEW.id;
+
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER tasks_revision_trigger
+    AFTER INSERT OR UPDATE OF list_id, title, description, status, priority, sprint_id, archived_at, deleted_at ON tasks
+    FOR EACH ROW EXECUTE FUNCTION record_task_revision();
@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/G20-00/task-management-service-go/internal/delivery/http"
+	rpc "github.com/G20-00/task-management-service-go/pkg/grpc"
+	"github.com/G20-00/task-management-service-go/pkg/protobuf"
+)
+
+type userIDKey struct{}
+
+// AuthUnaryInterceptor verifica el JWT de la metadata authorization antes de cada llamada
+// unaria y guarda el usuario en el contexto.
+func AuthUnaryInterceptor(ctx context.Context, req protobuf.Message, _ *rpc.MethodInfo, handler rpc.UnaryHandler) (protobuf.Message, error) {
+	ctx, err := authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// AuthStreamInterceptor verifica el JWT de la metadata authorization antes de cada llamada
+// de streaming y guarda el usuario en el contexto del stream.
+func AuthStreamInterceptor(req protobuf.Message, stream rpc.ServerStream, _ *rpc.MethodInfo, handler rpc.StreamHandler) error {
+	ctx, err := authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+	return handler(req, rpc.WrapServerStream(stream, ctx))
+}
+
+// authenticate validates the bearer token of a call as JWTMiddleware does for the REST API.
+func authenticate(ctx context.Context) (context.Context, error) {
+	header := rpc.IncomingMetadata(ctx).Get("authorization")
+	if header == "" || !strings.HasPrefix(header, "Bearer ") {
+		return nil, rpc.Errorf(rpc.Unauthenticated, "missing or invalid authorization metadata")
+	}
+	token, err := http.ParseJWT(strings.TrimPrefix(header, "Bearer "))
+	if err != nil || !token.Valid {
+		return nil, rpc.Errorf(rpc.Unauthenticated, "invalid or expired token")
+	}
+	userID, ok := http.GetUserIDFromToken(token)
+	if !ok {
+		return nil, rpc.Errorf(rpc.Unauthenticated, "invalid token claims")
+	}
+	return context.WithValue(ctx, userIDKey{}, userID), nil
+}
+
+// CurrentUserID devuelve el usuario autenticado por los interceptores, o "" si no hay ninguno.
+func CurrentUserID(ctx context.Context) string {
+	userID, _ := ctx.Value(userIDKey{}).(string) //nolint:errcheck
+	return userID
+}
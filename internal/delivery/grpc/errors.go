@@ -0,0 +1,44 @@
+package grpc
+
+import (
+	"errors"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	rpc "github.com/G20-00/task-management-service-go/pkg/grpc"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+)
+
+// statusError returns the status a method reports to the client for an error of the use
+// cases. Domain errors keep their message, with the code of their kind; any other error is
+// logged and reported as internal.
+func statusError(method string, err error) error {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return rpc.Errorf(domainErrorCode(domainErr), "%s", err.Error())
+	}
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"layer":  "handler",
+		"method": method,
+		"error":  err.Error(),
+	}).Error("Failed to handle gRPC call")
+	return rpc.Errorf(rpc.Internal, "internal error")
+}
+
+// domainErrorCode maps the kind of a domain error to a gRPC status code. A version
+// conflict is Aborted, since the client can read the resource again and retry; any other
+// conflict, or a token that expired, is FailedPrecondition.
+func domainErrorCode(err *domain.Error) rpc.Code {
+	switch err.Kind {
+	case domain.ErrorInvalid:
+		return rpc.InvalidArgument
+	case domain.ErrorNotFound:
+		return rpc.NotFound
+	case domain.ErrorForbidden:
+		return rpc.PermissionDenied
+	}
+	if errors.Is(err, domain.ErrVersionConflict) {
+		return rpc.Aborted
+	}
+	return rpc.FailedPrecondition
+}
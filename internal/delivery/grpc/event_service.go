@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	rpc "github.com/G20-00/task-management-service-go/pkg/grpc"
+)
+
+// WatchEvents sends the events of every list, or of the list of the request, as they are
+// published, until the client cancels the call. The events published after the sequence
+// of the request are replayed first. The call ends with ResourceExhausted when the client
+// falls too far behind, so that it resumes from the last event it received.
+func (h *Handler) WatchEvents(req *WatchEventsRequest, stream rpc.ServerStream) error {
+	if req.AfterSequence < 0 {
+		return rpc.Errorf(rpc.InvalidArgument, "invalid after_sequence: must not be negative")
+	}
+
+	events, err := h.events.Subscribe(req.ListID, req.AfterSequence)
+	if err != nil {
+		return statusError("WatchEvents", err)
+	}
+	defer events.Close()
+
+	replayed := make(map[int64]bool, len(events.Replay))
+	for _, event := range events.Replay {
+		if err := stream.Send(toEvent(event)); err != nil {
+			return err
+		}
+		replayed[event.Sequence] = true
+	}
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events.Events:
+			if !ok {
+				return rpc.Errorf(rpc.ResourceExhausted, "the stream fell too far behind; watch again from the last sequence received")
+			}
+			if replayed[event.Sequence] {
+				continue
+			}
+			if err := stream.Send(toEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
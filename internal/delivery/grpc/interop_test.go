@@ -0,0 +1,180 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/protobuf"
+)
+
+// grpcClient calls a server over cleartext HTTP/2 the way grpc-go does: the same transport
+// framing, headers and length-prefixed messages, without the generated stubs.
+type grpcClient struct {
+	t      *testing.T
+	addr   string
+	client *http.Client
+}
+
+// newGRPCClient serves h on a local listener and returns a client connected to it.
+func newGRPCClient(t *testing.T, h *Handler) *grpcClient {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	server := NewServer(h).NewHTTPServer(listener.Addr().String())
+	go server.Serve(listener) //nolint:errcheck
+	t.Cleanup(func() { server.Close() })
+
+	protocols := &http.Protocols{}
+	protocols.SetUnencryptedHTTP2(true)
+	return &grpcClient{t: t, addr: listener.Addr().String(), client: &http.Client{
+		Transport: &http.Transport{Protocols: protocols},
+	}}
+}
+
+// call starts an rpc with the headers grpc-go sends and returns the response, whose body
+// is read by the caller.
+func (c *grpcClient) call(ctx context.Context, method string, req protobuf.Message) *http.Response {
+	c.t.Helper()
+	data := protobuf.Marshal(req)
+	body := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(body[1:], uint32(len(data)))
+	body = append(body, data...)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+c.addr+method, bytes.NewReader(body))
+	if err != nil {
+		c.t.Fatalf("unexpected error: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/grpc")
+	httpReq.Header.Set("Te", "trailers")
+	httpReq.Header.Set("User-Agent", "grpc-go/1.64.0")
+	httpReq.Header.Set("Grpc-Accept-Encoding", "gzip")
+	httpReq.Header.Set("Grpc-Timeout", "5S")
+	httpReq.Header.Set("Authorization", bearer(c.t))
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		c.t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.ProtoMajor != 2 {
+		c.t.Errorf("expected HTTP/2, got %s", resp.Proto)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/grpc" {
+		c.t.Errorf("expected 200 application/grpc, got %d %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	return resp
+}
+
+// readMessage reads the next length-prefixed message of a response into m. It returns
+// false once the server ends the stream.
+func readMessage(t *testing.T, body io.Reader, m protobuf.Message) bool {
+	t.Helper()
+	var prefix [5]byte
+	if _, err := io.ReadFull(body, prefix[:]); err == io.EOF {
+		return false
+	} else if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prefix[0] != 0 {
+		t.Fatalf("expected an uncompressed message, got flag %d", prefix[0])
+	}
+	data := make([]byte, binary.BigEndian.Uint32(prefix[1:]))
+	if _, err := io.ReadFull(body, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.UnmarshalProto(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return true
+}
+
+func TestInterop_UnaryCalls(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	tasks := &mockTasks{
+		CreateFn: func(listID, title, description, priority, changedBy string) (*domain.Task, error) {
+			return &domain.Task{ID: "t1", ListID: listID, Title: title, Status: "pending", Priority: priority,
+				Version: 1, CreatedAt: created, UpdatedAt: created}, nil
+		},
+		GetByIDFn: func(id string) (*domain.Task, error) {
+			return nil, domain.ErrTaskNotFound
+		},
+	}
+	c := newGRPCClient(t, NewHandler(tasks, &mockLists{}, &mockEvents{}))
+
+	t.Run("ok", func(t *testing.T) {
+		resp := c.call(context.Background(), "/tasks.v1.TaskService/CreateTask", &CreateTaskRequest{ListID: "l1", Title: "Deploy", Priority: "high"})
+		defer resp.Body.Close()
+
+		got := &Task{}
+		if !readMessage(t, resp.Body, got) {
+			t.Fatal("expected a response message")
+		}
+		if readMessage(t, resp.Body, &Task{}) {
+			t.Error("expected a single response message")
+		}
+		if got.ID != "t1" || got.Title != "Deploy" || got.Priority != "high" || !got.CreatedAt.Equal(created) {
+			t.Errorf("unexpected response %+v", got)
+		}
+		if status := resp.Trailer.Get("Grpc-Status"); status != "0" {
+			t.Errorf("expected status 0, got %q", status)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		resp := c.call(context.Background(), "/tasks.v1.TaskService/GetTask", &IDRequest{ID: "missing"})
+		defer resp.Body.Close()
+
+		if readMessage(t, resp.Body, &Task{}) {
+			t.Error("expected no response message")
+		}
+		if status := resp.Trailer.Get("Grpc-Status"); status != "5" {
+			t.Errorf("expected status 5, got %q %q", status, resp.Trailer.Get("Grpc-Message"))
+		}
+	})
+}
+
+func TestInterop_ServerStreaming(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	live := make(chan *domain.Event)
+	closed := make(chan struct{})
+	events := &mockEvents{SubscribeFn: func(listID string, lastEventID int64) (*domain.EventStream, error) {
+		return &domain.EventStream{
+			Replay: []*domain.Event{{ID: "e1", Sequence: 1, Type: domain.EventTaskCreated, ListID: listID, OccurredAt: at}},
+			Events: live,
+			Close:  func() { close(closed) },
+		}, nil
+	}}
+	c := newGRPCClient(t, NewHandler(&mockTasks{}, &mockLists{}, events))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resp := c.call(ctx, "/tasks.v1.EventService/WatchEvents", &WatchEventsRequest{ListID: "l1"})
+	defer resp.Body.Close()
+
+	// Each event must reach the client while the stream is still open.
+	got := &Event{}
+	if !readMessage(t, resp.Body, got) || got.ID != "e1" {
+		t.Fatalf("expected replayed event e1, got %+v", got)
+	}
+	live <- &domain.Event{ID: "e2", Sequence: 2, Type: domain.EventTaskUpdated, ListID: "l1", OccurredAt: at}
+	got = &Event{}
+	if !readMessage(t, resp.Body, got) || got.ID != "e2" || got.Sequence != 2 {
+		t.Fatalf("expected live event e2, got %+v", got)
+	}
+
+	// Cancelling the call on the client ends the subscription on the server.
+	cancel()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Error("expected the subscription to be closed after the client cancelled")
+	}
+}
@@ -0,0 +1,576 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/protobuf"
+)
+
+// The messages of api/proto/tasks/v1/tasks.proto, with the same field numbers.
+
+// Task is the tasks.v1.Task message.
+type Task struct {
+	ID          string
+	ListID      string
+	Title       string
+	Description string
+	Status      string
+	Priority    string
+	DueDate     *time.Time
+	Labels      []string
+	ParentID    string
+	SprintID    string
+	Version     int32
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+	ArchivedAt  *time.Time
+}
+
+// MarshalProto writes the fields of the message.
+func (m *Task) MarshalProto(e *protobuf.Encoder) {
+	e.String(1, m.ID)
+	e.String(2, m.ListID)
+	e.String(3, m.Title)
+	e.String(4, m.Description)
+	e.String(5, m.Status)
+	e.String(6, m.Priority)
+	e.Timestamp(7, m.DueDate)
+	e.Strings(8, m.Labels)
+	e.String(9, m.ParentID)
+	e.String(10, m.SprintID)
+	e.Int32(11, m.Version)
+	e.Timestamp(12, m.CreatedAt)
+	e.Timestamp(13, m.UpdatedAt)
+	e.Timestamp(14, m.ArchivedAt)
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *Task) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) (err error) {
+		switch f.Number {
+		case 1:
+			m.ID, err = f.String()
+		case 2:
+			m.ListID, err = f.String()
+		case 3:
+			m.Title, err = f.String()
+		case 4:
+			m.Description, err = f.String()
+		case 5:
+			m.Status, err = f.String()
+		case 6:
+			m.Priority, err = f.String()
+		case 7:
+			m.DueDate, err = f.Timestamp()
+		case 8:
+			var label string
+			label, err = f.String()
+			m.Labels = append(m.Labels, label)
+		case 9:
+			m.ParentID, err = f.String()
+		case 10:
+			m.SprintID, err = f.String()
+		case 11:
+			m.Version, err = f.Int32()
+		case 12:
+			m.CreatedAt, err = f.Timestamp()
+		case 13:
+			m.UpdatedAt, err = f.Timestamp()
+		case 14:
+			m.ArchivedAt, err = f.Timestamp()
+		}
+		return err
+	})
+}
+
+// TaskList is the tasks.v1.TaskList message.
+type TaskList struct {
+	ID          string
+	Name        string
+	Description string
+	Version     int32
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+	ArchivedAt  *time.Time
+}
+
+// MarshalProto writes the fields of the message.
+func (m *TaskList) MarshalProto(e *protobuf.Encoder) {
+	e.String(1, m.ID)
+	e.String(2, m.Name)
+	e.String(3, m.Description)
+	e.Int32(4, m.Version)
+	e.Timestamp(5, m.CreatedAt)
+	e.Timestamp(6, m.UpdatedAt)
+	e.Timestamp(7, m.ArchivedAt)
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *TaskList) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) (err error) {
+		switch f.Number {
+		case 1:
+			m.ID, err = f.String()
+		case 2:
+			m.Name, err = f.String()
+		case 3:
+			m.Description, err = f.String()
+		case 4:
+			m.Version, err = f.Int32()
+		case 5:
+			m.CreatedAt, err = f.Timestamp()
+		case 6:
+			m.UpdatedAt, err = f.Timestamp()
+		case 7:
+			m.ArchivedAt, err = f.Timestamp()
+		}
+		return err
+	})
+}
+
+// CreateTaskRequest is the tasks.v1.CreateTaskRequest message.
+type CreateTaskRequest struct {
+	ListID      string
+	Title       string
+	Description string
+	Priority    string
+}
+
+// MarshalProto writes the fields of the message.
+func (m *CreateTaskRequest) MarshalProto(e *protobuf.Encoder) {
+	e.String(1, m.ListID)
+	e.String(2, m.Title)
+	e.String(3, m.Description)
+	e.String(4, m.Priority)
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *CreateTaskRequest) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) (err error) {
+		switch f.Number {
+		case 1:
+			m.ListID, err = f.String()
+		case 2:
+			m.Title, err = f.String()
+		case 3:
+			m.Description, err = f.String()
+		case 4:
+			m.Priority, err = f.String()
+		}
+		return err
+	})
+}
+
+// IDRequest is the message of the requests that name a single task or list:
+// tasks.v1.GetTaskRequest, DeleteTaskRequest, GetTaskListRequest and DeleteTaskListRequest.
+type IDRequest struct {
+	ID string
+}
+
+// MarshalProto writes the fields of the message.
+func (m *IDRequest) MarshalProto(e *protobuf.Encoder) {
+	e.String(1, m.ID)
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *IDRequest) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) (err error) {
+		if f.Number == 1 {
+			m.ID, err = f.String()
+		}
+		return err
+	})
+}
+
+// ListTasksRequest is the tasks.v1.ListTasksRequest message.
+type ListTasksRequest struct {
+	ListID          string
+	Status          string
+	Priority        string
+	Filter          string
+	IncludeArchived bool
+	PageSize        int32
+	PageToken       string
+	OrderBy         string
+	IncludeTotal    bool
+}
+
+// MarshalProto writes the fields of the message.
+func (m *ListTasksRequest) MarshalProto(e *protobuf.Encoder) {
+	e.String(1, m.ListID)
+	e.String(2, m.Status)
+	e.String(3, m.Priority)
+	e.String(4, m.Filter)
+	e.Bool(5, m.IncludeArchived)
+	e.Int32(6, m.PageSize)
+	e.String(7, m.PageToken)
+	e.String(8, m.OrderBy)
+	e.Bool(9, m.IncludeTotal)
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *ListTasksRequest) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) (err error) {
+		switch f.Number {
+		case 1:
+			m.ListID, err = f.String()
+		case 2:
+			m.Status, err = f.String()
+		case 3:
+			m.Priority, err = f.String()
+		case 4:
+			m.Filter, err = f.String()
+		case 5:
+			m.IncludeArchived, err = f.Bool()
+		case 6:
+			m.PageSize, err = f.Int32()
+		case 7:
+			m.PageToken, err = f.String()
+		case 8:
+			m.OrderBy, err = f.String()
+		case 9:
+			m.IncludeTotal, err = f.Bool()
+		}
+		return err
+	})
+}
+
+// ListTasksResponse is the tasks.v1.ListTasksResponse message.
+type ListTasksResponse struct {
+	Tasks         []*Task
+	NextPageToken string
+	TotalSize     int32
+}
+
+// MarshalProto writes the fields of the message.
+func (m *ListTasksResponse) MarshalProto(e *protobuf.Encoder) {
+	for _, t := range m.Tasks {
+		e.Message(1, t)
+	}
+	e.String(2, m.NextPageToken)
+	e.Int32(3, m.TotalSize)
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *ListTasksResponse) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) (err error) {
+		switch f.Number {
+		case 1:
+			t := &Task{}
+			err = f.Message(t)
+			m.Tasks = append(m.Tasks, t)
+		case 2:
+			m.NextPageToken, err = f.String()
+		case 3:
+			m.TotalSize, err = f.Int32()
+		}
+		return err
+	})
+}
+
+// UpdateTaskRequest is the tasks.v1.UpdateTaskRequest message.
+type UpdateTaskRequest struct {
+	Task       *Task
+	UpdateMask *FieldMask
+}
+
+// MarshalProto writes the fields of the message.
+func (m *UpdateTaskRequest) MarshalProto(e *protobuf.Encoder) {
+	if m.Task != nil {
+		e.Message(1, m.Task)
+	}
+	if m.UpdateMask != nil {
+		e.Message(2, m.UpdateMask)
+	}
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *UpdateTaskRequest) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) error {
+		switch f.Number {
+		case 1:
+			if m.Task == nil {
+				m.Task = &Task{}
+			}
+			return f.Message(m.Task)
+		case 2:
+			if m.UpdateMask == nil {
+				m.UpdateMask = &FieldMask{}
+			}
+			return f.Message(m.UpdateMask)
+		}
+		return nil
+	})
+}
+
+// CreateTaskListRequest is the tasks.v1.CreateTaskListRequest message.
+type CreateTaskListRequest struct {
+	Name        string
+	Description string
+}
+
+// MarshalProto writes the fields of the message.
+func (m *CreateTaskListRequest) MarshalProto(e *protobuf.Encoder) {
+	e.String(1, m.Name)
+	e.String(2, m.Description)
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *CreateTaskListRequest) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) (err error) {
+		switch f.Number {
+		case 1:
+			m.Name, err = f.String()
+		case 2:
+			m.Description, err = f.String()
+		}
+		return err
+	})
+}
+
+// ListTaskListsRequest is the tasks.v1.ListTaskListsRequest message.
+type ListTaskListsRequest struct {
+	IncludeArchived bool
+	PageSize        int32
+	PageToken       string
+	OrderBy         string
+	IncludeTotal    bool
+}
+
+// MarshalProto writes the fields of the message.
+func (m *ListTaskListsRequest) MarshalProto(e *protobuf.Encoder) {
+	e.Bool(1, m.IncludeArchived)
+	e.Int32(2, m.PageSize)
+	e.String(3, m.PageToken)
+	e.String(4, m.OrderBy)
+	e.Bool(5, m.IncludeTotal)
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *ListTaskListsRequest) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) (err error) {
+		switch f.Number {
+		case 1:
+			m.IncludeArchived, err = f.Bool()
+		case 2:
+			m.PageSize, err = f.Int32()
+		case 3:
+			m.PageToken, err = f.String()
+		case 4:
+			m.OrderBy, err = f.String()
+		case 5:
+			m.IncludeTotal, err = f.Bool()
+		}
+		return err
+	})
+}
+
+// ListTaskListsResponse is the tasks.v1.ListTaskListsResponse message.
+type ListTaskListsResponse struct {
+	TaskLists     []*TaskList
+	NextPageToken string
+	TotalSize     int32
+}
+
+// MarshalProto writes the fields of the message.
+func (m *ListTaskListsResponse) MarshalProto(e *protobuf.Encoder) {
+	for _, list := range m.TaskLists {
+		e.Message(1, list)
+	}
+	e.String(2, m.NextPageToken)
+	e.Int32(3, m.TotalSize)
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *ListTaskListsResponse) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) (err error) {
+		switch f.Number {
+		case 1:
+			list := &TaskList{}
+			err = f.Message(list)
+			m.TaskLists = append(m.TaskLists, list)
+		case 2:
+			m.NextPageToken, err = f.String()
+		case 3:
+			m.TotalSize, err = f.Int32()
+		}
+		return err
+	})
+}
+
+// UpdateTaskListRequest is the tasks.v1.UpdateTaskListRequest message.
+type UpdateTaskListRequest struct {
+	TaskList   *TaskList
+	UpdateMask *FieldMask
+}
+
+// MarshalProto writes the fields of the message.
+func (m *UpdateTaskListRequest) MarshalProto(e *protobuf.Encoder) {
+	if m.TaskList != nil {
+		e.Message(1, m.TaskList)
+	}
+	if m.UpdateMask != nil {
+		e.Message(2, m.UpdateMask)
+	}
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *UpdateTaskListRequest) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) error {
+		switch f.Number {
+		case 1:
+			if m.TaskList == nil {
+				m.TaskList = &TaskList{}
+			}
+			return f.Message(m.TaskList)
+		case 2:
+			if m.UpdateMask == nil {
+				m.UpdateMask = &FieldMask{}
+			}
+			return f.Message(m.UpdateMask)
+		}
+		return nil
+	})
+}
+
+// WatchEventsRequest is the tasks.v1.WatchEventsRequest message.
+type WatchEventsRequest struct {
+	ListID        string
+	AfterSequence int64
+}
+
+// MarshalProto writes the fields of the message.
+func (m *WatchEventsRequest) MarshalProto(e *protobuf.Encoder) {
+	e.String(1, m.ListID)
+	e.Int64(2, m.AfterSequence)
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *WatchEventsRequest) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) (err error) {
+		switch f.Number {
+		case 1:
+			m.ListID, err = f.String()
+		case 2:
+			m.AfterSequence, err = f.Int64()
+		}
+		return err
+	})
+}
+
+// Event is the tasks.v1.Event message.
+type Event struct {
+	ID         string
+	Sequence   int64
+	Type       string
+	ListID     string
+	OccurredAt *time.Time
+	Data       []byte
+}
+
+// MarshalProto writes the fields of the message.
+func (m *Event) MarshalProto(e *protobuf.Encoder) {
+	e.String(1, m.ID)
+	e.Int64(2, m.Sequence)
+	e.String(3, m.Type)
+	e.String(4, m.ListID)
+	e.Timestamp(5, m.OccurredAt)
+	e.Bytes(6, m.Data)
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *Event) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) (err error) {
+		switch f.Number {
+		case 1:
+			m.ID, err = f.String()
+		case 2:
+			m.Sequence, err = f.Int64()
+		case 3:
+			m.Type, err = f.String()
+		case 4:
+			m.ListID, err = f.String()
+		case 5:
+			m.OccurredAt, err = f.Timestamp()
+		case 6:
+			m.Data, err = f.Bytes()
+		}
+		return err
+	})
+}
+
+// FieldMask is the google.protobuf.FieldMask well-known type.
+type FieldMask struct {
+	Paths []string
+}
+
+// MarshalProto writes the fields of the message.
+func (m *FieldMask) MarshalProto(e *protobuf.Encoder) {
+	e.Strings(1, m.Paths)
+}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *FieldMask) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) error {
+		if f.Number != 1 {
+			return nil
+		}
+		path, err := f.String()
+		m.Paths = append(m.Paths, path)
+		return err
+	})
+}
+
+// Empty is the google.protobuf.Empty well-known type.
+type Empty struct{}
+
+// MarshalProto writes the fields of the message.
+func (m *Empty) MarshalProto(*protobuf.Encoder) {}
+
+// UnmarshalProto reads the fields of an encoded message.
+func (m *Empty) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(protobuf.Field) error { return nil })
+}
+
+func toTask(t *domain.Task) *Task {
+	return &Task{
+		ID:          t.ID,
+		ListID:      t.ListID,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Priority:    t.Priority,
+		DueDate:     t.DueDate,
+		Labels:      t.Labels,
+		ParentID:    t.ParentID,
+		SprintID:    t.SprintID,
+		Version:     int32(t.Version),
+		CreatedAt:   &t.CreatedAt,
+		UpdatedAt:   &t.UpdatedAt,
+		ArchivedAt:  t.ArchivedAt,
+	}
+}
+
+func toTaskList(list *domain.TaskList) *TaskList {
+	return &TaskList{
+		ID:          list.ID,
+		Name:        list.Name,
+		Description: list.Description,
+		Version:     int32(list.Version),
+		CreatedAt:   &list.CreatedAt,
+		UpdatedAt:   &list.UpdatedAt,
+		ArchivedAt:  list.ArchivedAt,
+	}
+}
+
+func toEvent(event *domain.Event) *Event {
+	return &Event{
+		ID:         event.ID,
+		Sequence:   event.Sequence,
+		Type:       event.Type,
+		ListID:     event.ListID,
+		OccurredAt: &event.OccurredAt,
+		Data:       event.Data,
+	}
+}
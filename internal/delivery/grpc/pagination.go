@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	rpc "github.com/G20-00/task-management-service-go/pkg/grpc"
+)
+
+// defaultOrderBy is the order of a listing when the request sets none.
+const defaultOrderBy = "-created_at"
+
+// pageToken is the payload of an opaque page token. The order it was issued for is kept
+// so that a token cannot be replayed against a different order.
+type pageToken struct {
+	OrderBy string `json:"o"`
+	Value   string `json:"v"`
+	ID      string `json:"id"`
+}
+
+// pageRequest reads the paging fields of a list request. It returns the order the page is
+// sorted by, for the token of the next page.
+func pageRequest(size int32, token, orderBy string, includeTotal bool) (domain.PageRequest, string, error) {
+	if orderBy == "" {
+		orderBy = defaultOrderBy
+	}
+	page := domain.PageRequest{
+		Limit:        int(size),
+		Sort:         strings.TrimPrefix(orderBy, "-"),
+		Desc:         strings.HasPrefix(orderBy, "-"),
+		IncludeTotal: includeTotal,
+	}
+	if size < 0 {
+		return page, orderBy, rpc.Errorf(rpc.InvalidArgument, "invalid page_size: must not be negative")
+	}
+
+	if token != "" {
+		payload, err := base64.RawURLEncoding.DecodeString(token)
+		decoded := pageToken{}
+		if err != nil || json.Unmarshal(payload, &decoded) != nil || decoded.ID == "" {
+			return page, orderBy, rpc.Errorf(rpc.InvalidArgument, "invalid page_token")
+		}
+		if decoded.OrderBy != orderBy {
+			return page, orderBy, rpc.Errorf(rpc.InvalidArgument, "invalid page_token: it was issued for order_by %s", decoded.OrderBy)
+		}
+		page.After = &domain.Cursor{Value: decoded.Value, ID: decoded.ID}
+	}
+
+	return page, orderBy, nil
+}
+
+// nextPageToken turns the position of the last item of a page into an opaque page token,
+// empty on the last page.
+func nextPageToken(orderBy string, next *domain.Cursor) string {
+	if next == nil {
+		return ""
+	}
+
+	payload, err := json.Marshal(pageToken{OrderBy: orderBy, Value: next.Value, ID: next.ID})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// totalSize returns the total of a page, 0 when it was not requested.
+func totalSize(total *int) int32 {
+	if total == nil {
+		return 0
+	}
+	return int32(*total)
+}
@@ -0,0 +1,317 @@
+package grpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/pkg/protobuf"
+)
+
+// protoFile is the definition the hand-written messages and services must match.
+const protoFile = "../../../api/proto/tasks/v1/tasks.proto"
+
+// protoField is a field of a message as declared in the .proto file.
+type protoField struct {
+	name     string
+	kind     string
+	number   int
+	repeated bool
+}
+
+// protoMethod is an rpc of a service as declared in the .proto file.
+type protoMethod struct {
+	name   string
+	input  string
+	output string
+}
+
+// protoDescriptors holds the messages and services declared in a .proto file, with the
+// well-known types it imports.
+type protoDescriptors struct {
+	pkg      string
+	messages map[string][]protoField
+	services map[string][]protoMethod
+}
+
+var (
+	protoComment = regexp.MustCompile(`//.*`)
+	protoPackage = regexp.MustCompile(`\bpackage\s+([\w.]+)\s*;`)
+	protoMessage = regexp.MustCompile(`\bmessage\s+(\w+)\s*\{([^}]*)\}`)
+	protoFieldRe = regexp.MustCompile(`(repeated\s+)?([\w.]+)\s+(\w+)\s*=\s*(\d+)\s*;`)
+	protoService = regexp.MustCompile(`\bservice\s+(\w+)\s*\{([^}]*)\}`)
+	protoRPC     = regexp.MustCompile(`\brpc\s+(\w+)\s*\(\s*([\w.]+)\s*\)\s*returns\s*\(\s*(stream\s+)?([\w.]+)\s*\)`)
+)
+
+// parseProto reads the messages and services of a .proto file. It understands the subset
+// of the language tasks.proto uses: top-level messages with scalar, message and repeated
+// fields, and services of unary and server streaming rpcs.
+func parseProto(t *testing.T, path string) *protoDescriptors {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	source := protoComment.ReplaceAllString(string(raw), "")
+
+	d := &protoDescriptors{
+		messages: map[string][]protoField{
+			"google.protobuf.FieldMask": {{name: "paths", kind: "string", number: 1, repeated: true}},
+			"google.protobuf.Empty":     {},
+		},
+		services: map[string][]protoMethod{},
+	}
+	if match := protoPackage.FindStringSubmatch(source); match != nil {
+		d.pkg = match[1]
+	}
+	for _, message := range protoMessage.FindAllStringSubmatch(source, -1) {
+		fields := []protoField{}
+		for _, field := range protoFieldRe.FindAllStringSubmatch(message[2], -1) {
+			number := 0
+			for _, digit := range field[4] {
+				number = number*10 + int(digit-'0')
+			}
+			fields = append(fields, protoField{name: field[3], kind: field[2], number: number, repeated: field[1] != ""})
+		}
+		sort.Slice(fields, func(i, j int) bool { return fields[i].number < fields[j].number })
+		d.messages[message[1]] = fields
+	}
+	for _, service := range protoService.FindAllStringSubmatch(source, -1) {
+		for _, rpc := range protoRPC.FindAllStringSubmatch(service[2], -1) {
+			d.services[service[1]] = append(d.services[service[1]], protoMethod{name: rpc[1], input: rpc[2], output: rpc[4]})
+		}
+	}
+	return d
+}
+
+// goMessages maps the messages of tasks.proto to the Go types that encode them.
+var goMessages = map[string]func() protobuf.Message{
+	"Task":                      func() protobuf.Message { return &Task{} },
+	"TaskList":                  func() protobuf.Message { return &TaskList{} },
+	"CreateTaskRequest":         func() protobuf.Message { return &CreateTaskRequest{} },
+	"GetTaskRequest":            func() protobuf.Message { return &IDRequest{} },
+	"ListTasksRequest":          func() protobuf.Message { return &ListTasksRequest{} },
+	"ListTasksResponse":         func() protobuf.Message { return &ListTasksResponse{} },
+	"UpdateTaskRequest":         func() protobuf.Message { return &UpdateTaskRequest{} },
+	"DeleteTaskRequest":         func() protobuf.Message { return &IDRequest{} },
+	"CreateTaskListRequest":     func() protobuf.Message { return &CreateTaskListRequest{} },
+	"GetTaskListRequest":        func() protobuf.Message { return &IDRequest{} },
+	"ListTaskListsRequest":      func() protobuf.Message { return &ListTaskListsRequest{} },
+	"ListTaskListsResponse":     func() protobuf.Message { return &ListTaskListsResponse{} },
+	"UpdateTaskListRequest":     func() protobuf.Message { return &UpdateTaskListRequest{} },
+	"DeleteTaskListRequest":     func() protobuf.Message { return &IDRequest{} },
+	"WatchEventsRequest":        func() protobuf.Message { return &WatchEventsRequest{} },
+	"Event":                     func() protobuf.Message { return &Event{} },
+	"google.protobuf.FieldMask": func() protobuf.Message { return &FieldMask{} },
+	"google.protobuf.Empty":     func() protobuf.Message { return &Empty{} },
+}
+
+// golden encodes a message from its descriptor alone, giving every field a value derived
+// from its name and number, in field number order as protoc-generated code does.
+func (d *protoDescriptors) golden(t *testing.T, message string) []byte {
+	t.Helper()
+	fields, ok := d.messages[message]
+	if !ok {
+		t.Fatalf("message %s is not declared", message)
+	}
+
+	var buf []byte
+	appendBytes := func(number int, v []byte) {
+		buf = binary.AppendUvarint(buf, uint64(number)<<3|uint64(protobuf.Bytes))
+		buf = binary.AppendUvarint(buf, uint64(len(v)))
+		buf = append(buf, v...)
+	}
+	appendVarint := func(number int, v uint64) {
+		buf = binary.AppendUvarint(buf, uint64(number)<<3|uint64(protobuf.Varint))
+		buf = binary.AppendUvarint(buf, v)
+	}
+
+	for _, f := range fields {
+		values := 1
+		if f.repeated {
+			values = 2
+		}
+		for i := 0; i < values; i++ {
+			switch f.kind {
+			case "string":
+				appendBytes(f.number, []byte(sampleString(f, i)))
+			case "bytes":
+				appendBytes(f.number, []byte(`{"`+f.name+`":true}`))
+			case "int32":
+				appendVarint(f.number, uint64(sampleInt32(f)))
+			case "int64":
+				appendVarint(f.number, uint64(sampleInt64(f)))
+			case "bool":
+				appendVarint(f.number, 1)
+			case "google.protobuf.Timestamp":
+				ts := sampleTime(f)
+				var tsBuf []byte
+				tsBuf = binary.AppendUvarint(tsBuf, 1<<3|uint64(protobuf.Varint))
+				tsBuf = binary.AppendUvarint(tsBuf, uint64(ts.Unix()))
+				tsBuf = binary.AppendUvarint(tsBuf, 2<<3|uint64(protobuf.Varint))
+				tsBuf = binary.AppendUvarint(tsBuf, uint64(ts.Nanosecond()))
+				appendBytes(f.number, tsBuf)
+			default:
+				appendBytes(f.number, d.golden(t, f.kind))
+			}
+		}
+	}
+	return buf
+}
+
+func sampleString(f protoField, i int) string {
+	if f.repeated {
+		return f.name + "-" + string(rune('a'+i))
+	}
+	return f.name
+}
+
+func sampleInt32(f protoField) int32 { return int32(100 + f.number) }
+
+func sampleInt64(f protoField) int64 { return int64(f.number) << 33 }
+
+func sampleTime(f protoField) time.Time {
+	return time.Unix(1700000000+int64(f.number), int64(f.number)*1000).UTC()
+}
+
+// goFieldName returns the name of the Go struct field of a .proto field: list_id is ListID.
+func goFieldName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "id" {
+			b.WriteString("ID")
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// checkDecoded reports the fields of a decoded message that do not hold the values golden
+// gave them, following the Go struct field of each .proto field.
+func (d *protoDescriptors) checkDecoded(t *testing.T, message string, v reflect.Value) {
+	t.Helper()
+	v = reflect.Indirect(v)
+	for _, f := range d.messages[message] {
+		field := v.FieldByName(goFieldName(f.name))
+		if !field.IsValid() {
+			t.Errorf("%s.%s: no Go field %s", message, f.name, goFieldName(f.name))
+			continue
+		}
+
+		var got, want interface{}
+		switch f.kind {
+		case "string":
+			got, want = field.Interface(), sampleString(f, 0)
+			if f.repeated {
+				want = []string{sampleString(f, 0), sampleString(f, 1)}
+			}
+		case "bytes":
+			got, want = field.Interface(), []byte(`{"`+f.name+`":true}`)
+		case "int32":
+			got, want = field.Interface(), sampleInt32(f)
+		case "int64":
+			got, want = field.Interface(), sampleInt64(f)
+		case "bool":
+			got, want = field.Interface(), true
+		case "google.protobuf.Timestamp":
+			if field.IsNil() {
+				t.Errorf("%s.%s: timestamp not decoded", message, f.name)
+				continue
+			}
+			got, want = *field.Interface().(*time.Time), sampleTime(f)
+		default:
+			if f.repeated {
+				if field.Len() != 2 {
+					t.Errorf("%s.%s: expected 2 elements, got %d", message, f.name, field.Len())
+					continue
+				}
+				for i := 0; i < field.Len(); i++ {
+					d.checkDecoded(t, f.kind, field.Index(i))
+				}
+			} else if field.IsNil() {
+				t.Errorf("%s.%s: message not decoded", message, f.name)
+			} else {
+				d.checkDecoded(t, f.kind, field)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s.%s (field %d): expected %v, got %v", message, f.name, f.number, want, got)
+		}
+	}
+}
+
+// TestMessages_MatchProto checks every message against its definition in tasks.proto: a
+// message encoded from the .proto alone decodes into the Go field of the same name, and
+// the Go encoding of the decoded message is identical to it. A field number, type or
+// name changed on only one side makes this test fail.
+func TestMessages_MatchProto(t *testing.T) {
+	d := parseProto(t, protoFile)
+	if d.pkg != "tasks.v1" {
+		t.Fatalf("expected package tasks.v1, got %q", d.pkg)
+	}
+
+	for message := range d.messages {
+		t.Run(message, func(t *testing.T) {
+			newMessage, ok := goMessages[message]
+			if !ok {
+				t.Fatalf("message %s has no Go type", message)
+			}
+
+			golden := d.golden(t, message)
+			m := newMessage()
+			if err := m.UnmarshalProto(golden); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			d.checkDecoded(t, message, reflect.ValueOf(m))
+
+			if encoded := protobuf.Marshal(m); !bytes.Equal(encoded, golden) {
+				t.Errorf("expected the Go encoding to match the .proto\n got %x\nwant %x", encoded, golden)
+			}
+		})
+	}
+
+	for message := range goMessages {
+		if _, ok := d.messages[message]; !ok {
+			t.Errorf("Go type of message %s is not declared in the .proto", message)
+		}
+	}
+}
+
+// TestServices_MatchProto calls every rpc of tasks.proto with a request encoded from the
+// .proto alone. Without credentials each call must be rejected as unauthenticated, which
+// the server only does once the method exists and its request decoded.
+func TestServices_MatchProto(t *testing.T) {
+	d := parseProto(t, protoFile)
+	h := NewHandler(&mockTasks{}, &mockLists{}, &mockEvents{})
+
+	rpcs := 0
+	for service, methods := range d.services {
+		for _, method := range methods {
+			rpcs++
+			path := "/" + d.pkg + "." + service + "/" + method.name
+			t.Run(path, func(t *testing.T) {
+				if _, ok := goMessages[method.input]; !ok {
+					t.Fatalf("request %s has no Go type", method.input)
+				}
+				if _, ok := goMessages[method.output]; !ok {
+					t.Fatalf("response %s has no Go type", method.output)
+				}
+				_, status, message := invokeRaw(t, h, path, "", d.golden(t, method.input))
+				if status != "16" {
+					t.Errorf("expected status 16, got %s %q", status, message)
+				}
+			})
+		}
+	}
+	if rpcs != 11 {
+		t.Errorf("expected the 11 rpcs of tasks.proto, got %d", rpcs)
+	}
+}
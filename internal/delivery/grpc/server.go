@@ -0,0 +1,115 @@
+// Package grpc exposes the task and task list use cases as the gRPC services defined in
+// api/proto/tasks/v1/tasks.proto, next to the REST API and with the same JWTs.
+package grpc
+
+import (
+	"context"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	rpc "github.com/G20-00/task-management-service-go/pkg/grpc"
+	"github.com/G20-00/task-management-service-go/pkg/protobuf"
+)
+
+// TaskService define las operaciones de tareas que expone la API gRPC.
+type TaskService interface {
+	Create(listID, title, description, priority, changedBy string) (*domain.Task, error)
+	List(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error)
+	GetByID(id string) (*domain.Task, error)
+	Patch(id string, version int, patch domain.TaskPatch, changedBy string) (*domain.Task, error)
+	Delete(id, changedBy string) error
+}
+
+// TaskListService define las operaciones de listas de tareas que expone la API gRPC.
+type TaskListService interface {
+	Create(name, description string) (*domain.TaskList, error)
+	List(includeArchived bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error)
+	GetByID(id string) (*domain.TaskList, error)
+	Patch(id string, version int, patch domain.TaskListPatch) (*domain.TaskList, error)
+	Delete(id, changedBy string) error
+}
+
+// EventService define la interfaz para suscribirse a los eventos en tiempo real.
+type EventService interface {
+	Subscribe(listID string, lastEventID int64) (*domain.EventStream, error)
+}
+
+// Handler implementa los métodos de los servicios gRPC de tareas, listas y eventos.
+type Handler struct {
+	tasks  TaskService
+	lists  TaskListService
+	events EventService
+}
+
+// NewHandler creates a new Handler instance.
+func NewHandler(tasks TaskService, lists TaskListService, events EventService) *Handler {
+	return &Handler{
+		tasks:  tasks,
+		lists:  lists,
+		events: events,
+	}
+}
+
+// NewServer returns a gRPC server with the services of the handler, whose calls must all
+// be authenticated with a JWT.
+func NewServer(h *Handler) *rpc.Server {
+	server := rpc.NewServer()
+	server.UseUnary(AuthUnaryInterceptor)
+	server.UseStream(AuthStreamInterceptor)
+
+	server.RegisterService(rpc.ServiceDesc{Name: "tasks.v1.TaskService", Methods: []rpc.MethodDesc{
+		unary("CreateTask", h.CreateTask),
+		unary("GetTask", h.GetTask),
+		unary("ListTasks", h.ListTasks),
+		unary("UpdateTask", h.UpdateTask),
+		unary("DeleteTask", h.DeleteTask),
+	}})
+	server.RegisterService(rpc.ServiceDesc{Name: "tasks.v1.TaskListService", Methods: []rpc.MethodDesc{
+		unary("CreateTaskList", h.CreateTaskList),
+		unary("GetTaskList", h.GetTaskList),
+		unary("ListTaskLists", h.ListTaskLists),
+		unary("UpdateTaskList", h.UpdateTaskList),
+		unary("DeleteTaskList", h.DeleteTaskList),
+	}})
+	server.RegisterService(rpc.ServiceDesc{Name: "tasks.v1.EventService", Methods: []rpc.MethodDesc{
+		stream("WatchEvents", h.WatchEvents),
+	}})
+
+	return server
+}
+
+// request constrains the request type of a method to a pointer to a message struct, so
+// that an empty request can be allocated.
+type request[R any] interface {
+	*R
+	protobuf.Message
+}
+
+// unary describes a unary method from a typed handler method.
+func unary[R any, Req request[R], Resp protobuf.Message](name string, method func(context.Context, Req) (Resp, error)) rpc.MethodDesc {
+	return rpc.MethodDesc{
+		Name:       name,
+		NewRequest: func() protobuf.Message { return Req(new(R)) },
+		Unary: func(ctx context.Context, req protobuf.Message) (protobuf.Message, error) {
+			typed, ok := req.(Req)
+			if !ok {
+				return nil, rpc.Errorf(rpc.Internal, "unexpected request type %T", req)
+			}
+			return method(ctx, typed)
+		},
+	}
+}
+
+// stream describes a server streaming method from a typed handler method.
+func stream[R any, Req request[R]](name string, method func(Req, rpc.ServerStream) error) rpc.MethodDesc {
+	return rpc.MethodDesc{
+		Name:       name,
+		NewRequest: func() protobuf.Message { return Req(new(R)) },
+		Stream: func(req protobuf.Message, stream rpc.ServerStream) error {
+			typed, ok := req.(Req)
+			if !ok {
+				return rpc.Errorf(rpc.Internal, "unexpected request type %T", req)
+			}
+			return method(typed, stream)
+		},
+	}
+}
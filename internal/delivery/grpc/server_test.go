@@ -0,0 +1,376 @@
+package grpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	deliveryhttp "github.com/G20-00/task-management-service-go/internal/delivery/http"
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/protobuf"
+)
+
+type mockTasks struct {
+	CreateFn  func(listID, title, description, priority, changedBy string) (*domain.Task, error)
+	ListFn    func(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error)
+	GetByIDFn func(id string) (*domain.Task, error)
+	PatchFn   func(id string, version int, patch domain.TaskPatch) (*domain.Task, error)
+	DeleteFn  func(id string) error
+}
+
+func (m *mockTasks) Create(listID, title, description, priority, changedBy string) (*domain.Task, error) {
+	return m.CreateFn(listID, title, description, priority, changedBy)
+}
+
+func (m *mockTasks) List(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error) {
+	return m.ListFn(filter, page)
+}
+
+func (m *mockTasks) GetByID(id string) (*domain.Task, error) {
+	return m.GetByIDFn(id)
+}
+
+func (m *mockTasks) Patch(id string, version int, patch domain.TaskPatch, _ string) (*domain.Task, error) {
+	return m.PatchFn(id, version, patch)
+}
+
+func (m *mockTasks) Delete(id, _ string) error {
+	return m.DeleteFn(id)
+}
+
+type mockLists struct {
+	CreateFn  func(name, description string) (*domain.TaskList, error)
+	ListFn    func(includeArchived bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error)
+	GetByIDFn func(id string) (*domain.TaskList, error)
+	PatchFn   func(id string, version int, patch domain.TaskListPatch) (*domain.TaskList, error)
+	DeleteFn  func(id string) error
+}
+
+func (m *mockLists) Create(name, description string) (*domain.TaskList, error) {
+	return m.CreateFn(name, description)
+}
+
+func (m *mockLists) List(includeArchived bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error) {
+	return m.ListFn(includeArchived, page)
+}
+
+func (m *mockLists) GetByID(id string) (*domain.TaskList, error) {
+	return m.GetByIDFn(id)
+}
+
+func (m *mockLists) Patch(id string, version int, patch domain.TaskListPatch) (*domain.TaskList, error) {
+	return m.PatchFn(id, version, patch)
+}
+
+func (m *mockLists) Delete(id, _ string) error {
+	return m.DeleteFn(id)
+}
+
+type mockEvents struct {
+	SubscribeFn func(listID string, lastEventID int64) (*domain.EventStream, error)
+}
+
+func (m *mockEvents) Subscribe(listID string, lastEventID int64) (*domain.EventStream, error) {
+	return m.SubscribeFn(listID, lastEventID)
+}
+
+func bearer(t *testing.T) string {
+	t.Helper()
+	token, err := deliveryhttp.GenerateJWT("user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return "Bearer " + token
+}
+
+// invoke calls a method through the server and returns the encoded responses and the
+// status code and message of the call.
+func invoke(t *testing.T, h *Handler, method, authorization string, req protobuf.Message) ([][]byte, string, string) {
+	t.Helper()
+	return invokeRaw(t, h, method, authorization, protobuf.Marshal(req))
+}
+
+// invokeRaw calls method with an already encoded request message.
+func invokeRaw(t *testing.T, h *Handler, method, authorization string, data []byte) ([][]byte, string, string) {
+	t.Helper()
+	body := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(body[1:], uint32(len(data)))
+	body = append(body, data...)
+
+	httpReq := httptest.NewRequest(http.MethodPost, method, bytes.NewReader(body))
+	httpReq.ProtoMajor = 2
+	httpReq.Header.Set("Content-Type", "application/grpc")
+	if authorization != "" {
+		httpReq.Header.Set("Authorization", authorization)
+	}
+
+	rec := httptest.NewRecorder()
+	NewServer(h).ServeHTTP(rec, httpReq)
+	resp := rec.Result()
+
+	var messages [][]byte
+	for raw := rec.Body.Bytes(); len(raw) > 0; {
+		length := binary.BigEndian.Uint32(raw[1:5])
+		messages = append(messages, raw[5:5+length])
+		raw = raw[5+length:]
+	}
+	return messages, resp.Trailer.Get("Grpc-Status"), resp.Trailer.Get("Grpc-Message")
+}
+
+func decode(t *testing.T, messages [][]byte, m protobuf.Message) {
+	t.Helper()
+	if len(messages) != 1 {
+		t.Fatalf("expected one response, got %d", len(messages))
+	}
+	if err := m.UnmarshalProto(messages[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAuthInterceptors(t *testing.T) {
+	h := NewHandler(&mockTasks{}, &mockLists{}, &mockEvents{})
+
+	cases := []struct {
+		name          string
+		method        string
+		authorization string
+		message       string
+	}{
+		{"missing metadata", "/tasks.v1.TaskService/GetTask", "", "missing or invalid authorization metadata"},
+		{"not bearer", "/tasks.v1.TaskService/GetTask", "Basic abc", "missing or invalid authorization metadata"},
+		{"invalid token", "/tasks.v1.TaskService/GetTask", "Bearer nope", "invalid or expired token"},
+		{"stream", "/tasks.v1.EventService/WatchEvents", "", "missing or invalid authorization metadata"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, status, message := invoke(t, h, tc.method, tc.authorization, &IDRequest{ID: "t1"})
+			if status != "16" || message != tc.message {
+				t.Errorf("expected status 16 %q, got %s %q", tc.message, status, message)
+			}
+		})
+	}
+}
+
+func TestTaskService(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	due := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	task := &domain.Task{ID: "t1", ListID: "l1", Title: "Deploy", Status: "pending", Priority: "high",
+		Labels: []string{"ops"}, DueDate: &due, Version: 3, CreatedAt: created, UpdatedAt: created}
+	var createdBy string
+
+	var gotFilter domain.TaskFilter
+	var gotPage domain.PageRequest
+	var gotPatch domain.TaskPatch
+	var gotVersion int
+	tasks := &mockTasks{
+		CreateFn: func(listID, title, description, priority, changedBy string) (*domain.Task, error) {
+			createdBy = changedBy
+			if title == "" {
+				return nil, domain.NewInvalidError("title", "invalid_title", "title cannot be empty")
+			}
+			return task, nil
+		},
+		GetByIDFn: func(id string) (*domain.Task, error) {
+			switch id {
+			case "t1":
+				return task, nil
+			case "broken":
+				return nil, errors.New("pq: connection refused")
+			}
+			return nil, domain.ErrTaskNotFound
+		},
+		ListFn: func(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error) {
+			gotFilter, gotPage = filter, page
+			total := 7
+			return &domain.Page[*domain.Task]{Items: []*domain.Task{task}, Next: &domain.Cursor{Value: "v", ID: "t1"}, Total: &total}, nil
+		},
+		PatchFn: func(id string, version int, patch domain.TaskPatch) (*domain.Task, error) {
+			gotPatch, gotVersion = patch, version
+			if version == 1 {
+				return nil, domain.ErrVersionConflict
+			}
+			return task, nil
+		},
+		DeleteFn: func(id string) error { return nil },
+	}
+	h := NewHandler(tasks, &mockLists{}, &mockEvents{})
+	auth := bearer(t)
+
+	t.Run("create", func(t *testing.T) {
+		messages, status, _ := invoke(t, h, "/tasks.v1.TaskService/CreateTask", auth, &CreateTaskRequest{ListID: "l1", Title: "Deploy"})
+		got := &Task{}
+		decode(t, messages, got)
+		if status != "0" || got.ID != "t1" || got.Version != 3 || !got.DueDate.Equal(due) || !got.CreatedAt.Equal(created) || got.Labels[0] != "ops" {
+			t.Errorf("unexpected response %s %+v", status, got)
+		}
+		if createdBy != "user-1" {
+			t.Errorf("expected the task created by user-1, got %q", createdBy)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		messages, status, _ := invoke(t, h, "/tasks.v1.TaskService/ListTasks", auth, &ListTasksRequest{
+			ListID: "l1", Status: "pending", Filter: `title ~ "x"`, PageSize: 10, OrderBy: "title", IncludeTotal: true,
+		})
+		got := &ListTasksResponse{}
+		decode(t, messages, got)
+		if status != "0" || len(got.Tasks) != 1 || got.TotalSize != 7 || got.NextPageToken == "" {
+			t.Fatalf("unexpected response %s %+v", status, got)
+		}
+		if gotFilter.ListID != "l1" || gotFilter.Status != "pending" || gotFilter.Expression != `title ~ "x"` ||
+			gotPage.Limit != 10 || gotPage.Sort != "title" || gotPage.Desc || !gotPage.IncludeTotal {
+			t.Errorf("unexpected filter %+v and page %+v", gotFilter, gotPage)
+		}
+
+		_, _, _ = invoke(t, h, "/tasks.v1.TaskService/ListTasks", auth, &ListTasksRequest{OrderBy: "title", PageToken: got.NextPageToken})
+		if gotPage.After == nil || gotPage.After.ID != "t1" || gotPage.After.Value != "v" {
+			t.Errorf("expected the page after t1, got %+v", gotPage.After)
+		}
+
+		_, status, message := invoke(t, h, "/tasks.v1.TaskService/ListTasks", auth, &ListTasksRequest{PageToken: got.NextPageToken})
+		if status != "3" || message != "invalid page_token: it was issued for order_by title" {
+			t.Errorf("expected the token to be rejected, got %s %q", status, message)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		_, status, _ := invoke(t, h, "/tasks.v1.TaskService/UpdateTask", auth, &UpdateTaskRequest{
+			Task:       &Task{ID: "t1", Title: "Nuevo", Version: 3},
+			UpdateMask: &FieldMask{Paths: []string{"title", "due_date", "labels"}},
+		})
+		if status != "0" || gotVersion != 3 {
+			t.Fatalf("unexpected status %s, version %d", status, gotVersion)
+		}
+		if !gotPatch.Title.Set || gotPatch.Title.Value != "Nuevo" || !gotPatch.DueDate.Null || !gotPatch.Labels.Null || gotPatch.Status.Set {
+			t.Errorf("unexpected patch %+v", gotPatch)
+		}
+	})
+
+	cases := []struct {
+		name    string
+		method  string
+		req     protobuf.Message
+		status  string
+		message string
+	}{
+		{"not found", "/tasks.v1.TaskService/GetTask", &IDRequest{ID: "t9"}, "5", "task not found"},
+		{"invalid argument", "/tasks.v1.TaskService/CreateTask", &CreateTaskRequest{ListID: "l1"}, "3", "title cannot be empty"},
+		{"version conflict", "/tasks.v1.TaskService/UpdateTask", &UpdateTaskRequest{Task: &Task{ID: "t1", Title: "x", Version: 1}, UpdateMask: &FieldMask{Paths: []string{"title"}}}, "10", "version conflict"},
+		{"missing mask", "/tasks.v1.TaskService/UpdateTask", &UpdateTaskRequest{Task: &Task{ID: "t1"}}, "3", "invalid request: update_mask is required"},
+		{"unknown mask path", "/tasks.v1.TaskService/UpdateTask", &UpdateTaskRequest{Task: &Task{ID: "t1"}, UpdateMask: &FieldMask{Paths: []string{"version"}}}, "3", `invalid update_mask: field "version" cannot be updated`},
+		{"internal error", "/tasks.v1.TaskService/GetTask", &IDRequest{ID: "broken"}, "13", "internal error"},
+		{"delete", "/tasks.v1.TaskService/DeleteTask", &IDRequest{ID: "t1"}, "0", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, status, message := invoke(t, h, tc.method, auth, tc.req)
+			if status != tc.status || message != tc.message {
+				t.Errorf("expected status %s %q, got %s %q", tc.status, tc.message, status, message)
+			}
+		})
+	}
+}
+
+func TestTaskListService(t *testing.T) {
+	list := &domain.TaskList{ID: "l1", Name: "Backlog", Version: 2}
+
+	var gotPatch domain.TaskListPatch
+	lists := &mockLists{
+		CreateFn: func(name, description string) (*domain.TaskList, error) { return list, nil },
+		GetByIDFn: func(id string) (*domain.TaskList, error) {
+			return nil, domain.ErrTaskListNotFound
+		},
+		ListFn: func(includeArchived bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error) {
+			if !includeArchived || page.Sort != "created_at" || !page.Desc {
+				t.Errorf("unexpected listing %v %+v", includeArchived, page)
+			}
+			return &domain.Page[*domain.TaskList]{Items: []*domain.TaskList{list}}, nil
+		},
+		PatchFn: func(id string, version int, patch domain.TaskListPatch) (*domain.TaskList, error) {
+			gotPatch = patch
+			return list, nil
+		},
+		DeleteFn: func(id string) error { return nil },
+	}
+	h := NewHandler(&mockTasks{}, lists, &mockEvents{})
+	auth := bearer(t)
+
+	messages, status, _ := invoke(t, h, "/tasks.v1.TaskListService/CreateTaskList", auth, &CreateTaskListRequest{Name: "Backlog"})
+	created := &TaskList{}
+	decode(t, messages, created)
+	if status != "0" || created.ID != "l1" || created.Version != 2 {
+		t.Errorf("unexpected response %s %+v", status, created)
+	}
+
+	messages, status, _ = invoke(t, h, "/tasks.v1.TaskListService/ListTaskLists", auth, &ListTaskListsRequest{IncludeArchived: true})
+	page := &ListTaskListsResponse{}
+	decode(t, messages, page)
+	if status != "0" || len(page.TaskLists) != 1 || page.NextPageToken != "" {
+		t.Errorf("unexpected response %s %+v", status, page)
+	}
+
+	_, status, _ = invoke(t, h, "/tasks.v1.TaskListService/UpdateTaskList", auth, &UpdateTaskListRequest{
+		TaskList: &TaskList{ID: "l1"}, UpdateMask: &FieldMask{Paths: []string{"description"}},
+	})
+	if status != "0" || !gotPatch.Description.Null || gotPatch.Name.Set {
+		t.Errorf("unexpected status %s and patch %+v", status, gotPatch)
+	}
+
+	_, status, message := invoke(t, h, "/tasks.v1.TaskListService/GetTaskList", auth, &IDRequest{ID: "l9"})
+	if status != "5" || message != "task list not found" {
+		t.Errorf("expected not found, got %s %q", status, message)
+	}
+}
+
+func TestEventService_WatchEvents(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	live := make(chan *domain.Event, 3)
+	live <- &domain.Event{ID: "e2", Sequence: 2, Type: domain.EventTaskUpdated, ListID: "l1", OccurredAt: at}
+	live <- &domain.Event{ID: "e3", Sequence: 3, Type: domain.EventTaskDeleted, ListID: "l1", OccurredAt: at}
+	close(live)
+
+	closed := false
+	events := &mockEvents{SubscribeFn: func(listID string, lastEventID int64) (*domain.EventStream, error) {
+		if listID == "l9" {
+			return nil, domain.ErrTaskListNotFound
+		}
+		if listID != "l1" || lastEventID != 1 {
+			t.Errorf("unexpected subscription %s %d", listID, lastEventID)
+		}
+		return &domain.EventStream{
+			Replay: []*domain.Event{{ID: "e2", Sequence: 2, Type: domain.EventTaskUpdated, ListID: "l1", OccurredAt: at, Data: []byte(`{"id":"t1"}`)}},
+			Events: live,
+			Close:  func() { closed = true },
+		}, nil
+	}}
+	h := NewHandler(&mockTasks{}, &mockLists{}, events)
+	auth := bearer(t)
+
+	messages, status, message := invoke(t, h, "/tasks.v1.EventService/WatchEvents", auth, &WatchEventsRequest{ListID: "l1", AfterSequence: 1})
+	if len(messages) != 2 {
+		t.Fatalf("expected the replayed event and one live event, got %d", len(messages))
+	}
+	first, second := &Event{}, &Event{}
+	decode(t, messages[:1], first)
+	decode(t, messages[1:], second)
+	if first.Sequence != 2 || string(first.Data) != `{"id":"t1"}` || !first.OccurredAt.Equal(at) || second.ID != "e3" {
+		t.Errorf("unexpected events %+v %+v", first, second)
+	}
+	if status != "8" || message == "" {
+		t.Errorf("expected the stream to end with status 8, got %s %q", status, message)
+	}
+	if !closed {
+		t.Error("expected the subscription to be closed")
+	}
+
+	_, status, _ = invoke(t, h, "/tasks.v1.EventService/WatchEvents", auth, &WatchEventsRequest{ListID: "l9"})
+	if status != "5" {
+		t.Errorf("expected status 5, got %s", status)
+	}
+}
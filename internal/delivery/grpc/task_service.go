@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	rpc "github.com/G20-00/task-management-service-go/pkg/grpc"
+)
+
+// CreateTask creates a task in a list.
+func (h *Handler) CreateTask(ctx context.Context, req *CreateTaskRequest) (*Task, error) {
+	t, err := h.tasks.Create(req.ListID, req.Title, req.Description, req.Priority, CurrentUserID(ctx))
+	if err != nil {
+		return nil, statusError("CreateTask", err)
+	}
+
+	return toTask(t), nil
+}
+
+// GetTask returns a task by its ID.
+func (h *Handler) GetTask(_ context.Context, req *IDRequest) (*Task, error) {
+	t, err := h.tasks.GetByID(req.ID)
+	if err != nil {
+		return nil, statusError("GetTask", err)
+	}
+	return toTask(t), nil
+}
+
+// ListTasks returns one page of the tasks matching the filters of the request.
+func (h *Handler) ListTasks(_ context.Context, req *ListTasksRequest) (*ListTasksResponse, error) {
+	page, orderBy, err := pageRequest(req.PageSize, req.PageToken, req.OrderBy, req.IncludeTotal)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.tasks.List(domain.TaskFilter{
+		ListID:          req.ListID,
+		Status:          req.Status,
+		Priority:        req.Priority,
+		IncludeArchived: req.IncludeArchived,
+		Expression:      req.Filter,
+	}, page)
+	if err != nil {
+		return nil, statusError("ListTasks", err)
+	}
+
+	resp := &ListTasksResponse{
+		Tasks:         make([]*Task, 0, len(result.Items)),
+		NextPageToken: nextPageToken(orderBy, result.Next),
+		TotalSize:     totalSize(result.Total),
+	}
+	for _, t := range result.Items {
+		resp.Tasks = append(resp.Tasks, toTask(t))
+	}
+	return resp, nil
+}
+
+// UpdateTask changes the fields of a task named by the update mask. A version other than
+// 0 in the task guards the update against lost writes.
+func (h *Handler) UpdateTask(ctx context.Context, req *UpdateTaskRequest) (*Task, error) {
+	if req.Task == nil || req.Task.ID == "" {
+		return nil, rpc.Errorf(rpc.InvalidArgument, "invalid request: task.id is required")
+	}
+	if req.UpdateMask == nil || len(req.UpdateMask.Paths) == 0 {
+		return nil, rpc.Errorf(rpc.InvalidArgument, "invalid request: update_mask is required")
+	}
+	patch, err := newTaskPatch(req.Task, req.UpdateMask.Paths)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := h.tasks.Patch(req.Task.ID, int(req.Task.Version), patch, CurrentUserID(ctx))
+	if err != nil {
+		return nil, statusError("UpdateTask", err)
+	}
+
+	return toTask(t), nil
+}
+
+// DeleteTask moves a task to the trash.
+func (h *Handler) DeleteTask(ctx context.Context, req *IDRequest) (*Empty, error) {
+	if err := h.tasks.Delete(req.ID, CurrentUserID(ctx)); err != nil {
+		return nil, statusError("DeleteTask", err)
+	}
+	return &Empty{}, nil
+}
+
+// newTaskPatch turns the paths of an update mask into a partial update. Since proto3 does
+// not tell an empty field from an unset one, a named field left empty is cleared.
+func newTaskPatch(t *Task, paths []string) (domain.TaskPatch, error) {
+	patch := domain.TaskPatch{}
+
+	for _, path := range paths {
+		switch path {
+		case "list_id":
+			patch.ListID = stringPatch(t.ListID)
+		case "title":
+			patch.Title = stringPatch(t.Title)
+		case "description":
+			patch.Description = stringPatch(t.Description)
+		case "status":
+			patch.Status = stringPatch(t.Status)
+		case "priority":
+			patch.Priority = stringPatch(t.Priority)
+		case "due_date":
+			patch.DueDate = domain.PatchField[time.Time]{Set: true, Null: t.DueDate == nil}
+			if t.DueDate != nil {
+				patch.DueDate.Value = *t.DueDate
+			}
+		case "labels":
+			patch.Labels = domain.PatchField[[]string]{Set: true, Null: len(t.Labels) == 0, Value: t.Labels}
+		default:
+			return patch, rpc.Errorf(rpc.InvalidArgument, "invalid update_mask: field %q cannot be updated", path)
+		}
+	}
+
+	return patch, nil
+}
+
+func stringPatch(value string) domain.PatchField[string] {
+	return domain.PatchField[string]{Set: true, Null: value == "", Value: value}
+}
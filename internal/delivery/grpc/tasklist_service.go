@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	rpc "github.com/G20-00/task-management-service-go/pkg/grpc"
+)
+
+// CreateTaskList creates a task list.
+func (h *Handler) CreateTaskList(_ context.Context, req *CreateTaskListRequest) (*TaskList, error) {
+	list, err := h.lists.Create(req.Name, req.Description)
+	if err != nil {
+		return nil, statusError("CreateTaskList", err)
+	}
+	return toTaskList(list), nil
+}
+
+// GetTaskList returns a task list by its ID.
+func (h *Handler) GetTaskList(_ context.Context, req *IDRequest) (*TaskList, error) {
+	list, err := h.lists.GetByID(req.ID)
+	if err != nil {
+		return nil, statusError("GetTaskList", err)
+	}
+	return toTaskList(list), nil
+}
+
+// ListTaskLists returns one page of the task lists.
+func (h *Handler) ListTaskLists(_ context.Context, req *ListTaskListsRequest) (*ListTaskListsResponse, error) {
+	page, orderBy, err := pageRequest(req.PageSize, req.PageToken, req.OrderBy, req.IncludeTotal)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.lists.List(req.IncludeArchived, page)
+	if err != nil {
+		return nil, statusError("ListTaskLists", err)
+	}
+
+	resp := &ListTaskListsResponse{
+		TaskLists:     make([]*TaskList, 0, len(result.Items)),
+		NextPageToken: nextPageToken(orderBy, result.Next),
+		TotalSize:     totalSize(result.Total),
+	}
+	for _, list := range result.Items {
+		resp.TaskLists = append(resp.TaskLists, toTaskList(list))
+	}
+	return resp, nil
+}
+
+// UpdateTaskList changes the fields of a task list named by the update mask. A version
+// other than 0 in the list guards the update against lost writes.
+func (h *Handler) UpdateTaskList(_ context.Context, req *UpdateTaskListRequest) (*TaskList, error) {
+	if req.TaskList == nil || req.TaskList.ID == "" {
+		return nil, rpc.Errorf(rpc.InvalidArgument, "invalid request: task_list.id is required")
+	}
+	if req.UpdateMask == nil || len(req.UpdateMask.Paths) == 0 {
+		return nil, rpc.Errorf(rpc.InvalidArgument, "invalid request: update_mask is required")
+	}
+
+	patch := domain.TaskListPatch{}
+	for _, path := range req.UpdateMask.Paths {
+		switch path {
+		case "name":
+			patch.Name = stringPatch(req.TaskList.Name)
+		case "description":
+			patch.Description = stringPatch(req.TaskList.Description)
+		default:
+			return nil, rpc.Errorf(rpc.InvalidArgument, "invalid update_mask: field %q cannot be updated", path)
+		}
+	}
+
+	list, err := h.lists.Patch(req.TaskList.ID, int(req.TaskList.Version), patch)
+	if err != nil {
+		return nil, statusError("UpdateTaskList", err)
+	}
+	return toTaskList(list), nil
+}
+
+// DeleteTaskList moves a task list and its tasks to the trash.
+func (h *Handler) DeleteTaskList(ctx context.Context, req *IDRequest) (*Empty, error) {
+	if err := h.lists.Delete(req.ID, CurrentUserID(ctx)); err != nil {
+		return nil, statusError("DeleteTaskList", err)
+	}
+	return &Empty{}, nil
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// ArchiveService define la interfaz para operaciones de archivado.
+type ArchiveService interface {
+	ArchiveTask(id, changedBy string) error
+	UnarchiveTask(id, changedBy string) error
+	ArchiveList(id string) error
+	UnarchiveList(id string) error
+	ArchiveCompletedInList(listID, changedBy string) (int64, error)
+}
+
+// ArchiveHandler maneja las solicitudes HTTP de archivado de tareas y listas.
+type ArchiveHandler struct {
+	service ArchiveService
+}
+
+// NewArchiveHandler creates a new ArchiveHandler instance.
+func NewArchiveHandler(service ArchiveService) *ArchiveHandler {
+	return &ArchiveHandler{
+		service: service,
+	}
+}
+
+// ArchiveTask archives a task.
+func (h *ArchiveHandler) ArchiveTask(c *fiber.Ctx) error {
+	return h.handle(c, "ArchiveTask", func(id string) error {
+		return h.service.ArchiveTask(id, CurrentUserID(c))
+	})
+}
+
+// UnarchiveTask unarchives a task.
+func (h *ArchiveHandler) UnarchiveTask(c *fiber.Ctx) error {
+	return h.handle(c, "UnarchiveTask", func(id string) error {
+		return h.service.UnarchiveTask(id, CurrentUserID(c))
+	})
+}
+
+// ArchiveTaskList archives a task list, making it read-only.
+func (h *ArchiveHandler) ArchiveTaskList(c *fiber.Ctx) error {
+	return h.handle(c, "ArchiveTaskList", h.service.ArchiveList)
+}
+
+// UnarchiveTaskList unarchives a task list.
+func (h *ArchiveHandler) UnarchiveTaskList(c *fiber.Ctx) error {
+	return h.handle(c, "UnarchiveTaskList", h.service.UnarchiveList)
+}
+
+// ArchiveCompletedTasks archives all completed tasks of a task list.
+func (h *ArchiveHandler) ArchiveCompletedTasks(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	count, err := h.service.ArchiveCompletedInList(id, CurrentUserID(c))
+	if err != nil {
+		return useCaseError(c, "ArchiveCompletedTasks", err, "Failed to archive completed tasks")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"archived": count,
+	})
+}
+
+func (h *ArchiveHandler) handle(c *fiber.Ctx, method string, action func(id string) error) error {
+	id := c.Params("id")
+
+	if err := action(id); err != nil {
+		return useCaseError(c, method, err, "Failed to change archived state")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
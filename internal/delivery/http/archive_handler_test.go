@@ -0,0 +1,128 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockArchiveService struct {
+	ArchiveTaskFn            func(id string) error
+	ArchiveCompletedInListFn func(listID string) (int64, error)
+}
+
+func (m *mockArchiveService) ArchiveTask(id, _ string) error {
+	if m.ArchiveTaskFn != nil {
+		return m.ArchiveTaskFn(id)
+	}
+	return nil
+}
+func (m *mockArchiveService) UnarchiveTask(string, string) error { return nil }
+func (m *mockArchiveService) ArchiveList(string) error           { return nil }
+func (m *mockArchiveService) UnarchiveList(string) error         { return nil }
+func (m *mockArchiveService) ArchiveCompletedInList(listID, _ string) (int64, error) {
+	if m.ArchiveCompletedInListFn != nil {
+		return m.ArchiveCompletedInListFn(listID)
+	}
+	return 0, nil
+}
+
+func TestArchiveTask_Success(t *testing.T) {
+	app := newTestApp()
+	h := NewArchiveHandler(&mockArchiveService{})
+	app.Post("/tasks/:id/archive", h.ArchiveTask)
+	resp, err := app.Test(httptest.NewRequest("POST", "/tasks/1/archive", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNoContent {
+		t.Errorf("expected 204, got %d", resp.StatusCode)
+	}
+}
+
+func TestArchiveTask_NotFound(t *testing.T) {
+	app := newTestApp()
+	h := NewArchiveHandler(&mockArchiveService{
+		ArchiveTaskFn: func(id string) error { return domain.ErrTaskNotFound },
+	})
+	app.Post("/tasks/:id/archive", h.ArchiveTask)
+	resp, err := app.Test(httptest.NewRequest("POST", "/tasks/1/archive", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestArchiveCompletedTasks_ReturnsCount(t *testing.T) {
+	app := newTestApp()
+	h := NewArchiveHandler(&mockArchiveService{
+		ArchiveCompletedInListFn: func(listID string) (int64, error) { return 5, nil },
+	})
+	app.Post("/lists/:id/archive-completed", h.ArchiveCompletedTasks)
+	resp, err := app.Test(httptest.NewRequest("POST", "/lists/l1/archive-completed", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	var body map[string]int64
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("error decoding body: %v", err)
+	}
+	if body["archived"] != 5 {
+		t.Errorf("expected 5 archived, got %d", body["archived"])
+	}
+}
+
+func TestArchiveCompletedTasks_ListNotWritable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"trashed list", domain.ErrTaskListNotFound, fiber.StatusNotFound},
+		{"archived list", domain.ErrTaskListArchived, fiber.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp()
+			h := NewArchiveHandler(&mockArchiveService{
+				ArchiveCompletedInListFn: func(string) (int64, error) { return 0, tt.err },
+			})
+			app.Post("/lists/:id/archive-completed", h.ArchiveCompletedTasks)
+			resp, err := app.Test(httptest.NewRequest("POST", "/lists/l1/archive-completed", http.NoBody))
+			if err != nil {
+				t.Fatalf("error ejecutando app.Test: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestGetTasks_IncludeArchived(t *testing.T) {
+	app := newTestApp()
+	var gotIncludeArchived bool
+	h := NewTaskHandler(&mockTaskService{
+		ListFn: func(filter domain.TaskFilter, _ domain.PageRequest) (*domain.Page[*domain.Task], error) {
+			gotIncludeArchived = filter.IncludeArchived
+			return &domain.Page[*domain.Task]{Items: []*domain.Task{{ID: "1", CreatedAt: time.Now(), UpdatedAt: time.Now()}}}, nil
+		},
+	})
+	app.Get("/tasks", h.GetTasks)
+	resp, err := app.Test(httptest.NewRequest("GET", "/tasks?include_archived=true", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK || !gotIncludeArchived {
+		t.Errorf("expected 200 with archived tasks included, got %d (include_archived=%v)", resp.StatusCode, gotIncludeArchived)
+	}
+}
@@ -11,11 +11,11 @@ func AuthHandler(c *fiber.Ctx) error {
 	}
 	var req loginRequest
 	if err := c.BodyParser(&req); err != nil || req.UserID == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request")
 	}
 	token, err := GenerateJWT(req.UserID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Could not generate token"})
+		return fiber.NewError(fiber.StatusInternalServerError, "Could not generate token")
 	}
 	return c.JSON(fiber.Map{"token": token})
 }
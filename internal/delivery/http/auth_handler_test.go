@@ -10,7 +10,7 @@ import (
 )
 
 func TestAuthHandler_InvalidBody(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	app.Post("/login", AuthHandler)
 	req := httptest.NewRequest("POST", "/login", strings.NewReader("{"))
 	req.Header.Set("Content-Type", "application/json")
@@ -24,7 +24,7 @@ func TestAuthHandler_InvalidBody(t *testing.T) {
 }
 
 func TestAuthHandler_EmptyUserID(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	app.Post("/login", AuthHandler)
 	body := `{"user_id": ""}`
 	req := httptest.NewRequest("POST", "/login", strings.NewReader(body))
@@ -39,7 +39,7 @@ func TestAuthHandler_EmptyUserID(t *testing.T) {
 }
 
 func TestAuthHandler_Success(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	app.Post("/login", AuthHandler)
 	body := `{"user_id": "user1"}`
 	req := httptest.NewRequest("POST", "/login", strings.NewReader(body))
@@ -6,20 +6,34 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-// JWTMiddleware verifica el JWT en el header Authorization.
+// userIDLocalsKey es la clave bajo la que JWTMiddleware guarda el usuario autenticado.
+const userIDLocalsKey = "user_id"
+
+// JWTMiddleware verifica el JWT en el header Authorization y guarda el usuario en c.Locals.
+// A request already authenticated, e.g. by RegisterAuthMiddleware, is not verified again.
 func JWTMiddleware(c *fiber.Ctx) error {
+	if CurrentUserID(c) != "" {
+		return c.Next()
+	}
 	header := c.Get("Authorization")
 	if header == "" || !strings.HasPrefix(header, "Bearer ") {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Missing or invalid Authorization header"})
+		return fiber.NewError(fiber.StatusUnauthorized, "Missing or invalid Authorization header")
 	}
 	tokenStr := strings.TrimPrefix(header, "Bearer ")
 	token, err := ParseJWT(tokenStr)
 	if err != nil || !token.Valid {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid or expired token"})
+		return fiber.NewError(fiber.StatusUnauthorized, "Invalid or expired token")
 	}
-	_, ok := GetUserIDFromToken(token)
+	userID, ok := GetUserIDFromToken(token)
 	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid token claims"})
+		return fiber.NewError(fiber.StatusUnauthorized, "Invalid token claims")
 	}
+	c.Locals(userIDLocalsKey, userID)
 	return c.Next()
 }
+
+// CurrentUserID devuelve el usuario autenticado por JWTMiddleware, o "" si no hay ninguno.
+func CurrentUserID(c *fiber.Ctx) string {
+	userID, _ := c.Locals(userIDLocalsKey).(string) //nolint:errcheck
+	return userID
+}
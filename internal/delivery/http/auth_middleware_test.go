@@ -9,7 +9,7 @@ import (
 )
 
 func TestJWTMiddleware_MissingHeader(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	app.Use(JWTMiddleware)
 	app.Get("/protected", func(c *fiber.Ctx) error { return c.SendStatus(200) })
 	resp, err := app.Test(httptest.NewRequest("GET", "/protected", http.NoBody))
@@ -22,7 +22,7 @@ func TestJWTMiddleware_MissingHeader(t *testing.T) {
 }
 
 func TestJWTMiddleware_InvalidHeader(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	app.Use(JWTMiddleware)
 	app.Get("/protected", func(c *fiber.Ctx) error { return c.SendStatus(200) })
 	req := httptest.NewRequest("GET", "/protected", http.NoBody)
@@ -37,7 +37,7 @@ func TestJWTMiddleware_InvalidHeader(t *testing.T) {
 }
 
 func TestJWTMiddleware_InvalidToken(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	app.Use(JWTMiddleware)
 	app.Get("/protected", func(c *fiber.Ctx) error { return c.SendStatus(200) })
 	req := httptest.NewRequest("GET", "/protected", http.NoBody)
@@ -56,7 +56,7 @@ func TestJWTMiddleware_ValidToken(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error generando JWT: %v", err)
 	}
-	app := fiber.New()
+	app := newTestApp()
 	app.Use(JWTMiddleware)
 	app.Get("/protected", func(c *fiber.Ctx) error { return c.SendStatus(200) })
 	req := httptest.NewRequest("GET", "/protected", http.NoBody)
@@ -69,3 +69,25 @@ func TestJWTMiddleware_ValidToken(t *testing.T) {
 		t.Errorf("expected 200, got %d", resp.StatusCode)
 	}
 }
+
+func TestJWTMiddleware_StoresUserID(t *testing.T) {
+	app := newTestApp()
+	app.Use(JWTMiddleware)
+	var userID string
+	app.Get("/protected", func(c *fiber.Ctx) error {
+		userID = CurrentUserID(c)
+		return c.SendStatus(200)
+	})
+	token, err := GenerateJWT("user-42")
+	if err != nil {
+		t.Fatalf("GenerateJWT error: %v", err)
+	}
+	req := httptest.NewRequest("GET", "/protected", http.NoBody)
+	req.Header.Set("Authorization", "Bearer "+token)
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("app.Test error: %v", err)
+	}
+	if userID != "user-42" {
+		t.Errorf("expected user-42, got %q", userID)
+	}
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// BulkTaskRequest represents the request body of a bulk task operation.
+type BulkTaskRequest struct {
+	Action string   `json:"action"`
+	IDs    []string `json:"ids"`
+	Filter string   `json:"filter"`
+	// Mode is "atomic" or "best-effort" (the default).
+	Mode         string              `json:"mode"`
+	Tasks        []CreateTaskRequest `json:"tasks"`
+	Fields       json.RawMessage     `json:"fields"`
+	ListID       string              `json:"list_id"`
+	AddLabels    []string            `json:"add_labels"`
+	RemoveLabels []string            `json:"remove_labels"`
+}
+
+// BulkItemResponse represents the outcome of one item of a bulk operation.
+type BulkItemResponse struct {
+	Index  int           `json:"index"`
+	ID     string        `json:"id,omitempty"`
+	Status int           `json:"status"`
+	Code   string        `json:"code,omitempty"`
+	Error  string        `json:"error,omitempty"`
+	Task   *TaskResponse `json:"task,omitempty"`
+}
+
+// BulkTaskResponse represents the response body of a bulk task operation.
+type BulkTaskResponse struct {
+	Applied   bool               `json:"applied"`
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Results   []BulkItemResponse `json:"results"`
+}
+
+// toBulkRequest maps the request body to a bulk request. fields is read as a merge
+// patch, so null clears a field the same way it does on PATCH.
+func (r *BulkTaskRequest) toBulkRequest() (domain.BulkRequest, error) {
+	req := domain.BulkRequest{
+		Action:       r.Action,
+		IDs:          r.IDs,
+		Filter:       r.Filter,
+		ListID:       r.ListID,
+		AddLabels:    r.AddLabels,
+		RemoveLabels: r.RemoveLabels,
+	}
+
+	switch r.Mode {
+	case "atomic":
+		req.Atomic = true
+	case "", "best-effort":
+	default:
+		return req, invalidBulkRequest("mode", "invalid bulk request: mode must be atomic or best-effort")
+	}
+
+	for _, t := range r.Tasks {
+		req.Tasks = append(req.Tasks, domain.NewTask{ListID: t.ListID, Title: t.Title, Description: t.Description, Priority: t.Priority})
+	}
+
+	if len(r.Fields) > 0 {
+		changes, err := mergePatchChanges(r.Fields)
+		if err != nil {
+			return req, invalidBulkRequest("fields", "invalid bulk request: fields must be a JSON object")
+		}
+		if req.Fields, err = newTaskPatch(changes); err != nil {
+			return req, invalidBulkRequest("fields", err.Error())
+		}
+	}
+
+	return req, nil
+}
+
+// invalidBulkRequest returns the error of a field of a bulk request that cannot be read.
+func invalidBulkRequest(field, message string) error {
+	return &requestError{status: fiber.StatusBadRequest, code: "invalid_bulk_request", field: field, message: message}
+}
@@ -0,0 +1,30 @@
+package http
+
+import "time"
+
+// CreateChecklistItemRequest represents the request body for adding a checklist item.
+type CreateChecklistItemRequest struct {
+	Title string `json:"title"`
+}
+
+// UpdateChecklistItemRequest represents the request body for renaming or checking a checklist item.
+// Omitted fields are left unchanged.
+type UpdateChecklistItemRequest struct {
+	Title *string `json:"title" openapi:"nullable"`
+	Done  *bool   `json:"done" openapi:"nullable"`
+}
+
+// ReorderChecklistRequest represents the request body for reordering a checklist.
+type ReorderChecklistRequest struct {
+	ItemIDs []string `json:"item_ids"`
+}
+
+// ChecklistItemResponse represents the response body for a checklist item.
+type ChecklistItemResponse struct {
+	ID        string    `json:"id"`
+	Position  int       `json:"position"`
+	Title     string    `json:"title"`
+	Done      bool      `json:"done"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
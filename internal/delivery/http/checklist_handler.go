@@ -0,0 +1,133 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// ChecklistService define la interfaz para operaciones de checklists de tareas.
+type ChecklistService interface {
+	GetItems(taskID string) ([]*domain.ChecklistItem, error)
+	AddItem(taskID, title string) (*domain.ChecklistItem, error)
+	UpdateItem(taskID, id string, title *string, done *bool) (*domain.ChecklistItem, error)
+	ToggleItem(taskID, id string) (*domain.ChecklistItem, error)
+	Reorder(taskID string, ids []string) ([]*domain.ChecklistItem, error)
+	DeleteItem(taskID, id string) error
+	ConvertToTask(taskID, id, changedBy string) (*domain.Task, error)
+}
+
+// ChecklistHandler maneja las solicitudes HTTP de los checklists de tareas.
+type ChecklistHandler struct {
+	service ChecklistService
+}
+
+// NewChecklistHandler creates a new ChecklistHandler instance.
+func NewChecklistHandler(service ChecklistService) *ChecklistHandler {
+	return &ChecklistHandler{
+		service: service,
+	}
+}
+
+// GetChecklist retrieves the ordered checklist of a task.
+func (h *ChecklistHandler) GetChecklist(c *fiber.Ctx) error {
+	items, err := h.service.GetItems(c.Params("id"))
+	if err != nil {
+		return useCaseError(c, "GetChecklist", err, "Failed to get checklist")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newChecklistResponses(items))
+}
+
+// AddChecklistItem appends an item to the checklist of a task.
+func (h *ChecklistHandler) AddChecklistItem(c *fiber.Ctx) error {
+	var req CreateChecklistItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	item, err := h.service.AddItem(c.Params("id"), req.Title)
+	if err != nil {
+		return useCaseError(c, "AddChecklistItem", err, "Failed to add checklist item")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(newChecklistResponse(item))
+}
+
+// UpdateChecklistItem renames a checklist item or sets its done state.
+func (h *ChecklistHandler) UpdateChecklistItem(c *fiber.Ctx) error {
+	var req UpdateChecklistItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	item, err := h.service.UpdateItem(c.Params("id"), c.Params("itemId"), req.Title, req.Done)
+	if err != nil {
+		return useCaseError(c, "UpdateChecklistItem", err, "Failed to update checklist item")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newChecklistResponse(item))
+}
+
+// ToggleChecklistItem flips the done state of a checklist item.
+func (h *ChecklistHandler) ToggleChecklistItem(c *fiber.Ctx) error {
+	item, err := h.service.ToggleItem(c.Params("id"), c.Params("itemId"))
+	if err != nil {
+		return useCaseError(c, "ToggleChecklistItem", err, "Failed to toggle checklist item")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newChecklistResponse(item))
+}
+
+// ReorderChecklist sets the order of the checklist of a task.
+func (h *ChecklistHandler) ReorderChecklist(c *fiber.Ctx) error {
+	var req ReorderChecklistRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	items, err := h.service.Reorder(c.Params("id"), req.ItemIDs)
+	if err != nil {
+		return useCaseError(c, "ReorderChecklist", err, "Failed to reorder checklist")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newChecklistResponses(items))
+}
+
+// DeleteChecklistItem removes a checklist item.
+func (h *ChecklistHandler) DeleteChecklistItem(c *fiber.Ctx) error {
+	if err := h.service.DeleteItem(c.Params("id"), c.Params("itemId")); err != nil {
+		return useCaseError(c, "DeleteChecklistItem", err, "Failed to delete checklist item")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ConvertChecklistItem turns a checklist item into a subtask of the task.
+func (h *ChecklistHandler) ConvertChecklistItem(c *fiber.Ctx) error {
+	t, err := h.service.ConvertToTask(c.Params("id"), c.Params("itemId"), CurrentUserID(c))
+	if err != nil {
+		return useCaseError(c, "ConvertChecklistItem", err, "Failed to convert checklist item")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(newTaskResponse(t))
+}
+
+func newChecklistResponse(item *domain.ChecklistItem) ChecklistItemResponse {
+	return ChecklistItemResponse{
+		ID:        item.ID,
+		Position:  item.Position,
+		Title:     item.Title,
+		Done:      item.Done,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+}
+
+func newChecklistResponses(items []*domain.ChecklistItem) []ChecklistItemResponse {
+	responses := make([]ChecklistItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = newChecklistResponse(item)
+	}
+	return responses
+}
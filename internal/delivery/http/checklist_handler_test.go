@@ -0,0 +1,103 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockChecklistService struct {
+	UpdateItemFn    func(taskID, id string, title *string, done *bool) (*domain.ChecklistItem, error)
+	ConvertToTaskFn func(taskID, id string) (*domain.Task, error)
+}
+
+func (m *mockChecklistService) GetItems(string) ([]*domain.ChecklistItem, error) { return nil, nil }
+func (m *mockChecklistService) AddItem(taskID, title string) (*domain.ChecklistItem, error) {
+	return &domain.ChecklistItem{TaskID: taskID, Title: title}, nil
+}
+func (m *mockChecklistService) UpdateItem(taskID, id string, title *string, done *bool) (*domain.ChecklistItem, error) {
+	return m.UpdateItemFn(taskID, id, title, done)
+}
+func (m *mockChecklistService) ToggleItem(string, string) (*domain.ChecklistItem, error) {
+	return nil, domain.ErrChecklistItemNotFound
+}
+func (m *mockChecklistService) Reorder(string, []string) ([]*domain.ChecklistItem, error) {
+	return nil, nil
+}
+func (m *mockChecklistService) DeleteItem(string, string) error { return nil }
+func (m *mockChecklistService) ConvertToTask(taskID, id, _ string) (*domain.Task, error) {
+	return m.ConvertToTaskFn(taskID, id)
+}
+
+func TestUpdateChecklistItem_OnlyDone(t *testing.T) {
+	app := newTestApp()
+	var gotTitle *string
+	h := NewChecklistHandler(&mockChecklistService{
+		UpdateItemFn: func(_, id string, title *string, done *bool) (*domain.ChecklistItem, error) {
+			gotTitle = title
+			return &domain.ChecklistItem{ID: id, Done: *done}, nil
+		},
+	})
+	app.Patch("/tasks/:id/checklist/:itemId", h.UpdateChecklistItem)
+	req := httptest.NewRequest("PATCH", "/tasks/1/checklist/a", strings.NewReader(`{"done":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+	if gotTitle != nil {
+		t.Errorf("expected title to be left unchanged, got %q", *gotTitle)
+	}
+}
+
+func TestToggleChecklistItem_NotFound(t *testing.T) {
+	app := newTestApp()
+	h := NewChecklistHandler(&mockChecklistService{})
+	app.Post("/tasks/:id/checklist/:itemId/toggle", h.ToggleChecklistItem)
+	resp, err := app.Test(httptest.NewRequest("POST", "/tasks/1/checklist/a/toggle", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestConvertChecklistItem_ArchivedList(t *testing.T) {
+	app := newTestApp()
+	h := NewChecklistHandler(&mockChecklistService{
+		ConvertToTaskFn: func(string, string) (*domain.Task, error) { return nil, domain.ErrTaskListArchived },
+	})
+	app.Post("/tasks/:id/checklist/:itemId/convert", h.ConvertChecklistItem)
+	resp, err := app.Test(httptest.NewRequest("POST", "/tasks/1/checklist/a/convert", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected 409, got %d", resp.StatusCode)
+	}
+}
+
+func TestNewTaskResponse_ChecklistProgress(t *testing.T) {
+	resp := newTaskResponse(&domain.Task{ID: "1", ChecklistTotal: 4, ChecklistDone: 1})
+	if resp.Checklist == nil || resp.Checklist.Percentage != 25 {
+		t.Fatalf("unexpected checklist progress: %+v", resp.Checklist)
+	}
+
+	body, err := json.Marshal(newTaskResponse(&domain.Task{ID: "2"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(body), "checklist") {
+		t.Errorf("expected no checklist for task without items, got %s", body)
+	}
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// taskETag identifies a representation of a task. It starts with the task version, which
+// is what If-Match compares, followed by the checklist progress that is also rendered but
+// does not change the version of the task.
+func taskETag(t *domain.Task) string {
+	return fmt.Sprintf(`"%d-%d-%d"`, t.Version, t.ChecklistDone, t.ChecklistTotal)
+}
+
+// taskListETag identifies a representation of a task list: its version followed by the
+// task counts it is rendered with.
+func taskListETag(list *domain.TaskList, stats *domain.ListStats) string {
+	if stats == nil {
+		stats = &domain.ListStats{}
+	}
+	return fmt.Sprintf(`"%d-%d-%d-%d-%d"`, list.Version, stats.Total, stats.Pending, stats.InProgress, stats.Completed)
+}
+
+// notModified reports whether the If-None-Match header of the request matches etag.
+func notModified(c *fiber.Ctx, etag string) bool {
+	header := c.Get(fiber.HeaderIfNoneMatch)
+	if header == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ifMatchVersion reads the version an update is conditioned on from the If-Match header.
+// It returns 0 when the header is absent or "*", which accepts any version, and an error
+// when the header does not hold an ETag issued by this API.
+func ifMatchVersion(c *fiber.Ctx) (int, error) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	tag := strings.Trim(header, `"`)
+	prefix, _, _ := strings.Cut(tag, "-")
+	version, err := strconv.Atoi(prefix)
+	if err != nil || version <= 0 || strings.HasPrefix(header, "W/") {
+		return 0, errors.New("invalid If-Match header")
+	}
+
+	return version, nil
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/graphql"
+)
+
+// GraphQLTaskService define las operaciones de tareas que expone la API GraphQL.
+type GraphQLTaskService interface {
+	Create(listID, title, description, priority, changedBy string) (*domain.Task, error)
+	List(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error)
+	GetByID(id string) (*domain.Task, error)
+	GetByListIDs(listIDs []string, includeArchived bool) (map[string][]*domain.Task, error)
+	Patch(id string, version int, patch domain.TaskPatch, changedBy string) (*domain.Task, error)
+	Delete(id, changedBy string) error
+}
+
+// GraphQLTaskListService define las operaciones de listas de tareas que expone la API GraphQL.
+type GraphQLTaskListService interface {
+	Create(name, description string) (*domain.TaskList, error)
+	List(includeArchived bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error)
+	GetByID(id string) (*domain.TaskList, error)
+	GetByIDs(ids []string) (map[string]*domain.TaskList, error)
+	Patch(id string, version int, patch domain.TaskListPatch) (*domain.TaskList, error)
+	Delete(id, changedBy string) error
+	GetStats(listIDs []string) (map[string]*domain.ListStats, error)
+}
+
+// GraphQLLimits acota la profundidad y la complejidad estimada de cada operación GraphQL.
+type GraphQLLimits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// GraphQLHandler maneja las peticiones HTTP de la API GraphQL de listas y tareas.
+type GraphQLHandler struct {
+	tasks  GraphQLTaskService
+	lists  GraphQLTaskListService
+	limits GraphQLLimits
+	schema *graphql.Schema
+}
+
+// GraphQLRequest represents the body of a GraphQL request.
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName" openapi:"nullable"`
+	Variables     map[string]interface{} `json:"variables" openapi:"nullable"`
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler instance.
+func NewGraphQLHandler(tasks GraphQLTaskService, lists GraphQLTaskListService, limits GraphQLLimits) *GraphQLHandler {
+	h := &GraphQLHandler{
+		tasks:  tasks,
+		lists:  lists,
+		limits: limits,
+	}
+	h.schema = h.newGraphQLSchema()
+	return h
+}
+
+// Execute runs a GraphQL query or mutation sent as a JSON body. It responds 200 with the
+// data and field errors once the operation runs, and 400 when the request is rejected
+// before running: syntax, validation, variables or depth and complexity limits.
+func (h *GraphQLHandler) Execute(c *fiber.Ctx) error {
+	var req GraphQLRequest
+	if err := c.BodyParser(&req); err != nil || req.Query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(&graphql.Result{
+			Errors: []*graphql.Error{{Message: "request body must be a JSON object with a query"}},
+		})
+	}
+
+	return h.execute(c, req, false)
+}
+
+// ExecuteQuery runs a GraphQL query sent in the query, operationName and variables query
+// parameters. Mutations are rejected, since a GET request must not change anything.
+func (h *GraphQLHandler) ExecuteQuery(c *fiber.Ctx) error {
+	req := GraphQLRequest{Query: c.Query("query"), OperationName: c.Query("operationName")}
+	if raw := c.Query("variables"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(&graphql.Result{
+				Errors: []*graphql.Error{{Message: "variables must be a JSON object"}},
+			})
+		}
+	}
+	if req.Query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(&graphql.Result{
+			Errors: []*graphql.Error{{Message: "query is required"}},
+		})
+	}
+
+	return h.execute(c, req, true)
+}
+
+// GetSchema returns the schema of the API in the GraphQL schema definition language.
+func (h *GraphQLHandler) GetSchema(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	return c.SendString(h.schema.SDL())
+}
+
+func (h *GraphQLHandler) execute(c *fiber.Ctx, req GraphQLRequest, queryOnly bool) error {
+	ctx := context.WithValue(c.UserContext(), graphqlContextKey{}, h.newGraphQLRequest(CurrentUserID(c)))
+
+	result := graphql.Execute(graphql.Params{
+		Schema:        h.schema,
+		Query:         req.Query,
+		OperationName: req.OperationName,
+		Variables:     req.Variables,
+		Context:       ctx,
+		MaxDepth:      h.limits.MaxDepth,
+		MaxComplexity: h.limits.MaxComplexity,
+		QueryOnly:     queryOnly,
+	})
+
+	status := fiber.StatusOK
+	if !result.Executed {
+		status = fiber.StatusBadRequest
+	}
+	return c.Status(status).JSON(result)
+}
@@ -0,0 +1,311 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// graphqlTasks keeps tasks in memory and counts the batched lookups.
+type graphqlTasks struct {
+	tasks       []*domain.Task
+	byListCalls [][]string
+	patched     domain.TaskPatch
+	patchedVer  int
+	failList    bool
+}
+
+func (m *graphqlTasks) Create(listID, title, description, priority, _ string) (*domain.Task, error) {
+	t := &domain.Task{ID: "new", ListID: listID, Title: title, Description: description, Priority: priority, Status: "pending", Version: 1}
+	m.tasks = append(m.tasks, t)
+	return t, nil
+}
+func (m *graphqlTasks) List(filter domain.TaskFilter, _ domain.PageRequest) (*domain.Page[*domain.Task], error) {
+	if m.failList {
+		return nil, errors.New("pq: connection refused")
+	}
+	if filter.Status != "" && filter.Status != "pending" && filter.Status != "completed" {
+		return nil, domain.NewInvalidError("status", "invalid_status", "invalid status")
+	}
+	return &domain.Page[*domain.Task]{Items: m.tasks, Next: &domain.Cursor{Value: "v", ID: m.tasks[len(m.tasks)-1].ID}}, nil
+}
+func (m *graphqlTasks) GetByID(id string) (*domain.Task, error) {
+	for _, t := range m.tasks {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, domain.ErrTaskNotFound
+}
+func (m *graphqlTasks) GetByListIDs(listIDs []string, _ bool) (map[string][]*domain.Task, error) {
+	m.byListCalls = append(m.byListCalls, listIDs)
+	found := map[string][]*domain.Task{}
+	for _, t := range m.tasks {
+		found[t.ListID] = append(found[t.ListID], t)
+	}
+	return found, nil
+}
+func (m *graphqlTasks) Patch(id string, version int, patch domain.TaskPatch, _ string) (*domain.Task, error) {
+	m.patched, m.patchedVer = patch, version
+	t, err := m.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if patch.Status.Set {
+		t.Status = patch.Status.Value
+	}
+	return t, nil
+}
+func (m *graphqlTasks) Delete(id, _ string) error {
+	_, err := m.GetByID(id)
+	return err
+}
+
+// graphqlLists keeps lists in memory and counts the batched lookups.
+type graphqlLists struct {
+	lists      []*domain.TaskList
+	byIDsCalls [][]string
+	statsCalls [][]string
+}
+
+func (m *graphqlLists) Create(name, description string) (*domain.TaskList, error) {
+	if name == "" {
+		return nil, domain.NewInvalidError("name", "invalid_name", "name cannot be empty")
+	}
+	return &domain.TaskList{ID: "new", Name: name, Description: description, Version: 1}, nil
+}
+func (m *graphqlLists) List(bool, domain.PageRequest) (*domain.Page[*domain.TaskList], error) {
+	return &domain.Page[*domain.TaskList]{Items: m.lists}, nil
+}
+func (m *graphqlLists) GetByID(id string) (*domain.TaskList, error) {
+	for _, l := range m.lists {
+		if l.ID == id {
+			return l, nil
+		}
+	}
+	return nil, domain.ErrTaskListNotFound
+}
+func (m *graphqlLists) GetByIDs(ids []string) (map[string]*domain.TaskList, error) {
+	m.byIDsCalls = append(m.byIDsCalls, ids)
+	found := map[string]*domain.TaskList{}
+	for _, l := range m.lists {
+		found[l.ID] = l
+	}
+	return found, nil
+}
+func (m *graphqlLists) Patch(string, int, domain.TaskListPatch) (*domain.TaskList, error) {
+	return nil, domain.ErrVersionConflict
+}
+func (m *graphqlLists) Delete(string, string) error { return nil }
+func (m *graphqlLists) GetStats(listIDs []string) (map[string]*domain.ListStats, error) {
+	m.statsCalls = append(m.statsCalls, listIDs)
+	return map[string]*domain.ListStats{"l1": {ListID: "l1", Total: 4, Completed: 1}}, nil
+}
+
+func newGraphQLTestApp(limits GraphQLLimits) (*fiber.App, *graphqlTasks, *graphqlLists) {
+	tasks := &graphqlTasks{tasks: []*domain.Task{
+		{ID: "t1", ListID: "l1", Title: "Write", Status: "pending", Priority: "high", Version: 2},
+		{ID: "t2", ListID: "l2", Title: "Review", Status: "completed", Priority: "low", Version: 1, Labels: []string{"docs"}},
+		{ID: "t3", ListID: "l1", Title: "Ship", Status: "completed", Priority: "medium", Version: 5},
+	}}
+	lists := &graphqlLists{lists: []*domain.TaskList{{ID: "l1", Name: "Work", Version: 3}, {ID: "l2", Name: "Home", Version: 1}}}
+
+	app := newTestApp()
+	h := NewGraphQLHandler(tasks, lists, limits)
+	app.Post("/api/graphql", h.Execute)
+	app.Get("/api/graphql", h.ExecuteQuery)
+	app.Get("/api/graphql/schema", h.GetSchema)
+	return app, tasks, lists
+}
+
+func postGraphQL(t *testing.T, app *fiber.App, query string, variables map[string]interface{}) (int, string) {
+	t.Helper()
+	body, err := json.Marshal(GraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	return doGraphQL(t, app, req)
+}
+
+func doGraphQL(t *testing.T, app *fiber.App, req *http.Request) (int, string) {
+	t.Helper()
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestGraphQL_ListsWithTasksAndStatsInOneBatch(t *testing.T) {
+	app, tasks, lists := newGraphQLTestApp(GraphQLLimits{})
+
+	status, body := postGraphQL(t, app, `{
+		lists(first: 10) {
+			nodes { id name completionPercentage stats { total completed } tasks(status: "completed") { id list { name } } }
+			nextCursor
+		}
+	}`, nil)
+	if status != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", status, body)
+	}
+
+	want := `{"data":{"lists":{"nodes":[` +
+		`{"id":"l1","name":"Work","completionPercentage":25,"stats":{"total":4,"completed":1},"tasks":[{"id":"t3","list":{"name":"Work"}}]},` +
+		`{"id":"l2","name":"Home","completionPercentage":0,"stats":{"total":0,"completed":0},"tasks":[{"id":"t2","list":{"name":"Home"}}]}],` +
+		`"nextCursor":null}}}`
+	if body != want {
+		t.Errorf("expected %s, got %s", want, body)
+	}
+	if len(lists.statsCalls) != 1 || len(lists.statsCalls[0]) != 2 || len(tasks.byListCalls) != 1 {
+		t.Errorf("expected one batched lookup per field, got stats %v and tasks %v", lists.statsCalls, tasks.byListCalls)
+	}
+	if len(lists.byIDsCalls) != 0 {
+		t.Errorf("expected the listed lists to be reused for task.list, got %v", lists.byIDsCalls)
+	}
+}
+
+func TestGraphQL_TasksWithListsInOneBatch(t *testing.T) {
+	app, _, lists := newGraphQLTestApp(GraphQLLimits{})
+
+	status, body := postGraphQL(t, app, `query Tasks($status: String) {
+		tasks(status: $status) { nodes { title labels list { id } } nextCursor }
+		missing: task(id: "nope") { id }
+	}`, map[string]interface{}{"status": "pending"})
+	if status != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", status, body)
+	}
+
+	var result struct {
+		Data struct {
+			Tasks struct {
+				Nodes []struct {
+					Title  string
+					Labels []string
+					List   struct{ ID string }
+				}
+				NextCursor string
+			}
+			Missing *struct{}
+		}
+	}
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	nodes := result.Data.Tasks.Nodes
+	if len(nodes) != 3 || nodes[1].List.ID != "l2" || nodes[1].Labels[0] != "docs" || nodes[0].Labels == nil || result.Data.Missing != nil {
+		t.Errorf("unexpected tasks: %s", body)
+	}
+	if cursor, err := decodeCursor(result.Data.Tasks.NextCursor); err != nil || cursor.Sort != "-created_at" || cursor.ID != "t3" {
+		t.Errorf("expected a cursor for the default sort, got %q", result.Data.Tasks.NextCursor)
+	}
+	if len(lists.byIDsCalls) != 1 || len(lists.byIDsCalls[0]) != 2 {
+		t.Errorf("expected the lists of the tasks to be loaded in one batch, got %v", lists.byIDsCalls)
+	}
+}
+
+func TestGraphQL_Mutations(t *testing.T) {
+	app, tasks, _ := newGraphQLTestApp(GraphQLLimits{})
+
+	status, body := postGraphQL(t, app, `mutation($id: ID!) {
+		updateTask(id: $id, version: 2, status: "completed", dueDate: null, labels: ["a", "b"]) { id status version }
+		createTask(listId: "l1", title: "New") { id priority }
+		deleteTask(id: "t2")
+	}`, map[string]interface{}{"id": "t1"})
+	if status != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", status, body)
+	}
+
+	want := `{"data":{"updateTask":{"id":"t1","status":"completed","version":2},"createTask":{"id":"new","priority":"medium"},"deleteTask":true}}`
+	if body != want {
+		t.Errorf("expected %s, got %s", want, body)
+	}
+	patch := tasks.patched
+	if tasks.patchedVer != 2 || patch.Status.Value != "completed" || !patch.DueDate.Null || len(patch.Labels.Value) != 2 || patch.Title.Set {
+		t.Errorf("unexpected patch: %+v", patch)
+	}
+}
+
+func TestGraphQL_FieldErrors(t *testing.T) {
+	app, tasks, _ := newGraphQLTestApp(GraphQLLimits{})
+	tasks.failList = true
+
+	status, body := postGraphQL(t, app, `mutation {
+		updateList(id: "l1", version: 1, name: "Renamed") { id }
+		createList(name: "") { id }
+	}`, nil)
+	if status != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", status, body)
+	}
+	for _, want := range []string{`"data":null`, `"message":"version conflict"`, `"path":["updateList"]`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %s in %s", want, body)
+		}
+	}
+
+	_, body = postGraphQL(t, app, `{ tasks { nodes { id } } }`, nil)
+	if !strings.Contains(body, `"message":"internal error"`) || strings.Contains(body, "pq:") {
+		t.Errorf("expected the database error to be hidden, got %s", body)
+	}
+}
+
+func TestGraphQL_RejectedRequests(t *testing.T) {
+	app, _, _ := newGraphQLTestApp(GraphQLLimits{MaxDepth: 4, MaxComplexity: 500})
+
+	cases := []struct {
+		query   string
+		message string
+	}{
+		{`{ lists { nodes { tasks { list { tasks { id } } } } } }`, "query depth 6 exceeds the maximum of 4"},
+		{`{ tasks(first: 200) { nodes { id title } } }`, "query complexity exceeds the maximum of 500"},
+		{`{ tasks { nodes { secret } } }`, `cannot query field \"secret\" on type \"Task\"`},
+		{`{ task }`, `argument \"id\" of type \"ID!\" is required on field \"Query.task\"`},
+	}
+	for _, tc := range cases {
+		status, body := postGraphQL(t, app, tc.query, nil)
+		if status != fiber.StatusBadRequest || !strings.Contains(body, tc.message) || strings.Contains(body, `"data"`) {
+			t.Errorf("%s: expected 400 with %q, got %d %s", tc.query, tc.message, status, body)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(`{"variables":{}}`))
+	req.Header.Set("Content-Type", "application/json")
+	if status, _ := doGraphQL(t, app, req); status != fiber.StatusBadRequest {
+		t.Errorf("expected 400 without a query, got %d", status)
+	}
+}
+
+func TestGraphQL_GetRequests(t *testing.T) {
+	app, _, _ := newGraphQLTestApp(GraphQLLimits{})
+
+	query := url.Values{"query": {`query($id: ID!) { task(id: $id) { title } }`}, "variables": {`{"id":"t1"}`}}
+	status, body := doGraphQL(t, app, httptest.NewRequest(http.MethodGet, "/api/graphql?"+query.Encode(), http.NoBody))
+	if status != fiber.StatusOK || body != `{"data":{"task":{"title":"Write"}}}` {
+		t.Errorf("expected the task, got %d %s", status, body)
+	}
+
+	mutation := url.Values{"query": {`mutation { deleteTask(id: "t1") }`}}
+	status, body = doGraphQL(t, app, httptest.NewRequest(http.MethodGet, "/api/graphql?"+mutation.Encode(), http.NoBody))
+	if status != fiber.StatusBadRequest || !strings.Contains(body, "mutations are not allowed") {
+		t.Errorf("expected the mutation to be rejected, got %d %s", status, body)
+	}
+
+	status, body = doGraphQL(t, app, httptest.NewRequest(http.MethodGet, "/api/graphql/schema", http.NoBody))
+	if status != fiber.StatusOK || !strings.Contains(body, "type TaskList {") || !strings.Contains(body, "updateTask(id: ID!, version: Int") {
+		t.Errorf("expected the schema, got %d %s", status, body)
+	}
+}
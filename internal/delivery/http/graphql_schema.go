@@ -0,0 +1,541 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/dataloader"
+	"github.com/G20-00/task-management-service-go/pkg/graphql"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+)
+
+// graphqlDefaultPageSize is the page size of the lists and tasks queries when first is omitted.
+const graphqlDefaultPageSize = 20
+
+// graphqlDefaultListTasks is the number of tasks TaskList.tasks returns when first is omitted.
+const graphqlDefaultListTasks = 50
+
+type graphqlContextKey struct{}
+
+// graphqlRequest is the state of one GraphQL request: the authenticated user and the
+// loaders that batch the lookups of its resolvers.
+type graphqlRequest struct {
+	userID string
+	lists  *dataloader.Loader[string, *domain.TaskList]
+	stats  *dataloader.Loader[string, *domain.ListStats]
+	tasks  *dataloader.Loader[listTasksKey, []*domain.Task]
+}
+
+// listTasksKey selects the tasks of a list loaded by TaskList.tasks.
+type listTasksKey struct {
+	listID          string
+	includeArchived bool
+}
+
+// graphqlConnection is a page of a connection field.
+type graphqlConnection struct {
+	nodes      interface{}
+	nextCursor *string
+}
+
+func (h *GraphQLHandler) newGraphQLRequest(userID string) *graphqlRequest {
+	return &graphqlRequest{
+		userID: userID,
+		lists: dataloader.New(func(ids []string) (map[string]*domain.TaskList, error) {
+			return h.lists.GetByIDs(ids)
+		}),
+		stats: dataloader.New(func(ids []string) (map[string]*domain.ListStats, error) {
+			return h.lists.GetStats(ids)
+		}),
+		tasks: dataloader.New(func(keys []listTasksKey) (map[listTasksKey][]*domain.Task, error) {
+			byArchived := map[bool][]string{}
+			for _, key := range keys {
+				byArchived[key.includeArchived] = append(byArchived[key.includeArchived], key.listID)
+			}
+
+			found := map[listTasksKey][]*domain.Task{}
+			for includeArchived, listIDs := range byArchived {
+				tasks, err := h.tasks.GetByListIDs(listIDs, includeArchived)
+				if err != nil {
+					return nil, err
+				}
+				for listID, listTasks := range tasks {
+					found[listTasksKey{listID: listID, includeArchived: includeArchived}] = listTasks
+				}
+			}
+			return found, nil
+		}),
+	}
+}
+
+func requestState(ctx context.Context) *graphqlRequest {
+	state, _ := ctx.Value(graphqlContextKey{}).(*graphqlRequest) //nolint:errcheck
+	return state
+}
+
+// timeScalar serializes times as RFC 3339 strings.
+var timeScalar = &graphql.Scalar{
+	Name:        "Time",
+	Description: "An instant, as an RFC 3339 string.",
+	Serialize: func(value interface{}) (interface{}, error) {
+		switch t := value.(type) {
+		case time.Time:
+			return t.Format(time.RFC3339Nano), nil
+		case *time.Time:
+			return t.Format(time.RFC3339Nano), nil
+		}
+		return nil, errors.New("Time cannot represent the value")
+	},
+	ParseValue: func(value interface{}) (interface{}, error) {
+		raw, ok := value.(string)
+		if !ok {
+			return nil, errors.New("Time must be an RFC 3339 string")
+		}
+		return time.Parse(time.RFC3339, raw)
+	},
+}
+
+// newGraphQLSchema builds the schema of the GraphQL API. Queries go through the same
+// services as the REST routes, and mutations map onto their create, patch and delete
+// operations.
+func (h *GraphQLHandler) newGraphQLSchema() *graphql.Schema {
+	nonNull := graphql.NewNonNull
+	str, id, integer, boolean := graphql.String, graphql.ID, graphql.Int, graphql.Boolean
+
+	stats := &graphql.Object{Name: "ListStats", Description: "Live tasks of a list per status.", Fields: []*graphql.Field{
+		{Name: "total", Type: nonNull(integer), Resolve: statsField(func(s *domain.ListStats) interface{} { return s.Total })},
+		{Name: "pending", Type: nonNull(integer), Resolve: statsField(func(s *domain.ListStats) interface{} { return s.Pending })},
+		{Name: "inProgress", Type: nonNull(integer), Resolve: statsField(func(s *domain.ListStats) interface{} { return s.InProgress })},
+		{Name: "completed", Type: nonNull(integer), Resolve: statsField(func(s *domain.ListStats) interface{} { return s.Completed })},
+	}}
+
+	list := &graphql.Object{Name: "TaskList", Description: "A task list."}
+	task := &graphql.Object{Name: "Task", Description: "A task of a list."}
+
+	list.Fields = []*graphql.Field{
+		{Name: "id", Type: nonNull(id), Resolve: listField(func(l *domain.TaskList) interface{} { return l.ID })},
+		{Name: "name", Type: nonNull(str), Resolve: listField(func(l *domain.TaskList) interface{} { return l.Name })},
+		{Name: "description", Type: nonNull(str), Resolve: listField(func(l *domain.TaskList) interface{} { return l.Description })},
+		{Name: "createdAt", Type: nonNull(timeScalar), Resolve: listField(func(l *domain.TaskList) interface{} { return l.CreatedAt })},
+		{Name: "updatedAt", Type: nonNull(timeScalar), Resolve: listField(func(l *domain.TaskList) interface{} { return l.UpdatedAt })},
+		{Name: "archivedAt", Type: timeScalar, Resolve: listField(func(l *domain.TaskList) interface{} { return l.ArchivedAt })},
+		{Name: "version", Type: nonNull(integer), Resolve: listField(func(l *domain.TaskList) interface{} { return l.Version })},
+		{Name: "stats", Type: nonNull(stats), Resolve: h.resolveListStats},
+		{Name: "completionPercentage", Type: nonNull(graphql.Float), Description: "Percentage of the live tasks that are completed.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				thunk, err := h.resolveListStats(p)
+				if err != nil {
+					return nil, err
+				}
+				return graphql.Thunk(func() (interface{}, error) {
+					value, err := thunk.(graphql.Thunk)()
+					if err != nil {
+						return nil, err
+					}
+					s := value.(*domain.ListStats)
+					if s.Total == 0 {
+						return 0.0, nil
+					}
+					return float64(s.Completed) / float64(s.Total) * 100, nil
+				}), nil
+			}},
+		{Name: "tasks", Type: nonNull(graphql.NewList(nonNull(task))), Description: "The tasks of the list, oldest first.",
+			Args: []*graphql.Argument{
+				{Name: "status", Type: str},
+				{Name: "includeArchived", Type: boolean, Default: false},
+				{Name: "first", Type: integer, Default: graphqlDefaultListTasks},
+			},
+			Resolve: h.resolveListTasks, Complexity: pagedComplexity},
+	}
+
+	task.Fields = []*graphql.Field{
+		{Name: "id", Type: nonNull(id), Resolve: taskField(func(t *domain.Task) interface{} { return t.ID })},
+		{Name: "listId", Type: nonNull(id), Resolve: taskField(func(t *domain.Task) interface{} { return t.ListID })},
+		{Name: "title", Type: nonNull(str), Resolve: taskField(func(t *domain.Task) interface{} { return t.Title })},
+		{Name: "description", Type: nonNull(str), Resolve: taskField(func(t *domain.Task) interface{} { return t.Description })},
+		{Name: "status", Type: nonNull(str), Resolve: taskField(func(t *domain.Task) interface{} { return t.Status })},
+		{Name: "priority", Type: nonNull(str), Resolve: taskField(func(t *domain.Task) interface{} { return t.Priority })},
+		{Name: "createdAt", Type: nonNull(timeScalar), Resolve: taskField(func(t *domain.Task) interface{} { return t.CreatedAt })},
+		{Name: "updatedAt", Type: nonNull(timeScalar), Resolve: taskField(func(t *domain.Task) interface{} { return t.UpdatedAt })},
+		{Name: "archivedAt", Type: timeScalar, Resolve: taskField(func(t *domain.Task) interface{} { return t.ArchivedAt })},
+		{Name: "dueDate", Type: timeScalar, Resolve: taskField(func(t *domain.Task) interface{} { return t.DueDate })},
+		{Name: "labels", Type: nonNull(graphql.NewList(nonNull(str))), Resolve: taskField(func(t *domain.Task) interface{} {
+			if t.Labels == nil {
+				return []string{}
+			}
+			return t.Labels
+		})},
+		{Name: "parentId", Type: id, Resolve: taskField(func(t *domain.Task) interface{} { return optionalString(t.ParentID) })},
+		{Name: "version", Type: nonNull(integer), Resolve: taskField(func(t *domain.Task) interface{} { return t.Version })},
+		{Name: "checklistTotal", Type: nonNull(integer), Resolve: taskField(func(t *domain.Task) interface{} { return t.ChecklistTotal })},
+		{Name: "checklistDone", Type: nonNull(integer), Resolve: taskField(func(t *domain.Task) interface{} { return t.ChecklistDone })},
+		{Name: "list", Type: list, Description: "The list the task belongs to.", Resolve: h.resolveTaskList},
+	}
+
+	listConnection := newConnectionType("TaskListConnection", list)
+	taskConnection := newConnectionType("TaskConnection", task)
+
+	pageArgs := func(extra ...*graphql.Argument) []*graphql.Argument {
+		return append([]*graphql.Argument{
+			{Name: "first", Type: integer, Default: graphqlDefaultPageSize},
+			{Name: "after", Type: str, Description: "The nextCursor of the previous page."},
+			{Name: "sort", Type: str, Default: "-created_at"},
+			{Name: "includeArchived", Type: boolean, Default: false},
+		}, extra...)
+	}
+
+	query := &graphql.Object{Name: "Query", Fields: []*graphql.Field{
+		{Name: "lists", Type: nonNull(listConnection), Args: pageArgs(), Resolve: h.resolveLists, Complexity: pagedComplexity},
+		{Name: "list", Type: list, Args: []*graphql.Argument{{Name: "id", Type: nonNull(id)}}, Resolve: h.resolveList},
+		{Name: "tasks", Type: nonNull(taskConnection), Args: pageArgs(
+			&graphql.Argument{Name: "listId", Type: id},
+			&graphql.Argument{Name: "status", Type: str},
+			&graphql.Argument{Name: "priority", Type: str},
+			&graphql.Argument{Name: "filter", Type: str, Description: "A filter expression, as in the filter parameter of GET /api/tasks."},
+		), Resolve: h.resolveTasks, Complexity: pagedComplexity},
+		{Name: "task", Type: task, Args: []*graphql.Argument{{Name: "id", Type: nonNull(id)}}, Resolve: h.resolveTask},
+	}}
+
+	version := &graphql.Argument{Name: "version", Type: integer, Description: "Apply the change only if the entity is still at this version."}
+	mutation := &graphql.Object{Name: "Mutation", Fields: []*graphql.Field{
+		{Name: "createList", Type: nonNull(list), Args: []*graphql.Argument{
+			{Name: "name", Type: nonNull(str)},
+			{Name: "description", Type: str, Default: ""},
+		}, Resolve: h.createList},
+		{Name: "updateList", Type: nonNull(list), Args: []*graphql.Argument{
+			{Name: "id", Type: nonNull(id)}, version,
+			{Name: "name", Type: str},
+			{Name: "description", Type: str},
+		}, Resolve: h.updateList},
+		{Name: "deleteList", Type: nonNull(boolean), Args: []*graphql.Argument{{Name: "id", Type: nonNull(id)}}, Resolve: h.deleteList},
+		{Name: "createTask", Type: nonNull(task), Args: []*graphql.Argument{
+			{Name: "listId", Type: nonNull(id)},
+			{Name: "title", Type: nonNull(str)},
+			{Name: "description", Type: str, Default: ""},
+			{Name: "priority", Type: str, Default: "medium"},
+		}, Resolve: h.createTask},
+		{Name: "updateTask", Type: nonNull(task), Args: []*graphql.Argument{
+			{Name: "id", Type: nonNull(id)}, version,
+			{Name: "listId", Type: id},
+			{Name: "title", Type: str},
+			{Name: "description", Type: str},
+			{Name: "status", Type: str},
+			{Name: "priority", Type: str},
+			{Name: "dueDate", Type: str, Description: "A date (YYYY-MM-DD) or RFC 3339 time, null to clear it."},
+			{Name: "labels", Type: graphql.NewList(nonNull(str))},
+		}, Resolve: h.updateTask},
+		{Name: "deleteTask", Type: nonNull(boolean), Args: []*graphql.Argument{{Name: "id", Type: nonNull(id)}}, Resolve: h.deleteTask},
+	}}
+
+	return &graphql.Schema{Query: query, Mutation: mutation}
+}
+
+func newConnectionType(name string, node *graphql.Object) *graphql.Object {
+	return &graphql.Object{Name: name, Description: "A page of " + node.Name + " items.", Fields: []*graphql.Field{
+		{Name: "nodes", Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(node))),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphqlConnection).nodes, nil
+			}},
+		{Name: "nextCursor", Type: graphql.String, Description: "The cursor of the next page, null on the last page.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if next := p.Source.(*graphqlConnection).nextCursor; next != nil {
+					return *next, nil
+				}
+				return nil, nil
+			}},
+	}}
+}
+
+// pagedComplexity multiplies the cost of the selection by the number of items requested.
+func pagedComplexity(args map[string]interface{}, childComplexity int) int {
+	first, _ := args["first"].(int) //nolint:errcheck
+	if first < 1 {
+		first = 1
+	}
+	return 1 + first*childComplexity
+}
+
+func listField(value func(*domain.TaskList) interface{}) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return value(p.Source.(*domain.TaskList)), nil
+	}
+}
+
+func taskField(value func(*domain.Task) interface{}) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return value(p.Source.(*domain.Task)), nil
+	}
+}
+
+func statsField(value func(*domain.ListStats) interface{}) graphql.ResolveFunc {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return value(p.Source.(*domain.ListStats)), nil
+	}
+}
+
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// graphqlPageRequest reads the first, after and sort arguments of a connection field.
+func graphqlPageRequest(args map[string]interface{}) (domain.PageRequest, string, error) {
+	sort, _ := args["sort"].(string) //nolint:errcheck
+	page := domain.PageRequest{
+		Sort: strings.TrimPrefix(sort, "-"),
+		Desc: strings.HasPrefix(sort, "-"),
+	}
+
+	if page.Limit, _ = args["first"].(int); page.Limit <= 0 { //nolint:errcheck
+		return page, sort, errors.New("first must be a positive integer")
+	}
+
+	if raw, _ := args["after"].(string); raw != "" { //nolint:errcheck
+		cursor, err := decodeCursor(raw)
+		if err != nil {
+			return page, sort, domain.ErrInvalidCursor
+		}
+		if cursor.Sort != sort {
+			return page, sort, errors.New("cursor does not match sort " + sort)
+		}
+		page.After = &domain.Cursor{Value: cursor.Value, ID: cursor.ID}
+	}
+
+	return page, sort, nil
+}
+
+func (h *GraphQLHandler) resolveLists(p graphql.ResolveParams) (interface{}, error) {
+	page, sort, err := graphqlPageRequest(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.lists.List(p.Args["includeArchived"].(bool), page)
+	if err != nil {
+		return nil, graphqlError("lists", err)
+	}
+
+	state := requestState(p.Context)
+	for _, list := range result.Items {
+		state.lists.Prime(list.ID, list)
+	}
+	return &graphqlConnection{nodes: result.Items, nextCursor: encodeCursor(sort, result.Next)}, nil
+}
+
+func (h *GraphQLHandler) resolveList(p graphql.ResolveParams) (interface{}, error) {
+	list, err := h.lists.GetByID(p.Args["id"].(string))
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskListNotFound) {
+			return nil, nil
+		}
+		return nil, graphqlError("list", err)
+	}
+
+	requestState(p.Context).lists.Prime(list.ID, list)
+	return list, nil
+}
+
+func (h *GraphQLHandler) resolveTasks(p graphql.ResolveParams) (interface{}, error) {
+	page, sort, err := graphqlPageRequest(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.TaskFilter{IncludeArchived: p.Args["includeArchived"].(bool)}
+	filter.ListID, _ = p.Args["listId"].(string)     //nolint:errcheck
+	filter.Status, _ = p.Args["status"].(string)     //nolint:errcheck
+	filter.Priority, _ = p.Args["priority"].(string) //nolint:errcheck
+	filter.Expression, _ = p.Args["filter"].(string) //nolint:errcheck
+
+	result, err := h.tasks.List(filter, page)
+	if err != nil {
+		return nil, graphqlError("tasks", err)
+	}
+
+	return &graphqlConnection{nodes: result.Items, nextCursor: encodeCursor(sort, result.Next)}, nil
+}
+
+func (h *GraphQLHandler) resolveTask(p graphql.ResolveParams) (interface{}, error) {
+	t, err := h.tasks.GetByID(p.Args["id"].(string))
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return nil, nil
+		}
+		return nil, graphqlError("task", err)
+	}
+	return t, nil
+}
+
+// resolveListStats loads the stats of the list with the stats of the other lists of the level.
+func (h *GraphQLHandler) resolveListStats(p graphql.ResolveParams) (interface{}, error) {
+	list := p.Source.(*domain.TaskList)
+	load := requestState(p.Context).stats.Load(list.ID)
+
+	return graphql.Thunk(func() (interface{}, error) {
+		stats, ok, err := load()
+		if err != nil {
+			return nil, graphqlError("TaskList.stats", err)
+		}
+		if !ok {
+			stats = &domain.ListStats{ListID: list.ID}
+		}
+		return stats, nil
+	}), nil
+}
+
+// resolveListTasks loads the tasks of the list with the tasks of the other lists of the level.
+func (h *GraphQLHandler) resolveListTasks(p graphql.ResolveParams) (interface{}, error) {
+	list := p.Source.(*domain.TaskList)
+	status, _ := p.Args["status"].(string) //nolint:errcheck
+	first := p.Args["first"].(int)
+	if first <= 0 {
+		return nil, errors.New("first must be a positive integer")
+	}
+
+	load := requestState(p.Context).tasks.Load(listTasksKey{listID: list.ID, includeArchived: p.Args["includeArchived"].(bool)})
+
+	return graphql.Thunk(func() (interface{}, error) {
+		all, _, err := load()
+		if err != nil {
+			return nil, graphqlError("TaskList.tasks", err)
+		}
+
+		tasks := []*domain.Task{}
+		for _, t := range all {
+			if len(tasks) == first {
+				break
+			}
+			if status == "" || t.Status == status {
+				tasks = append(tasks, t)
+			}
+		}
+		return tasks, nil
+	}), nil
+}
+
+// resolveTaskList loads the list of the task with the lists of the other tasks of the level.
+func (h *GraphQLHandler) resolveTaskList(p graphql.ResolveParams) (interface{}, error) {
+	load := requestState(p.Context).lists.Load(p.Source.(*domain.Task).ListID)
+
+	return graphql.Thunk(func() (interface{}, error) {
+		list, ok, err := load()
+		if err != nil {
+			return nil, graphqlError("Task.list", err)
+		}
+		if !ok {
+			return nil, nil
+		}
+		return list, nil
+	}), nil
+}
+
+func (h *GraphQLHandler) createList(p graphql.ResolveParams) (interface{}, error) {
+	list, err := h.lists.Create(p.Args["name"].(string), p.Args["description"].(string))
+	if err != nil {
+		return nil, graphqlError("createList", err)
+	}
+	return list, nil
+}
+
+func (h *GraphQLHandler) updateList(p graphql.ResolveParams) (interface{}, error) {
+	changes, err := graphqlPatchChanges(p.Args, map[string]string{"name": "name", "description": "description"})
+	if err != nil {
+		return nil, err
+	}
+	patch, err := newTaskListPatch(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	version, _ := p.Args["version"].(int) //nolint:errcheck
+	list, err := h.lists.Patch(p.Args["id"].(string), version, patch)
+	if err != nil {
+		return nil, graphqlError("updateList", err)
+	}
+	return list, nil
+}
+
+func (h *GraphQLHandler) deleteList(p graphql.ResolveParams) (interface{}, error) {
+	if err := h.lists.Delete(p.Args["id"].(string), requestState(p.Context).userID); err != nil {
+		return nil, graphqlError("deleteList", err)
+	}
+	return true, nil
+}
+
+func (h *GraphQLHandler) createTask(p graphql.ResolveParams) (interface{}, error) {
+	t, err := h.tasks.Create(p.Args["listId"].(string), p.Args["title"].(string), p.Args["description"].(string), p.Args["priority"].(string), requestState(p.Context).userID)
+	if err != nil {
+		return nil, graphqlError("createTask", err)
+	}
+	return t, nil
+}
+
+func (h *GraphQLHandler) updateTask(p graphql.ResolveParams) (interface{}, error) {
+	changes, err := graphqlPatchChanges(p.Args, map[string]string{
+		"listId": "list_id", "title": "title", "description": "description", "status": "status",
+		"priority": "priority", "dueDate": "due_date", "labels": "labels",
+	})
+	if err != nil {
+		return nil, err
+	}
+	patch, err := newTaskPatch(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	version, _ := p.Args["version"].(int) //nolint:errcheck
+	t, err := h.tasks.Patch(p.Args["id"].(string), version, patch, requestState(p.Context).userID)
+	if err != nil {
+		return nil, graphqlError("updateTask", err)
+	}
+	return t, nil
+}
+
+func (h *GraphQLHandler) deleteTask(p graphql.ResolveParams) (interface{}, error) {
+	if err := h.tasks.Delete(p.Args["id"].(string), requestState(p.Context).userID); err != nil {
+		return nil, graphqlError("deleteTask", err)
+	}
+	return true, nil
+}
+
+// graphqlPatchChanges turns the arguments given to an update mutation into the members of
+// a merge patch, so that they are validated like a PATCH body. A null argument clears
+// the field.
+func graphqlPatchChanges(args map[string]interface{}, fields map[string]string) (map[string]json.RawMessage, error) {
+	changes := map[string]json.RawMessage{}
+	for arg, field := range fields {
+		value, ok := args[arg]
+		if !ok {
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		changes[field] = encoded
+	}
+	return changes, nil
+}
+
+// graphqlError returns the error a resolver reports to the client. Domain errors are
+// returned as they are; any other error is logged and reported as internal.
+func graphqlError(field string, err error) error {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return err
+	}
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"layer":  "handler",
+		"method": "GraphQL",
+		"field":  field,
+		"error":  err.Error(),
+	}).Error("Failed to resolve GraphQL field")
+	return errors.New("internal error")
+}
@@ -0,0 +1,27 @@
+package http
+
+import "time"
+
+// FieldChangeResponse represents the change of a single field in a task revision.
+type FieldChangeResponse struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// TaskRevisionResponse represents a task revision with its snapshot and field-level diff.
+type TaskRevisionResponse struct {
+	Revision      int                   `json:"revision"`
+	ChangedBy     string                `json:"changed_by"`
+	ChangedAt     time.Time             `json:"changed_at"`
+	ChangedFields []string              `json:"changed_fields"`
+	Changes       []FieldChangeResponse `json:"changes"`
+	ListID        string                `json:"list_id"`
+	Title         string                `json:"title"`
+	Description   string                `json:"description"`
+	Status        string                `json:"status"`
+	Priority      string                `json:"priority"`
+	SprintID      string                `json:"sprint_id,omitempty"`
+	Archived      bool                  `json:"archived"`
+	Deleted       bool                  `json:"deleted"`
+}
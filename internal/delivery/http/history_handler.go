@@ -0,0 +1,79 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/internal/usecase/history"
+)
+
+// HistoryService define la interfaz para consultar y revertir el historial de tareas.
+type HistoryService interface {
+	GetHistory(taskID string) ([]*history.Entry, error)
+	Revert(taskID string, revision int, changedBy string) (*domain.Task, error)
+}
+
+// HistoryHandler maneja las solicitudes HTTP del historial de versiones de tareas.
+type HistoryHandler struct {
+	service HistoryService
+}
+
+// NewHistoryHandler creates a new HistoryHandler instance.
+func NewHistoryHandler(service HistoryService) *HistoryHandler {
+	return &HistoryHandler{
+		service: service,
+	}
+}
+
+// GetTaskHistory retrieves all revisions of a task with field-level diffs.
+func (h *HistoryHandler) GetTaskHistory(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	entries, err := h.service.GetHistory(id)
+	if err != nil {
+		return useCaseError(c, "GetTaskHistory", err, "Failed to get task history")
+	}
+
+	responses := make([]TaskRevisionResponse, len(entries))
+	for i, entry := range entries {
+		rev := entry.Revision
+		changes := make([]FieldChangeResponse, len(entry.Changes))
+		for j, change := range entry.Changes {
+			changes[j] = FieldChangeResponse{Field: change.Field, Old: change.Old, New: change.New}
+		}
+		responses[i] = TaskRevisionResponse{
+			Revision:      rev.Revision,
+			ChangedBy:     rev.ChangedBy,
+			ChangedAt:     rev.ChangedAt,
+			ChangedFields: rev.ChangedFields,
+			Changes:       changes,
+			ListID:        rev.ListID,
+			Title:         rev.Title,
+			Description:   rev.Description,
+			Status:        rev.Status,
+			Priority:      rev.Priority,
+			SprintID:      rev.SprintID,
+			Archived:      rev.Archived,
+			Deleted:       rev.Deleted,
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses)
+}
+
+// RevertTask restores a task to a previous revision, recording it as a new revision.
+func (h *HistoryHandler) RevertTask(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	revision, err := c.ParamsInt("revision")
+	if err != nil || revision <= 0 {
+		return invalidParameter("revision", "Invalid revision")
+	}
+
+	t, err := h.service.Revert(id, revision, CurrentUserID(c))
+	if err != nil {
+		return useCaseError(c, "RevertTask", err, "Failed to revert task")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newTaskResponse(t))
+}
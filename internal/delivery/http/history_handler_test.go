@@ -0,0 +1,114 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/internal/usecase/history"
+)
+
+type mockHistoryService struct {
+	GetHistoryFn func(taskID string) ([]*history.Entry, error)
+	RevertFn     func(taskID string, revision int, changedBy string) (*domain.Task, error)
+}
+
+func (m *mockHistoryService) GetHistory(taskID string) ([]*history.Entry, error) {
+	return m.GetHistoryFn(taskID)
+}
+func (m *mockHistoryService) Revert(taskID string, revision int, changedBy string) (*domain.Task, error) {
+	return m.RevertFn(taskID, revision, changedBy)
+}
+
+func TestGetTaskHistory_Success(t *testing.T) {
+	app := newTestApp()
+	h := NewHistoryHandler(&mockHistoryService{
+		GetHistoryFn: func(taskID string) ([]*history.Entry, error) {
+			return []*history.Entry{{
+				Revision: &domain.TaskRevision{Revision: 1, TaskID: taskID, ChangedAt: time.Now()},
+				Changes:  []domain.FieldChange{{Field: "title", New: "A"}},
+			}}, nil
+		},
+	})
+	app.Get("/tasks/:id/history", h.GetTaskHistory)
+	resp, err := app.Test(httptest.NewRequest("GET", "/tasks/1/history", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestGetTaskHistory_NotFound(t *testing.T) {
+	app := newTestApp()
+	h := NewHistoryHandler(&mockHistoryService{
+		GetHistoryFn: func(string) ([]*history.Entry, error) { return nil, domain.ErrTaskNotFound },
+	})
+	app.Get("/tasks/:id/history", h.GetTaskHistory)
+	resp, err := app.Test(httptest.NewRequest("GET", "/tasks/1/history", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestRevertTask_InvalidRevision(t *testing.T) {
+	app := newTestApp()
+	h := NewHistoryHandler(&mockHistoryService{})
+	app.Post("/tasks/:id/history/:revision/revert", h.RevertTask)
+	resp, err := app.Test(httptest.NewRequest("POST", "/tasks/1/history/abc/revert", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestRevertTask_Success(t *testing.T) {
+	app := newTestApp()
+	h := NewHistoryHandler(&mockHistoryService{
+		RevertFn: func(taskID string, revision int, changedBy string) (*domain.Task, error) {
+			if revision != 2 {
+				return nil, errors.New("unexpected revision")
+			}
+			return &domain.Task{ID: taskID, Title: "A"}, nil
+		},
+	})
+	app.Post("/tasks/:id/history/:revision/revert", h.RevertTask)
+	resp, err := app.Test(httptest.NewRequest("POST", "/tasks/1/history/2/revert", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestDeleteTask_AttributesRevisionToUser(t *testing.T) {
+	token, err := GenerateJWT("user-1")
+	if err != nil {
+		t.Fatalf("error generando JWT: %v", err)
+	}
+	app := newTestApp()
+	service := &mockTaskService{}
+	h := NewTaskHandler(service)
+	app.Delete("/tasks/:id", JWTMiddleware, h.DeleteTask)
+	req := httptest.NewRequest("DELETE", "/tasks/1", http.NoBody)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNoContent || service.changedBy != "user-1" {
+		t.Errorf("expected 204 with the delete attributed to user-1, got %d and %q", resp.StatusCode, service.changedBy)
+	}
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// replayedHeaders are the response headers stored with an idempotency key and sent again
+// when its response is replayed.
+var replayedHeaders = []string{fiber.HeaderContentType, fiber.HeaderETag, fiber.HeaderLocation}
+
+// IdempotencyService define la interfaz para operaciones de claves de idempotencia.
+type IdempotencyService interface {
+	Begin(userID, key, fingerprint string) (*domain.IdempotencyRecord, error)
+	Complete(userID, key string, statusCode int, headers map[string]string, body []byte) error
+	Release(userID, key string) error
+}
+
+// IdempotencyMiddleware guarda y repite las respuestas de las peticiones con Idempotency-Key.
+type IdempotencyMiddleware struct {
+	service IdempotencyService
+}
+
+// NewIdempotencyMiddleware creates a new IdempotencyMiddleware instance.
+func NewIdempotencyMiddleware(service IdempotencyService) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		service: service,
+	}
+}
+
+// Handle processes POST and PATCH requests that carry an Idempotency-Key header at most once
+// per user and key: the first one runs and its response is stored, retries with the same
+// key and request get the stored response with Idempotent-Replayed set, and reusing the key
+// for a different request is rejected with 422. Errors returned by the handlers are written
+// with the error handler of the app first, so a 4xx error is stored like any response.
+// Responses with a 5xx status are not stored, so the request can be retried with the same
+// key. Requests without the header, or not authenticated, are passed through unchanged.
+func (m *IdempotencyMiddleware) Handle(c *fiber.Ctx) error {
+	key := c.Get(idempotencyKeyHeader)
+	if key == "" || (c.Method() != fiber.MethodPost && c.Method() != fiber.MethodPatch) {
+		return c.Next()
+	}
+	userID := CurrentUserID(c)
+	if userID == "" {
+		return c.Next()
+	}
+
+	replay, err := m.service.Begin(userID, key, requestFingerprint(c))
+	if err != nil {
+		return useCaseError(c, "IdempotencyMiddleware", err, "Failed to check idempotency key")
+	}
+	if replay != nil {
+		for header, value := range replay.Headers {
+			c.Set(header, value)
+		}
+		c.Set(idempotentReplayedHeader, "true")
+		return c.Status(replay.StatusCode).Send(replay.Body)
+	}
+
+	// Handlers return their errors, so the response is written here to store it like any
+	// other: a 4xx problem is replayed, while a 5xx one releases the key below.
+	if err := c.Next(); err != nil {
+		if err := c.App().Config().ErrorHandler(c, err); err != nil {
+			m.release(c, userID, key)
+			return err
+		}
+	}
+
+	status := c.Response().StatusCode()
+	if status >= fiber.StatusInternalServerError {
+		m.release(c, userID, key)
+		return nil
+	}
+
+	headers := map[string]string{}
+	for _, header := range replayedHeaders {
+		if value := c.GetRespHeader(header); value != "" {
+			headers[header] = value
+		}
+	}
+	body := append([]byte{}, c.Response().Body()...)
+	if err := m.service.Complete(userID, key, status, headers, body); err != nil {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"layer":  "handler",
+			"method": "IdempotencyMiddleware",
+			"path":   c.Path(),
+			"error":  err.Error(),
+		}).Error("Failed to store idempotent response")
+	}
+
+	return nil
+}
+
+func (m *IdempotencyMiddleware) release(c *fiber.Ctx, userID, key string) {
+	if err := m.service.Release(userID, key); err != nil {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"layer":  "handler",
+			"method": "IdempotencyMiddleware",
+			"path":   c.Path(),
+			"error":  err.Error(),
+		}).Error("Failed to release idempotency key")
+	}
+}
+
+// requestFingerprint identifies a request by its method, URL and body, so that a key
+// reused for another request can be told apart from a retry.
+func requestFingerprint(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method() + " " + c.OriginalURL() + "\n"))
+	hash.Write(c.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,242 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockIdempotencyService struct {
+	BeginFn    func(userID, key, fingerprint string) (*domain.IdempotencyRecord, error)
+	CompleteFn func(userID, key string, statusCode int, headers map[string]string, body []byte) error
+	ReleaseFn  func(userID, key string) error
+}
+
+func (m *mockIdempotencyService) Begin(userID, key, fingerprint string) (*domain.IdempotencyRecord, error) {
+	return m.BeginFn(userID, key, fingerprint)
+}
+func (m *mockIdempotencyService) Complete(userID, key string, statusCode int, headers map[string]string, body []byte) error {
+	return m.CompleteFn(userID, key, statusCode, headers, body)
+}
+func (m *mockIdempotencyService) Release(userID, key string) error { return m.ReleaseFn(userID, key) }
+
+func newIdempotentApp(t *testing.T, service IdempotencyService, handler fiber.Handler) *fiber.App {
+	t.Helper()
+	app := newTestApp()
+	RegisterAuthMiddleware(app)
+	RegisterIdempotencyMiddleware(app, NewIdempotencyMiddleware(service))
+	app.Post("/api/tasks", JWTMiddleware, handler)
+	return app
+}
+
+func newIdempotentRequest(t *testing.T, key, body string) *http.Request {
+	t.Helper()
+	token, err := GenerateJWT("user-1")
+	if err != nil {
+		t.Fatalf("error generando token: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/api/tasks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	return req
+}
+
+func TestIdempotencyMiddleware_StoresResponse(t *testing.T) {
+	var fingerprint, storedBody string
+	var storedStatus int
+	var storedHeaders map[string]string
+	app := newIdempotentApp(t, &mockIdempotencyService{
+		BeginFn: func(userID, key, fp string) (*domain.IdempotencyRecord, error) {
+			if userID != "user-1" || key != "k1" {
+				t.Errorf("unexpected user or key: %s, %s", userID, key)
+			}
+			fingerprint = fp
+			return nil, nil
+		},
+		CompleteFn: func(_, _ string, status int, headers map[string]string, body []byte) error {
+			storedStatus, storedHeaders, storedBody = status, headers, string(body)
+			return nil
+		},
+	}, func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"id": "t1"})
+	})
+
+	resp, err := app.Test(newIdempotentRequest(t, "k1", `{"title":"a"}`))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Errorf("expected 201, got %d", resp.StatusCode)
+	}
+	if len(fingerprint) != 64 {
+		t.Errorf("expected a sha256 fingerprint, got %q", fingerprint)
+	}
+	if storedStatus != fiber.StatusCreated || storedBody != `{"id":"t1"}` || storedHeaders[fiber.HeaderContentType] != fiber.MIMEApplicationJSON {
+		t.Errorf("unexpected stored response: %d %v %s", storedStatus, storedHeaders, storedBody)
+	}
+}
+
+func TestIdempotencyMiddleware_Replay(t *testing.T) {
+	called := false
+	app := newIdempotentApp(t, &mockIdempotencyService{
+		BeginFn: func(string, string, string) (*domain.IdempotencyRecord, error) {
+			return &domain.IdempotencyRecord{
+				StatusCode: fiber.StatusCreated,
+				Headers:    map[string]string{fiber.HeaderContentType: fiber.MIMEApplicationJSON},
+				Body:       []byte(`{"id":"t1"}`),
+			}, nil
+		},
+	}, func(c *fiber.Ctx) error {
+		called = true
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	resp, err := app.Test(newIdempotentRequest(t, "k1", `{"title":"a"}`))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("error leyendo respuesta: %v", err)
+	}
+	if called {
+		t.Error("expected the handler not to run on a replay")
+	}
+	if resp.StatusCode != fiber.StatusCreated || string(body) != `{"id":"t1"}` || resp.Header.Get("Idempotent-Replayed") != "true" {
+		t.Errorf("unexpected replay: %d %s %v", resp.StatusCode, body, resp.Header)
+	}
+	if resp.Header.Get(fiber.HeaderContentType) != fiber.MIMEApplicationJSON {
+		t.Errorf("expected the stored content type, got %q", resp.Header.Get(fiber.HeaderContentType))
+	}
+}
+
+func TestIdempotencyMiddleware_Errors(t *testing.T) {
+	cases := map[error]int{
+		domain.ErrIdempotencyKeyReused: fiber.StatusUnprocessableEntity,
+		domain.ErrIdempotencyKeyInUse:  fiber.StatusConflict,
+		domain.NewInvalidError("Idempotency-Key", "invalid_idempotency_key", "invalid idempotency key: at most 255 characters"): fiber.StatusBadRequest,
+		errors.New("db error"): fiber.StatusInternalServerError,
+	}
+	for beginErr, expected := range cases {
+		app := newIdempotentApp(t, &mockIdempotencyService{
+			BeginFn: func(string, string, string) (*domain.IdempotencyRecord, error) { return nil, beginErr },
+		}, func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusCreated) })
+
+		resp, err := app.Test(newIdempotentRequest(t, "k1", `{"title":"b"}`))
+		if err != nil {
+			t.Fatalf("error ejecutando app.Test: %v", err)
+		}
+		if resp.StatusCode != expected {
+			t.Errorf("%s: expected %d, got %d", beginErr, expected, resp.StatusCode)
+		}
+	}
+}
+
+func TestIdempotencyMiddleware_ReleasesOnServerError(t *testing.T) {
+	released := false
+	app := newIdempotentApp(t, &mockIdempotencyService{
+		BeginFn:   func(string, string, string) (*domain.IdempotencyRecord, error) { return nil, nil },
+		ReleaseFn: func(string, string) error { released = true; return nil },
+	}, func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create task"})
+	})
+
+	resp, err := app.Test(newIdempotentRequest(t, "k1", `{"title":"a"}`))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusInternalServerError || !released {
+		t.Errorf("expected the key to be released after a 500, got %d, released %v", resp.StatusCode, released)
+	}
+}
+
+func TestIdempotencyMiddleware_StoresClientError(t *testing.T) {
+	var storedStatus int
+	var storedBody string
+	released := false
+	app := newIdempotentApp(t, &mockIdempotencyService{
+		BeginFn: func(string, string, string) (*domain.IdempotencyRecord, error) { return nil, nil },
+		CompleteFn: func(userID, key string, statusCode int, headers map[string]string, body []byte) error {
+			storedStatus, storedBody = statusCode, string(body)
+			return nil
+		},
+		ReleaseFn: func(string, string) error { released = true; return nil },
+	}, func(c *fiber.Ctx) error {
+		return domain.ErrTaskListNotFound
+	})
+
+	resp, err := app.Test(newIdempotentRequest(t, "k1", `{"title":"a","list_id":"missing"}`))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Fatalf("expected 404, got %d", resp.StatusCode)
+	}
+	if released || storedStatus != fiber.StatusNotFound || storedBody != `{"error":"task list not found"}` {
+		t.Errorf("expected the 404 error to be stored, got status %d, body %s, released %v", storedStatus, storedBody, released)
+	}
+}
+
+func TestIdempotencyMiddleware_ReleasesOnServerErrorReturned(t *testing.T) {
+	completed, released := false, false
+	app := newIdempotentApp(t, &mockIdempotencyService{
+		BeginFn:    func(string, string, string) (*domain.IdempotencyRecord, error) { return nil, nil },
+		CompleteFn: func(string, string, int, map[string]string, []byte) error { completed = true; return nil },
+		ReleaseFn:  func(string, string) error { released = true; return nil },
+	}, func(c *fiber.Ctx) error {
+		return errors.New("pq: connection refused")
+	})
+
+	resp, err := app.Test(newIdempotentRequest(t, "k1", `{"title":"a"}`))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusInternalServerError || !released || completed {
+		t.Errorf("expected the key to be released after a 500, got %d, released %v, completed %v", resp.StatusCode, released, completed)
+	}
+}
+
+func TestIdempotencyMiddleware_WithoutKey(t *testing.T) {
+	app := newIdempotentApp(t, &mockIdempotencyService{}, func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	resp, err := app.Test(newIdempotentRequest(t, "", `{"title":"a"}`))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Errorf("expected 201, got %d", resp.StatusCode)
+	}
+}
+
+func TestIdempotencyMiddleware_WithoutToken(t *testing.T) {
+	app := newIdempotentApp(t, &mockIdempotencyService{
+		BeginFn: func(string, string, string) (*domain.IdempotencyRecord, error) {
+			t.Error("expected the key not to be looked up without a token")
+			return nil, nil
+		},
+	}, func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	req := httptest.NewRequest("POST", "/api/tasks", http.NoBody)
+	req.Header.Set("Idempotency-Key", "k1")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,547 @@
+package http
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/pkg/openapi"
+)
+
+type loginRequest struct {
+	UserID string `json:"user_id" openapi:"required,minLength=1"`
+}
+
+type loginResponse struct {
+	Token string `json:"token" openapi:"required"`
+}
+
+// taskMergePatch documents the members a JSON Merge Patch of a task may carry. Omitted
+// members are left unchanged and null clears the member.
+type taskMergePatch struct {
+	ListID      string   `json:"list_id" openapi:"minLength=1"`
+	Title       string   `json:"title" openapi:"minLength=1,maxLength=255"`
+	Description string   `json:"description" openapi:"nullable"`
+	Status      string   `json:"status" openapi:"enum=pending|in-progress|completed"`
+	Priority    string   `json:"priority" openapi:"enum=low|medium|high"`
+	DueDate     string   `json:"due_date" openapi:"nullable"`
+	Labels      []string `json:"labels" openapi:"nullable,minItems=1"`
+}
+
+// taskListMergePatch documents the members a JSON Merge Patch of a task list may carry.
+type taskListMergePatch struct {
+	Name        string `json:"name" openapi:"minLength=1,maxLength=255"`
+	Description string `json:"description" openapi:"nullable"`
+}
+
+// jsonPatchOperation documents one operation of a JSON Patch (RFC 6902).
+type jsonPatchOperation struct {
+	Op    string          `json:"op" openapi:"required,enum=add|remove|replace|move|copy|test"`
+	Path  string          `json:"path" openapi:"required"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// NewOpenAPIDocument describes the routes of RegisterRoutes and of the other route groups
+// with the request and response bodies of their DTOs. TestOpenAPIDocumentMatchesRoutes
+// keeps it in sync with the router.
+func NewOpenAPIDocument() *openapi.Document {
+	doc := openapi.New(openapi.Info{
+		Title:       "Task Management Service",
+		Version:     "1.0.0",
+		Description: "API REST de listas y tareas.",
+	})
+	doc.Components.SecuritySchemes = map[string]*openapi.SecurityScheme{
+		"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+	}
+	doc.Security = []map[string][]string{{"bearerAuth": {}}}
+
+	d := &documenter{doc: doc, components: doc.Components}
+	d.addAuth()
+	for _, version := range documentedVersions {
+		d.version = version
+		d.addTasks()
+		d.addTaskLists()
+	}
+	d.addRouteGroups()
+	return doc
+}
+
+// documentedVersion describes how the operations of one API version are documented.
+type documentedVersion struct {
+	prefix string
+	// suffix keeps the operation IDs of the versions unique.
+	suffix     string
+	deprecated bool
+	envelope   bool
+	// problems marks the versions whose errors are application/problem+json.
+	problems bool
+	// negotiated marks the unversioned prefix, served as v2 when Accept asks for it.
+	negotiated bool
+}
+
+var documentedVersions = []documentedVersion{
+	{prefix: "/api", deprecated: true, negotiated: true},
+	{prefix: "/api/" + apiV1, suffix: "V1", deprecated: true},
+	{prefix: "/api/" + apiV2, suffix: "V2", envelope: true, problems: true},
+}
+
+// documenter builds the operations of the document, sharing the schemas of the bodies.
+type documenter struct {
+	doc        *openapi.Document
+	components *openapi.Components
+	version    documentedVersion
+}
+
+// add documents an operation of the current version; path is relative to its prefix.
+func (d *documenter) add(method, path string, op *openapi.Operation) {
+	op.OperationID += d.version.suffix
+	if d.version.deprecated {
+		op.Deprecated = true
+		op.Description = strings.TrimSpace(op.Description + "\n\nDeprecated: v1 responses carry Deprecation, Sunset and a Link to the successor-version under /api/v2.")
+	}
+	if d.version.negotiated {
+		op.Description += " Served as v2 when Accept asks for application/vnd.tasks.v2+json."
+	}
+	d.doc.Add(method, d.version.prefix+path, op)
+}
+
+// resource returns the schema of a resource response of the current version, wrapped in
+// an envelope in v2.
+func (d *documenter) resource(name string, schema *openapi.Schema) *openapi.Schema {
+	if !d.version.envelope {
+		return schema
+	}
+	envelope := name + "Envelope"
+	d.components.Schemas[envelope] = &openapi.Schema{
+		Type:       openapi.Types{"object"},
+		Properties: map[string]*openapi.Schema{"data": schema},
+		Required:   []string{"data"},
+	}
+	return openapi.Ref(envelope)
+}
+
+func (d *documenter) addAuth() {
+	public := []map[string][]string{}
+	d.doc.Add(fiber.MethodPost, "/api/login", &openapi.Operation{
+		OperationID: "login",
+		Summary:     "Generate a JWT for a user",
+		Tags:        []string{"auth"},
+		RequestBody: d.jsonBody(loginRequest{}),
+		Responses: map[string]*openapi.Response{
+			"200": d.jsonResponse("Token issued", d.components.SchemaOf(loginResponse{})),
+			"400": d.errorResponse("Invalid request"),
+			"500": d.errorResponse("The token could not be generated"),
+		},
+		Security: &public,
+	})
+}
+
+func (d *documenter) addTasks() {
+	item := d.components.SchemaOf(TaskResponse{})
+	taskPage := d.page("TaskPage", item)
+	task := d.resource("Task", item)
+	bulk := d.resource("BulkTask", d.components.SchemaOf(BulkTaskResponse{}))
+
+	d.add(fiber.MethodPost, "/tasks", &openapi.Operation{
+		OperationID: "createTask",
+		Summary:     "Create a task",
+		Tags:        []string{"tasks"},
+		Parameters:  []*openapi.Parameter{idempotencyKeyParameter()},
+		RequestBody: d.jsonBody(CreateTaskRequest{}),
+		Responses: d.responses(map[string]*openapi.Response{
+			"201": d.jsonResponse("Task created", task),
+			"404": d.errorResponse("The task list does not exist or is in the trash"),
+			"409": d.errorResponse("The task list is archived"),
+		}),
+	})
+	d.add(fiber.MethodGet, "/tasks", &openapi.Operation{
+		OperationID: "listTasks",
+		Summary:     "List one page of tasks",
+		Description: "Filters by status and priority or by a filter expression, e.g. `status in (pending,in-progress) and priority = high`.",
+		Tags:        []string{"tasks"},
+		Parameters: append(pageParameters(),
+			queryParameter("list_id", "Only the tasks of this list", openapi.String("")),
+			queryParameter("status", "Only the tasks with this status", enumSchema("pending", "in-progress", "completed")),
+			queryParameter("priority", "Only the tasks with this priority", enumSchema("low", "medium", "high")),
+			queryParameter("filter", "Filter expression", openapi.String("")),
+			queryParameter("include_archived", "Include archived tasks", openapi.Boolean()),
+		),
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("One page of tasks", taskPage),
+		}),
+	})
+	d.add(fiber.MethodPost, "/tasks/bulk", &openapi.Operation{
+		OperationID: "bulkTasks",
+		Summary:     "Apply one action to many tasks",
+		Description: "Responds 200 when every item succeeded and 207 otherwise.",
+		Tags:        []string{"tasks"},
+		Parameters:  []*openapi.Parameter{idempotencyKeyParameter()},
+		RequestBody: d.jsonBody(BulkTaskRequest{}),
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("Every item succeeded", bulk),
+			"207": d.jsonResponse("Some items failed", bulk),
+		}),
+	})
+
+	d.addTask("/tasks/{id}", "Task", []*openapi.Parameter{pathParameter("id", "Task ID")}, task)
+
+	listTasksParameters := []*openapi.Parameter{pathParameter("id", "Task list ID"), pathParameter("taskId", "Task ID")}
+	d.add(fiber.MethodPost, "/lists/{id}/tasks", &openapi.Operation{
+		OperationID: "createListTask",
+		Summary:     "Create a task (nested route)",
+		Tags:        []string{"tasks"},
+		Parameters:  []*openapi.Parameter{pathParameter("id", "Task list ID"), idempotencyKeyParameter()},
+		RequestBody: d.jsonBody(CreateTaskRequest{}),
+		Responses: d.responses(map[string]*openapi.Response{
+			"201": d.jsonResponse("Task created", task),
+			"404": d.errorResponse("The task list does not exist or is in the trash"),
+			"409": d.errorResponse("The task list is archived"),
+		}),
+	})
+	d.add(fiber.MethodGet, "/lists/{id}/tasks/{taskId}", &openapi.Operation{
+		OperationID: "getListTask",
+		Summary:     "Get a task (nested route)",
+		Tags:        []string{"tasks"},
+		Parameters:  append(listTasksParameters, ifNoneMatchParameter()),
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.etagResponse("The task", task),
+			"304": {Description: "The task has not changed since the ETag of If-None-Match"},
+			"404": d.errorResponse("Task not found"),
+		}),
+	})
+	d.add(fiber.MethodPatch, "/lists/{id}/tasks/{taskId}/state", &openapi.Operation{
+		OperationID: "updateListTaskState",
+		Summary:     "Replace a task (nested route)",
+		Tags:        []string{"tasks"},
+		Parameters:  append(listTasksParameters, ifMatchParameter()),
+		RequestBody: d.jsonBody(UpdateTaskRequest{}),
+		Responses:   d.replaceResponses("Task", task),
+	})
+	d.add(fiber.MethodDelete, "/lists/{id}/tasks/{taskId}", &openapi.Operation{
+		OperationID: "deleteListTask",
+		Summary:     "Delete a task (nested route)",
+		Tags:        []string{"tasks"},
+		Parameters:  listTasksParameters,
+		Responses: d.responses(map[string]*openapi.Response{
+			"204": {Description: "Task deleted"},
+			"404": d.errorResponse("Task not found"),
+		}),
+	})
+}
+
+// addTask documents the GET, PUT, PATCH and DELETE operations of /api/tasks/{id}.
+func (d *documenter) addTask(path, name string, parameters []*openapi.Parameter, task *openapi.Schema) {
+	d.add(fiber.MethodGet, path, &openapi.Operation{
+		OperationID: "get" + name,
+		Summary:     "Get a task",
+		Tags:        []string{"tasks"},
+		Parameters:  append(parameters, ifNoneMatchParameter()),
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.etagResponse("The task", task),
+			"304": {Description: "The task has not changed since the ETag of If-None-Match"},
+			"404": d.errorResponse("Task not found"),
+		}),
+	})
+	d.add(fiber.MethodPut, path, &openapi.Operation{
+		OperationID: "update" + name,
+		Summary:     "Replace a task",
+		Tags:        []string{"tasks"},
+		Parameters:  append(parameters, ifMatchParameter()),
+		RequestBody: d.jsonBody(UpdateTaskRequest{}),
+		Responses:   d.replaceResponses("Task", task),
+	})
+	d.add(fiber.MethodPatch, path, &openapi.Operation{
+		OperationID: "patch" + name,
+		Summary:     "Partially update a task",
+		Tags:        []string{"tasks"},
+		Parameters:  append(parameters, ifMatchParameter(), idempotencyKeyParameter()),
+		RequestBody: d.patchBody(taskMergePatch{}),
+		Responses:   d.patchResponses("Task", task),
+	})
+	d.add(fiber.MethodDelete, path, &openapi.Operation{
+		OperationID: "delete" + name,
+		Summary:     "Move a task to the trash",
+		Tags:        []string{"tasks"},
+		Parameters:  parameters,
+		Responses: d.responses(map[string]*openapi.Response{
+			"204": {Description: "Task deleted"},
+			"404": d.errorResponse("Task not found"),
+		}),
+	})
+}
+
+func (d *documenter) addTaskLists() {
+	item := d.components.SchemaOf(TaskListResponse{})
+	listPage := d.page("TaskListPage", item)
+	list := d.resource("TaskList", item)
+	id := []*openapi.Parameter{pathParameter("id", "Task list ID")}
+
+	d.add(fiber.MethodPost, "/lists", &openapi.Operation{
+		OperationID: "createTaskList",
+		Summary:     "Create a task list",
+		Tags:        []string{"lists"},
+		Parameters:  []*openapi.Parameter{idempotencyKeyParameter()},
+		RequestBody: d.jsonBody(CreateTaskListRequest{}),
+		Responses: d.responses(map[string]*openapi.Response{
+			"201": d.jsonResponse("Task list created", list),
+		}),
+	})
+	d.add(fiber.MethodGet, "/lists", &openapi.Operation{
+		OperationID: "listTaskLists",
+		Summary:     "List one page of task lists with their completion",
+		Tags:        []string{"lists"},
+		Parameters: append(pageParameters(),
+			queryParameter("include_archived", "Include archived task lists", openapi.Boolean()),
+		),
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("One page of task lists", listPage),
+		}),
+	})
+	d.add(fiber.MethodGet, "/lists/{id}", &openapi.Operation{
+		OperationID: "getTaskList",
+		Summary:     "Get a task list",
+		Tags:        []string{"lists"},
+		Parameters:  append(id, ifNoneMatchParameter()),
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.etagResponse("The task list", list),
+			"304": {Description: "The task list has not changed since the ETag of If-None-Match"},
+			"404": d.errorResponse("Task list not found"),
+		}),
+	})
+	d.add(fiber.MethodPut, "/lists/{id}", &openapi.Operation{
+		OperationID: "updateTaskList",
+		Summary:     "Replace a task list",
+		Tags:        []string{"lists"},
+		Parameters:  append(id, ifMatchParameter()),
+		RequestBody: d.jsonBody(UpdateTaskListRequest{}),
+		Responses:   d.replaceResponses("Task list", list),
+	})
+	d.add(fiber.MethodPatch, "/lists/{id}", &openapi.Operation{
+		OperationID: "patchTaskList",
+		Summary:     "Partially update a task list",
+		Tags:        []string{"lists"},
+		Parameters:  append(id, ifMatchParameter(), idempotencyKeyParameter()),
+		RequestBody: d.patchBody(taskListMergePatch{}),
+		Responses:   d.patchResponses("Task list", list),
+	})
+	d.add(fiber.MethodDelete, "/lists/{id}", &openapi.Operation{
+		OperationID: "deleteTaskList",
+		Summary:     "Move a task list and its tasks to the trash",
+		Tags:        []string{"lists"},
+		Parameters:  id,
+		Responses: d.responses(map[string]*openapi.Response{
+			"204": {Description: "Task list deleted"},
+			"404": d.errorResponse("Task list not found"),
+		}),
+	})
+}
+
+func (d *documenter) jsonBody(v any) *openapi.RequestBody {
+	return &openapi.RequestBody{
+		Required: true,
+		Content: map[string]*openapi.MediaType{
+			fiber.MIMEApplicationJSON: {Schema: d.components.SchemaOf(v)},
+		},
+	}
+}
+
+// optionalJSONBody documents a JSON body the operation also accepts empty.
+func (d *documenter) optionalJSONBody(v any) *openapi.RequestBody {
+	body := d.jsonBody(v)
+	body.Required = false
+	return body
+}
+
+// patchBody documents a PATCH body as a merge patch, also accepted as application/json,
+// or as a JSON Patch.
+func (d *documenter) patchBody(mergePatch any) *openapi.RequestBody {
+	merge := d.components.SchemaOf(mergePatch)
+	return &openapi.RequestBody{
+		Required: true,
+		Content: map[string]*openapi.MediaType{
+			mergePatchContentType:     {Schema: merge},
+			fiber.MIMEApplicationJSON: {Schema: merge},
+			jsonPatchContentType:      {Schema: openapi.ArrayOf(d.components.SchemaOf(jsonPatchOperation{}))},
+		},
+	}
+}
+
+func (d *documenter) jsonResponse(description string, schema *openapi.Schema) *openapi.Response {
+	return &openapi.Response{
+		Description: description,
+		Content:     map[string]*openapi.MediaType{fiber.MIMEApplicationJSON: {Schema: schema}},
+	}
+}
+
+func (d *documenter) etagResponse(description string, schema *openapi.Schema) *openapi.Response {
+	response := d.jsonResponse(description, schema)
+	response.Headers = map[string]*openapi.Header{
+		fiber.HeaderETag: {Description: "Version of the resource, for If-Match and If-None-Match", Schema: openapi.String("")},
+	}
+	return response
+}
+
+// errorResponse documents an error response of the current version: application/problem+json
+// in v2, the legacy body in v1 and both under the negotiated prefix.
+func (d *documenter) errorResponse(description string) *openapi.Response {
+	content := map[string]*openapi.MediaType{}
+	if !d.version.problems {
+		content[fiber.MIMEApplicationJSON] = &openapi.MediaType{Schema: d.components.SchemaOf(LegacyError{})}
+	}
+	if d.version.problems || d.version.negotiated {
+		content[problemContentType] = &openapi.MediaType{Schema: d.components.SchemaOf(Problem{})}
+	}
+	return &openapi.Response{Description: description, Content: content}
+}
+
+// responses adds the responses every authenticated operation can return.
+func (d *documenter) responses(responses map[string]*openapi.Response) map[string]*openapi.Response {
+	defaults := map[string]*openapi.Response{
+		"400": d.errorResponse("Invalid request"),
+		"401": d.errorResponse("Missing or invalid token"),
+		"500": d.errorResponse("Internal error"),
+	}
+	for status, response := range defaults {
+		if _, ok := responses[status]; !ok {
+			responses[status] = response
+		}
+	}
+	return responses
+}
+
+func (d *documenter) replaceResponses(resource string, schema *openapi.Schema) map[string]*openapi.Response {
+	return d.responses(map[string]*openapi.Response{
+		"200": d.etagResponse(resource+" updated", schema),
+		"404": d.errorResponse(resource + " not found"),
+		"409": d.errorResponse("The task list is archived"),
+		"412": d.errorResponse("Invalid If-Match header, or the resource changed since that version"),
+	})
+}
+
+func (d *documenter) patchResponses(resource string, schema *openapi.Schema) map[string]*openapi.Response {
+	responses := d.replaceResponses(resource, schema)
+	responses["409"] = d.errorResponse("The task list is archived, a test operation failed or the resource changed while the JSON Patch was applied")
+	responses["415"] = d.errorResponse("Unsupported patch media type")
+	return responses
+}
+
+// page registers the schema of a page of a cursor paginated listing.
+func (d *documenter) page(name string, item *openapi.Schema) *openapi.Schema {
+	d.components.Schemas[name] = &openapi.Schema{
+		Type: openapi.Types{"object"},
+		Properties: map[string]*openapi.Schema{
+			"data":        openapi.ArrayOf(item),
+			"next_cursor": openapi.String("").Nullable(),
+			"total":       openapi.Integer(),
+		},
+		Required: []string{"data", "next_cursor"},
+	}
+	return openapi.Ref(name)
+}
+
+func pageParameters() []*openapi.Parameter {
+	return []*openapi.Parameter{
+		queryParameter("fields", "Comma separated fields to return; id is always returned", openapi.String("")),
+		queryParameter("limit", "Page size", openapi.Integer()),
+		queryParameter("sort", "Field to sort by, prefixed with - for descending order (default -created_at)", openapi.String("")),
+		queryParameter("cursor", "The next_cursor of the previous page", openapi.String("")),
+		queryParameter("include_total", "Count every item of the listing", openapi.Boolean()),
+	}
+}
+
+func pathParameter(name, description string) *openapi.Parameter {
+	return &openapi.Parameter{Name: name, In: "path", Description: description, Required: true, Schema: openapi.String("")}
+}
+
+func queryParameter(name, description string, schema *openapi.Schema) *openapi.Parameter {
+	return &openapi.Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func ifMatchParameter() *openapi.Parameter {
+	return &openapi.Parameter{Name: fiber.HeaderIfMatch, In: "header", Description: "Apply the change only at this ETag", Schema: openapi.String("")}
+}
+
+func ifNoneMatchParameter() *openapi.Parameter {
+	return &openapi.Parameter{Name: fiber.HeaderIfNoneMatch, In: "header", Description: "Respond 304 if the resource still has this ETag", Schema: openapi.String("")}
+}
+
+func idempotencyKeyParameter() *openapi.Parameter {
+	return &openapi.Parameter{Name: idempotencyKeyHeader, In: "header", Description: "Apply the request at most once per key", Schema: openapi.String("")}
+}
+
+func enumSchema(values ...string) *openapi.Schema {
+	schema := openapi.String("")
+	schema.Enum = values
+	return schema
+}
+
+// OpenAPIHandler sirve el documento OpenAPI de la API y su interfaz de documentación.
+type OpenAPIHandler struct {
+	document []byte
+}
+
+// NewOpenAPIHandler creates a new OpenAPIHandler instance.
+func NewOpenAPIHandler(doc *openapi.Document) (*OpenAPIHandler, error) {
+	document, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &OpenAPIHandler{document: document}, nil
+}
+
+// GetDocument returns the OpenAPI document.
+func (h *OpenAPIHandler) GetDocument(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.Send(h.document)
+}
+
+// GetDocs returns a Swagger UI page that renders the OpenAPI document.
+func (h *OpenAPIHandler) GetDocs(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(docsPage)
+}
+
+const docsPage = `<!DOCTYPE html>
+<html lang="es">
+<head>
+  <meta charset="utf-8">
+  <title>Task Management Service</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>`
+
+// RequestValidationMiddleware rechaza las peticiones cuyo cuerpo no cumple el esquema del documento OpenAPI.
+type RequestValidationMiddleware struct {
+	document *openapi.Document
+}
+
+// NewRequestValidationMiddleware creates a middleware that validates request bodies against doc.
+func NewRequestValidationMiddleware(doc *openapi.Document) *RequestValidationMiddleware {
+	return &RequestValidationMiddleware{document: doc}
+}
+
+// Handle validates the body of a documented operation against its schema and responds 400
+// with the offending fields when it does not match. Requests to operations the document
+// does not describe, and bodies of media types it does not describe, are passed on.
+func (m *RequestValidationMiddleware) Handle(c *fiber.Ctx) error {
+	op, ok := m.document.Match(c.Method(), c.Path())
+	if !ok || op.RequestBody == nil {
+		return c.Next()
+	}
+
+	errs := m.document.ValidateBody(op, c.Get(fiber.HeaderContentType), c.Body())
+	if len(errs) == 0 {
+		return c.Next()
+	}
+
+	return &validationError{fields: errs}
+}
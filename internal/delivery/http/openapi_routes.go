@@ -0,0 +1,686 @@
+package http
+
+import (
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/openapi"
+)
+
+// archivedTasksResponse documents the response of archive-completed.
+type archivedTasksResponse struct {
+	Archived int `json:"archived" openapi:"required"`
+}
+
+// assignedTasksResponse documents the response of the assignment of tasks to a sprint.
+type assignedTasksResponse struct {
+	Assigned int `json:"assigned" openapi:"required"`
+}
+
+// searchResponse documents the response of a full-text search.
+type searchResponse struct {
+	Data []SearchResultResponse `json:"data" openapi:"required"`
+}
+
+// graphqlResponse documents a GraphQL response: the data of an executed operation and its
+// errors.
+type graphqlResponse struct {
+	Data   json.RawMessage        `json:"data"`
+	Errors []graphqlResponseError `json:"errors"`
+}
+
+// graphqlResponseError documents one error of a GraphQL response.
+type graphqlResponseError struct {
+	Message   string                    `json:"message" openapi:"required"`
+	Locations []graphqlResponseLocation `json:"locations"`
+	Path      []json.RawMessage         `json:"path"`
+}
+
+// graphqlResponseLocation documents the position in the query of a GraphQL error.
+type graphqlResponseLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// addRouteGroups documents the route groups registered outside RegisterRoutes. They are
+// only served under /api.
+func (d *documenter) addRouteGroups() {
+	d.version = documentedVersion{prefix: "/api"}
+	d.addTrash()
+	d.addArchive()
+	d.addHistory()
+	d.addTemplates()
+	d.addChecklist()
+	d.addSprints()
+	d.addReports()
+	d.addSearch()
+	d.addViews()
+	d.addWebhooks()
+	d.addStream()
+	d.addSync()
+	d.addGraphQL()
+}
+
+func (d *documenter) addTrash() {
+	d.add(fiber.MethodGet, "/trash", &openapi.Operation{
+		OperationID: "getTrash",
+		Summary:     "List the task lists and tasks in the trash",
+		Tags:        []string{"trash"},
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The trash", d.resource("Trash", d.components.SchemaOf(TrashResponse{}))),
+		}),
+	})
+	d.add(fiber.MethodPost, "/trash/tasks/{id}/restore", &openapi.Operation{
+		OperationID: "restoreTask",
+		Summary:     "Restore a task from the trash",
+		Tags:        []string{"trash"},
+		Parameters:  []*openapi.Parameter{pathParameter("id", "Task ID")},
+		Responses: d.responses(map[string]*openapi.Response{
+			"204": {Description: "Task restored"},
+			"404": d.errorResponse("Task not found in the trash"),
+			"409": d.errorResponse("The task list of the task is in the trash or archived"),
+		}),
+	})
+	d.add(fiber.MethodPost, "/trash/lists/{id}/restore", &openapi.Operation{
+		OperationID: "restoreTaskList",
+		Summary:     "Restore a task list and the tasks deleted with it",
+		Tags:        []string{"trash"},
+		Parameters:  []*openapi.Parameter{pathParameter("id", "Task list ID")},
+		Responses: d.responses(map[string]*openapi.Response{
+			"204": {Description: "Task list restored"},
+			"404": d.errorResponse("Task list not found in the trash"),
+		}),
+	})
+}
+
+func (d *documenter) addArchive() {
+	task := []*openapi.Parameter{pathParameter("id", "Task ID")}
+	list := []*openapi.Parameter{pathParameter("id", "Task list ID")}
+
+	for _, action := range []struct {
+		method, path, operationID, summary, done, notFound string
+		parameters                                         []*openapi.Parameter
+	}{
+		{fiber.MethodPost, "/tasks/{id}/archive", "archiveTask", "Archive a task", "Task archived", "Task not found", task},
+		{fiber.MethodPost, "/tasks/{id}/unarchive", "unarchiveTask", "Unarchive a task", "Task unarchived", "Task not found", task},
+		{fiber.MethodPost, "/lists/{id}/archive", "archiveTaskList", "Archive a task list and hide its tasks", "Task list archived", "Task list not found", list},
+		{fiber.MethodPost, "/lists/{id}/unarchive", "unarchiveTaskList", "Unarchive a task list", "Task list unarchived", "Task list not found", list},
+	} {
+		d.add(action.method, action.path, &openapi.Operation{
+			OperationID: action.operationID,
+			Summary:     action.summary,
+			Tags:        []string{"archive"},
+			Parameters:  action.parameters,
+			Responses: d.responses(map[string]*openapi.Response{
+				"204": {Description: action.done},
+				"404": d.errorResponse(action.notFound),
+				"409": d.errorResponse("The task list is archived"),
+			}),
+		})
+	}
+
+	d.add(fiber.MethodPost, "/lists/{id}/archive-completed", &openapi.Operation{
+		OperationID: "archiveCompletedTasks",
+		Summary:     "Archive the completed tasks of a task list",
+		Tags:        []string{"archive"},
+		Parameters:  list,
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("Number of archived tasks", d.resource("ArchivedTasks", d.components.SchemaOf(archivedTasksResponse{}))),
+			"404": d.errorResponse("The task list does not exist or is in the trash"),
+			"409": d.errorResponse("The task list is archived"),
+		}),
+	})
+}
+
+func (d *documenter) addHistory() {
+	d.add(fiber.MethodGet, "/tasks/{id}/history", &openapi.Operation{
+		OperationID: "getTaskHistory",
+		Summary:     "List the revisions of a task with their field-level changes",
+		Tags:        []string{"history"},
+		Parameters:  []*openapi.Parameter{pathParameter("id", "Task ID")},
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The revisions of the task", d.resource("TaskHistory", openapi.ArrayOf(d.components.SchemaOf(TaskRevisionResponse{})))),
+			"404": d.errorResponse("Task not found"),
+		}),
+	})
+	d.add(fiber.MethodPost, "/tasks/{id}/history/{revision}/revert", &openapi.Operation{
+		OperationID: "revertTask",
+		Summary:     "Restore the fields of a task to one of its revisions",
+		Tags:        []string{"history"},
+		Parameters:  []*openapi.Parameter{pathParameter("id", "Task ID"), pathParameter("revision", "Revision number")},
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("Task reverted", d.resource("Task", d.components.SchemaOf(TaskResponse{}))),
+			"404": d.errorResponse("Task or revision not found"),
+			"409": d.errorResponse("The task list is archived"),
+		}),
+	})
+}
+
+func (d *documenter) addTemplates() {
+	template := d.resource("Template", d.components.SchemaOf(TemplateResponse{}))
+	id := []*openapi.Parameter{pathParameter("id", "Template ID")}
+
+	d.add(fiber.MethodPost, "/lists/{id}/template", &openapi.Operation{
+		OperationID: "saveListAsTemplate",
+		Summary:     "Save a task list and its tasks as a template",
+		Description: "The body is optional; the template is named after the task list by default.",
+		Tags:        []string{"templates"},
+		Parameters:  []*openapi.Parameter{pathParameter("id", "Task list ID"), idempotencyKeyParameter()},
+		RequestBody: d.optionalJSONBody(SaveTemplateRequest{}),
+		Responses: d.responses(map[string]*openapi.Response{
+			"201": d.jsonResponse("Template created", template),
+			"404": d.errorResponse("Task list not found"),
+		}),
+	})
+	d.add(fiber.MethodGet, "/templates", &openapi.Operation{
+		OperationID: "listTemplates",
+		Summary:     "List the templates",
+		Tags:        []string{"templates"},
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The templates", d.resource("TemplateList", openapi.ArrayOf(d.components.SchemaOf(TemplateResponse{})))),
+		}),
+	})
+	d.add(fiber.MethodGet, "/templates/{id}", &openapi.Operation{
+		OperationID: "getTemplate",
+		Summary:     "Get a template with its items",
+		Tags:        []string{"templates"},
+		Parameters:  id,
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The template", template),
+			"404": d.errorResponse("Template not found"),
+		}),
+	})
+	d.add(fiber.MethodDelete, "/templates/{id}", &openapi.Operation{
+		OperationID: "deleteTemplate",
+		Summary:     "Delete a template",
+		Tags:        []string{"templates"},
+		Parameters:  id,
+		Responses: d.responses(map[string]*openapi.Response{
+			"204": {Description: "Template deleted"},
+			"404": d.errorResponse("Template not found"),
+		}),
+	})
+	d.add(fiber.MethodPost, "/templates/{id}/instantiate", &openapi.Operation{
+		OperationID: "instantiateTemplate",
+		Summary:     "Create a task list and its tasks from a template",
+		Description: "The body is optional; {{variables}} in the titles and descriptions are replaced by the given values.",
+		Tags:        []string{"templates"},
+		Parameters:  append(id, idempotencyKeyParameter()),
+		RequestBody: d.optionalJSONBody(InstantiateTemplateRequest{}),
+		Responses: d.responses(map[string]*openapi.Response{
+			"201": d.jsonResponse("Task list created", d.resource("InstantiateTemplate", d.components.SchemaOf(InstantiateTemplateResponse{}))),
+			"404": d.errorResponse("Template not found"),
+		}),
+	})
+}
+
+func (d *documenter) addChecklist() {
+	item := d.resource("ChecklistItem", d.components.SchemaOf(ChecklistItemResponse{}))
+	items := d.resource("Checklist", openapi.ArrayOf(d.components.SchemaOf(ChecklistItemResponse{})))
+	task := []*openapi.Parameter{pathParameter("id", "Task ID")}
+	itemParameters := []*openapi.Parameter{pathParameter("id", "Task ID"), pathParameter("itemId", "Checklist item ID")}
+	withErrors := func(responses map[string]*openapi.Response) map[string]*openapi.Response {
+		responses["404"] = d.errorResponse("Task or checklist item not found")
+		responses["409"] = d.errorResponse("The task list is archived")
+		return d.responses(responses)
+	}
+
+	d.add(fiber.MethodGet, "/tasks/{id}/checklist", &openapi.Operation{
+		OperationID: "getChecklist",
+		Summary:     "List the checklist items of a task",
+		Tags:        []string{"checklist"},
+		Parameters:  task,
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The checklist", items),
+			"404": d.errorResponse("Task not found"),
+		}),
+	})
+	d.add(fiber.MethodPost, "/tasks/{id}/checklist", &openapi.Operation{
+		OperationID: "addChecklistItem",
+		Summary:     "Add an item to the checklist of a task",
+		Tags:        []string{"checklist"},
+		Parameters:  append(task, idempotencyKeyParameter()),
+		RequestBody: d.jsonBody(CreateChecklistItemRequest{}),
+		Responses:   withErrors(map[string]*openapi.Response{"201": d.jsonResponse("Checklist item created", item)}),
+	})
+	d.add(fiber.MethodPut, "/tasks/{id}/checklist/order", &openapi.Operation{
+		OperationID: "reorderChecklist",
+		Summary:     "Reorder the checklist of a task",
+		Description: "item_ids must list every item of the checklist once.",
+		Tags:        []string{"checklist"},
+		Parameters:  task,
+		RequestBody: d.jsonBody(ReorderChecklistRequest{}),
+		Responses:   withErrors(map[string]*openapi.Response{"200": d.jsonResponse("The reordered checklist", items)}),
+	})
+	d.add(fiber.MethodPatch, "/tasks/{id}/checklist/{itemId}", &openapi.Operation{
+		OperationID: "updateChecklistItem",
+		Summary:     "Rename or check a checklist item",
+		Tags:        []string{"checklist"},
+		Parameters:  itemParameters,
+		RequestBody: d.jsonBody(UpdateChecklistItemRequest{}),
+		Responses:   withErrors(map[string]*openapi.Response{"200": d.jsonResponse("Checklist item updated", item)}),
+	})
+	d.add(fiber.MethodDelete, "/tasks/{id}/checklist/{itemId}", &openapi.Operation{
+		OperationID: "deleteChecklistItem",
+		Summary:     "Delete a checklist item",
+		Tags:        []string{"checklist"},
+		Parameters:  itemParameters,
+		Responses:   withErrors(map[string]*openapi.Response{"204": {Description: "Checklist item deleted"}}),
+	})
+	d.add(fiber.MethodPost, "/tasks/{id}/checklist/{itemId}/toggle", &openapi.Operation{
+		OperationID: "toggleChecklistItem",
+		Summary:     "Check or uncheck a checklist item",
+		Tags:        []string{"checklist"},
+		Parameters:  itemParameters,
+		Responses:   withErrors(map[string]*openapi.Response{"200": d.jsonResponse("Checklist item toggled", item)}),
+	})
+	d.add(fiber.MethodPost, "/tasks/{id}/checklist/{itemId}/convert", &openapi.Operation{
+		OperationID: "convertChecklistItem",
+		Summary:     "Turn a checklist item into a task of the same list",
+		Tags:        []string{"checklist"},
+		Parameters:  append(itemParameters, idempotencyKeyParameter()),
+		Responses:   withErrors(map[string]*openapi.Response{"201": d.jsonResponse("Task created", d.resource("Task", d.components.SchemaOf(TaskResponse{})))}),
+	})
+}
+
+func (d *documenter) addSprints() {
+	sprint := d.resource("Sprint", d.components.SchemaOf(SprintResponse{}))
+	id := []*openapi.Parameter{pathParameter("id", "Sprint ID")}
+	withErrors := func(responses map[string]*openapi.Response) map[string]*openapi.Response {
+		responses["404"] = d.errorResponse("Sprint not found")
+		responses["409"] = d.errorResponse("The sprint is not in a state that allows the change")
+		return d.responses(responses)
+	}
+
+	d.add(fiber.MethodPost, "/sprints", &openapi.Operation{
+		OperationID: "createSprint",
+		Summary:     "Create a sprint or milestone",
+		Tags:        []string{"sprints"},
+		Parameters:  []*openapi.Parameter{idempotencyKeyParameter()},
+		RequestBody: d.jsonBody(SprintRequest{}),
+		Responses: d.responses(map[string]*openapi.Response{
+			"201": d.jsonResponse("Sprint created", sprint),
+		}),
+	})
+	d.add(fiber.MethodGet, "/sprints", &openapi.Operation{
+		OperationID: "listSprints",
+		Summary:     "List the sprints and milestones",
+		Tags:        []string{"sprints"},
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The sprints", d.resource("SprintList", openapi.ArrayOf(d.components.SchemaOf(SprintResponse{})))),
+		}),
+	})
+	d.add(fiber.MethodGet, "/sprints/{id}", &openapi.Operation{
+		OperationID: "getSprint",
+		Summary:     "Get a sprint",
+		Tags:        []string{"sprints"},
+		Parameters:  id,
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The sprint", sprint),
+			"404": d.errorResponse("Sprint not found"),
+		}),
+	})
+	d.add(fiber.MethodPut, "/sprints/{id}", &openapi.Operation{
+		OperationID: "updateSprint",
+		Summary:     "Replace a sprint",
+		Tags:        []string{"sprints"},
+		Parameters:  id,
+		RequestBody: d.jsonBody(SprintRequest{}),
+		Responses:   withErrors(map[string]*openapi.Response{"200": d.jsonResponse("Sprint updated", sprint)}),
+	})
+	d.add(fiber.MethodDelete, "/sprints/{id}", &openapi.Operation{
+		OperationID: "deleteSprint",
+		Summary:     "Delete a sprint and unassign its tasks",
+		Tags:        []string{"sprints"},
+		Parameters:  id,
+		Responses:   withErrors(map[string]*openapi.Response{"204": {Description: "Sprint deleted"}}),
+	})
+	d.add(fiber.MethodGet, "/sprints/{id}/tasks", &openapi.Operation{
+		OperationID: "getSprintTasks",
+		Summary:     "List the tasks of a sprint",
+		Tags:        []string{"sprints"},
+		Parameters:  id,
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The tasks of the sprint", d.resource("SprintTasks", openapi.ArrayOf(d.components.SchemaOf(TaskResponse{})))),
+			"404": d.errorResponse("Sprint not found"),
+		}),
+	})
+	d.add(fiber.MethodPost, "/sprints/{id}/tasks", &openapi.Operation{
+		OperationID: "assignSprintTasks",
+		Summary:     "Add tasks to a sprint",
+		Tags:        []string{"sprints"},
+		Parameters:  id,
+		RequestBody: d.jsonBody(AssignSprintTasksRequest{}),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": d.jsonResponse("Number of assigned tasks", d.resource("AssignedTasks", d.components.SchemaOf(assignedTasksResponse{}))),
+		}),
+	})
+	d.add(fiber.MethodDelete, "/sprints/{id}/tasks/{taskId}", &openapi.Operation{
+		OperationID: "unassignSprintTask",
+		Summary:     "Remove a task from a sprint",
+		Tags:        []string{"sprints"},
+		Parameters:  append(id, pathParameter("taskId", "Task ID")),
+		Responses: withErrors(map[string]*openapi.Response{
+			"204": {Description: "Task removed from the sprint"},
+			"404": d.errorResponse("Sprint not found or the task is not in it"),
+		}),
+	})
+	d.add(fiber.MethodPost, "/sprints/{id}/start", &openapi.Operation{
+		OperationID: "startSprint",
+		Summary:     "Start a planned sprint",
+		Tags:        []string{"sprints"},
+		Parameters:  id,
+		Responses:   withErrors(map[string]*openapi.Response{"200": d.jsonResponse("Sprint started", sprint)}),
+	})
+	d.add(fiber.MethodPost, "/sprints/{id}/close", &openapi.Operation{
+		OperationID: "closeSprint",
+		Summary:     "Close a sprint",
+		Description: "The body is optional; the unfinished tasks move to next_sprint_id when given.",
+		Tags:        []string{"sprints"},
+		Parameters:  id,
+		RequestBody: d.optionalJSONBody(CloseSprintRequest{}),
+		Responses:   withErrors(map[string]*openapi.Response{"200": d.jsonResponse("Sprint closed", sprint)}),
+	})
+	d.add(fiber.MethodGet, "/sprints/{id}/summary", &openapi.Operation{
+		OperationID: "getSprintSummary",
+		Summary:     "Compare the committed and completed tasks of a sprint",
+		Tags:        []string{"sprints"},
+		Parameters:  id,
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The summary of the sprint", d.resource("SprintSummary", d.components.SchemaOf(SprintSummaryResponse{}))),
+			"404": d.errorResponse("Sprint not found"),
+		}),
+	})
+}
+
+func (d *documenter) addReports() {
+	reports := []struct {
+		path, operationID, summary string
+		points                     any
+	}{
+		{"/burndown", "Burndown", "Remaining tasks per day", []BurndownPointResponse{}},
+		{"/burnup", "Burnup", "Completed tasks and scope per day", []BurnupPointResponse{}},
+		{"/cumulative-flow", "CumulativeFlow", "Tasks per status per day", []CumulativeFlowPointResponse{}},
+	}
+	for _, owner := range []struct{ path, operationID, name, description string }{
+		{"/lists/{id}", "list", "Task list", "Task list ID"},
+		{"/sprints/{id}", "sprint", "Sprint", "Sprint ID"},
+	} {
+		for _, report := range reports {
+			d.add(fiber.MethodGet, owner.path+report.path, &openapi.Operation{
+				OperationID: owner.operationID + report.operationID,
+				Summary:     report.summary,
+				Tags:        []string{"reports"},
+				Parameters: []*openapi.Parameter{
+					pathParameter("id", owner.description),
+					queryParameter("from", "First day (YYYY-MM-DD)", openapi.String("date")),
+					queryParameter("to", "Last day (YYYY-MM-DD)", openapi.String("date")),
+				},
+				Responses: d.responses(map[string]*openapi.Response{
+					"200": d.jsonResponse("One point per day", d.resource(report.operationID, d.components.SchemaOf(report.points))),
+					"404": d.errorResponse(owner.name + " not found"),
+				}),
+			})
+		}
+	}
+}
+
+func (d *documenter) addSearch() {
+	d.add(fiber.MethodGet, "/search", &openapi.Operation{
+		OperationID: "searchTasks",
+		Summary:     "Search tasks by title and description",
+		Tags:        []string{"search"},
+		Parameters: []*openapi.Parameter{
+			queryParameter("q", "Search terms", openapi.String("")),
+			queryParameter("status", "Only the tasks with this status", enumSchema("pending", "in-progress", "completed")),
+			queryParameter("priority", "Only the tasks with this priority", enumSchema("low", "medium", "high")),
+			queryParameter("include_archived", "Include archived tasks", openapi.Boolean()),
+			queryParameter("limit", "Maximum number of results", openapi.Integer()),
+			queryParameter("offset", "Number of results to skip", openapi.Integer()),
+		},
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The matching tasks, best first", d.components.SchemaOf(searchResponse{})),
+		}),
+	})
+}
+
+func (d *documenter) addViews() {
+	view := d.resource("View", d.components.SchemaOf(ViewResponse{}))
+	id := []*openapi.Parameter{pathParameter("id", "View ID")}
+	withErrors := func(responses map[string]*openapi.Response) map[string]*openapi.Response {
+		responses["403"] = d.errorResponse("The view belongs to another user")
+		responses["404"] = d.errorResponse("View not found")
+		return d.responses(responses)
+	}
+
+	d.add(fiber.MethodPost, "/views", &openapi.Operation{
+		OperationID: "createView",
+		Summary:     "Save a view of the tasks",
+		Tags:        []string{"views"},
+		Parameters:  []*openapi.Parameter{idempotencyKeyParameter()},
+		RequestBody: d.jsonBody(ViewRequest{}),
+		Responses: d.responses(map[string]*openapi.Response{
+			"201": d.jsonResponse("View created", view),
+			"404": d.errorResponse("Task list not found"),
+		}),
+	})
+	d.add(fiber.MethodGet, "/views", &openapi.Operation{
+		OperationID: "listViews",
+		Summary:     "List the views of the user and the shared views",
+		Tags:        []string{"views"},
+		Parameters:  []*openapi.Parameter{queryParameter("list_id", "Only the views of this list", openapi.String(""))},
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The views", d.resource("ViewList", openapi.ArrayOf(d.components.SchemaOf(ViewResponse{})))),
+		}),
+	})
+	d.add(fiber.MethodGet, "/views/{id}", &openapi.Operation{
+		OperationID: "getView",
+		Summary:     "Get a view",
+		Tags:        []string{"views"},
+		Parameters:  id,
+		Responses:   withErrors(map[string]*openapi.Response{"200": d.jsonResponse("The view", view)}),
+	})
+	d.add(fiber.MethodPut, "/views/{id}", &openapi.Operation{
+		OperationID: "updateView",
+		Summary:     "Replace a view",
+		Tags:        []string{"views"},
+		Parameters:  id,
+		RequestBody: d.jsonBody(ViewRequest{}),
+		Responses:   withErrors(map[string]*openapi.Response{"200": d.jsonResponse("View updated", view)}),
+	})
+	d.add(fiber.MethodDelete, "/views/{id}", &openapi.Operation{
+		OperationID: "deleteView",
+		Summary:     "Delete a view",
+		Tags:        []string{"views"},
+		Parameters:  id,
+		Responses:   withErrors(map[string]*openapi.Response{"204": {Description: "View deleted"}}),
+	})
+	d.add(fiber.MethodGet, "/views/{id}/tasks", &openapi.Operation{
+		OperationID: "getViewTasks",
+		Summary:     "Run a view and list one page of its tasks",
+		Description: "The sort and fields of the view apply unless sort or fields are given.",
+		Tags:        []string{"views"},
+		Parameters:  append(id, pageParameters()...),
+		Responses:   withErrors(map[string]*openapi.Response{"200": d.jsonResponse("One page of tasks", d.page("TaskPage", d.components.SchemaOf(TaskResponse{})))}),
+	})
+}
+
+func (d *documenter) addWebhooks() {
+	webhook := d.resource("Webhook", d.components.SchemaOf(WebhookResponse{}))
+	id := []*openapi.Parameter{pathParameter("id", "Webhook ID")}
+	delivery := []*openapi.Parameter{pathParameter("id", "Webhook ID"), pathParameter("deliveryId", "Delivery ID")}
+	withErrors := func(responses map[string]*openapi.Response) map[string]*openapi.Response {
+		responses["403"] = d.errorResponse("The webhook belongs to another user")
+		if responses["404"] == nil {
+			responses["404"] = d.errorResponse("Webhook not found")
+		}
+		return d.responses(responses)
+	}
+
+	d.add(fiber.MethodPost, "/webhooks", &openapi.Operation{
+		OperationID: "createWebhook",
+		Summary:     "Subscribe a URL to task and list events",
+		Description: "The response carries the signing secret, which is not returned again.",
+		Tags:        []string{"webhooks"},
+		Parameters:  []*openapi.Parameter{idempotencyKeyParameter()},
+		RequestBody: d.jsonBody(WebhookRequest{}),
+		Responses: d.responses(map[string]*openapi.Response{
+			"201": d.jsonResponse("Webhook created", webhook),
+			"404": d.errorResponse("Task list not found"),
+		}),
+	})
+	d.add(fiber.MethodGet, "/webhooks", &openapi.Operation{
+		OperationID: "listWebhooks",
+		Summary:     "List the webhooks of the user",
+		Tags:        []string{"webhooks"},
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The webhooks", d.resource("WebhookList", openapi.ArrayOf(d.components.SchemaOf(WebhookResponse{})))),
+		}),
+	})
+	d.add(fiber.MethodGet, "/webhooks/{id}", &openapi.Operation{
+		OperationID: "getWebhook",
+		Summary:     "Get a webhook",
+		Tags:        []string{"webhooks"},
+		Parameters:  id,
+		Responses:   withErrors(map[string]*openapi.Response{"200": d.jsonResponse("The webhook", webhook)}),
+	})
+	d.add(fiber.MethodPut, "/webhooks/{id}", &openapi.Operation{
+		OperationID: "updateWebhook",
+		Summary:     "Replace a webhook",
+		Tags:        []string{"webhooks"},
+		Parameters:  id,
+		RequestBody: d.jsonBody(WebhookRequest{}),
+		Responses:   withErrors(map[string]*openapi.Response{"200": d.jsonResponse("Webhook updated", webhook)}),
+	})
+	d.add(fiber.MethodDelete, "/webhooks/{id}", &openapi.Operation{
+		OperationID: "deleteWebhook",
+		Summary:     "Delete a webhook",
+		Tags:        []string{"webhooks"},
+		Parameters:  id,
+		Responses:   withErrors(map[string]*openapi.Response{"204": {Description: "Webhook deleted"}}),
+	})
+	d.add(fiber.MethodGet, "/webhooks/{id}/deliveries", &openapi.Operation{
+		OperationID: "listWebhookDeliveries",
+		Summary:     "List the deliveries of a webhook",
+		Tags:        []string{"webhooks"},
+		Parameters:  append(id, queryParameter("status", "Only the deliveries with this status", enumSchema(domain.DeliveryPending, domain.DeliveryDelivered, domain.DeliveryDead))),
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": d.jsonResponse("The deliveries", d.resource("WebhookDeliveryList", openapi.ArrayOf(d.components.SchemaOf(WebhookDeliveryResponse{})))),
+		}),
+	})
+	d.add(fiber.MethodGet, "/webhooks/{id}/deliveries/{deliveryId}", &openapi.Operation{
+		OperationID: "getWebhookDelivery",
+		Summary:     "Get a delivery with its payload and attempts",
+		Tags:        []string{"webhooks"},
+		Parameters:  delivery,
+		Responses: withErrors(map[string]*openapi.Response{
+			"200": d.jsonResponse("The delivery", d.resource("WebhookDeliveryDetail", d.components.SchemaOf(WebhookDeliveryDetailResponse{}))),
+			"404": d.errorResponse("Webhook or delivery not found"),
+		}),
+	})
+	d.add(fiber.MethodPost, "/webhooks/{id}/deliveries/{deliveryId}/redeliver", &openapi.Operation{
+		OperationID: "redeliverWebhookDelivery",
+		Summary:     "Send a delivery again",
+		Tags:        []string{"webhooks"},
+		Parameters:  delivery,
+		Responses: withErrors(map[string]*openapi.Response{
+			"202": d.jsonResponse("Delivery queued", d.resource("WebhookDelivery", d.components.SchemaOf(WebhookDeliveryResponse{}))),
+			"404": d.errorResponse("Webhook or delivery not found"),
+		}),
+	})
+}
+
+func (d *documenter) addStream() {
+	d.add(fiber.MethodGet, "/events", &openapi.Operation{
+		OperationID: "streamEvents",
+		Summary:     "Stream the task and list events as Server-Sent Events",
+		Description: "A client that reconnects with Last-Event-ID first receives the events it missed.",
+		Tags:        []string{"events"},
+		Parameters: []*openapi.Parameter{
+			queryParameter("list_id", "Only the events of this list", openapi.String("")),
+			queryParameter("access_token", "The JWT, for clients that cannot set the Authorization header", openapi.String("")),
+			queryParameter("last_event_id", "Resume after this event, like Last-Event-ID", openapi.String("")),
+			{Name: "Last-Event-ID", In: "header", Description: "Resume after this event", Schema: openapi.String("")},
+		},
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": {
+				Description: "The event stream",
+				Content:     map[string]*openapi.MediaType{"text/event-stream": {Schema: openapi.String("")}},
+			},
+			"404": d.errorResponse("Task list not found"),
+		}),
+	})
+}
+
+func (d *documenter) addSync() {
+	d.add(fiber.MethodGet, "/sync", &openapi.Operation{
+		OperationID: "getChanges",
+		Summary:     "List the changes since a sync token",
+		Description: "Without a token the feed starts from the beginning.",
+		Tags:        []string{"sync"},
+		Parameters: []*openapi.Parameter{
+			queryParameter("since", "The token of the previous response", openapi.String("")),
+			queryParameter("limit", "Maximum number of changes", openapi.Integer()),
+		},
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The changes and the token to continue from", d.resource("Sync", d.components.SchemaOf(SyncResponse{}))),
+			"410": d.errorResponse("The token is older than the retained changes; resync from scratch"),
+		}),
+	})
+	push := d.resource("SyncPush", d.components.SchemaOf(SyncPushResponse{}))
+	d.add(fiber.MethodPost, "/sync", &openapi.Operation{
+		OperationID: "pushChanges",
+		Summary:     "Apply the changes made by an offline client",
+		Description: "Responds 200 when every change was applied and 207 otherwise.",
+		Tags:        []string{"sync"},
+		Parameters:  []*openapi.Parameter{idempotencyKeyParameter()},
+		RequestBody: d.jsonBody(SyncPushRequest{}),
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("Every change was applied", push),
+			"207": d.jsonResponse("Some changes were not applied", push),
+		}),
+	})
+}
+
+func (d *documenter) addGraphQL() {
+	result := d.components.SchemaOf(graphqlResponse{})
+	responses := func() map[string]*openapi.Response {
+		return d.responses(map[string]*openapi.Response{
+			"200": d.jsonResponse("The result of the operation", result),
+			"400": d.jsonResponse("The operation is invalid or exceeds the depth or complexity limits", result),
+		})
+	}
+
+	d.add(fiber.MethodPost, "/graphql", &openapi.Operation{
+		OperationID: "executeGraphQL",
+		Summary:     "Execute a GraphQL query or mutation",
+		Tags:        []string{"graphql"},
+		RequestBody: d.jsonBody(GraphQLRequest{}),
+		Responses:   responses(),
+	})
+	d.add(fiber.MethodGet, "/graphql", &openapi.Operation{
+		OperationID: "queryGraphQL",
+		Summary:     "Execute a GraphQL query",
+		Description: "Mutations are only accepted by POST.",
+		Tags:        []string{"graphql"},
+		Parameters: []*openapi.Parameter{
+			queryParameter("query", "The GraphQL document", openapi.String("")),
+			queryParameter("operationName", "The operation to execute", openapi.String("")),
+			queryParameter("variables", "The variables, as a JSON object", openapi.String("")),
+		},
+		Responses: responses(),
+	})
+	d.add(fiber.MethodGet, "/graphql/schema", &openapi.Operation{
+		OperationID: "getGraphQLSchema",
+		Summary:     "Get the GraphQL schema in SDL",
+		Tags:        []string{"graphql"},
+		Responses: d.responses(map[string]*openapi.Response{
+			"200": {
+				Description: "The schema",
+				Content:     map[string]*openapi.MediaType{fiber.MIMETextPlain: {Schema: openapi.String("")}},
+			},
+		}),
+	})
+}
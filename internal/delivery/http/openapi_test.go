@@ -0,0 +1,244 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/pkg/openapi"
+)
+
+func TestOpenAPIDocumentMatchesRoutes(t *testing.T) {
+	app := newTestApp()
+	RegisterRoutes(app, nil, nil, APIVersioning{})
+	registerRouteGroups(app)
+
+	registered := []string{}
+	for _, route := range app.GetRoutes(true) {
+		if route.Method == fiber.MethodHead {
+			continue
+		}
+		path := strings.TrimSuffix(route.Path, "/")
+		segments := strings.Split(path, "/")
+		for i, segment := range segments {
+			if strings.HasPrefix(segment, ":") {
+				segments[i] = "{" + strings.TrimPrefix(segment, ":") + "}"
+			}
+		}
+		registered = append(registered, route.Method+" "+strings.Join(segments, "/"))
+	}
+	sort.Strings(registered)
+
+	documented := []string{}
+	for path, item := range NewOpenAPIDocument().Paths {
+		for method := range item {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(documented)
+
+	if !reflect.DeepEqual(registered, documented) {
+		t.Errorf("the OpenAPI document is out of sync with the routes\nregistered: %v\ndocumented: %v", registered, documented)
+	}
+}
+
+func TestOpenAPIDocumentSchemas(t *testing.T) {
+	doc := NewOpenAPIDocument()
+
+	for _, name := range []string{
+		"CreateTaskRequest", "UpdateTaskRequest", "TaskResponse", "CreateTaskListRequest", "UpdateTaskListRequest", "TaskListResponse",
+		"TrashResponse", "TaskRevisionResponse", "SaveTemplateRequest", "InstantiateTemplateRequest", "TemplateResponse", "InstantiateTemplateResponse",
+		"CreateChecklistItemRequest", "UpdateChecklistItemRequest", "ReorderChecklistRequest", "ChecklistItemResponse",
+		"SprintRequest", "AssignSprintTasksRequest", "CloseSprintRequest", "SprintResponse", "SprintSummaryResponse",
+		"BurndownPointResponse", "BurnupPointResponse", "CumulativeFlowPointResponse", "SearchResultResponse", "ViewRequest", "ViewResponse",
+		"WebhookRequest", "WebhookResponse", "WebhookDeliveryResponse", "WebhookDeliveryDetailResponse",
+		"SyncResponse", "SyncPushRequest", "SyncPushResponse", "GraphQLRequest",
+	} {
+		if doc.Components.Schemas[name] == nil {
+			t.Errorf("expected component schema %s", name)
+		}
+	}
+
+	// Every JSON field of the DTOs must be described.
+	for name, v := range map[string]interface{}{
+		"TaskResponse": TaskResponse{}, "TaskListResponse": TaskListResponse{}, "CreateTaskRequest": CreateTaskRequest{},
+		"SprintResponse": SprintResponse{}, "TemplateResponse": TemplateResponse{}, "WebhookDeliveryDetailResponse": WebhookDeliveryDetailResponse{},
+	} {
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(encoded, &fields); err != nil {
+			t.Fatal(err)
+		}
+		for field := range fields {
+			if doc.Components.Schemas[name].Properties[field] == nil {
+				t.Errorf("expected %s.%s to be documented", name, field)
+			}
+		}
+	}
+
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			for _, param := range op.Parameters {
+				if param.In == "path" && !strings.Contains(path, "{"+param.Name+"}") {
+					t.Errorf("%s %s: path parameter %s is not in the path", method, path, param.Name)
+				}
+			}
+		}
+	}
+
+	login, ok := doc.Match(fiber.MethodPost, "/api/login")
+	if !ok || login.Security == nil || len(*login.Security) != 0 {
+		t.Error("expected login to be documented as public")
+	}
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	h, err := NewOpenAPIHandler(NewOpenAPIDocument())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	app := newTestApp()
+	RegisterOpenAPIRoutes(app, h)
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/openapi.json", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var doc openapi.Document
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("expected OpenAPI 3.1.0, got %q", doc.OpenAPI)
+	}
+	if title := doc.Components.Schemas["CreateTaskRequest"].Properties["title"]; title == nil || *title.MaxLength != 255 {
+		t.Errorf("expected title to be limited to 255 characters, got %+v", title)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/docs", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != fiber.StatusOK || !strings.Contains(string(body), "/openapi.json") {
+		t.Errorf("expected the docs page to load /openapi.json, got %d %s", resp.StatusCode, body)
+	}
+}
+
+func TestRequestValidationMiddleware(t *testing.T) {
+	app := newTestApp()
+	RegisterRequestValidationMiddleware(app, NewRequestValidationMiddleware(NewOpenAPIDocument()))
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) }
+	app.Post("/api/tasks", ok)
+	app.Patch("/api/tasks/:id", ok)
+	app.Post("/api/sprints/:id/close", ok)
+	app.Get("/api/unknown", ok)
+
+	cases := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantDetails []openapi.FieldError
+	}{
+		{"valid task", fiber.MethodPost, "/api/tasks", fiber.MIMEApplicationJSON, `{"list_id":"l1","title":"Tarea","priority":"high"}`, fiber.StatusNoContent, nil},
+		{"missing title", fiber.MethodPost, "/api/tasks", fiber.MIMEApplicationJSON, `{"list_id":"l1"}`, fiber.StatusBadRequest, []openapi.FieldError{{Field: "/title", Message: "is required"}}},
+		{"title too long", fiber.MethodPost, "/api/tasks", fiber.MIMEApplicationJSON, `{"title":"` + strings.Repeat("a", 256) + `"}`, fiber.StatusBadRequest, []openapi.FieldError{{Field: "/title", Message: "must be at most 255 characters long"}}},
+		{"invalid priority", fiber.MethodPost, "/api/tasks", fiber.MIMEApplicationJSON, `{"title":"Tarea","priority":"urgent"}`, fiber.StatusBadRequest, []openapi.FieldError{{Field: "/priority", Message: "must be one of: low, medium, high"}}},
+		{"invalid JSON", fiber.MethodPost, "/api/tasks", fiber.MIMEApplicationJSON, `{"title":`, fiber.StatusBadRequest, []openapi.FieldError{{Field: "", Message: "must be valid JSON"}}},
+		{"merge patch", fiber.MethodPatch, "/api/tasks/t1", mergePatchContentType, `{"description":null,"status":"completed"}`, fiber.StatusNoContent, nil},
+		{"merge patch with null title", fiber.MethodPatch, "/api/tasks/t1", mergePatchContentType, `{"title":null}`, fiber.StatusBadRequest, []openapi.FieldError{{Field: "/title", Message: "must be a string"}}},
+		{"json patch", fiber.MethodPatch, "/api/tasks/t1", jsonPatchContentType, `[{"op":"replace","path":"/title","value":"x"}]`, fiber.StatusNoContent, nil},
+		{"json patch with unknown op", fiber.MethodPatch, "/api/tasks/t1", jsonPatchContentType, `[{"op":"rename","path":"/title"}]`, fiber.StatusBadRequest, []openapi.FieldError{{Field: "/0/op", Message: "must be one of: add, remove, replace, move, copy, test"}}},
+		{"optional body", fiber.MethodPost, "/api/sprints/s1/close", fiber.MIMEApplicationJSON, ``, fiber.StatusNoContent, nil},
+		{"optional body with invalid field", fiber.MethodPost, "/api/sprints/s1/close", fiber.MIMEApplicationJSON, `{"next_sprint_id":1}`, fiber.StatusBadRequest, []openapi.FieldError{{Field: "/next_sprint_id", Message: "must be a string"}}},
+		{"undocumented route", fiber.MethodGet, "/api/unknown", fiber.MIMEApplicationJSON, `{"anything":1}`, fiber.StatusNoContent, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set(fiber.HeaderContentType, tc.contentType)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("expected %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+			if tc.wantStatus != fiber.StatusBadRequest {
+				return
+			}
+
+			var body LegacyError
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+			if body.Error != "Request body does not match the schema" {
+				t.Errorf("unexpected error: %q", body.Error)
+			}
+			if !reflect.DeepEqual(body.Details, tc.wantDetails) {
+				t.Errorf("expected details %v, got %v", tc.wantDetails, body.Details)
+			}
+		})
+	}
+}
+
+func TestRequestValidationMiddleware_AfterAuthentication(t *testing.T) {
+	app := newTestApp()
+	RegisterAuthMiddleware(app)
+	RegisterRequestValidationMiddleware(app, NewRequestValidationMiddleware(NewOpenAPIDocument()))
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) }
+	app.Post("/api/login", ok)
+	app.Post("/api/tasks", JWTMiddleware, ok)
+
+	token, err := GenerateJWT("user-1")
+	if err != nil {
+		t.Fatalf("error generando token: %v", err)
+	}
+
+	cases := []struct {
+		name       string
+		path       string
+		token      string
+		body       string
+		wantStatus int
+	}{
+		{"invalid body without token", "/api/tasks", "", `{"list_id":"l1"}`, fiber.StatusUnauthorized},
+		{"invalid body with invalid token", "/api/tasks", "not-a-token", `{"list_id":"l1"}`, fiber.StatusUnauthorized},
+		{"invalid body with token", "/api/tasks", token, `{"list_id":"l1"}`, fiber.StatusBadRequest},
+		{"valid body with token", "/api/tasks", token, `{"list_id":"l1","title":"Tarea"}`, fiber.StatusNoContent},
+		{"login without token", "/api/login", "", `{"user_id":"user-1"}`, fiber.StatusNoContent},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPost, tc.path, strings.NewReader(tc.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			if tc.token != "" {
+				req.Header.Set(fiber.HeaderAuthorization, "Bearer "+tc.token)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("expected %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+		})
+	}
+}
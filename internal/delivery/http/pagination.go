@@ -0,0 +1,143 @@
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// PageResponse envuelve una página de un listado paginado por cursor.
+type PageResponse struct {
+	Data       interface{} `json:"data"`
+	NextCursor *string     `json:"next_cursor"`
+	Total      *int        `json:"total,omitempty"`
+}
+
+// pageCursor is the payload of an opaque cursor. The sort it was issued for is kept so
+// that a cursor cannot be replayed against a different order.
+type pageCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// parsePageRequest reads the limit, sort, cursor and include_total query parameters.
+// sort is a field name, prefixed with "-" for descending order.
+func parsePageRequest(c *fiber.Ctx, defaultSort string) (domain.PageRequest, error) {
+	page := domain.PageRequest{IncludeTotal: c.QueryBool("include_total")}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit := c.QueryInt("limit", -1)
+		if limit <= 0 {
+			return page, invalidParameter("limit", "limit must be a positive integer")
+		}
+		page.Limit = limit
+	}
+
+	sort := c.Query("sort", defaultSort)
+	page.Sort = strings.TrimPrefix(sort, "-")
+	page.Desc = strings.HasPrefix(sort, "-")
+
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := decodeCursor(raw)
+		if err != nil {
+			return page, domain.ErrInvalidCursor
+		}
+		if cursor.Sort != sort {
+			return page, invalidParameter("cursor", "cursor does not match sort "+sort)
+		}
+		page.After = &domain.Cursor{Value: cursor.Value, ID: cursor.ID}
+	}
+
+	return page, nil
+}
+
+// encodeCursor turns the position of the last item of a page into an opaque cursor.
+func encodeCursor(sort string, cursor *domain.Cursor) *string {
+	if cursor == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(pageCursor{Sort: sort, Value: cursor.Value, ID: cursor.ID})
+	if err != nil {
+		return nil
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return &encoded
+}
+
+func decodeCursor(raw string) (*pageCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := &pageCursor{}
+	if err := json.Unmarshal(payload, cursor); err != nil {
+		return nil, err
+	}
+	if cursor.ID == "" {
+		return nil, errors.New("cursor without id")
+	}
+
+	return cursor, nil
+}
+
+// parseFields reads the fields query parameter of a sparse fieldset. It returns nil when
+// every field is requested and an error naming the first field that is not allowed.
+func parseFields(c *fiber.Ctx, allowed map[string]bool) ([]string, error) {
+	raw := c.Query("fields")
+	if raw == "" {
+		return nil, nil
+	}
+
+	fields := []string{}
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !allowed[field] {
+			return nil, invalidParameter("fields", "unknown field: "+field)
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// selectFields keeps only the requested fields of each item, plus id which is always
+// returned. Items are returned unchanged when fields is nil.
+func selectFields[T any](items []T, fields []string) (interface{}, error) {
+	if fields == nil {
+		return items, nil
+	}
+
+	selected := make([]map[string]json.RawMessage, len(items))
+	for i, item := range items {
+		encoded, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+
+		all := map[string]json.RawMessage{}
+		if err := json.Unmarshal(encoded, &all); err != nil {
+			return nil, err
+		}
+
+		selected[i] = map[string]json.RawMessage{"id": all["id"]}
+		for _, field := range fields {
+			if value, ok := all[field]; ok {
+				selected[i][field] = value
+			}
+		}
+	}
+
+	return selected, nil
+}
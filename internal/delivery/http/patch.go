@@ -0,0 +1,254 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/jsonpatch"
+)
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+// readOnlyPatchFields are rendered in the document a JSON Patch applies to, so that test
+// operations can check them, but cannot be changed.
+var readOnlyPatchFields = map[string]bool{"id": true, "version": true}
+
+// isJSONPatch reports whether the body of a PATCH request is a JSON Patch (RFC 6902)
+// document. Any other body is read as a JSON Merge Patch (RFC 7396), which is also what
+// a partial application/json body means. It returns an error for unsupported media types.
+func isJSONPatch(c *fiber.Ctx) (bool, error) {
+	mediaType, _, _ := strings.Cut(c.Get(fiber.HeaderContentType), ";")
+
+	switch strings.TrimSpace(strings.ToLower(mediaType)) {
+	case jsonPatchContentType:
+		return true, nil
+	case mergePatchContentType, fiber.MIMEApplicationJSON, "":
+		return false, nil
+	}
+
+	return false, &requestError{
+		status:  fiber.StatusUnsupportedMediaType,
+		code:    "unsupported_media_type",
+		message: "unsupported patch media type: use " + mergePatchContentType + " or " + jsonPatchContentType,
+	}
+}
+
+// mergePatchChanges returns the members of a merge patch, which must be a JSON object.
+func mergePatchChanges(body []byte) (map[string]json.RawMessage, error) {
+	changes := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &changes); err != nil || changes == nil {
+		return nil, errors.New("invalid patch: a merge patch must be a JSON object")
+	}
+	return changes, nil
+}
+
+// jsonPatchChanges applies a JSON Patch to the document of a resource and returns the
+// members it changed, with removed members as null, the same way a merge patch would
+// express them.
+func jsonPatchChanges(body []byte, document map[string]interface{}) (map[string]json.RawMessage, error) {
+	ops, err := jsonpatch.Decode(body)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := jsonpatch.Apply(document, ops)
+	if err != nil {
+		return nil, err
+	}
+	patched, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid patch: the document must remain a JSON object")
+	}
+
+	changes := map[string]json.RawMessage{}
+	for field, value := range document {
+		if _, ok := patched[field]; !ok {
+			changes[field] = json.RawMessage("null")
+			continue
+		}
+		before, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		after, err := json.Marshal(patched[field])
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(before, after) {
+			changes[field] = after
+		}
+	}
+	for field, value := range patched {
+		if _, ok := document[field]; !ok {
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			changes[field] = encoded
+		}
+	}
+
+	return changes, nil
+}
+
+// decodePatchField reads the new value of a field from a patch; null clears the field.
+func decodePatchField[T any](field string, raw json.RawMessage, kind string) (domain.PatchField[T], error) {
+	patch := domain.PatchField[T]{Set: true}
+	if string(bytes.TrimSpace(raw)) == "null" {
+		patch.Null = true
+		return patch, nil
+	}
+
+	if err := json.Unmarshal(raw, &patch.Value); err != nil {
+		return patch, errors.New("invalid patch: field " + field + " must be " + kind)
+	}
+	return patch, nil
+}
+
+// checkPatchField rejects changes to fields that do not exist or cannot be patched.
+func checkPatchField(field string) error {
+	if readOnlyPatchFields[field] {
+		return errors.New("invalid patch: field " + field + " is read-only")
+	}
+	return errors.New("invalid patch: unknown field " + field)
+}
+
+// taskPatchDocument is the JSON document of a task that a JSON Patch applies to.
+func taskPatchDocument(t *domain.Task) map[string]interface{} {
+	var dueDate interface{}
+	if t.DueDate != nil {
+		dueDate = t.DueDate.Format(time.RFC3339Nano)
+	}
+	labels := t.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+
+	return map[string]interface{}{
+		"id":          t.ID,
+		"version":     t.Version,
+		"list_id":     t.ListID,
+		"title":       t.Title,
+		"description": t.Description,
+		"status":      t.Status,
+		"priority":    t.Priority,
+		"due_date":    dueDate,
+		"labels":      labels,
+	}
+}
+
+// newTaskPatch converts the changed members of a task document into a task patch.
+func newTaskPatch(changes map[string]json.RawMessage) (domain.TaskPatch, error) {
+	patch := domain.TaskPatch{}
+
+	for _, field := range sortedFields(changes) {
+		raw := changes[field]
+		var err error
+		switch field {
+		case "list_id":
+			patch.ListID, err = decodePatchField[string](field, raw, "a string")
+		case "title":
+			patch.Title, err = decodePatchField[string](field, raw, "a string")
+		case "description":
+			patch.Description, err = decodePatchField[string](field, raw, "a string")
+		case "status":
+			patch.Status, err = decodePatchField[string](field, raw, "a string")
+		case "priority":
+			patch.Priority, err = decodePatchField[string](field, raw, "a string")
+		case "labels":
+			patch.Labels, err = decodePatchField[[]string](field, raw, "an array of strings")
+		case "due_date":
+			var day domain.PatchField[string]
+			day, err = decodePatchField[string](field, raw, "a date (YYYY-MM-DD) or RFC 3339 time")
+			if err == nil {
+				patch.DueDate, err = parseDueDate(day)
+			}
+		default:
+			err = checkPatchField(field)
+		}
+		if err != nil {
+			return patch, err
+		}
+	}
+
+	return patch, nil
+}
+
+func parseDueDate(day domain.PatchField[string]) (domain.PatchField[time.Time], error) {
+	patch := domain.PatchField[time.Time]{Set: day.Set, Null: day.Null}
+	if day.Null {
+		return patch, nil
+	}
+
+	due, err := time.Parse(time.RFC3339, day.Value)
+	if err != nil {
+		if due, err = time.Parse("2006-01-02", day.Value); err != nil {
+			return patch, errors.New("invalid patch: field due_date must be a date (YYYY-MM-DD) or RFC 3339 time")
+		}
+	}
+	patch.Value = due
+	return patch, nil
+}
+
+// taskListPatchDocument is the JSON document of a task list that a JSON Patch applies to.
+func taskListPatchDocument(list *domain.TaskList) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          list.ID,
+		"version":     list.Version,
+		"name":        list.Name,
+		"description": list.Description,
+	}
+}
+
+// newTaskListPatch converts the changed members of a task list document into a task list patch.
+func newTaskListPatch(changes map[string]json.RawMessage) (domain.TaskListPatch, error) {
+	patch := domain.TaskListPatch{}
+
+	for _, field := range sortedFields(changes) {
+		raw := changes[field]
+		var err error
+		switch field {
+		case "name":
+			patch.Name, err = decodePatchField[string](field, raw, "a string")
+		case "description":
+			patch.Description, err = decodePatchField[string](field, raw, "a string")
+		default:
+			err = checkPatchField(field)
+		}
+		if err != nil {
+			return patch, err
+		}
+	}
+
+	return patch, nil
+}
+
+// sortedFields returns the members of a patch in a stable order, so that the first
+// invalid one is always the one reported.
+func sortedFields(changes map[string]json.RawMessage) []string {
+	fields := make([]string, 0, len(changes))
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// patchBodyError maps the errors of reading a patch body to a problem: a failed test
+// operation is a conflict with the current state, anything else a bad request.
+func patchBodyError(err error) error {
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return &requestError{status: fiber.StatusConflict, code: "patch_test_failed", message: err.Error()}
+	}
+	return &requestError{status: fiber.StatusBadRequest, code: "invalid_patch", message: err.Error()}
+}
@@ -0,0 +1,228 @@
+package http
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+	"github.com/G20-00/task-management-service-go/pkg/openapi"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	// problemTypePrefix is followed by the code of a problem to form its type URI.
+	problemTypePrefix = "urn:problem-type:tasks:"
+	// requestIDLocal is where the requestid middleware stores the ID of a request.
+	requestIDLocal = "requestid"
+
+	internalErrorDetail  = "An unexpected error occurred"
+	validationFailedCode = "validation_failed"
+)
+
+// Problem describe un error de la API en el formato application/problem+json (RFC 9457,
+// antes RFC 7807), extendido con un código estable y el ID de la petición.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status" openapi:"required"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Code is a stable, machine-readable identifier of the problem.
+	Code      string `json:"code" openapi:"required"`
+	RequestID string `json:"request_id,omitempty"`
+	// Errors lists the invalid fields of the request, if any.
+	Errors []openapi.FieldError `json:"errors,omitempty"`
+}
+
+// LegacyError describe un error de la API v1 y de las rutas sin versión, con el formato
+// anterior a problem+json.
+type LegacyError struct {
+	Error string `json:"error" openapi:"required"`
+	// Details lists the fields of a body that does not match its schema.
+	Details []openapi.FieldError `json:"details,omitempty"`
+}
+
+// requestError is a problem with the request itself, found before any use case is called.
+type requestError struct {
+	status  int
+	code    string
+	field   string
+	message string
+}
+
+func (e *requestError) Error() string {
+	return e.message
+}
+
+// validationError holds the fields of a request body that do not match its schema.
+type validationError struct {
+	fields []openapi.FieldError
+}
+
+func (e *validationError) Error() string {
+	return "request body does not match the schema"
+}
+
+var (
+	errInvalidBody    = &requestError{status: fiber.StatusBadRequest, code: "invalid_body", message: "Invalid request body"}
+	errInvalidIfMatch = &requestError{status: fiber.StatusPreconditionFailed, code: "invalid_if_match", message: "Invalid If-Match header"}
+)
+
+// missingField returns the error of a required field the request does not have.
+func missingField(field, message string) error {
+	return &requestError{status: fiber.StatusBadRequest, code: "missing_field", field: field, message: message}
+}
+
+// invalidParameter returns the error of a query parameter with an invalid value.
+func invalidParameter(parameter, message string) error {
+	return &requestError{status: fiber.StatusBadRequest, code: "invalid_parameter", field: parameter, message: message}
+}
+
+// useCaseError passes the domain errors of a use case on to ErrorHandler. Any other error
+// is logged and replaced with a 500 whose detail is message, so that its text never
+// reaches the client.
+func useCaseError(c *fiber.Ctx, method string, err error, message string) error {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return err
+	}
+
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"layer":      "handler",
+		"method":     method,
+		"path":       c.Path(),
+		"request_id": requestID(c),
+		"error":      err.Error(),
+	}).Error(message)
+	return fiber.NewError(fiber.StatusInternalServerError, message)
+}
+
+// ErrorHandler is the error handler of the Fiber app. Every error returned by a handler or
+// middleware gets a status and a code: domain errors and request errors their own, Fiber
+// errors one derived from their status, and any other error a 500 internal_error whose
+// text is logged but not sent. Requests served as v2 get an application/problem+json
+// response; v1 and the unversioned routes keep their {"error": ...} body.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	problem := newProblem(c, err)
+	if apiVersion(c) != apiV2 {
+		return c.Status(problem.Status).JSON(legacyError(problem))
+	}
+	return c.Status(problem.Status).JSON(problem, problemContentType)
+}
+
+// legacyError returns the v1 body of a problem. Only a body that does not match its schema
+// lists its fields, as before problem details.
+func legacyError(problem Problem) LegacyError {
+	body := LegacyError{Error: problem.Detail}
+	if problem.Code == validationFailedCode {
+		body.Details = problem.Errors
+	}
+	return body
+}
+
+// newProblem returns the problem of an error returned while serving c.
+func newProblem(c *fiber.Ctx, err error) Problem {
+	problem := Problem{
+		Instance:  c.OriginalURL(),
+		RequestID: requestID(c),
+	}
+
+	var (
+		domainErr     *domain.Error
+		requestErr    *requestError
+		validationErr *validationError
+		fiberErr      *fiber.Error
+	)
+	switch {
+	case errors.As(err, &domainErr):
+		problem.Status = domainErrorStatus(domainErr, c.Get(fiber.HeaderIfMatch) != "")
+		problem.Code = domainErr.Code
+		problem.Detail = domainErr.Message
+		if domainErr.Field != "" {
+			problem.Errors = []openapi.FieldError{{Field: domainErr.Field, Message: domainErr.Message}}
+		}
+	case errors.As(err, &requestErr):
+		problem.Status = requestErr.status
+		problem.Code = requestErr.code
+		problem.Detail = requestErr.message
+		if requestErr.field != "" {
+			problem.Errors = []openapi.FieldError{{Field: requestErr.field, Message: requestErr.message}}
+		}
+	case errors.As(err, &validationErr):
+		problem.Status = fiber.StatusBadRequest
+		problem.Code = validationFailedCode
+		problem.Detail = "Request body does not match the schema"
+		problem.Errors = validationErr.fields
+	case errors.As(err, &fiberErr):
+		problem.Status = fiberErr.Code
+		problem.Code = statusCode(fiberErr.Code)
+		problem.Detail = fiberErr.Message
+	default:
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"layer":      "handler",
+			"method":     c.Method(),
+			"path":       c.Path(),
+			"request_id": problem.RequestID,
+			"error":      err.Error(),
+		}).Error("Unhandled error")
+		problem.Status = fiber.StatusInternalServerError
+		problem.Code = "internal_error"
+		problem.Detail = internalErrorDetail
+	}
+
+	problem.Type = problemTypePrefix + problem.Code
+	problem.Title = utils.StatusMessage(problem.Status)
+	return problem
+}
+
+// domainErrorStatus is the HTTP status of a domain error. A version conflict is 412 when
+// the client sent If-Match, since its precondition failed, and 409 when the resource
+// changed between reading it, e.g. to apply a JSON Patch, and writing the result. An
+// idempotency key reused for another request is 422, as a retry would fail the same way.
+func domainErrorStatus(err *domain.Error, ifMatch bool) int {
+	switch err.Kind {
+	case domain.ErrorInvalid:
+		return fiber.StatusBadRequest
+	case domain.ErrorNotFound:
+		return fiber.StatusNotFound
+	case domain.ErrorConflict:
+		if ifMatch && errors.Is(err, domain.ErrVersionConflict) {
+			return fiber.StatusPreconditionFailed
+		}
+		if errors.Is(err, domain.ErrIdempotencyKeyReused) {
+			return fiber.StatusUnprocessableEntity
+		}
+		return fiber.StatusConflict
+	case domain.ErrorForbidden:
+		return fiber.StatusForbidden
+	case domain.ErrorGone:
+		return fiber.StatusGone
+	}
+	return fiber.StatusInternalServerError
+}
+
+// statusCode derives the code of a problem from its HTTP status, e.g. not_found for 404.
+// A 500 is internal_error, the same as an error ErrorHandler does not know.
+func statusCode(status int) string {
+	if status == fiber.StatusInternalServerError {
+		return "internal_error"
+	}
+	message := utils.StatusMessage(status)
+	if message == "" {
+		return "http_error"
+	}
+	return strings.ReplaceAll(strings.ToLower(message), " ", "_")
+}
+
+// requestID returns the ID the requestid middleware gave to the request, if any.
+func requestID(c *fiber.Ctx) string {
+	id, ok := c.Locals(requestIDLocal).(string)
+	if !ok {
+		return ""
+	}
+	return id
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/openapi"
+)
+
+// newTestApp returns an app that writes errors the way the service does.
+func newTestApp() *fiber.App {
+	return fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+}
+
+func TestErrorHandler(t *testing.T) {
+	app := newTestApp()
+	RegisterRequestIDMiddleware(app)
+	app.Get("/api/v2/error/:kind", func(c *fiber.Ctx) error {
+		switch c.Params("kind") {
+		case "not-found":
+			return domain.ErrTaskNotFound
+		case "invalid":
+			return domain.NewInvalidError("priority", "invalid_priority", "invalid priority: must be low, medium, or high")
+		case "conflict":
+			return domain.ErrVersionConflict
+		case "forbidden":
+			return domain.ErrViewNotOwned
+		case "gone":
+			return domain.ErrSyncTokenExpired
+		case "key-reused":
+			return domain.ErrIdempotencyKeyReused
+		case "request":
+			return missingField("title", "Title is required")
+		case "fiber":
+			return fiber.NewError(fiber.StatusUnauthorized, "Invalid or expired token")
+		case "use-case":
+			return useCaseError(c, "Test", errors.New(`pq: relation "tasks" does not exist`), "Failed to get task")
+		}
+		return errors.New(`pq: password authentication failed for user "tasks"`)
+	})
+
+	cases := []struct {
+		name       string
+		path       string
+		ifMatch    string
+		wantStatus int
+		wantCode   string
+		wantDetail string
+		wantErrors []openapi.FieldError
+	}{
+		{"domain not found", "/api/v2/error/not-found", "", fiber.StatusNotFound, "task_not_found", "task not found", nil},
+		{"domain invalid", "/api/v2/error/invalid", "", fiber.StatusBadRequest, "invalid_priority", "invalid priority: must be low, medium, or high",
+			[]openapi.FieldError{{Field: "priority", Message: "invalid priority: must be low, medium, or high"}}},
+		{"version conflict", "/api/v2/error/conflict", "", fiber.StatusConflict, "version_conflict", "version conflict", nil},
+		{"version conflict with If-Match", "/api/v2/error/conflict", `"1"`, fiber.StatusPreconditionFailed, "version_conflict", "version conflict", nil},
+		{"forbidden", "/api/v2/error/forbidden", "", fiber.StatusForbidden, "view_not_owned", "view not owned", nil},
+		{"gone", "/api/v2/error/gone", "", fiber.StatusGone, "sync_token_expired", "sync token expired", nil},
+		{"idempotency key reused", "/api/v2/error/key-reused", "", fiber.StatusUnprocessableEntity, "idempotency_key_reused", "idempotency key reused with a different request", nil},
+		{"request", "/api/v2/error/request", "", fiber.StatusBadRequest, "missing_field", "Title is required",
+			[]openapi.FieldError{{Field: "title", Message: "Title is required"}}},
+		{"fiber", "/api/v2/error/fiber", "", fiber.StatusUnauthorized, "unauthorized", "Invalid or expired token", nil},
+		{"use case", "/api/v2/error/use-case", "", fiber.StatusInternalServerError, "internal_error", "Failed to get task", nil},
+		{"unknown", "/api/v2/error/other", "", fiber.StatusInternalServerError, "internal_error", internalErrorDetail, nil},
+		{"unknown route", "/api/v2/missing", "", fiber.StatusNotFound, "not_found", "Cannot GET /api/v2/missing", nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, tc.path, http.NoBody)
+			if tc.ifMatch != "" {
+				req.Header.Set(fiber.HeaderIfMatch, tc.ifMatch)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("error ejecutando app.Test: %v", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+			if contentType := resp.Header.Get(fiber.HeaderContentType); contentType != problemContentType {
+				t.Errorf("expected %s, got %q", problemContentType, contentType)
+			}
+
+			raw, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(raw), "pq:") {
+				t.Fatalf("internal error text exposed: %s", raw)
+			}
+			var problem Problem
+			if err := json.Unmarshal(raw, &problem); err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+			if problem.Status != tc.wantStatus || problem.Code != tc.wantCode || problem.Detail != tc.wantDetail {
+				t.Errorf("unexpected problem: %+v", problem)
+			}
+			if problem.Type != problemTypePrefix+tc.wantCode || problem.Title == "" || problem.Instance != tc.path {
+				t.Errorf("unexpected type, title or instance: %+v", problem)
+			}
+			if !reflect.DeepEqual(problem.Errors, tc.wantErrors) {
+				t.Errorf("expected errors %v, got %v", tc.wantErrors, problem.Errors)
+			}
+			if problem.RequestID == "" || problem.RequestID != resp.Header.Get(fiber.HeaderXRequestID) {
+				t.Errorf("expected the request ID of the response header, got %q and %q", problem.RequestID, resp.Header.Get(fiber.HeaderXRequestID))
+			}
+		})
+	}
+}
+
+func TestErrorHandler_KeepsRequestID(t *testing.T) {
+	app := newTestApp()
+	RegisterRequestIDMiddleware(app)
+	app.Get("/api/v2/lists/:id", func(c *fiber.Ctx) error { return domain.ErrTaskListNotFound })
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v2/lists/1", http.NoBody)
+	req.Header.Set(fiber.HeaderXRequestID, "req-123")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+
+	var problem Problem
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if problem.RequestID != "req-123" || resp.Header.Get(fiber.HeaderXRequestID) != "req-123" {
+		t.Errorf("expected request ID req-123, got %q", problem.RequestID)
+	}
+}
+
+func TestTaskListHandler_DoesNotExposeInternalErrors(t *testing.T) {
+	app := newTestApp()
+	h := NewTaskListHandler(&mockTaskListService{
+		CreateFn: func(name, description string) (*domain.TaskList, error) {
+			return nil, errors.New("pq: duplicate key value violates unique constraint")
+		},
+	})
+	app.Post("/lists", h.CreateTaskList)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/lists", strings.NewReader(`{"name":"Lista"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusInternalServerError || strings.Contains(string(raw), "pq:") {
+		t.Errorf("expected a 500 without the database error, got %d %s", resp.StatusCode, raw)
+	}
+}
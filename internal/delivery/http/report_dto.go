@@ -0,0 +1,23 @@
+package http
+
+// BurndownPointResponse represents the remaining work of a day in a burndown chart.
+type BurndownPointResponse struct {
+	Date      string  `json:"date"`
+	Remaining int     `json:"remaining"`
+	Ideal     float64 `json:"ideal"`
+}
+
+// BurnupPointResponse represents the completed work and scope of a day in a burnup chart.
+type BurnupPointResponse struct {
+	Date      string `json:"date"`
+	Completed int    `json:"completed"`
+	Scope     int    `json:"scope"`
+}
+
+// CumulativeFlowPointResponse represents the tasks per status of a day in a cumulative flow diagram.
+type CumulativeFlowPointResponse struct {
+	Date       string `json:"date"`
+	Pending    int    `json:"pending"`
+	InProgress int    `json:"in_progress"`
+	Completed  int    `json:"completed"`
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/internal/usecase/report"
+)
+
+const reportDateLayout = "2006-01-02"
+
+// ReportService define la interfaz para obtener las series diarias de estado.
+type ReportService interface {
+	ListFlow(listID string, from, to *time.Time) ([]*domain.FlowPoint, error)
+	SprintFlow(sprintID string, from, to *time.Time) ([]*domain.FlowPoint, error)
+}
+
+// ReportHandler maneja las solicitudes HTTP de burndown, burnup y flujo acumulado.
+type ReportHandler struct {
+	service ReportService
+}
+
+// NewReportHandler creates a new ReportHandler instance.
+func NewReportHandler(service ReportService) *ReportHandler {
+	return &ReportHandler{
+		service: service,
+	}
+}
+
+// ListBurndown returns the burndown series of a task list.
+func (h *ReportHandler) ListBurndown(c *fiber.Ctx) error {
+	return h.series(c, "ListBurndown", h.service.ListFlow, burndownResponse)
+}
+
+// ListBurnup returns the burnup series of a task list.
+func (h *ReportHandler) ListBurnup(c *fiber.Ctx) error {
+	return h.series(c, "ListBurnup", h.service.ListFlow, burnupResponse)
+}
+
+// ListCumulativeFlow returns the cumulative flow series of a task list.
+func (h *ReportHandler) ListCumulativeFlow(c *fiber.Ctx) error {
+	return h.series(c, "ListCumulativeFlow", h.service.ListFlow, cumulativeFlowResponse)
+}
+
+// SprintBurndown returns the burndown series of a sprint.
+func (h *ReportHandler) SprintBurndown(c *fiber.Ctx) error {
+	return h.series(c, "SprintBurndown", h.service.SprintFlow, burndownResponse)
+}
+
+// SprintBurnup returns the burnup series of a sprint.
+func (h *ReportHandler) SprintBurnup(c *fiber.Ctx) error {
+	return h.series(c, "SprintBurnup", h.service.SprintFlow, burnupResponse)
+}
+
+// SprintCumulativeFlow returns the cumulative flow series of a sprint.
+func (h *ReportHandler) SprintCumulativeFlow(c *fiber.Ctx) error {
+	return h.series(c, "SprintCumulativeFlow", h.service.SprintFlow, cumulativeFlowResponse)
+}
+
+// series parses the optional from/to query dates (YYYY-MM-DD), loads the daily counts and
+// maps them with the given chart builder.
+func (h *ReportHandler) series(c *fiber.Ctx, method string,
+	load func(id string, from, to *time.Time) ([]*domain.FlowPoint, error),
+	build func(points []*domain.FlowPoint) interface{}) error {
+	id := c.Params("id")
+
+	from, err := parseReportDate(c.Query("from"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid from date, expected YYYY-MM-DD")
+	}
+	to, err := parseReportDate(c.Query("to"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid to date, expected YYYY-MM-DD")
+	}
+
+	points, err := load(id, from, to)
+	if err != nil {
+		return useCaseError(c, method, err, "Failed to build report")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(build(points))
+}
+
+func parseReportDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(reportDateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func burndownResponse(points []*domain.FlowPoint) interface{} {
+	series := report.Burndown(points)
+	responses := make([]BurndownPointResponse, len(series))
+	for i, p := range series {
+		responses[i] = BurndownPointResponse{Date: p.Date.Format(reportDateLayout), Remaining: p.Remaining, Ideal: p.Ideal}
+	}
+	return responses
+}
+
+func burnupResponse(points []*domain.FlowPoint) interface{} {
+	series := report.Burnup(points)
+	responses := make([]BurnupPointResponse, len(series))
+	for i, p := range series {
+		responses[i] = BurnupPointResponse{Date: p.Date.Format(reportDateLayout), Completed: p.Completed, Scope: p.Scope}
+	}
+	return responses
+}
+
+func cumulativeFlowResponse(points []*domain.FlowPoint) interface{} {
+	responses := make([]CumulativeFlowPointResponse, len(points))
+	for i, p := range points {
+		responses[i] = CumulativeFlowPointResponse{
+			Date:       p.Date.Format(reportDateLayout),
+			Pending:    p.Pending,
+			InProgress: p.InProgress,
+			Completed:  p.Completed,
+		}
+	}
+	return responses
+}
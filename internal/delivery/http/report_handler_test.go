@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockReportService struct {
+	ListFlowFn func(listID string, from, to *time.Time) ([]*domain.FlowPoint, error)
+}
+
+func (m *mockReportService) ListFlow(listID string, from, to *time.Time) ([]*domain.FlowPoint, error) {
+	return m.ListFlowFn(listID, from, to)
+}
+func (m *mockReportService) SprintFlow(string, *time.Time, *time.Time) ([]*domain.FlowPoint, error) {
+	return nil, domain.ErrSprintNotFound
+}
+
+func TestListBurndown_Success(t *testing.T) {
+	app := newTestApp()
+	var gotFrom *time.Time
+	h := NewReportHandler(&mockReportService{
+		ListFlowFn: func(_ string, from, _ *time.Time) ([]*domain.FlowPoint, error) {
+			gotFrom = from
+			day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+			return []*domain.FlowPoint{
+				{Date: day, Total: 4, Completed: 1},
+				{Date: day.AddDate(0, 0, 1), Total: 4, Completed: 3},
+			}, nil
+		},
+	})
+	app.Get("/lists/:id/burndown", h.ListBurndown)
+	resp, err := app.Test(httptest.NewRequest("GET", "/lists/1/burndown?from=2024-05-01", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var body []BurndownPointResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	if len(body) != 2 || body[0].Date != "2024-05-01" || body[1].Remaining != 1 {
+		t.Errorf("unexpected burndown: %+v", body)
+	}
+	if gotFrom == nil || gotFrom.Day() != 1 {
+		t.Errorf("expected from date to be parsed, got %v", gotFrom)
+	}
+}
+
+func TestListCumulativeFlow_InvalidDate(t *testing.T) {
+	app := newTestApp()
+	h := NewReportHandler(&mockReportService{})
+	app.Get("/lists/:id/cumulative-flow", h.ListCumulativeFlow)
+	resp, err := app.Test(httptest.NewRequest("GET", "/lists/1/cumulative-flow?to=yesterday", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestSprintBurnup_NotFound(t *testing.T) {
+	app := newTestApp()
+	h := NewReportHandler(&mockReportService{})
+	app.Get("/sprints/:id/burnup", h.SprintBurnup)
+	resp, err := app.Test(httptest.NewRequest("GET", "/sprints/1/burnup", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+}
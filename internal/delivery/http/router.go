@@ -1,35 +1,230 @@
 // Package http provides HTTP handlers and routing for the task management API.
 package http
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"strings"
 
-// RegisterRoutes configures all API routes for tasks and task lists.
-func RegisterRoutes(app *fiber.App, taskHandler *TaskHandler, taskListHandler *TaskListHandler) {
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+)
+
+// RegisterRoutes configures the login route and the task and task list routes of every API
+// version: v1 under /api/v1 and, for existing integrations, under /api, and v2 under
+// /api/v2. Requests to /api are served as v2 when their Accept header asks for
+// application/vnd.tasks.v2+json. v1 responses carry Deprecation and Sunset headers.
+//
+// Only tasks and task lists are versioned, since v2 only changes how they are returned. The
+// login and the other route groups are served under /api alone, in one representation,
+// whatever version the Accept header asks for.
+func RegisterRoutes(app *fiber.App, taskHandler *TaskHandler, taskListHandler *TaskListHandler, versioning APIVersioning) {
 	api := app.Group("/api")
 
 	api.Post("/login", AuthHandler)
 
+	registerVersionRoutes(api, versioning.negotiate, taskHandler, taskListHandler)
+	registerVersionRoutes(app.Group("/api/"+apiV1), versioning.useV1, taskHandler, taskListHandler)
+	registerVersionRoutes(app.Group("/api/"+apiV2), useV2, taskHandler, taskListHandler)
+}
+
+// registerVersionRoutes configures the task and task list routes of one API version.
+// version runs before each handler to choose the representation of the responses.
+func registerVersionRoutes(api fiber.Router, version fiber.Handler, taskHandler *TaskHandler, taskListHandler *TaskListHandler) {
 	tasks := api.Group("/tasks", JWTMiddleware)
-	tasks.Post("/", taskHandler.CreateTask)
-	tasks.Get("/", taskHandler.GetTasks)
-	tasks.Get(":id", taskHandler.GetTask)
-	tasks.Put(":id", taskHandler.UpdateTask)
-	tasks.Patch(":id", taskHandler.UpdateTask) // Permitir PATCH directo
-	tasks.Delete(":id", taskHandler.DeleteTask)
+	tasks.Post("/", version, taskHandler.CreateTask)
+	tasks.Get("/", version, taskHandler.GetTasks)
+	tasks.Post("/bulk", version, taskHandler.BulkTasks)
+	tasks.Get(":id", version, taskHandler.GetTask)
+	tasks.Put(":id", version, taskHandler.UpdateTask)
+	tasks.Patch(":id", version, taskHandler.PatchTask)
+	tasks.Delete(":id", version, taskHandler.DeleteTask)
 
 	// Rutas anidadas para compatibilidad con integración
 	lists := api.Group("/lists", JWTMiddleware)
-	lists.Post("/", taskListHandler.CreateTaskList)
-	lists.Get("/", taskListHandler.GetTaskLists)
-	lists.Get(":id", taskListHandler.GetTaskList)
-	lists.Put(":id", taskListHandler.UpdateTaskList)
-	lists.Delete(":id", taskListHandler.DeleteTaskList)
+	lists.Post("/", version, taskListHandler.CreateTaskList)
+	lists.Get("/", version, taskListHandler.GetTaskLists)
+	lists.Get(":id", version, taskListHandler.GetTaskList)
+	lists.Put(":id", version, taskListHandler.UpdateTaskList)
+	lists.Patch(":id", version, taskListHandler.PatchTaskList)
+	lists.Delete(":id", version, taskListHandler.DeleteTaskList)
 
 	// Tareas bajo listas (para integración)
-	lists.Post(":id/tasks", taskHandler.CreateTask)
-	lists.Get(":id/tasks/:taskId", taskHandler.GetTask)
-	lists.Patch(":id/tasks/:taskId/state", taskHandler.UpdateTask)
-	lists.Delete(":id/tasks/:taskId", taskHandler.DeleteTask)
+	lists.Post(":id/tasks", version, taskHandler.CreateTask)
+	lists.Get(":id/tasks/:taskId", version, taskHandler.GetTask)
+	lists.Patch(":id/tasks/:taskId/state", version, taskHandler.UpdateTask)
+	lists.Delete(":id/tasks/:taskId", version, taskHandler.DeleteTask)
+}
+
+// RegisterRequestIDMiddleware gives every request an ID, taken from its X-Request-ID
+// header or generated, which is echoed in the response and in the problems it returns.
+// It must be called before any other middleware or route is registered.
+func RegisterRequestIDMiddleware(app *fiber.App) {
+	app.Use(requestid.New(requestid.Config{ContextKey: requestIDLocal}))
+}
+
+// RegisterAuthMiddleware runs JWTMiddleware on every API route but the login, so that the
+// middlewares registered after it only see authenticated requests and a request without a
+// valid token gets a 401 before its body is validated. The stream also takes the token
+// from ?access_token=. It must be called after RegisterRequestIDMiddleware and before any
+// other middleware or route is registered.
+func RegisterAuthMiddleware(app *fiber.App) {
+	app.Use("/api/events", QueryTokenMiddleware)
+	app.Use("/api", authenticate)
+}
+
+// authenticate runs JWTMiddleware on a request unless its route is public.
+func authenticate(c *fiber.Ctx) error {
+	if strings.EqualFold(strings.TrimSuffix(c.Path(), "/"), "/api/login") {
+		return c.Next()
+	}
+	return JWTMiddleware(c)
+}
+
+// RegisterIdempotencyMiddleware applies the Idempotency-Key handling to every API route.
+// It must be called after RegisterAuthMiddleware and before the routes are registered.
+func RegisterIdempotencyMiddleware(app *fiber.App, middleware *IdempotencyMiddleware) {
+	app.Use("/api", middleware.Handle)
+}
+
+// RegisterTrashRoutes configures the trash listing and restore routes.
+func RegisterTrashRoutes(app *fiber.App, trashHandler *TrashHandler) {
+	trash := app.Group("/api/trash", JWTMiddleware)
+	trash.Get("/", trashHandler.GetTrash)
+	trash.Post("/tasks/:id/restore", trashHandler.RestoreTask)
+	trash.Post("/lists/:id/restore", trashHandler.RestoreTaskList)
+}
+
+// RegisterArchiveRoutes configures the archive and unarchive routes for tasks and task lists.
+func RegisterArchiveRoutes(app *fiber.App, archiveHandler *ArchiveHandler) {
+	api := app.Group("/api")
+
+	api.Post("/tasks/:id/archive", JWTMiddleware, archiveHandler.ArchiveTask)
+	api.Post("/tasks/:id/unarchive", JWTMiddleware, archiveHandler.UnarchiveTask)
+
+	api.Post("/lists/:id/archive", JWTMiddleware, archiveHandler.ArchiveTaskList)
+	api.Post("/lists/:id/unarchive", JWTMiddleware, archiveHandler.UnarchiveTaskList)
+	api.Post("/lists/:id/archive-completed", JWTMiddleware, archiveHandler.ArchiveCompletedTasks)
+}
+
+// RegisterHistoryRoutes configures the task version history routes.
+func RegisterHistoryRoutes(app *fiber.App, historyHandler *HistoryHandler) {
+	api := app.Group("/api")
+
+	api.Get("/tasks/:id/history", JWTMiddleware, historyHandler.GetTaskHistory)
+	api.Post("/tasks/:id/history/:revision/revert", JWTMiddleware, historyHandler.RevertTask)
+}
+
+// RegisterTemplateRoutes configures the task list template routes.
+func RegisterTemplateRoutes(app *fiber.App, templateHandler *TemplateHandler) {
+	api := app.Group("/api")
+
+	api.Post("/lists/:id/template", JWTMiddleware, templateHandler.SaveListAsTemplate)
+
+	templates := api.Group("/templates", JWTMiddleware)
+	templates.Get("/", templateHandler.GetTemplates)
+	templates.Get(":id", templateHandler.GetTemplate)
+	templates.Delete(":id", templateHandler.DeleteTemplate)
+	templates.Post(":id/instantiate", templateHandler.InstantiateTemplate)
+}
+
+// RegisterChecklistRoutes configures the task checklist routes.
+func RegisterChecklistRoutes(app *fiber.App, checklistHandler *ChecklistHandler) {
+	checklist := app.Group("/api/tasks/:id/checklist", JWTMiddleware)
+	checklist.Get("/", checklistHandler.GetChecklist)
+	checklist.Post("/", checklistHandler.AddChecklistItem)
+	checklist.Put("/order", checklistHandler.ReorderChecklist)
+	checklist.Patch("/:itemId", checklistHandler.UpdateChecklistItem)
+	checklist.Delete("/:itemId", checklistHandler.DeleteChecklistItem)
+	checklist.Post("/:itemId/toggle", checklistHandler.ToggleChecklistItem)
+	checklist.Post("/:itemId/convert", checklistHandler.ConvertChecklistItem)
+}
+
+// RegisterSprintRoutes configures the sprint and milestone routes.
+func RegisterSprintRoutes(app *fiber.App, sprintHandler *SprintHandler) {
+	sprints := app.Group("/api/sprints", JWTMiddleware)
+	sprints.Post("/", sprintHandler.CreateSprint)
+	sprints.Get("/", sprintHandler.GetSprints)
+	sprints.Get(":id", sprintHandler.GetSprint)
+	sprints.Put(":id", sprintHandler.UpdateSprint)
+	sprints.Delete(":id", sprintHandler.DeleteSprint)
+	sprints.Get(":id/tasks", sprintHandler.GetSprintTasks)
+	sprints.Post(":id/tasks", sprintHandler.AssignTasks)
+	sprints.Delete(":id/tasks/:taskId", sprintHandler.UnassignTask)
+	sprints.Post(":id/start", sprintHandler.StartSprint)
+	sprints.Post(":id/close", sprintHandler.CloseSprint)
+	sprints.Get(":id/summary", sprintHandler.GetSprintSummary)
+}
+
+// RegisterReportRoutes configures the burndown, burnup and cumulative flow routes for task lists and sprints.
+func RegisterReportRoutes(app *fiber.App, reportHandler *ReportHandler) {
+	api := app.Group("/api")
+
+	api.Get("/lists/:id/burndown", JWTMiddleware, reportHandler.ListBurndown)
+	api.Get("/lists/:id/burnup", JWTMiddleware, reportHandler.ListBurnup)
+	api.Get("/lists/:id/cumulative-flow", JWTMiddleware, reportHandler.ListCumulativeFlow)
+
+	api.Get("/sprints/:id/burndown", JWTMiddleware, reportHandler.SprintBurndown)
+	api.Get("/sprints/:id/burnup", JWTMiddleware, reportHandler.SprintBurnup)
+	api.Get("/sprints/:id/cumulative-flow", JWTMiddleware, reportHandler.SprintCumulativeFlow)
+}
+
+// RegisterSearchRoutes configures the full-text search route.
+func RegisterSearchRoutes(app *fiber.App, searchHandler *SearchHandler) {
+	app.Get("/api/search", JWTMiddleware, searchHandler.Search)
+}
+
+// RegisterViewRoutes configures the saved view routes.
+func RegisterViewRoutes(app *fiber.App, viewHandler *ViewHandler) {
+	views := app.Group("/api/views", JWTMiddleware)
+	views.Post("/", viewHandler.CreateView)
+	views.Get("/", viewHandler.GetViews)
+	views.Get(":id", viewHandler.GetView)
+	views.Put(":id", viewHandler.UpdateView)
+	views.Delete(":id", viewHandler.DeleteView)
+	views.Get(":id/tasks", viewHandler.GetViewTasks)
+}
+
+// RegisterWebhookRoutes configures the webhook subscription and delivery log routes.
+func RegisterWebhookRoutes(app *fiber.App, webhookHandler *WebhookHandler) {
+	webhooks := app.Group("/api/webhooks", JWTMiddleware)
+	webhooks.Post("/", webhookHandler.CreateWebhook)
+	webhooks.Get("/", webhookHandler.GetWebhooks)
+	webhooks.Get(":id", webhookHandler.GetWebhook)
+	webhooks.Put(":id", webhookHandler.UpdateWebhook)
+	webhooks.Delete(":id", webhookHandler.DeleteWebhook)
+	webhooks.Get(":id/deliveries", webhookHandler.GetWebhookDeliveries)
+	webhooks.Get(":id/deliveries/:deliveryId", webhookHandler.GetWebhookDelivery)
+	webhooks.Post(":id/deliveries/:deliveryId/redeliver", webhookHandler.RedeliverWebhookDelivery)
+}
+
+// RegisterStreamRoutes configures the Server-Sent Events stream of task and list events.
+// The token may also be sent as ?access_token=, since EventSource cannot set headers.
+func RegisterStreamRoutes(app *fiber.App, streamHandler *StreamHandler) {
+	app.Get("/api/events", QueryTokenMiddleware, JWTMiddleware, streamHandler.StreamEvents)
+}
+
+// RegisterSyncRoutes configures the changes feed and offline push routes.
+func RegisterSyncRoutes(app *fiber.App, syncHandler *SyncHandler) {
+	app.Get("/api/sync", JWTMiddleware, syncHandler.GetChanges)
+	app.Post("/api/sync", JWTMiddleware, syncHandler.PushChanges)
+}
+
+// RegisterGraphQLRoutes configures the GraphQL endpoint and its schema.
+func RegisterGraphQLRoutes(app *fiber.App, graphqlHandler *GraphQLHandler) {
+	app.Post("/api/graphql", JWTMiddleware, graphqlHandler.Execute)
+	app.Get("/api/graphql", JWTMiddleware, graphqlHandler.ExecuteQuery)
+	app.Get("/api/graphql/schema", JWTMiddleware, graphqlHandler.GetSchema)
+}
+
+// RegisterRequestValidationMiddleware rejects API requests whose body does not match the
+// OpenAPI document. It must be called after RegisterAuthMiddleware and before the routes
+// are registered.
+func RegisterRequestValidationMiddleware(app *fiber.App, middleware *RequestValidationMiddleware) {
+	app.Use("/api", middleware.Handle)
+}
 
-	// (Ya declarado arriba)
+// RegisterOpenAPIRoutes configures the OpenAPI document and documentation routes, which
+// do not require a token.
+func RegisterOpenAPIRoutes(app *fiber.App, openAPIHandler *OpenAPIHandler) {
+	app.Get("/openapi.json", openAPIHandler.GetDocument)
+	app.Get("/docs", openAPIHandler.GetDocs)
 }
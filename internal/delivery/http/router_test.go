@@ -7,7 +7,30 @@ import (
 )
 
 func TestRegisterRoutes(t *testing.T) {
-	app := fiber.New()
-	RegisterRoutes(app, nil, nil)
+	app := newTestApp()
+	RegisterRequestIDMiddleware(app)
+	RegisterAuthMiddleware(app)
+	RegisterRequestValidationMiddleware(app, nil)
+	RegisterIdempotencyMiddleware(app, nil)
+	RegisterRoutes(app, nil, nil, APIVersioning{})
+	registerRouteGroups(app)
+	RegisterOpenAPIRoutes(app, nil)
+}
 
+// registerRouteGroups registers, without handlers, every route group but those of
+// RegisterRoutes and RegisterOpenAPIRoutes.
+func registerRouteGroups(app *fiber.App) {
+	RegisterTrashRoutes(app, nil)
+	RegisterArchiveRoutes(app, nil)
+	RegisterHistoryRoutes(app, nil)
+	RegisterTemplateRoutes(app, nil)
+	RegisterChecklistRoutes(app, nil)
+	RegisterSprintRoutes(app, nil)
+	RegisterReportRoutes(app, nil)
+	RegisterSearchRoutes(app, nil)
+	RegisterViewRoutes(app, nil)
+	RegisterWebhookRoutes(app, nil)
+	RegisterStreamRoutes(app, nil)
+	RegisterSyncRoutes(app, nil)
+	RegisterGraphQLRoutes(app, nil)
 }
@@ -0,0 +1,28 @@
+package http
+
+import "github.com/G20-00/task-management-service-go/internal/domain"
+
+// SearchResultResponse represents a task found by a full-text search.
+type SearchResultResponse struct {
+	Task       TaskResponse     `json:"task"`
+	Rank       float64          `json:"rank"`
+	Highlights SearchHighlights `json:"highlights"`
+}
+
+// SearchHighlights holds the title and description fragments with the matched terms
+// wrapped in <mark> tags.
+type SearchHighlights struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+func newSearchResultResponse(result *domain.SearchResult) SearchResultResponse {
+	return SearchResultResponse{
+		Task: newTaskResponse(result.Task),
+		Rank: result.Rank,
+		Highlights: SearchHighlights{
+			Title:       result.TitleSnippet,
+			Description: result.DescriptionSnippet,
+		},
+	}
+}
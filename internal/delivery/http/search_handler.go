@@ -0,0 +1,48 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// SearchService define la interfaz para la búsqueda de texto completo en tareas.
+type SearchService interface {
+	Search(query string, filter domain.TaskFilter, limit, offset int) ([]*domain.SearchResult, error)
+}
+
+// SearchHandler maneja las solicitudes HTTP de búsqueda.
+type SearchHandler struct {
+	service SearchService
+}
+
+// NewSearchHandler creates a new SearchHandler instance.
+func NewSearchHandler(service SearchService) *SearchHandler {
+	return &SearchHandler{
+		service: service,
+	}
+}
+
+// Search finds tasks by title and description (?q=...), optionally filtered by status,
+// priority and include_archived, and paginated with limit and offset.
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	filter := domain.TaskFilter{
+		Status:          c.Query("status"),
+		Priority:        c.Query("priority"),
+		IncludeArchived: c.QueryBool("include_archived"),
+	}
+
+	results, err := h.service.Search(c.Query("q"), filter, c.QueryInt("limit"), c.QueryInt("offset"))
+	if err != nil {
+		return useCaseError(c, "Search", err, "Failed to search tasks")
+	}
+
+	responses := make([]SearchResultResponse, len(results))
+	for i, result := range results {
+		responses[i] = newSearchResultResponse(result)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": responses,
+	})
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockSearchService struct {
+	SearchFn func(query string, filter domain.TaskFilter, limit, offset int) ([]*domain.SearchResult, error)
+}
+
+func (m *mockSearchService) Search(query string, filter domain.TaskFilter, limit, offset int) ([]*domain.SearchResult, error) {
+	return m.SearchFn(query, filter, limit, offset)
+}
+
+func TestSearch_Success(t *testing.T) {
+	app := newTestApp()
+	var gotFilter domain.TaskFilter
+	h := NewSearchHandler(&mockSearchService{
+		SearchFn: func(query string, filter domain.TaskFilter, limit, offset int) ([]*domain.SearchResult, error) {
+			gotFilter = filter
+			return []*domain.SearchResult{{
+				Task:         &domain.Task{ID: "1", Title: "Canción"},
+				Rank:         0.6,
+				TitleSnippet: "<mark>Canción</mark>",
+			}}, nil
+		},
+	})
+	app.Get("/search", h.Search)
+	resp, err := app.Test(httptest.NewRequest("GET", "/search?q=cancion&status=pending&priority=high", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var body struct {
+		Data []SearchResultResponse `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	if len(body.Data) != 1 || body.Data[0].Task.ID != "1" || body.Data[0].Highlights.Title != "<mark>Canción</mark>" {
+		t.Errorf("unexpected response: %+v", body)
+	}
+	if gotFilter.Status != "pending" || gotFilter.Priority != "high" {
+		t.Errorf("expected the filters to be passed, got %+v", gotFilter)
+	}
+}
+
+func TestSearch_EmptyQuery(t *testing.T) {
+	app := newTestApp()
+	h := NewSearchHandler(&mockSearchService{
+		SearchFn: func(string, domain.TaskFilter, int, int) ([]*domain.SearchResult, error) {
+			return nil, domain.NewInvalidError("q", "invalid_query", "search query cannot be empty")
+		},
+	})
+	app.Get("/search", h.Search)
+	resp, err := app.Test(httptest.NewRequest("GET", "/search", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestSearch_ServiceError(t *testing.T) {
+	app := newTestApp()
+	h := NewSearchHandler(&mockSearchService{
+		SearchFn: func(string, domain.TaskFilter, int, int) ([]*domain.SearchResult, error) {
+			return nil, errors.New("db down")
+		},
+	})
+	app.Get("/search", h.Search)
+	resp, err := app.Test(httptest.NewRequest("GET", "/search?q=x", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", resp.StatusCode)
+	}
+}
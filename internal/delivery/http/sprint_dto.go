@@ -0,0 +1,72 @@
+package http
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// SprintRequest represents the request body for creating or updating a sprint or milestone.
+type SprintRequest struct {
+	Name     string     `json:"name"`
+	Goal     string     `json:"goal"`
+	Kind     string     `json:"kind"`
+	StartsAt *time.Time `json:"starts_at" openapi:"nullable"`
+	EndsAt   *time.Time `json:"ends_at" openapi:"nullable"`
+}
+
+// AssignSprintTasksRequest represents the request body for adding tasks to a sprint.
+type AssignSprintTasksRequest struct {
+	TaskIDs []string `json:"task_ids"`
+}
+
+// CloseSprintRequest represents the request body for closing a sprint.
+type CloseSprintRequest struct {
+	NextSprintID string `json:"next_sprint_id"`
+}
+
+// SprintResponse represents the response body for a sprint.
+type SprintResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Goal       string     `json:"goal"`
+	Kind       string     `json:"kind"`
+	Status     string     `json:"status"`
+	StartsAt   *time.Time `json:"starts_at,omitempty"`
+	EndsAt     *time.Time `json:"ends_at,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	ClosedAt   *time.Time `json:"closed_at,omitempty"`
+	RolledOver int        `json:"rolled_over"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// SprintSummaryResponse represents the committed vs completed counts of a sprint.
+type SprintSummaryResponse struct {
+	SprintID           string `json:"sprint_id"`
+	Committed          int    `json:"committed"`
+	CommittedCompleted int    `json:"committed_completed"`
+	Added              int    `json:"added"`
+	Completed          int    `json:"completed"`
+	Remaining          int    `json:"remaining"`
+	Total              int    `json:"total"`
+	RolledOver         int    `json:"rolled_over"`
+}
+
+// newSprintResponse maps a domain sprint to its response body.
+func newSprintResponse(s *domain.Sprint) SprintResponse {
+	return SprintResponse{
+		ID:         s.ID,
+		Name:       s.Name,
+		Goal:       s.Goal,
+		Kind:       s.Kind,
+		Status:     s.Status,
+		StartsAt:   s.StartsAt,
+		EndsAt:     s.EndsAt,
+		StartedAt:  s.StartedAt,
+		ClosedAt:   s.ClosedAt,
+		RolledOver: s.RolledOver,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
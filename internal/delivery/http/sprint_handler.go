@@ -0,0 +1,189 @@
+package http
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// SprintService define la interfaz para operaciones de sprints e hitos.
+type SprintService interface {
+	Create(name, goal, kind string, startsAt, endsAt *time.Time) (*domain.Sprint, error)
+	GetAll() ([]*domain.Sprint, error)
+	GetByID(id string) (*domain.Sprint, error)
+	Update(id, name, goal, kind string, startsAt, endsAt *time.Time) (*domain.Sprint, error)
+	Delete(id, changedBy string) error
+	AssignTasks(sprintID string, taskIDs []string, changedBy string) (int64, error)
+	UnassignTask(sprintID, taskID, changedBy string) error
+	GetTasks(sprintID string) ([]*domain.Task, error)
+	Start(id string) (*domain.Sprint, error)
+	Close(id, nextID, changedBy string) (*domain.Sprint, error)
+	Summary(id string) (*domain.SprintSummary, error)
+}
+
+// SprintHandler maneja las solicitudes HTTP de sprints e hitos.
+type SprintHandler struct {
+	service SprintService
+}
+
+// NewSprintHandler creates a new SprintHandler instance.
+func NewSprintHandler(service SprintService) *SprintHandler {
+	return &SprintHandler{
+		service: service,
+	}
+}
+
+// CreateSprint handles the creation of a new sprint or milestone.
+func (h *SprintHandler) CreateSprint(c *fiber.Ctx) error {
+	var req SprintRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	s, err := h.service.Create(req.Name, req.Goal, req.Kind, req.StartsAt, req.EndsAt)
+	if err != nil {
+		return useCaseError(c, "CreateSprint", err, "Failed to create sprint")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(newSprintResponse(s))
+}
+
+// GetSprints retrieves all sprints and milestones.
+func (h *SprintHandler) GetSprints(c *fiber.Ctx) error {
+	sprints, err := h.service.GetAll()
+	if err != nil {
+		return useCaseError(c, "GetSprints", err, "Failed to get sprints")
+	}
+
+	responses := make([]SprintResponse, len(sprints))
+	for i, s := range sprints {
+		responses[i] = newSprintResponse(s)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses)
+}
+
+// GetSprint retrieves a sprint by its ID.
+func (h *SprintHandler) GetSprint(c *fiber.Ctx) error {
+	s, err := h.service.GetByID(c.Params("id"))
+	if err != nil {
+		return useCaseError(c, "GetSprint", err, "Failed to get sprint")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newSprintResponse(s))
+}
+
+// UpdateSprint updates the name, goal, kind and dates of a sprint.
+func (h *SprintHandler) UpdateSprint(c *fiber.Ctx) error {
+	var req SprintRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	s, err := h.service.Update(c.Params("id"), req.Name, req.Goal, req.Kind, req.StartsAt, req.EndsAt)
+	if err != nil {
+		return useCaseError(c, "UpdateSprint", err, "Failed to update sprint")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newSprintResponse(s))
+}
+
+// DeleteSprint removes a sprint, leaving its tasks unassigned.
+func (h *SprintHandler) DeleteSprint(c *fiber.Ctx) error {
+	if err := h.service.Delete(c.Params("id"), CurrentUserID(c)); err != nil {
+		return useCaseError(c, "DeleteSprint", err, "Failed to delete sprint")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// AssignTasks adds tasks to a sprint.
+func (h *SprintHandler) AssignTasks(c *fiber.Ctx) error {
+	var req AssignSprintTasksRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	count, err := h.service.AssignTasks(c.Params("id"), req.TaskIDs, CurrentUserID(c))
+	if err != nil {
+		return useCaseError(c, "AssignTasks", err, "Failed to assign tasks")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"assigned": count,
+	})
+}
+
+// UnassignTask removes a task from a sprint.
+func (h *SprintHandler) UnassignTask(c *fiber.Ctx) error {
+	if err := h.service.UnassignTask(c.Params("id"), c.Params("taskId"), CurrentUserID(c)); err != nil {
+		return useCaseError(c, "UnassignTask", err, "Failed to unassign task")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetSprintTasks retrieves the tasks of a sprint.
+func (h *SprintHandler) GetSprintTasks(c *fiber.Ctx) error {
+	tasks, err := h.service.GetTasks(c.Params("id"))
+	if err != nil {
+		return useCaseError(c, "GetSprintTasks", err, "Failed to get sprint tasks")
+	}
+
+	responses := make([]TaskResponse, len(tasks))
+	for i, t := range tasks {
+		responses[i] = newTaskResponse(t)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses)
+}
+
+// StartSprint activates a planned sprint.
+func (h *SprintHandler) StartSprint(c *fiber.Ctx) error {
+	s, err := h.service.Start(c.Params("id"))
+	if err != nil {
+		return useCaseError(c, "StartSprint", err, "Failed to start sprint")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newSprintResponse(s))
+}
+
+// CloseSprint closes an active sprint, rolling unfinished tasks into the next sprint.
+func (h *SprintHandler) CloseSprint(c *fiber.Ctx) error {
+	var req CloseSprintRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	s, err := h.service.Close(c.Params("id"), req.NextSprintID, CurrentUserID(c))
+	if err != nil {
+		return useCaseError(c, "CloseSprint", err, "Failed to close sprint")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newSprintResponse(s))
+}
+
+// GetSprintSummary retrieves the committed vs completed counts of a sprint.
+func (h *SprintHandler) GetSprintSummary(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	summary, err := h.service.Summary(id)
+	if err != nil {
+		return useCaseError(c, "GetSprintSummary", err, "Failed to get sprint summary")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(SprintSummaryResponse{
+		SprintID:           id,
+		Committed:          summary.Committed,
+		CommittedCompleted: summary.CommittedCompleted,
+		Added:              summary.Added,
+		Completed:          summary.Completed,
+		Remaining:          summary.Remaining,
+		Total:              summary.Total,
+		RolledOver:         summary.RolledOver,
+	})
+}
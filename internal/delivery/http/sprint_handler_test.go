@@ -0,0 +1,103 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockSprintService struct {
+	CreateFn  func(name, goal, kind string, startsAt, endsAt *time.Time) (*domain.Sprint, error)
+	CloseFn   func(id, nextID string) (*domain.Sprint, error)
+	SummaryFn func(id string) (*domain.SprintSummary, error)
+}
+
+func (m *mockSprintService) Create(name, goal, kind string, startsAt, endsAt *time.Time) (*domain.Sprint, error) {
+	return m.CreateFn(name, goal, kind, startsAt, endsAt)
+}
+func (m *mockSprintService) GetAll() ([]*domain.Sprint, error)                   { return nil, nil }
+func (m *mockSprintService) GetByID(string) (*domain.Sprint, error)              { return nil, nil }
+func (m *mockSprintService) Delete(string, string) error                         { return nil }
+func (m *mockSprintService) UnassignTask(string, string, string) error           { return nil }
+func (m *mockSprintService) GetTasks(string) ([]*domain.Task, error)             { return nil, nil }
+func (m *mockSprintService) Start(string) (*domain.Sprint, error)                { return nil, nil }
+func (m *mockSprintService) AssignTasks(string, []string, string) (int64, error) { return 0, nil }
+func (m *mockSprintService) Update(string, string, string, string, *time.Time, *time.Time) (*domain.Sprint, error) {
+	return nil, nil
+}
+func (m *mockSprintService) Close(id, nextID, _ string) (*domain.Sprint, error) {
+	return m.CloseFn(id, nextID)
+}
+func (m *mockSprintService) Summary(id string) (*domain.SprintSummary, error) {
+	return m.SummaryFn(id)
+}
+
+func TestCreateSprint_ParsesDates(t *testing.T) {
+	app := newTestApp()
+	var gotEnd *time.Time
+	h := NewSprintHandler(&mockSprintService{
+		CreateFn: func(name, goal, kind string, _, endsAt *time.Time) (*domain.Sprint, error) {
+			gotEnd = endsAt
+			return &domain.Sprint{ID: "s", Name: name, Goal: goal, Kind: kind, Status: "planned", EndsAt: endsAt}, nil
+		},
+	})
+	app.Post("/sprints", h.CreateSprint)
+	body := `{"name":"S1","kind":"milestone","ends_at":"2024-06-30T00:00:00Z"}`
+	req := httptest.NewRequest("POST", "/sprints", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Errorf("expected 201, got %d", resp.StatusCode)
+	}
+	if gotEnd == nil || gotEnd.Month() != time.June {
+		t.Errorf("expected end date to be parsed, got %v", gotEnd)
+	}
+}
+
+func TestCloseSprint_NotActive(t *testing.T) {
+	app := newTestApp()
+	var gotNext string
+	h := NewSprintHandler(&mockSprintService{
+		CloseFn: func(_, nextID string) (*domain.Sprint, error) {
+			gotNext = nextID
+			return nil, domain.ErrSprintNotActive
+		},
+	})
+	app.Post("/sprints/:id/close", h.CloseSprint)
+	req := httptest.NewRequest("POST", "/sprints/s1/close", strings.NewReader(`{"next_sprint_id":"s2"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected 409, got %d", resp.StatusCode)
+	}
+	if gotNext != "s2" {
+		t.Errorf("expected next sprint s2, got %q", gotNext)
+	}
+}
+
+func TestGetSprintSummary_NotFound(t *testing.T) {
+	app := newTestApp()
+	h := NewSprintHandler(&mockSprintService{
+		SummaryFn: func(string) (*domain.SprintSummary, error) { return nil, domain.ErrSprintNotFound },
+	})
+	app.Get("/sprints/:id/summary", h.GetSprintSummary)
+	resp, err := app.Test(httptest.NewRequest("GET", "/sprints/s1/summary", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// streamRetry is the reconnection delay suggested to clients, in milliseconds.
+const streamRetry = 3000
+
+// StreamService define la interfaz para suscribirse a los eventos en tiempo real.
+type StreamService interface {
+	Subscribe(listID string, lastEventID int64) (*domain.EventStream, error)
+}
+
+// StreamHandler maneja las conexiones Server-Sent Events de eventos de tareas y listas.
+type StreamHandler struct {
+	service   StreamService
+	heartbeat time.Duration
+}
+
+// NewStreamHandler creates a new StreamHandler instance that writes a heartbeat comment
+// every heartbeat while no event is sent.
+func NewStreamHandler(service StreamService, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{
+		service:   service,
+		heartbeat: heartbeat,
+	}
+}
+
+// QueryTokenMiddleware accepts the JWT in the access_token query parameter when there is
+// no Authorization header, since browsers cannot set headers on an EventSource. It must
+// run before JWTMiddleware.
+func QueryTokenMiddleware(c *fiber.Ctx) error {
+	if token := c.Query("access_token"); token != "" && c.Get(fiber.HeaderAuthorization) == "" {
+		c.Request().Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	}
+	return c.Next()
+}
+
+// StreamEvents opens a stream of the events of every list, or of the list given by
+// ?list_id=, and keeps the response open, sending every event as it is published. A client
+// that reconnects with Last-Event-ID first receives the events it missed.
+func (h *StreamHandler) StreamEvents(c *fiber.Ctx) error {
+	listID := c.Query("list_id")
+	lastEventID, err := lastEventID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid Last-Event-ID")
+	}
+
+	events, err := h.service.Subscribe(listID, lastEventID)
+	if err != nil {
+		return useCaseError(c, "StreamEvents", err, "Failed to subscribe to events")
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer events.Close()
+		h.writeEvents(w, events)
+	})
+
+	return nil
+}
+
+// writeEvents writes the stream until it is closed or the client goes away, which is
+// noticed when a write fails.
+func (h *StreamHandler) writeEvents(w *bufio.Writer, events *domain.EventStream) {
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", streamRetry); err != nil {
+		return
+	}
+	replayed := make(map[int64]bool, len(events.Replay))
+	for _, event := range events.Replay {
+		if err := writeEvent(w, event); err != nil {
+			return
+		}
+		replayed[event.Sequence] = true
+	}
+	if err := w.Flush(); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case event, ok := <-events.Events:
+			if !ok {
+				return
+			}
+			if replayed[event.Sequence] {
+				continue
+			}
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if _, err := w.WriteString(": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes an event in the Server-Sent Events format, with its sequence as the id.
+func writeEvent(w *bufio.Writer, event *domain.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Sequence, event.Type, data)
+	return err
+}
+
+// lastEventID reads the id of the last event a client received, sent by EventSource in the
+// Last-Event-ID header or by other clients as ?last_event_id=. It is 0 when absent.
+func lastEventID(c *fiber.Ctx) (int64, error) {
+	value := c.Get("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid last event id %q", value)
+	}
+	return id, nil
+}
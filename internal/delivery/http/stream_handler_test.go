@@ -0,0 +1,125 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockStreamService struct {
+	SubscribeFn func(listID string, lastEventID int64) (*domain.EventStream, error)
+}
+
+func (m *mockStreamService) Subscribe(listID string, lastEventID int64) (*domain.EventStream, error) {
+	return m.SubscribeFn(listID, lastEventID)
+}
+
+// closedStream returns a stream whose live events are already queued and which then ends.
+func closedStream(replay []*domain.Event, live ...*domain.Event) *domain.EventStream {
+	events := make(chan *domain.Event, len(live))
+	for _, event := range live {
+		events <- event
+	}
+	close(events)
+	return &domain.EventStream{Replay: replay, Events: events, Close: func() {}}
+}
+
+func TestStreamEvents_ReplaysAndStreams(t *testing.T) {
+	var gotListID string
+	var gotLastEventID int64
+	app := newTestApp()
+	h := NewStreamHandler(&mockStreamService{
+		SubscribeFn: func(listID string, lastEventID int64) (*domain.EventStream, error) {
+			gotListID, gotLastEventID = listID, lastEventID
+			return closedStream(
+				[]*domain.Event{{Sequence: 42, Type: domain.EventTaskCreated, ListID: "l1"}},
+				&domain.Event{Sequence: 42, Type: domain.EventTaskCreated, ListID: "l1"},
+				&domain.Event{Sequence: 43, Type: domain.EventTaskUpdated, ListID: "l1"},
+			), nil
+		},
+	}, time.Minute)
+	app.Get("/api/events", h.StreamEvents)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/events?list_id=l1", http.NoBody)
+	req.Header.Set("Last-Event-ID", "41")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK || resp.Header.Get(fiber.HeaderContentType) != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %d %s", resp.StatusCode, resp.Header.Get(fiber.HeaderContentType))
+	}
+	if gotListID != "l1" || gotLastEventID != 41 {
+		t.Errorf("unexpected subscription: %s %d", gotListID, gotLastEventID)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := string(body)
+	if !strings.HasPrefix(text, "retry: 3000\n\n") {
+		t.Errorf("expected the retry delay first, got %q", text)
+	}
+	if strings.Count(text, "id: 42\n") != 1 {
+		t.Errorf("expected the replayed event once, got %q", text)
+	}
+	if !strings.Contains(text, "id: 43\nevent: task.updated\ndata: {") {
+		t.Errorf("expected the live event, got %q", text)
+	}
+}
+
+func TestStreamEvents_Errors(t *testing.T) {
+	app := newTestApp()
+	h := NewStreamHandler(&mockStreamService{
+		SubscribeFn: func(listID string, _ int64) (*domain.EventStream, error) {
+			if listID == "missing" {
+				return nil, domain.ErrTaskListNotFound
+			}
+			return nil, errors.New("db down")
+		},
+	}, time.Minute)
+	app.Get("/api/events", h.StreamEvents)
+
+	cases := []struct {
+		url    string
+		status int
+	}{
+		{"/api/events?last_event_id=abc", fiber.StatusBadRequest},
+		{"/api/events?list_id=missing", fiber.StatusNotFound},
+		{"/api/events", fiber.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, tc.url, http.NoBody))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s: expected %d, got %d", tc.url, tc.status, resp.StatusCode)
+		}
+	}
+}
+
+func TestQueryTokenMiddleware(t *testing.T) {
+	app := newTestApp()
+	app.Get("/", QueryTokenMiddleware, func(c *fiber.Ctx) error {
+		return c.SendString(c.Get(fiber.HeaderAuthorization))
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/?access_token=abc", http.NoBody))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body) //nolint:errcheck
+	if string(body) != "Bearer abc" {
+		t.Errorf("expected the query token as a bearer token, got %q", body)
+	}
+}
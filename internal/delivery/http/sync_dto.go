@@ -0,0 +1,156 @@
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// SyncChangeResponse represents one change of the changes feed. Task or list holds the
+// current state of an upserted entity; lists are sent without their task counts.
+type SyncChangeResponse struct {
+	Sequence int64             `json:"sequence"`
+	Type     string            `json:"type"`
+	Op       string            `json:"op"`
+	ID       string            `json:"id"`
+	Task     *TaskResponse     `json:"task,omitempty"`
+	List     *TaskListResponse `json:"list,omitempty"`
+}
+
+// SyncResponse represents a page of the changes feed and the token to continue from.
+type SyncResponse struct {
+	Changes []SyncChangeResponse `json:"changes"`
+	Token   string               `json:"token"`
+	HasMore bool                 `json:"has_more"`
+}
+
+// SyncPushChangeRequest represents a change made by an offline client. Data holds the
+// fields of a create or update, with the same members as a merge patch of the entity.
+type SyncPushChangeRequest struct {
+	Type        string          `json:"type"`
+	Op          string          `json:"op"`
+	ID          string          `json:"id"`
+	BaseVersion int             `json:"base_version"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// SyncPushRequest represents the request body of a push of offline changes.
+type SyncPushRequest struct {
+	Changes []SyncPushChangeRequest `json:"changes"`
+}
+
+// SyncPushResultResponse represents the outcome of one pushed change. Status is applied,
+// conflict, rejected (the change is invalid) or failed (it can be retried).
+type SyncPushResultResponse struct {
+	Index  int               `json:"index"`
+	Type   string            `json:"type"`
+	ID     string            `json:"id"`
+	Status string            `json:"status"`
+	Error  string            `json:"error,omitempty"`
+	Task   *TaskResponse     `json:"task,omitempty"`
+	List   *TaskListResponse `json:"list,omitempty"`
+}
+
+// SyncPushResponse represents the response body of a push of offline changes.
+type SyncPushResponse struct {
+	Applied   int                      `json:"applied"`
+	Conflicts int                      `json:"conflicts"`
+	Failed    int                      `json:"failed"`
+	Results   []SyncPushResultResponse `json:"results"`
+}
+
+// Statuses of a pushed change that was not applied because of an error.
+const (
+	syncRejected = "rejected"
+	syncFailed   = "failed"
+)
+
+// syncToken is the payload of an opaque sync token.
+type syncToken struct {
+	Sequence int64 `json:"seq"`
+}
+
+func encodeSyncToken(sequence int64) string {
+	payload, err := json.Marshal(syncToken{Sequence: sequence})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// decodeSyncToken returns the sequence a token continues from, 0 for an empty token.
+func decodeSyncToken(raw string) (int64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return 0, errors.New("invalid sync token")
+	}
+	var token syncToken
+	if err := json.Unmarshal(payload, &token); err != nil || token.Sequence < 0 {
+		return 0, errors.New("invalid sync token")
+	}
+
+	return token.Sequence, nil
+}
+
+func newSyncResponse(feed *domain.SyncFeed) SyncResponse {
+	response := SyncResponse{
+		Changes: make([]SyncChangeResponse, len(feed.Changes)),
+		Token:   encodeSyncToken(feed.Sequence),
+		HasMore: feed.HasMore,
+	}
+
+	for i, change := range feed.Changes {
+		response.Changes[i] = SyncChangeResponse{Sequence: change.Sequence, Type: change.Type, Op: change.Op, ID: change.ID}
+		if change.Task != nil {
+			task := newTaskResponse(change.Task)
+			response.Changes[i].Task = &task
+		}
+		if change.List != nil {
+			list := newTaskListResponse(change.List, nil)
+			response.Changes[i].List = &list
+		}
+	}
+
+	return response
+}
+
+// toSyncPush maps the request body to the pushed changes, reading data as a merge patch
+// of the entity type.
+func (r *SyncPushRequest) toSyncPush() ([]domain.SyncPush, error) {
+	changes := make([]domain.SyncPush, len(r.Changes))
+	for i, c := range r.Changes {
+		changes[i] = domain.SyncPush{Type: c.Type, Op: c.Op, ID: c.ID, BaseVersion: c.BaseVersion}
+		if len(c.Data) == 0 {
+			continue
+		}
+
+		fields, err := mergePatchChanges(c.Data)
+		if err != nil {
+			return nil, invalidSyncChange(i, "data must be a JSON object")
+		}
+		switch c.Type {
+		case domain.SyncTask:
+			changes[i].TaskPatch, err = newTaskPatch(fields)
+		case domain.SyncList:
+			changes[i].ListPatch, err = newTaskListPatch(fields)
+		}
+		if err != nil {
+			return nil, invalidSyncChange(i, err.Error())
+		}
+	}
+
+	return changes, nil
+}
+
+// invalidSyncChange returns the error of a pushed change whose data cannot be read, with
+// the same code as the batches the use case refuses.
+func invalidSyncChange(index int, reason string) error {
+	return domain.NewInvalidError("changes", "invalid_sync_batch", fmt.Sprintf("invalid sync batch: change %d: %s", index, reason))
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+)
+
+// SyncService define la interfaz para la sincronización incremental de clientes offline.
+type SyncService interface {
+	Changes(since int64, limit int) (*domain.SyncFeed, error)
+	Push(changes []domain.SyncPush, changedBy string) ([]domain.SyncPushResult, error)
+}
+
+// SyncHandler maneja las peticiones HTTP del feed de cambios y de la subida de cambios offline.
+type SyncHandler struct {
+	service SyncService
+}
+
+// NewSyncHandler creates a new SyncHandler instance.
+func NewSyncHandler(service SyncService) *SyncHandler {
+	return &SyncHandler{
+		service: service,
+	}
+}
+
+// GetChanges returns the tasks and lists that changed since the given token, in order,
+// with the token to continue from. Without a token every live task and list is returned.
+func (h *SyncHandler) GetChanges(c *fiber.Ctx) error {
+	since, err := decodeSyncToken(c.Query("since"))
+	if err != nil {
+		return invalidParameter("since", err.Error())
+	}
+
+	limit := 0
+	if c.Query("limit") != "" {
+		if limit = c.QueryInt("limit", -1); limit <= 0 {
+			return invalidParameter("limit", "limit must be a positive integer")
+		}
+	}
+
+	feed, err := h.service.Changes(since, limit)
+	if err != nil {
+		return useCaseError(c, "GetChanges", err, "Failed to retrieve changes")
+	}
+
+	return c.JSON(newSyncResponse(feed))
+}
+
+// PushChanges applies the changes an offline client made, in order, and reports the
+// outcome of each one. It responds 200 when every change was applied and 207 otherwise.
+func (h *SyncHandler) PushChanges(c *fiber.Ctx) error {
+	var body SyncPushRequest
+	if err := c.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	changes, err := body.toSyncPush()
+	if err != nil {
+		return err
+	}
+
+	results, err := h.service.Push(changes, CurrentUserID(c))
+	if err != nil {
+		return useCaseError(c, "PushChanges", err, "Failed to push changes")
+	}
+
+	response := SyncPushResponse{Results: make([]SyncPushResultResponse, len(results))}
+	for i, result := range results {
+		item := SyncPushResultResponse{Index: result.Index, Type: result.Type, ID: result.ID, Status: result.Status}
+		if result.Task != nil {
+			task := newTaskResponse(result.Task)
+			item.Task = &task
+		}
+		if result.List != nil {
+			list := newTaskListResponse(result.List, nil)
+			item.List = &list
+		}
+
+		switch {
+		case result.Status == domain.SyncApplied:
+			response.Applied++
+		case result.Status == domain.SyncConflict:
+			response.Conflicts++
+			item.Error = result.Err.Error()
+		case isSyncRejection(result.Err):
+			response.Failed++
+			item.Status, item.Error = syncRejected, result.Err.Error()
+		default:
+			logger.GetLogger().WithFields(map[string]interface{}{
+				"layer":  "handler",
+				"method": "PushChanges",
+				"type":   result.Type,
+				"id":     result.ID,
+				"error":  result.Err.Error(),
+			}).Error("Failed to apply pushed change")
+			response.Failed++
+			item.Status, item.Error = syncFailed, "Failed to apply change"
+		}
+		response.Results[i] = item
+	}
+
+	status := fiber.StatusOK
+	if response.Applied < len(results) {
+		status = fiber.StatusMultiStatus
+	}
+	return c.Status(status).JSON(response)
+}
+
+// isSyncRejection reports whether a pushed change failed because it is invalid, so that
+// sending it again would fail the same way.
+func isSyncRejection(err error) bool {
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) {
+		return false
+	}
+	return domainErr.Kind == domain.ErrorInvalid || errors.Is(err, domain.ErrTaskListArchived)
+}
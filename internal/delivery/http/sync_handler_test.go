@@ -0,0 +1,182 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockSyncService struct {
+	ChangesFn func(since int64, limit int) (*domain.SyncFeed, error)
+	PushFn    func(changes []domain.SyncPush) ([]domain.SyncPushResult, error)
+}
+
+func (m *mockSyncService) Changes(since int64, limit int) (*domain.SyncFeed, error) {
+	return m.ChangesFn(since, limit)
+}
+func (m *mockSyncService) Push(changes []domain.SyncPush, _ string) ([]domain.SyncPushResult, error) {
+	return m.PushFn(changes)
+}
+
+func newSyncApp(service SyncService) *fiber.App {
+	app := newTestApp()
+	h := NewSyncHandler(service)
+	app.Get("/api/sync", h.GetChanges)
+	app.Post("/api/sync", h.PushChanges)
+	return app
+}
+
+func TestGetChanges_ReturnsChangesAndToken(t *testing.T) {
+	var gotSince int64
+	var gotLimit int
+	app := newSyncApp(&mockSyncService{
+		ChangesFn: func(since int64, limit int) (*domain.SyncFeed, error) {
+			gotSince, gotLimit = since, limit
+			return &domain.SyncFeed{
+				Changes: []*domain.SyncChange{
+					{Sequence: 11, Type: domain.SyncList, Op: domain.SyncUpsert, ID: "l1", List: &domain.TaskList{ID: "l1", Name: "List", Version: 2}},
+					{Sequence: 12, Type: domain.SyncTask, Op: domain.SyncDelete, ID: "t1"},
+				},
+				Sequence: 12,
+				HasMore:  true,
+			}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/sync?since="+encodeSyncToken(10)+"&limit=2", http.NoBody)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if gotSince != 10 || gotLimit != 2 {
+		t.Errorf("unexpected feed request: %d %d", gotSince, gotLimit)
+	}
+
+	var body SyncResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(body.Changes) != 2 || body.Changes[0].List == nil || body.Changes[0].List.Version != 2 ||
+		body.Changes[1].Op != domain.SyncDelete || body.Changes[1].Task != nil || !body.HasMore {
+		t.Errorf("unexpected changes: %+v", body)
+	}
+	if next, err := decodeSyncToken(body.Token); err != nil || next != 12 {
+		t.Errorf("expected a token for sequence 12, got %q", body.Token)
+	}
+}
+
+func TestGetChanges_Errors(t *testing.T) {
+	app := newSyncApp(&mockSyncService{
+		ChangesFn: func(since int64, _ int) (*domain.SyncFeed, error) {
+			if since == 1 {
+				return nil, domain.ErrSyncTokenExpired
+			}
+			return nil, errors.New("db down")
+		},
+	})
+
+	cases := []struct {
+		url    string
+		status int
+	}{
+		{"/api/sync?since=not-a-token", fiber.StatusBadRequest},
+		{"/api/sync?limit=0", fiber.StatusBadRequest},
+		{"/api/sync?since=" + encodeSyncToken(1), fiber.StatusGone},
+		{"/api/sync", fiber.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, tc.url, http.NoBody))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s: expected %d, got %d", tc.url, tc.status, resp.StatusCode)
+		}
+	}
+}
+
+func TestPushChanges_ReportsEachChange(t *testing.T) {
+	var pushed []domain.SyncPush
+	app := newSyncApp(&mockSyncService{
+		PushFn: func(changes []domain.SyncPush) ([]domain.SyncPushResult, error) {
+			pushed = changes
+			return []domain.SyncPushResult{
+				{Index: 0, Type: domain.SyncTask, ID: "t1", Status: domain.SyncApplied, Task: &domain.Task{ID: "t1", Version: 4}},
+				{Index: 1, Type: domain.SyncTask, ID: "t2", Status: domain.SyncConflict, Task: &domain.Task{ID: "t2", Version: 7}, Err: domain.ErrVersionConflict},
+				{Index: 2, Type: domain.SyncList, ID: "l1", Err: domain.NewInvalidError("name", "invalid_name", "name cannot be empty")},
+				{Index: 3, Type: domain.SyncList, ID: "l2", Err: errors.New("connection reset")},
+			}, nil
+		},
+	})
+
+	body := `{"changes":[
+		{"type":"task","op":"update","id":"t1","base_version":3,"data":{"status":"completed","due_date":null}},
+		{"type":"task","op":"delete","id":"t2","base_version":6},
+		{"type":"list","op":"create","id":"l1","data":{"name":""}},
+		{"type":"list","op":"update","id":"l2","base_version":1,"data":{"name":"L"}}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/sync", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusMultiStatus {
+		t.Fatalf("expected 207, got %d", resp.StatusCode)
+	}
+
+	first := pushed[0]
+	if first.BaseVersion != 3 || first.TaskPatch.Status.Value != "completed" || !first.TaskPatch.DueDate.Null || !pushed[2].ListPatch.Name.Set {
+		t.Errorf("unexpected pushed changes: %+v", pushed)
+	}
+
+	var result SyncPushResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Applied != 1 || result.Conflicts != 1 || result.Failed != 2 {
+		t.Errorf("unexpected counts: %+v", result)
+	}
+	statuses := []string{"applied", "conflict", "rejected", "failed"}
+	for i, item := range result.Results {
+		if item.Status != statuses[i] {
+			t.Errorf("change %d: expected %s, got %s", i, statuses[i], item.Status)
+		}
+	}
+	if result.Results[1].Task == nil || result.Results[1].Task.Version != 7 || result.Results[3].Error != "Failed to apply change" {
+		t.Errorf("unexpected results: %+v", result.Results)
+	}
+}
+
+func TestPushChanges_InvalidBatch(t *testing.T) {
+	app := newSyncApp(&mockSyncService{
+		PushFn: func([]domain.SyncPush) ([]domain.SyncPushResult, error) {
+			return nil, domain.NewInvalidError("changes", "invalid_sync_batch", "invalid sync batch: changes cannot be empty")
+		},
+	})
+
+	for _, body := range []string{
+		`{"changes":[{"type":"task","op":"update","id":"t1","base_version":1,"data":{"title":3}}]}`,
+		`{"changes":[{"type":"task","op":"update","id":"t1","base_version":1,"data":[]}]}`,
+		`{"changes":[]}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/sync", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, resp.StatusCode)
+		}
+	}
+}
@@ -1,33 +1,89 @@
 package http
 
-import "time"
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
 
 // CreateTaskRequest represents the request body for creating a task.
 type CreateTaskRequest struct {
 	ListID      string `json:"list_id"`
-	Title       string `json:"title"`
+	Title       string `json:"title" openapi:"required,minLength=1,maxLength=255"`
 	Description string `json:"description"`
-	Status      string `json:"status"`
-	Priority    string `json:"priority"`
+	Status      string `json:"status" openapi:"enum=pending|in-progress|completed"`
+	Priority    string `json:"priority" openapi:"enum=low|medium|high"`
 }
 
 // UpdateTaskRequest represents the request body for updating a task.
 type UpdateTaskRequest struct {
 	ListID      string `json:"list_id"`
-	Title       string `json:"title"`
+	Title       string `json:"title" openapi:"required,minLength=1,maxLength=255"`
 	Description string `json:"description"`
-	Status      string `json:"status"`
-	Priority    string `json:"priority"`
+	Status      string `json:"status" openapi:"required,enum=pending|in-progress|completed"`
+	Priority    string `json:"priority" openapi:"required,enum=low|medium|high"`
 }
 
 // TaskResponse represents the response body for a task.
 type TaskResponse struct {
-	ID          string    `json:"id"`
-	ListID      string    `json:"list_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	Priority    string    `json:"priority"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string             `json:"id"`
+	ListID      string             `json:"list_id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Status      string             `json:"status"`
+	Priority    string             `json:"priority"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	ArchivedAt  *time.Time         `json:"archived_at,omitempty"`
+	DueDate     *time.Time         `json:"due_date,omitempty"`
+	ParentID    string             `json:"parent_id,omitempty"`
+	Labels      []string           `json:"labels,omitempty"`
+	SprintID    string             `json:"sprint_id,omitempty"`
+	Checklist   *ChecklistProgress `json:"checklist,omitempty"`
+	Version     int                `json:"version"`
+}
+
+// taskFields are the TaskResponse fields that can be selected with fields=.
+var taskFields = map[string]bool{
+	"id": true, "list_id": true, "title": true, "description": true, "status": true,
+	"priority": true, "created_at": true, "updated_at": true, "archived_at": true,
+	"due_date": true, "parent_id": true, "labels": true, "sprint_id": true, "checklist": true,
+	"version": true,
+}
+
+// ChecklistProgress summarizes how many checklist items of a task are done.
+type ChecklistProgress struct {
+	Total      int     `json:"total"`
+	Done       int     `json:"done"`
+	Percentage float64 `json:"percentage"`
+}
+
+// newTaskResponse maps a domain task to its response body.
+func newTaskResponse(t *domain.Task) TaskResponse {
+	var checklist *ChecklistProgress
+	if t.ChecklistTotal > 0 {
+		checklist = &ChecklistProgress{
+			Total:      t.ChecklistTotal,
+			Done:       t.ChecklistDone,
+			Percentage: float64(t.ChecklistDone) / float64(t.ChecklistTotal) * 100,
+		}
+	}
+
+	return TaskResponse{
+		ID:          t.ID,
+		ListID:      t.ListID,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Priority:    t.Priority,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		ArchivedAt:  t.ArchivedAt,
+		DueDate:     t.DueDate,
+		ParentID:    t.ParentID,
+		Labels:      t.Labels,
+		SprintID:    t.SprintID,
+		Checklist:   checklist,
+		Version:     t.Version,
+	}
 }
@@ -2,6 +2,9 @@
 package http
 
 import (
+	"encoding/json"
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/G20-00/task-management-service-go/internal/domain"
@@ -10,12 +13,14 @@ import (
 
 // TaskService define la interfaz para operaciones de tareas.
 type TaskService interface {
-	Create(listID, title, description, priority string) (*domain.Task, error)
-	GetAll() ([]*domain.Task, error)
-	GetByFilters(status, priority string) ([]*domain.Task, error)
+	Create(listID, title, description, priority, changedBy string) (*domain.Task, error)
+	List(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error)
 	GetByID(id string) (*domain.Task, error)
-	Update(id, listID, title, description, status, priority string) (*domain.Task, error)
-	Delete(id string) error
+	Update(id, listID, title, description, status, priority, changedBy string) (*domain.Task, error)
+	UpdateIfMatch(id string, version int, listID, title, description, status, priority, changedBy string) (*domain.Task, error)
+	Patch(id string, version int, patch domain.TaskPatch, changedBy string) (*domain.Task, error)
+	Bulk(req domain.BulkRequest, changedBy string) (*domain.BulkResult, error)
+	Delete(id, changedBy string) error
 }
 
 // TaskHandler maneja las operaciones relacionadas con tareas HTTP.
@@ -35,20 +40,11 @@ func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
 	var req CreateTaskRequest
 
 	if err := c.BodyParser(&req); err != nil {
-		logger.GetLogger().WithFields(map[string]interface{}{
-			"layer":  "handler",
-			"method": "CreateTask",
-			"error":  err.Error(),
-		}).Error("Failed to parse request body")
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return errInvalidBody
 	}
 
 	if req.Title == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Title is required",
-		})
+		return missingField("title", "Title is required")
 	}
 
 	if req.Status == "" {
@@ -59,72 +55,58 @@ func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
 		req.Priority = "medium"
 	}
 
-	createdTask, err := h.service.Create(req.ListID, req.Title, req.Description, req.Priority)
+	createdTask, err := h.service.Create(req.ListID, req.Title, req.Description, req.Priority, CurrentUserID(c))
 	if err != nil {
-		logger.GetLogger().WithFields(map[string]interface{}{
-			"layer":  "handler",
-			"method": "CreateTask",
-			"error":  err.Error(),
-		}).Error("Failed to create task")
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to create task",
-		})
-	}
-
-	response := TaskResponse{
-		ID:          createdTask.ID,
-		ListID:      createdTask.ListID,
-		Title:       createdTask.Title,
-		Description: createdTask.Description,
-		Status:      createdTask.Status,
-		Priority:    createdTask.Priority,
-		CreatedAt:   createdTask.CreatedAt,
-		UpdatedAt:   createdTask.UpdatedAt,
-	}
-
-	return c.Status(fiber.StatusCreated).JSON(response)
+		return useCaseError(c, "CreateTask", err, "Failed to create task")
+	}
+
+	response := newTaskResponse(createdTask)
+
+	return sendResource(c, fiber.StatusCreated, response)
 }
 
-// GetTasks retrieves all tasks or filters them by status and priority.
+// GetTasks retrieves one page of tasks, optionally filtered by status and priority or by a
+// filter expression (?filter=status in (pending,in-progress) and priority = high).
+// Archived tasks are hidden unless include_archived=true is given. Pages are ordered by
+// sort (default -created_at) and continued with the next_cursor of the previous page.
 func (h *TaskHandler) GetTasks(c *fiber.Ctx) error {
-	status := c.Query("status")
-	priority := c.Query("priority")
+	fields, err := parseFields(c, taskFields)
+	if err != nil {
+		return err
+	}
 
-	var tasks []*domain.Task
-	var err error
+	page, err := parsePageRequest(c, "-created_at")
+	if err != nil {
+		return err
+	}
 
-	if status != "" || priority != "" {
-		tasks, err = h.service.GetByFilters(status, priority)
-	} else {
-		tasks, err = h.service.GetAll()
+	filter := domain.TaskFilter{
+		Status:          c.Query("status"),
+		Priority:        c.Query("priority"),
+		IncludeArchived: c.QueryBool("include_archived"),
+		Expression:      c.Query("filter"),
 	}
 
+	result, err := h.service.List(filter, page)
 	if err != nil {
-		logger.GetLogger().WithFields(map[string]interface{}{
-			"layer":  "handler",
-			"method": "GetTasks",
-			"error":  err.Error(),
-		}).Error("Failed to get tasks")
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get tasks",
-		})
-	}
-
-	responses := make([]TaskResponse, len(tasks))
-	for i, t := range tasks {
-		responses[i] = TaskResponse{
-			ID:          t.ID,
-			ListID:      t.ListID,
-			Title:       t.Title,
-			Description: t.Description,
-			Status:      t.Status,
-			Priority:    t.Priority,
-			CreatedAt:   t.CreatedAt,
-			UpdatedAt:   t.UpdatedAt,
-		}
+		return useCaseError(c, "GetTasks", err, "Failed to get tasks")
 	}
 
-	return c.Status(fiber.StatusOK).JSON(responses)
+	responses := make([]TaskResponse, len(result.Items))
+	for i, t := range result.Items {
+		responses[i] = newTaskResponse(t)
+	}
+
+	data, err := selectFields(responses, fields)
+	if err != nil {
+		return useCaseError(c, "GetTasks", err, "Failed to get tasks")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(PageResponse{
+		Data:       data,
+		NextCursor: encodeCursor(c.Query("sort", "-created_at"), result.Next),
+		Total:      result.Total,
+	})
 }
 
 // GetTask retrieves a single task by ID.
@@ -135,45 +117,27 @@ func (h *TaskHandler) GetTask(c *fiber.Ctx) error {
 	}
 
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Task ID is required",
-		})
+		return missingField("id", "Task ID is required")
 	}
 
 	t, err := h.service.GetByID(id)
 	if err != nil {
-		if err.Error() == "task not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Task not found",
-			})
-		}
+		return useCaseError(c, "GetTask", err, "Failed to get task")
+	}
+
+	etag := taskETag(t)
+	c.Set(fiber.HeaderETag, etag)
+	if notModified(c, etag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
 
-		logger.GetLogger().WithFields(map[string]interface{}{
-			"layer":  "handler",
-			"method": "GetTask",
-			"taskID": id,
-			"error":  err.Error(),
-		}).Error("Failed to get task")
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get task",
-		})
-	}
-
-	response := TaskResponse{
-		ID:          t.ID,
-		ListID:      t.ListID,
-		Title:       t.Title,
-		Description: t.Description,
-		Status:      t.Status,
-		Priority:    t.Priority,
-		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
-	}
-
-	return c.Status(fiber.StatusOK).JSON(response)
+	response := newTaskResponse(t)
+
+	return sendResource(c, fiber.StatusOK, response)
 }
 
-// UpdateTask updates an existing task.
+// UpdateTask updates an existing task. With an If-Match header the update only applies if
+// the task has not changed since that ETag was issued.
 func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
 	id := c.Params("id")
 	// Compatibilidad con rutas anidadas tipo /lists/:id/tasks/:taskId/state
@@ -182,74 +146,174 @@ func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
 	}
 
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Task ID is required",
-		})
+		return missingField("id", "Task ID is required")
 	}
 
 	var req UpdateTaskRequest
 
 	if err := c.BodyParser(&req); err != nil {
-		logger.GetLogger().WithFields(map[string]interface{}{
-			"layer":  "handler",
-			"method": "UpdateTask",
-			"taskID": id,
-			"error":  err.Error(),
-		}).Error("Failed to parse request body")
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return errInvalidBody
 	}
 
 	if req.Title == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Title is required",
-		})
+		return missingField("title", "Title is required")
 	}
 
 	if req.Status == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Status is required",
-		})
+		return missingField("status", "Status is required")
 	}
 
 	if req.Priority == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Priority is required",
-		})
+		return missingField("priority", "Priority is required")
 	}
 
-	updatedTask, err := h.service.Update(id, req.ListID, req.Title, req.Description, req.Status, req.Priority)
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return errInvalidIfMatch
+	}
+
+	var updatedTask *domain.Task
+	if version == 0 {
+		updatedTask, err = h.service.Update(id, req.ListID, req.Title, req.Description, req.Status, req.Priority, CurrentUserID(c))
+	} else {
+		updatedTask, err = h.service.UpdateIfMatch(id, version, req.ListID, req.Title, req.Description, req.Status, req.Priority, CurrentUserID(c))
+	}
 	if err != nil {
-		if err.Error() == "task not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Task not found",
-			})
+		return useCaseError(c, "UpdateTask", err, "Failed to update task")
+	}
+
+	c.Set(fiber.HeaderETag, taskETag(updatedTask))
+	response := newTaskResponse(updatedTask)
+
+	return sendResource(c, fiber.StatusOK, response)
+}
+
+// PatchTask partially updates a task with a JSON Merge Patch (RFC 7396) or, when sent as
+// application/json-patch+json, a JSON Patch (RFC 6902). Only the fields in the patch change.
+func (h *TaskHandler) PatchTask(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return errInvalidIfMatch
+	}
+
+	jsonPatch, err := isJSONPatch(c)
+	if err != nil {
+		return err
+	}
+
+	var changes map[string]json.RawMessage
+	if jsonPatch {
+		current, err := h.service.GetByID(id)
+		if err != nil {
+			return useCaseError(c, "PatchTask", err, "Failed to update task")
 		}
+		if version != 0 && current.Version != version {
+			return domain.ErrVersionConflict
+		}
+		// The patch was computed from this read, so the write must not overwrite a newer version.
+		version = current.Version
+		changes, err = jsonPatchChanges(c.Body(), taskPatchDocument(current))
+		if err != nil {
+			return patchBodyError(err)
+		}
+	} else if changes, err = mergePatchChanges(c.Body()); err != nil {
+		return patchBodyError(err)
+	}
+
+	patch, err := newTaskPatch(changes)
+	if err != nil {
+		return patchBodyError(err)
+	}
 
-		logger.GetLogger().WithFields(map[string]interface{}{
-			"layer":  "handler",
-			"method": "UpdateTask",
-			"taskID": id,
-			"error":  err.Error(),
-		}).Error("Failed to update task")
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to update task",
-		})
-	}
-
-	response := TaskResponse{
-		ID:          updatedTask.ID,
-		ListID:      updatedTask.ListID,
-		Title:       updatedTask.Title,
-		Description: updatedTask.Description,
-		Status:      updatedTask.Status,
-		Priority:    updatedTask.Priority,
-		CreatedAt:   updatedTask.CreatedAt,
-		UpdatedAt:   updatedTask.UpdatedAt,
-	}
-
-	return c.Status(fiber.StatusOK).JSON(response)
+	patchedTask, err := h.service.Patch(id, version, patch, CurrentUserID(c))
+	if err != nil {
+		return useCaseError(c, "PatchTask", err, "Failed to update task")
+	}
+
+	c.Set(fiber.HeaderETag, taskETag(patchedTask))
+	return sendResource(c, fiber.StatusOK, newTaskResponse(patchedTask))
+}
+
+// BulkTasks applies one action (create, update, move, label or delete) to many tasks and
+// reports the status of each item. It responds 200 when every item succeeded and 207 otherwise.
+func (h *TaskHandler) BulkTasks(c *fiber.Ctx) error {
+	var body BulkTaskRequest
+	if err := c.BodyParser(&body); err != nil {
+		return errInvalidBody
+	}
+
+	req, err := body.toBulkRequest()
+	if err != nil {
+		return err
+	}
+
+	result, err := h.service.Bulk(req, CurrentUserID(c))
+	if err != nil {
+		return useCaseError(c, "BulkTasks", err, "Failed to apply bulk operation")
+	}
+
+	response := BulkTaskResponse{Applied: result.Applied, Results: make([]BulkItemResponse, len(result.Items))}
+	for i, item := range result.Items {
+		status := bulkItemStatus(req.Action, item.Err)
+		response.Results[i] = BulkItemResponse{Index: item.Index, ID: item.ID, Status: status}
+		if item.Err != nil {
+			response.Failed++
+			response.Results[i].Code, response.Results[i].Error = bulkItemError(item.Err)
+			if status == fiber.StatusInternalServerError {
+				logger.GetLogger().WithFields(map[string]interface{}{
+					"layer":      "handler",
+					"method":     "BulkTasks",
+					"taskID":     item.ID,
+					"request_id": requestID(c),
+					"error":      item.Err.Error(),
+				}).Error("Failed to apply bulk item")
+			}
+			continue
+		}
+
+		response.Succeeded++
+		if req.Action != domain.BulkDelete {
+			task := newTaskResponse(item.Task)
+			response.Results[i].Task = &task
+		}
+	}
+
+	status := fiber.StatusOK
+	if response.Failed > 0 {
+		status = fiber.StatusMultiStatus
+	}
+	return sendResource(c, status, response)
+}
+
+// bulkItemStatus is the HTTP status reported for one item of a bulk operation.
+func bulkItemStatus(action string, err error) int {
+	if err == nil {
+		if action == domain.BulkCreate {
+			return fiber.StatusCreated
+		}
+		return fiber.StatusOK
+	}
+
+	if errors.Is(err, domain.ErrRolledBack) {
+		return fiber.StatusFailedDependency
+	}
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return domainErrorStatus(domainErr, false)
+	}
+	return fiber.StatusInternalServerError
+}
+
+// bulkItemError returns the code and the message reported for a failed item of a bulk
+// operation. Errors other than domain errors are not exposed.
+func bulkItemError(err error) (string, string) {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Code, domainErr.Message
+	}
+	return "internal_error", "Failed to apply change"
 }
 
 // DeleteTask deletes a task by ID.
@@ -261,28 +325,12 @@ func (h *TaskHandler) DeleteTask(c *fiber.Ctx) error {
 	}
 
 	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Task ID is required",
-		})
+		return missingField("id", "Task ID is required")
 	}
 
-	err := h.service.Delete(id)
+	err := h.service.Delete(id, CurrentUserID(c))
 	if err != nil {
-		if err.Error() == "task not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Task not found",
-			})
-		}
-
-		logger.GetLogger().WithFields(map[string]interface{}{
-			"layer":  "handler",
-			"method": "DeleteTask",
-			"taskID": id,
-			"error":  err.Error(),
-		}).Error("Failed to delete task")
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to delete task",
-		})
+		return useCaseError(c, "DeleteTask", err, "Failed to delete task")
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -14,9 +15,11 @@ import (
 )
 
 func TestGetTasks_EmptyList(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	mockService := &mockTaskService{
-		GetAllFn: func() ([]*domain.Task, error) { return []*domain.Task{}, nil },
+		ListFn: func(domain.TaskFilter, domain.PageRequest) (*domain.Page[*domain.Task], error) {
+			return &domain.Page[*domain.Task]{Items: []*domain.Task{}}, nil
+		},
 	}
 	h := NewTaskHandler(mockService)
 	app.Get("/tasks", h.GetTasks)
@@ -31,9 +34,11 @@ func TestGetTasks_EmptyList(t *testing.T) {
 }
 
 func TestGetTasks_Filtered_Empty(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	mockService := &mockTaskService{
-		GetByFiltersFn: func(status, priority string) ([]*domain.Task, error) { return []*domain.Task{}, nil },
+		ListFn: func(domain.TaskFilter, domain.PageRequest) (*domain.Page[*domain.Task], error) {
+			return &domain.Page[*domain.Task]{Items: []*domain.Task{}}, nil
+		},
 	}
 	h := NewTaskHandler(mockService)
 	app.Get("/tasks", h.GetTasks)
@@ -48,11 +53,11 @@ func TestGetTasks_Filtered_Empty(t *testing.T) {
 }
 
 func TestGetTasks_Filtered_OnlyStatus(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	mockService := &mockTaskService{
-		GetByFiltersFn: func(status, priority string) ([]*domain.Task, error) {
-			if status == "pending" && priority == "" {
-				return []*domain.Task{{ID: "1", Status: status, CreatedAt: time.Now(), UpdatedAt: time.Now()}}, nil
+		ListFn: func(filter domain.TaskFilter, _ domain.PageRequest) (*domain.Page[*domain.Task], error) {
+			if filter.Status == "pending" && filter.Priority == "" {
+				return &domain.Page[*domain.Task]{Items: []*domain.Task{{ID: "1", Status: filter.Status, CreatedAt: time.Now(), UpdatedAt: time.Now()}}}, nil
 			}
 			return nil, errors.New("unexpected params")
 		},
@@ -70,11 +75,11 @@ func TestGetTasks_Filtered_OnlyStatus(t *testing.T) {
 }
 
 func TestGetTasks_Filtered_OnlyPriority(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	mockService := &mockTaskService{
-		GetByFiltersFn: func(status, priority string) ([]*domain.Task, error) {
-			if status == "" && priority == "high" {
-				return []*domain.Task{{ID: "1", Priority: priority, CreatedAt: time.Now(), UpdatedAt: time.Now()}}, nil
+		ListFn: func(filter domain.TaskFilter, _ domain.PageRequest) (*domain.Page[*domain.Task], error) {
+			if filter.Status == "" && filter.Priority == "high" {
+				return &domain.Page[*domain.Task]{Items: []*domain.Task{{ID: "1", Priority: filter.Priority, CreatedAt: time.Now(), UpdatedAt: time.Now()}}}, nil
 			}
 			return nil, errors.New("unexpected params")
 		},
@@ -92,7 +97,7 @@ func TestGetTasks_Filtered_OnlyPriority(t *testing.T) {
 }
 
 func TestGetTask_ServiceErrorOther(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{
 		GetByIDFn: func(id string) (*domain.Task, error) { return nil, errors.New("db error") },
 	})
@@ -108,7 +113,7 @@ func TestGetTask_ServiceErrorOther(t *testing.T) {
 }
 
 func TestUpdateTask_ServiceErrorOther(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{
 		UpdateFn: func(id, listID, title, description, status, priority string) (*domain.Task, error) {
 			return nil, errors.New("db error")
@@ -127,7 +132,7 @@ func TestUpdateTask_ServiceErrorOther(t *testing.T) {
 }
 
 func TestDeleteTask_ServiceErrorOther(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{
 		DeleteFn: func(id string) error { return errors.New("db error") },
 	})
@@ -143,7 +148,7 @@ func TestDeleteTask_ServiceErrorOther(t *testing.T) {
 }
 
 func TestCreateTask_InvalidBody(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Post("/tasks", h.CreateTask)
 	req := httptest.NewRequest("POST", "/tasks", strings.NewReader("{malformed_json"))
@@ -158,7 +163,7 @@ func TestCreateTask_InvalidBody(t *testing.T) {
 }
 
 func TestUpdateTask_InvalidBody(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Put("/tasks/:id", h.UpdateTask)
 	req := httptest.NewRequest("PUT", "/tasks/1", strings.NewReader("{malformed_json"))
@@ -173,7 +178,7 @@ func TestUpdateTask_InvalidBody(t *testing.T) {
 }
 
 func TestGetTask_EmptyID(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Get("/tasks/:id", h.GetTask)
 	req := httptest.NewRequest("GET", "/tasks/", http.NoBody)
@@ -188,7 +193,7 @@ func TestGetTask_EmptyID(t *testing.T) {
 }
 
 func TestDeleteTask_EmptyID(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Delete("/tasks/:id", h.DeleteTask)
 	req := httptest.NewRequest("DELETE", "/tasks/", http.NoBody)
@@ -204,31 +209,32 @@ func TestDeleteTask_EmptyID(t *testing.T) {
 
 // mockTaskService implements TaskService for testing
 type mockTaskService struct {
-	CreateFn       func(listID, title, description, priority string) (*domain.Task, error)
-	GetAllFn       func() ([]*domain.Task, error)
-	GetByFiltersFn func(status, priority string) ([]*domain.Task, error)
-	GetByIDFn      func(id string) (*domain.Task, error)
-	UpdateFn       func(id, listID, title, description, status, priority string) (*domain.Task, error)
-	DeleteFn       func(id string) error
+	CreateFn  func(listID, title, description, priority string) (*domain.Task, error)
+	ListFn    func(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error)
+	GetByIDFn func(id string) (*domain.Task, error)
+	UpdateFn  func(id, listID, title, description, status, priority string) (*domain.Task, error)
+	DeleteFn  func(id string) error
+
+	UpdateIfMatchFn func(id string, version int, listID, title, description, status, priority string) (*domain.Task, error)
+	PatchFn         func(id string, version int, patch domain.TaskPatch) (*domain.Task, error)
+	BulkFn          func(req domain.BulkRequest) (*domain.BulkResult, error)
+
+	// changedBy records the user the last write was attributed to.
+	changedBy string
 }
 
-func (m *mockTaskService) Create(listID, title, description, priority string) (*domain.Task, error) {
+func (m *mockTaskService) Create(listID, title, description, priority, changedBy string) (*domain.Task, error) {
+	m.changedBy = changedBy
 	if m.CreateFn != nil {
 		return m.CreateFn(listID, title, description, priority)
 	}
 	return nil, nil
 }
-func (m *mockTaskService) GetAll() ([]*domain.Task, error) {
-	if m.GetAllFn != nil {
-		return m.GetAllFn()
+func (m *mockTaskService) List(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error) {
+	if m.ListFn != nil {
+		return m.ListFn(filter, page)
 	}
-	return nil, nil
-}
-func (m *mockTaskService) GetByFilters(status, priority string) ([]*domain.Task, error) {
-	if m.GetByFiltersFn != nil {
-		return m.GetByFiltersFn(status, priority)
-	}
-	return nil, nil
+	return &domain.Page[*domain.Task]{}, nil
 }
 func (m *mockTaskService) GetByID(id string) (*domain.Task, error) {
 	if m.GetByIDFn != nil {
@@ -236,13 +242,36 @@ func (m *mockTaskService) GetByID(id string) (*domain.Task, error) {
 	}
 	return nil, nil
 }
-func (m *mockTaskService) Update(id, listID, title, description, status, priority string) (*domain.Task, error) {
+func (m *mockTaskService) Update(id, listID, title, description, status, priority, changedBy string) (*domain.Task, error) {
+	m.changedBy = changedBy
 	if m.UpdateFn != nil {
 		return m.UpdateFn(id, listID, title, description, status, priority)
 	}
 	return nil, nil
 }
-func (m *mockTaskService) Delete(id string) error {
+func (m *mockTaskService) UpdateIfMatch(id string, version int, listID, title, description, status, priority, changedBy string) (*domain.Task, error) {
+	m.changedBy = changedBy
+	if m.UpdateIfMatchFn != nil {
+		return m.UpdateIfMatchFn(id, version, listID, title, description, status, priority)
+	}
+	return nil, nil
+}
+func (m *mockTaskService) Patch(id string, version int, patch domain.TaskPatch, changedBy string) (*domain.Task, error) {
+	m.changedBy = changedBy
+	if m.PatchFn != nil {
+		return m.PatchFn(id, version, patch)
+	}
+	return nil, nil
+}
+func (m *mockTaskService) Bulk(req domain.BulkRequest, changedBy string) (*domain.BulkResult, error) {
+	m.changedBy = changedBy
+	if m.BulkFn != nil {
+		return m.BulkFn(req)
+	}
+	return &domain.BulkResult{Applied: true}, nil
+}
+func (m *mockTaskService) Delete(id, changedBy string) error {
+	m.changedBy = changedBy
 	if m.DeleteFn != nil {
 		return m.DeleteFn(id)
 	}
@@ -250,10 +279,10 @@ func (m *mockTaskService) Delete(id string) error {
 }
 
 func TestGetTasks_Filtered_Success(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	mockService := &mockTaskService{
-		GetByFiltersFn: func(status, priority string) ([]*domain.Task, error) {
-			return []*domain.Task{{ID: "2", Status: status, Priority: priority, CreatedAt: time.Now(), UpdatedAt: time.Now()}}, nil
+		ListFn: func(filter domain.TaskFilter, _ domain.PageRequest) (*domain.Page[*domain.Task], error) {
+			return &domain.Page[*domain.Task]{Items: []*domain.Task{{ID: "2", Status: filter.Status, Priority: filter.Priority, CreatedAt: time.Now(), UpdatedAt: time.Now()}}}, nil
 		},
 	}
 	h := NewTaskHandler(mockService)
@@ -269,9 +298,9 @@ func TestGetTasks_Filtered_Success(t *testing.T) {
 }
 
 func TestGetTasks_Filtered_Error(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	mockService := &mockTaskService{
-		GetByFiltersFn: func(status, priority string) ([]*domain.Task, error) {
+		ListFn: func(domain.TaskFilter, domain.PageRequest) (*domain.Page[*domain.Task], error) {
 			return nil, errors.New("fail")
 		},
 	}
@@ -288,7 +317,7 @@ func TestGetTasks_Filtered_Error(t *testing.T) {
 }
 
 func TestUpdateTask_TitleRequired(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Put("/tasks/:id", h.UpdateTask)
 	req := httptest.NewRequest("PUT", "/tasks/1", strings.NewReader(`{"status":"pending","priority":"high"}`))
@@ -303,7 +332,7 @@ func TestUpdateTask_TitleRequired(t *testing.T) {
 }
 
 func TestUpdateTask_StatusRequired(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Put("/tasks/:taskId", h.UpdateTask)
 	req := httptest.NewRequest("PUT", "/tasks/1", strings.NewReader(`{"title":"T","priority":"high"}`))
@@ -318,7 +347,7 @@ func TestUpdateTask_StatusRequired(t *testing.T) {
 }
 
 func TestUpdateTask_PriorityRequired(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Put("/tasks/:taskId", h.UpdateTask)
 	req := httptest.NewRequest("PUT", "/tasks/1", strings.NewReader(`{"title":"T","status":"pending"}`))
@@ -333,10 +362,10 @@ func TestUpdateTask_PriorityRequired(t *testing.T) {
 }
 
 func TestUpdateTask_NotFound(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{
 		UpdateFn: func(id, listID, title, description, status, priority string) (*domain.Task, error) {
-			return nil, errors.New("task not found")
+			return nil, domain.ErrTaskNotFound
 		},
 	})
 	app.Put("/tasks/:taskId", h.UpdateTask)
@@ -352,9 +381,9 @@ func TestUpdateTask_NotFound(t *testing.T) {
 }
 
 func TestDeleteTask_NotFound_Error(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{
-		DeleteFn: func(id string) error { return errors.New("task not found") },
+		DeleteFn: func(id string) error { return domain.ErrTaskNotFound },
 	})
 	app.Delete("/tasks/:id", h.DeleteTask)
 	req := httptest.NewRequest("DELETE", "/tasks/1", http.NoBody)
@@ -368,7 +397,7 @@ func TestDeleteTask_NotFound_Error(t *testing.T) {
 }
 
 func TestCreateTask_Success(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	mockService := &mockTaskService{
 		CreateFn: func(listID, title, description, priority string) (*domain.Task, error) {
 			return &domain.Task{ID: "1", ListID: listID, Title: title, Description: description, Priority: priority, Status: "pending", CreatedAt: time.Now(), UpdatedAt: time.Now()}, nil
@@ -389,7 +418,7 @@ func TestCreateTask_Success(t *testing.T) {
 }
 
 func TestCreateTask_BadRequest(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Post("/tasks", h.CreateTask)
 	req := httptest.NewRequest("POST", "/tasks", strings.NewReader("{"))
@@ -404,13 +433,10 @@ func TestCreateTask_BadRequest(t *testing.T) {
 }
 
 func TestGetTasks_Success(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	mockService := &mockTaskService{
-		GetAllFn: func() ([]*domain.Task, error) {
-			return []*domain.Task{{ID: "1", Title: "T", CreatedAt: time.Now(), UpdatedAt: time.Now()}}, nil
-		},
-		GetByFiltersFn: func(status, priority string) ([]*domain.Task, error) {
-			return []*domain.Task{{ID: "2", Status: status, Priority: priority, CreatedAt: time.Now(), UpdatedAt: time.Now()}}, nil
+		ListFn: func(domain.TaskFilter, domain.PageRequest) (*domain.Page[*domain.Task], error) {
+			return &domain.Page[*domain.Task]{Items: []*domain.Task{{ID: "1", Title: "T", CreatedAt: time.Now(), UpdatedAt: time.Now()}}}, nil
 		},
 	}
 	h := NewTaskHandler(mockService)
@@ -426,9 +452,9 @@ func TestGetTasks_Success(t *testing.T) {
 }
 
 func TestGetTask_NotFound(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	mockService := &mockTaskService{
-		GetByIDFn: func(id string) (*domain.Task, error) { return nil, errors.New("task not found") },
+		GetByIDFn: func(id string) (*domain.Task, error) { return nil, domain.ErrTaskNotFound },
 	}
 	h := NewTaskHandler(mockService)
 	app.Get("/tasks/:id", h.GetTask)
@@ -443,7 +469,7 @@ func TestGetTask_NotFound(t *testing.T) {
 }
 
 func TestUpdateTask_BadRequest(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Put("/tasks/:id", h.UpdateTask)
 	req := httptest.NewRequest("PUT", "/tasks/1", strings.NewReader("{"))
@@ -458,7 +484,7 @@ func TestUpdateTask_BadRequest(t *testing.T) {
 }
 
 func TestDeleteTask_Success(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	mockService := &mockTaskService{
 		DeleteFn: func(id string) error { return nil },
 	}
@@ -475,7 +501,7 @@ func TestDeleteTask_Success(t *testing.T) {
 }
 
 func TestCreateTask_TitleRequired(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Post("/tasks", h.CreateTask)
 	body := `{"list_id":"l1","description":"D","priority":"high"}`
@@ -491,7 +517,7 @@ func TestCreateTask_TitleRequired(t *testing.T) {
 }
 
 func TestCreateTask_ServiceError(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{
 		CreateFn: func(listID, title, description, priority string) (*domain.Task, error) {
 			return nil, errors.New("fail")
@@ -511,9 +537,11 @@ func TestCreateTask_ServiceError(t *testing.T) {
 }
 
 func TestGetTasks_ServiceError(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{
-		GetAllFn: func() ([]*domain.Task, error) { return nil, errors.New("fail") },
+		ListFn: func(domain.TaskFilter, domain.PageRequest) (*domain.Page[*domain.Task], error) {
+			return nil, errors.New("fail")
+		},
 	})
 	app.Get("/tasks", h.GetTasks)
 	req := httptest.NewRequest("GET", "/tasks", http.NoBody)
@@ -527,7 +555,7 @@ func TestGetTasks_ServiceError(t *testing.T) {
 }
 
 func TestGetTask_BadRequest(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Get("/tasks/:id", h.GetTask)
 	req := httptest.NewRequest("GET", "/tasks/", http.NoBody)
@@ -542,7 +570,7 @@ func TestGetTask_BadRequest(t *testing.T) {
 }
 
 func TestGetTask_ServiceError(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{
 		GetByIDFn: func(id string) (*domain.Task, error) { return nil, errors.New("fail") },
 	})
@@ -558,7 +586,7 @@ func TestGetTask_ServiceError(t *testing.T) {
 }
 
 func TestGetTask_Success(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{
 		GetByIDFn: func(id string) (*domain.Task, error) {
 			return &domain.Task{ID: id, Title: "T", CreatedAt: time.Now(), UpdatedAt: time.Now()}, nil
@@ -576,7 +604,7 @@ func TestGetTask_Success(t *testing.T) {
 }
 
 func TestUpdateTask_MissingID(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Put("/tasks/:taskId", h.UpdateTask)
 	req := httptest.NewRequest("PUT", "/tasks/", strings.NewReader("{}"))
@@ -592,7 +620,7 @@ func TestUpdateTask_MissingID(t *testing.T) {
 }
 
 func TestUpdateTask_MissingFields(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Put("/tasks/:taskId", h.UpdateTask)
 	req := httptest.NewRequest("PUT", "/tasks/1", strings.NewReader(`{"title":"","status":"","priority":""}`))
@@ -607,7 +635,7 @@ func TestUpdateTask_MissingFields(t *testing.T) {
 }
 
 func TestUpdateTask_ServiceError(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{
 		UpdateFn: func(id, listID, title, description, status, priority string) (*domain.Task, error) {
 			return nil, errors.New("fail")
@@ -626,7 +654,7 @@ func TestUpdateTask_ServiceError(t *testing.T) {
 }
 
 func TestUpdateTask_Success(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{
 		UpdateFn: func(id, listID, title, description, status, priority string) (*domain.Task, error) {
 			return &domain.Task{ID: id, Title: title, Status: status, Priority: priority, CreatedAt: time.Now(), UpdatedAt: time.Now()}, nil
@@ -645,7 +673,7 @@ func TestUpdateTask_Success(t *testing.T) {
 }
 
 func TestDeleteTask_MissingID(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{})
 	app.Delete("/tasks/:id", h.DeleteTask)
 	req := httptest.NewRequest("DELETE", "/tasks/", http.NoBody)
@@ -660,7 +688,7 @@ func TestDeleteTask_MissingID(t *testing.T) {
 }
 
 func TestDeleteTask_ServiceError(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{
 		DeleteFn: func(id string) error { return errors.New("fail") },
 	})
@@ -676,9 +704,9 @@ func TestDeleteTask_ServiceError(t *testing.T) {
 }
 
 func TestDeleteTask_NotFound(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := NewTaskHandler(&mockTaskService{
-		DeleteFn: func(id string) error { return errors.New("task not found") },
+		DeleteFn: func(id string) error { return domain.ErrTaskNotFound },
 	})
 	app.Delete("/tasks/:taskId", h.DeleteTask)
 	req := httptest.NewRequest("DELETE", "/tasks/1", http.NoBody)
@@ -690,3 +718,369 @@ func TestDeleteTask_NotFound(t *testing.T) {
 		t.Errorf("expected status 404, got %d", resp.StatusCode)
 	}
 }
+
+func TestGetTasks_PaginationEnvelope(t *testing.T) {
+	app := newTestApp()
+	total := 3
+	var gotPage domain.PageRequest
+	h := NewTaskHandler(&mockTaskService{
+		ListFn: func(_ domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error) {
+			gotPage = page
+			return &domain.Page[*domain.Task]{
+				Items: []*domain.Task{{ID: "1", Title: "A", Priority: "high"}},
+				Next:  &domain.Cursor{Value: "3", ID: "1"},
+				Total: &total,
+			}, nil
+		},
+	})
+	app.Get("/tasks", h.GetTasks)
+	resp, err := app.Test(httptest.NewRequest("GET", "/tasks?limit=1&sort=-priority&include_total=true&fields=title", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if gotPage.Limit != 1 || gotPage.Sort != "priority" || !gotPage.Desc || !gotPage.IncludeTotal {
+		t.Errorf("unexpected page request: %+v", gotPage)
+	}
+
+	var body struct {
+		Data       []map[string]interface{} `json:"data"`
+		NextCursor *string                  `json:"next_cursor"`
+		Total      *int                     `json:"total"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	if len(body.Data) != 1 || len(body.Data[0]) != 2 || body.Data[0]["id"] != "1" || body.Data[0]["title"] != "A" {
+		t.Errorf("expected only id and title, got %v", body.Data)
+	}
+	if body.NextCursor == nil || body.Total == nil || *body.Total != 3 {
+		t.Fatalf("expected next_cursor and total, got %+v", body)
+	}
+
+	gotPage = domain.PageRequest{}
+	resp, err = app.Test(httptest.NewRequest("GET", "/tasks?sort=-priority&cursor="+*body.NextCursor, http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK || gotPage.After == nil || gotPage.After.Value != "3" || gotPage.After.ID != "1" {
+		t.Errorf("expected the cursor to be decoded, got %d %+v", resp.StatusCode, gotPage.After)
+	}
+}
+
+func TestGetTasks_PaginationBadRequests(t *testing.T) {
+	app := newTestApp()
+	h := NewTaskHandler(&mockTaskService{
+		ListFn: func(_ domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error) {
+			if page.Sort != "created_at" {
+				return nil, domain.NewInvalidError("sort", "invalid_sort", "invalid sort field")
+			}
+			if page.After != nil {
+				return nil, domain.ErrInvalidCursor
+			}
+			return &domain.Page[*domain.Task]{}, nil
+		},
+	})
+	app.Get("/tasks", h.GetTasks)
+
+	cursor := encodeCursor("-created_at", &domain.Cursor{Value: "x", ID: "1"})
+	tampered := encodeCursor("created_at", &domain.Cursor{Value: "x", ID: "1"})
+	for _, target := range []string{
+		"/tasks?fields=title,secret",
+		"/tasks?limit=0",
+		"/tasks?sort=description",
+		"/tasks?cursor=not-a-cursor",
+		"/tasks?sort=created_at&cursor=" + *cursor,
+		"/tasks?sort=created_at&cursor=" + *tampered,
+	} {
+		resp, err := app.Test(httptest.NewRequest("GET", target, http.NoBody))
+		if err != nil {
+			t.Fatalf("error ejecutando app.Test: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", target, resp.StatusCode)
+		}
+	}
+}
+
+func TestGetTasks_FilterExpression(t *testing.T) {
+	app := newTestApp()
+	var gotExpression string
+	h := NewTaskHandler(&mockTaskService{
+		ListFn: func(filter domain.TaskFilter, _ domain.PageRequest) (*domain.Page[*domain.Task], error) {
+			gotExpression = filter.Expression
+			if filter.Expression == "owner = bob" {
+				return nil, domain.WrapInvalidError("filter", "invalid_filter", errors.New(`invalid filter at position 1 near "owner": unknown field`))
+			}
+			return &domain.Page[*domain.Task]{}, nil
+		},
+	})
+	app.Get("/tasks", h.GetTasks)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/tasks?filter=priority%20%3D%20high", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK || gotExpression != "priority = high" {
+		t.Errorf("expected 200 with the expression passed through, got %d %q", resp.StatusCode, gotExpression)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/tasks?filter=owner%20%3D%20bob", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	var body LegacyError
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest || !strings.Contains(body.Error, "position 1") {
+		t.Errorf("expected 400 pointing at the token, got %d %v", resp.StatusCode, body)
+	}
+}
+
+func TestGetTask_ETagAndNotModified(t *testing.T) {
+	app := newTestApp()
+	h := NewTaskHandler(&mockTaskService{
+		GetByIDFn: func(id string) (*domain.Task, error) {
+			return &domain.Task{ID: id, Title: "T", Version: 3, ChecklistTotal: 2, ChecklistDone: 1}, nil
+		},
+	})
+	app.Get("/tasks/:id", h.GetTask)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/tasks/1", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != fiber.StatusOK || etag != `"3-1-2"` {
+		t.Fatalf("expected 200 with ETag \"3-1-2\", got %d %q", resp.StatusCode, etag)
+	}
+
+	req := httptest.NewRequest("GET", "/tasks/1", http.NoBody)
+	req.Header.Set("If-None-Match", etag)
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotModified {
+		t.Errorf("expected status 304, got %d", resp.StatusCode)
+	}
+
+	req = httptest.NewRequest("GET", "/tasks/1", http.NoBody)
+	req.Header.Set("If-None-Match", `"2-1-2"`)
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("expected status 200 for a stale ETag, got %d", resp.StatusCode)
+	}
+}
+
+func TestUpdateTask_IfMatch(t *testing.T) {
+	var gotVersion int
+	h := NewTaskHandler(&mockTaskService{
+		UpdateIfMatchFn: func(id string, version int, listID, title, description, status, priority string) (*domain.Task, error) {
+			gotVersion = version
+			if version != 3 {
+				return nil, domain.ErrVersionConflict
+			}
+			return &domain.Task{ID: id, Title: title, Status: status, Priority: priority, Version: 4}, nil
+		},
+	})
+	app := newTestApp()
+	app.Put("/tasks/:id", h.UpdateTask)
+	body := `{"title":"T","status":"pending","priority":"low"}`
+
+	cases := []struct {
+		ifMatch string
+		status  int
+		version int
+	}{
+		{`"3-0-0"`, fiber.StatusOK, 3},
+		{`"2-0-0"`, fiber.StatusPreconditionFailed, 2},
+		{`"abc"`, fiber.StatusPreconditionFailed, 0},
+	}
+	for _, tc := range cases {
+		gotVersion = 0
+		req := httptest.NewRequest("PUT", "/tasks/1", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", tc.ifMatch)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("error ejecutando app.Test: %v", err)
+		}
+		if resp.StatusCode != tc.status || gotVersion != tc.version {
+			t.Errorf("If-Match %s: expected %d with version %d, got %d with version %d", tc.ifMatch, tc.status, tc.version, resp.StatusCode, gotVersion)
+		}
+		if resp.StatusCode == fiber.StatusOK && resp.Header.Get("ETag") != `"4-0-0"` {
+			t.Errorf("expected ETag of the new version, got %q", resp.Header.Get("ETag"))
+		}
+	}
+}
+
+func TestPatchTask_MergePatch(t *testing.T) {
+	var got domain.TaskPatch
+	h := NewTaskHandler(&mockTaskService{
+		PatchFn: func(id string, version int, patch domain.TaskPatch) (*domain.Task, error) {
+			got = patch
+			return &domain.Task{ID: id, Title: "T", Status: "completed", Version: 2}, nil
+		},
+	})
+	app := newTestApp()
+	app.Patch("/tasks/:id", h.PatchTask)
+
+	req := httptest.NewRequest("PATCH", "/tasks/1", strings.NewReader(`{"status":"completed","description":null,"due_date":"2026-03-01"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if !got.Status.Set || got.Status.Value != "completed" || got.Title.Set || got.Priority.Set {
+		t.Errorf("only the patched fields must be set: %+v", got)
+	}
+	if !got.Description.Null || !got.DueDate.Set || got.DueDate.Value.Format("2006-01-02") != "2026-03-01" {
+		t.Errorf("unexpected description or due date: %+v", got)
+	}
+}
+
+func TestPatchTask_JSONPatch(t *testing.T) {
+	var gotVersion int
+	var got domain.TaskPatch
+	h := NewTaskHandler(&mockTaskService{
+		GetByIDFn: func(id string) (*domain.Task, error) {
+			return &domain.Task{ID: id, Title: "Old", Status: "pending", Priority: "low", Labels: []string{"a"}, Version: 7}, nil
+		},
+		PatchFn: func(id string, version int, patch domain.TaskPatch) (*domain.Task, error) {
+			gotVersion, got = version, patch
+			return &domain.Task{ID: id, Title: "New", Version: 8}, nil
+		},
+	})
+	app := newTestApp()
+	app.Patch("/tasks/:id", h.PatchTask)
+
+	body := `[{"op":"test","path":"/version","value":7},{"op":"replace","path":"/title","value":"New"},{"op":"add","path":"/labels/-","value":"b"}]`
+	req := httptest.NewRequest("PATCH", "/tasks/1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if gotVersion != 7 {
+		t.Errorf("expected the patch to be conditional on the version read, got %d", gotVersion)
+	}
+	if got.Title.Value != "New" || len(got.Labels.Value) != 2 || got.Status.Set {
+		t.Errorf("unexpected patch: %+v", got)
+	}
+}
+
+func TestPatchTask_Errors(t *testing.T) {
+	h := NewTaskHandler(&mockTaskService{
+		GetByIDFn: func(id string) (*domain.Task, error) {
+			return &domain.Task{ID: id, Title: "Old", Status: "pending", Priority: "low", Version: 7}, nil
+		},
+		PatchFn: func(id string, version int, patch domain.TaskPatch) (*domain.Task, error) {
+			if patch.Title.Null {
+				return nil, domain.NewInvalidError("title", "invalid_title", "title cannot be null")
+			}
+			return &domain.Task{ID: id}, nil
+		},
+	})
+	app := newTestApp()
+	app.Patch("/tasks/:id", h.PatchTask)
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"unknown field", "application/merge-patch+json", `{"owner":"x"}`, fiber.StatusBadRequest},
+		{"read-only field", "application/merge-patch+json", `{"version":9}`, fiber.StatusBadRequest},
+		{"wrong type", "application/merge-patch+json", `{"title":5}`, fiber.StatusBadRequest},
+		{"null title", "application/merge-patch+json", `{"title":null}`, fiber.StatusBadRequest},
+		{"not an object", "application/merge-patch+json", `["title"]`, fiber.StatusBadRequest},
+		{"failed test", "application/json-patch+json", `[{"op":"test","path":"/status","value":"completed"}]`, fiber.StatusConflict},
+		{"removed title", "application/json-patch+json", `[{"op":"remove","path":"/title"}]`, fiber.StatusBadRequest},
+		{"bad path", "application/json-patch+json", `[{"op":"replace","path":"/nope","value":1}]`, fiber.StatusBadRequest},
+		{"media type", "text/plain", `title=x`, fiber.StatusUnsupportedMediaType},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest("PATCH", "/tasks/1", strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", tc.contentType)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("error ejecutando app.Test: %v", err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s: expected status %d, got %d", tc.name, tc.status, resp.StatusCode)
+		}
+	}
+}
+
+func TestBulkTasks(t *testing.T) {
+	var got domain.BulkRequest
+	h := NewTaskHandler(&mockTaskService{
+		BulkFn: func(req domain.BulkRequest) (*domain.BulkResult, error) {
+			got = req
+			return &domain.BulkResult{Applied: true, Items: []domain.BulkItemResult{
+				{Index: 0, ID: "1", Task: &domain.Task{ID: "1", Status: "completed"}},
+				{Index: 1, ID: "2", Err: domain.ErrTaskNotFound},
+			}}, nil
+		},
+	})
+	app := newTestApp()
+	app.Post("/tasks/bulk", h.BulkTasks)
+
+	body := `{"action":"update","ids":["1","2"],"fields":{"status":"completed","due_date":null}}`
+	req := httptest.NewRequest("POST", "/tasks/bulk", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusMultiStatus {
+		t.Fatalf("expected status 207, got %d", resp.StatusCode)
+	}
+	if got.Atomic || got.Fields.Status.Value != "completed" || !got.Fields.DueDate.Null {
+		t.Errorf("unexpected bulk request: %+v", got)
+	}
+
+	var out BulkTaskResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	if out.Succeeded != 1 || out.Failed != 1 || out.Results[0].Status != 200 || out.Results[0].Task == nil || out.Results[1].Status != 404 {
+		t.Errorf("unexpected response: %+v", out)
+	}
+}
+
+func TestBulkTasks_BadRequests(t *testing.T) {
+	h := NewTaskHandler(&mockTaskService{
+		BulkFn: func(domain.BulkRequest) (*domain.BulkResult, error) {
+			return nil, domain.NewInvalidError("ids", "invalid_bulk_request", "invalid bulk request: either ids or filter is required")
+		},
+	})
+	app := newTestApp()
+	app.Post("/tasks/bulk", h.BulkTasks)
+
+	for _, body := range []string{`{"action":"delete","mode":"sometimes","ids":["1"]}`, `{"action":"update","ids":["1"],"fields":{"owner":"x"}}`, `{"action":"delete"}`} {
+		req := httptest.NewRequest("POST", "/tasks/bulk", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("error ejecutando app.Test: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", body, resp.StatusCode)
+		}
+	}
+}
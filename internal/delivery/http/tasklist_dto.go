@@ -1,25 +1,67 @@
 package http
 
-import "time"
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
 
 // CreateTaskListRequest represents the request body for creating a task list.
 type CreateTaskListRequest struct {
-	Name        string `json:"name"`
+	Name        string `json:"name" openapi:"required,minLength=1,maxLength=255"`
 	Description string `json:"description"`
 }
 
 // UpdateTaskListRequest represents the request body for updating a task list.
 type UpdateTaskListRequest struct {
-	Name        string `json:"name"`
+	Name        string `json:"name" openapi:"required,minLength=1,maxLength=255"`
 	Description string `json:"description"`
 }
 
 // TaskListResponse represents the response body for a task list.
 type TaskListResponse struct {
-	ID                   string    `json:"id"`
-	Name                 string    `json:"name"`
-	Description          string    `json:"description"`
-	CompletionPercentage float64   `json:"completion_percentage"`
-	CreatedAt            time.Time `json:"created_at"`
-	UpdatedAt            time.Time `json:"updated_at"`
+	ID                   string     `json:"id"`
+	Name                 string     `json:"name"`
+	Description          string     `json:"description"`
+	CompletionPercentage float64    `json:"completion_percentage"`
+	TotalTasks           int        `json:"total_tasks"`
+	PendingTasks         int        `json:"pending_tasks"`
+	InProgressTasks      int        `json:"in_progress_tasks"`
+	CompletedTasks       int        `json:"completed_tasks"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+	ArchivedAt           *time.Time `json:"archived_at,omitempty"`
+	Version              int        `json:"version"`
+}
+
+// taskListFields are the TaskListResponse fields that can be selected with fields=.
+var taskListFields = map[string]bool{
+	"id": true, "name": true, "description": true, "completion_percentage": true,
+	"total_tasks": true, "pending_tasks": true, "in_progress_tasks": true, "completed_tasks": true,
+	"created_at": true, "updated_at": true, "archived_at": true, "version": true,
+}
+
+// newTaskListResponse maps a domain task list and its task counts to its response body.
+func newTaskListResponse(list *domain.TaskList, stats *domain.ListStats) TaskListResponse {
+	response := TaskListResponse{
+		ID:          list.ID,
+		Name:        list.Name,
+		Description: list.Description,
+		CreatedAt:   list.CreatedAt,
+		UpdatedAt:   list.UpdatedAt,
+		ArchivedAt:  list.ArchivedAt,
+		Version:     list.Version,
+	}
+
+	if stats != nil {
+		response.TotalTasks = stats.Total
+		response.PendingTasks = stats.Pending
+		response.InProgressTasks = stats.InProgress
+		response.CompletedTasks = stats.Completed
+		if stats.Total > 0 {
+			response.CompletionPercentage = float64(stats.Completed) / float64(stats.Total) * 100
+		}
+	}
+
+	return response
 }
@@ -1,32 +1,34 @@
 package http
 
 import (
+	"encoding/json"
+
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/G20-00/task-management-service-go/internal/domain"
-	"github.com/G20-00/task-management-service-go/internal/usecase/task"
 )
 
 // TaskListService define la interfaz para operaciones de listas de tareas.
 type TaskListService interface {
 	Create(name, description string) (*domain.TaskList, error)
-	GetAll() ([]*domain.TaskList, error)
+	List(includeArchived bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error)
 	GetByID(id string) (*domain.TaskList, error)
 	Update(id, name, description string) (*domain.TaskList, error)
-	Delete(id string) error
+	UpdateIfMatch(id string, version int, name, description string) (*domain.TaskList, error)
+	Patch(id string, version int, patch domain.TaskListPatch) (*domain.TaskList, error)
+	Delete(id, changedBy string) error
+	GetStats(listIDs []string) (map[string]*domain.ListStats, error)
 }
 
 // TaskListHandler maneja las solicitudes HTTP para operaciones de listas de tareas.
 type TaskListHandler struct {
-	service     TaskListService
-	taskService *task.Service
+	service TaskListService
 }
 
 // NewTaskListHandler creates a new TaskListHandler instance.
-func NewTaskListHandler(service TaskListService, taskService *task.Service) *TaskListHandler {
+func NewTaskListHandler(service TaskListService) *TaskListHandler {
 	return &TaskListHandler{
-		service:     service,
-		taskService: taskService,
+		service: service,
 	}
 }
 
@@ -35,22 +37,16 @@ func (h *TaskListHandler) CreateTaskList(c *fiber.Ctx) error {
 	var req CreateTaskListRequest
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return errInvalidBody
 	}
 
 	if req.Name == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Name is required",
-		})
+		return missingField("name", "Name is required")
 	}
 
 	list, err := h.service.Create(req.Name, req.Description)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return useCaseError(c, "CreateTaskList", err, "Failed to create task list")
 	}
 
 	response := TaskListResponse{
@@ -62,128 +58,173 @@ func (h *TaskListHandler) CreateTaskList(c *fiber.Ctx) error {
 		UpdatedAt:            list.UpdatedAt,
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(response)
+	return sendResource(c, fiber.StatusCreated, response)
 }
 
-// GetTaskLists retrieves all task lists with their completion percentages.
+// GetTaskLists retrieves one page of task lists with their task counts and completion
+// percentages, computed for all lists of the page in a single aggregate query.
+// Archived lists are hidden unless include_archived=true is given.
 func (h *TaskListHandler) GetTaskLists(c *fiber.Ctx) error {
-	lists, err := h.service.GetAll()
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
-	}
-
-	responses := make([]TaskListResponse, len(lists))
-	for i, list := range lists {
-		percentage := h.calculateCompletionPercentage(list.ID)
-		responses[i] = TaskListResponse{
-			ID:                   list.ID,
-			Name:                 list.Name,
-			Description:          list.Description,
-			CompletionPercentage: percentage,
-			CreatedAt:            list.CreatedAt,
-			UpdatedAt:            list.UpdatedAt,
-		}
+	fields, err := parseFields(c, taskListFields)
+	if err != nil {
+		return err
+	}
+
+	page, err := parsePageRequest(c, "-created_at")
+	if err != nil {
+		return err
+	}
+
+	result, err := h.service.List(c.QueryBool("include_archived"), page)
+	if err != nil {
+		return useCaseError(c, "GetTaskLists", err, "Failed to get task lists")
+	}
+
+	ids := make([]string, len(result.Items))
+	for i, list := range result.Items {
+		ids[i] = list.ID
+	}
+
+	stats, err := h.service.GetStats(ids)
+	if err != nil {
+		return useCaseError(c, "GetTaskLists", err, "Failed to get task lists")
+	}
+
+	responses := make([]TaskListResponse, len(result.Items))
+	for i, list := range result.Items {
+		responses[i] = newTaskListResponse(list, stats[list.ID])
+	}
+
+	data, err := selectFields(responses, fields)
+	if err != nil {
+		return useCaseError(c, "GetTaskLists", err, "Failed to get task lists")
 	}
 
-	return c.JSON(responses)
+	return c.JSON(PageResponse{
+		Data:       data,
+		NextCursor: encodeCursor(c.Query("sort", "-created_at"), result.Next),
+		Total:      result.Total,
+	})
 }
 
-// GetTaskList retrieves a single task list by ID with completion percentage.
+// GetTaskList retrieves a single task list by ID with its task counts and completion percentage.
 func (h *TaskListHandler) GetTaskList(c *fiber.Ctx) error {
 	id := c.Params("id")
 
 	list, err := h.service.GetByID(id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return useCaseError(c, "GetTaskList", err, "Failed to get task list")
 	}
 
-	response := TaskListResponse{
-		ID:                   list.ID,
-		Name:                 list.Name,
-		Description:          list.Description,
-		CompletionPercentage: h.calculateCompletionPercentage(list.ID),
-		CreatedAt:            list.CreatedAt,
-		UpdatedAt:            list.UpdatedAt,
+	stats, err := h.service.GetStats([]string{list.ID})
+	if err != nil {
+		return useCaseError(c, "GetTaskList", err, "Failed to get task list")
 	}
 
-	return c.JSON(response)
+	etag := taskListETag(list, stats[list.ID])
+	c.Set(fiber.HeaderETag, etag)
+	if notModified(c, etag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	return sendResource(c, fiber.StatusOK, newTaskListResponse(list, stats[list.ID]))
 }
 
-// UpdateTaskList updates an existing task list.
+// UpdateTaskList updates an existing task list. With an If-Match header the update only
+// applies if the list has not changed since that ETag was issued.
 func (h *TaskListHandler) UpdateTaskList(c *fiber.Ctx) error {
 	id := c.Params("id")
 	var req UpdateTaskListRequest
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+		return errInvalidBody
 	}
 
-	list, err := h.service.Update(id, req.Name, req.Description)
+	version, err := ifMatchVersion(c)
 	if err != nil {
-		if err.Error() == "task list not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return errInvalidIfMatch
 	}
 
-	response := TaskListResponse{
-		ID:          list.ID,
-		Name:        list.Name,
-		Description: list.Description,
-		CreatedAt:   list.CreatedAt,
-		UpdatedAt:   list.UpdatedAt,
+	var list *domain.TaskList
+	if version == 0 {
+		list, err = h.service.Update(id, req.Name, req.Description)
+	} else {
+		list, err = h.service.UpdateIfMatch(id, version, req.Name, req.Description)
 	}
+	if err != nil {
+		return useCaseError(c, "UpdateTaskList", err, "Failed to update task list")
+	}
+
+	stats, err := h.service.GetStats([]string{list.ID})
+	if err != nil {
+		return useCaseError(c, "UpdateTaskList", err, "Failed to update task list")
+	}
+
+	c.Set(fiber.HeaderETag, taskListETag(list, stats[list.ID]))
 
-	return c.JSON(response)
+	return sendResource(c, fiber.StatusOK, newTaskListResponse(list, stats[list.ID]))
 }
 
-// DeleteTaskList deletes a task list by ID.
-func (h *TaskListHandler) DeleteTaskList(c *fiber.Ctx) error {
+// PatchTaskList partially updates a task list with a JSON Merge Patch (RFC 7396) or, when
+// sent as application/json-patch+json, a JSON Patch (RFC 6902). A null description clears it.
+func (h *TaskListHandler) PatchTaskList(c *fiber.Ctx) error {
 	id := c.Params("id")
 
-	if err := h.service.Delete(id); err != nil {
-		if err.Error() == "task list not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return errInvalidIfMatch
+	}
+
+	jsonPatch, err := isJSONPatch(c)
+	if err != nil {
+		return err
+	}
+
+	var changes map[string]json.RawMessage
+	if jsonPatch {
+		current, err := h.service.GetByID(id)
+		if err != nil {
+			return useCaseError(c, "PatchTaskList", err, "Failed to update task list")
+		}
+		if version != 0 && current.Version != version {
+			return domain.ErrVersionConflict
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		version = current.Version
+		changes, err = jsonPatchChanges(c.Body(), taskListPatchDocument(current))
+		if err != nil {
+			return patchBodyError(err)
+		}
+	} else if changes, err = mergePatchChanges(c.Body()); err != nil {
+		return patchBodyError(err)
 	}
 
-	return c.SendStatus(fiber.StatusNoContent)
-}
+	patch, err := newTaskListPatch(changes)
+	if err != nil {
+		return patchBodyError(err)
+	}
 
-func (h *TaskListHandler) calculateCompletionPercentage(listID string) float64 {
-	tasks, err := h.taskService.GetByFilters("", "")
+	list, err := h.service.Patch(id, version, patch)
 	if err != nil {
-		return 0.0
+		return useCaseError(c, "PatchTaskList", err, "Failed to update task list")
 	}
 
-	var totalTasks, completedTasks int
-	for _, t := range tasks {
-		if t.ListID == listID {
-			totalTasks++
-			if t.Status == "completed" {
-				completedTasks++
-			}
-		}
+	stats, err := h.service.GetStats([]string{list.ID})
+	if err != nil {
+		return useCaseError(c, "PatchTaskList", err, "Failed to update task list")
 	}
 
-	if totalTasks == 0 {
-		return 0.0
+	c.Set(fiber.HeaderETag, taskListETag(list, stats[list.ID]))
+
+	return sendResource(c, fiber.StatusOK, newTaskListResponse(list, stats[list.ID]))
+}
+
+// DeleteTaskList deletes a task list by ID.
+func (h *TaskListHandler) DeleteTaskList(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.service.Delete(id, CurrentUserID(c)); err != nil {
+		return useCaseError(c, "DeleteTaskList", err, "Failed to delete task list")
 	}
 
-	return (float64(completedTasks) / float64(totalTasks)) * 100
+	return c.SendStatus(fiber.StatusNoContent)
 }
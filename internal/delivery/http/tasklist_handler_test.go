@@ -1,6 +1,7 @@
 package http
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http/httptest"
 	"strings"
@@ -14,10 +15,14 @@ import (
 
 type mockTaskListService struct {
 	CreateFn  func(name, description string) (*domain.TaskList, error)
-	GetAllFn  func() ([]*domain.TaskList, error)
+	ListFn    func(includeArchived bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error)
 	GetByIDFn func(id string) (*domain.TaskList, error)
 	UpdateFn  func(id, name, description string) (*domain.TaskList, error)
 	DeleteFn  func(id string) error
+	StatsFn   func(listIDs []string) (map[string]*domain.ListStats, error)
+
+	UpdateIfMatchFn func(id string, version int, name, description string) (*domain.TaskList, error)
+	PatchFn         func(id string, version int, patch domain.TaskListPatch) (*domain.TaskList, error)
 }
 
 func (m *mockTaskListService) Create(name, description string) (*domain.TaskList, error) {
@@ -26,11 +31,11 @@ func (m *mockTaskListService) Create(name, description string) (*domain.TaskList
 	}
 	return nil, nil
 }
-func (m *mockTaskListService) GetAll() ([]*domain.TaskList, error) {
-	if m.GetAllFn != nil {
-		return m.GetAllFn()
+func (m *mockTaskListService) List(includeArchived bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error) {
+	if m.ListFn != nil {
+		return m.ListFn(includeArchived, page)
 	}
-	return nil, nil
+	return &domain.Page[*domain.TaskList]{}, nil
 }
 func (m *mockTaskListService) GetByID(id string) (*domain.TaskList, error) {
 	if m.GetByIDFn != nil {
@@ -44,15 +49,34 @@ func (m *mockTaskListService) Update(id, name, description string) (*domain.Task
 	}
 	return nil, nil
 }
-func (m *mockTaskListService) Delete(id string) error {
+func (m *mockTaskListService) UpdateIfMatch(id string, version int, name, description string) (*domain.TaskList, error) {
+	if m.UpdateIfMatchFn != nil {
+		return m.UpdateIfMatchFn(id, version, name, description)
+	}
+	return nil, nil
+}
+func (m *mockTaskListService) Patch(id string, version int, patch domain.TaskListPatch) (*domain.TaskList, error) {
+	if m.PatchFn != nil {
+		return m.PatchFn(id, version, patch)
+	}
+	return nil, nil
+}
+func (m *mockTaskListService) Delete(id, _ string) error {
 	if m.DeleteFn != nil {
 		return m.DeleteFn(id)
 	}
 	return nil
 }
 
+func (m *mockTaskListService) GetStats(listIDs []string) (map[string]*domain.ListStats, error) {
+	if m.StatsFn != nil {
+		return m.StatsFn(listIDs)
+	}
+	return map[string]*domain.ListStats{}, nil
+}
+
 func TestCreateTaskList_Success(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := &TaskListHandler{service: &mockTaskListService{
 		CreateFn: func(name, description string) (*domain.TaskList, error) {
 			return &domain.TaskList{ID: "1", Name: name, Description: description, CreatedAt: time.Now(), UpdatedAt: time.Now()}, nil
@@ -72,7 +96,7 @@ func TestCreateTaskList_Success(t *testing.T) {
 }
 
 func TestCreateTaskList_BadRequest(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := &TaskListHandler{service: &mockTaskListService{}}
 	app.Post("/lists", h.CreateTaskList)
 	req := httptest.NewRequest("POST", "/lists", strings.NewReader("{"))
@@ -87,7 +111,7 @@ func TestCreateTaskList_BadRequest(t *testing.T) {
 }
 
 func TestCreateTaskList_NameRequired(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := &TaskListHandler{service: &mockTaskListService{}}
 	app.Post("/lists", h.CreateTaskList)
 	body := `{"description":"desc"}`
@@ -103,7 +127,7 @@ func TestCreateTaskList_NameRequired(t *testing.T) {
 }
 
 func TestCreateTaskList_ServiceError(t *testing.T) {
-	app := fiber.New()
+	app := newTestApp()
 	h := &TaskListHandler{service: &mockTaskListService{
 		CreateFn: func(name, description string) (*domain.TaskList, error) {
 			return nil, errors.New("fail")
@@ -121,3 +145,118 @@ func TestCreateTaskList_ServiceError(t *testing.T) {
 		t.Errorf("expected 500, got %d", resp.StatusCode)
 	}
 }
+
+func TestGetTaskLists_UsesSingleStatsQuery(t *testing.T) {
+	app := newTestApp()
+	calls := 0
+	h := &TaskListHandler{service: &mockTaskListService{
+		ListFn: func(bool, domain.PageRequest) (*domain.Page[*domain.TaskList], error) {
+			return &domain.Page[*domain.TaskList]{Items: []*domain.TaskList{{ID: "a"}, {ID: "b"}}}, nil
+		},
+		StatsFn: func(listIDs []string) (map[string]*domain.ListStats, error) {
+			calls++
+			return map[string]*domain.ListStats{
+				"a": {ListID: "a", Total: 4, Pending: 1, InProgress: 1, Completed: 2},
+				"b": {ListID: "b"},
+			}, nil
+		},
+	}}
+	app.Get("/lists", h.GetTaskLists)
+	resp, err := app.Test(httptest.NewRequest("GET", "/lists", nil))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	var page struct {
+		Data []TaskListResponse `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	body := page.Data
+	if calls != 1 {
+		t.Errorf("expected a single stats call, got %d", calls)
+	}
+	if len(body) != 2 || body[0].CompletionPercentage != 50 || body[0].InProgressTasks != 1 || body[0].TotalTasks != 4 {
+		t.Errorf("unexpected response: %+v", body)
+	}
+	if body[1].CompletionPercentage != 0 {
+		t.Errorf("expected 0%% for empty list, got %v", body[1].CompletionPercentage)
+	}
+}
+
+func TestGetTaskList_ETagAndNotModified(t *testing.T) {
+	app := newTestApp()
+	h := NewTaskListHandler(&mockTaskListService{
+		GetByIDFn: func(id string) (*domain.TaskList, error) {
+			return &domain.TaskList{ID: id, Name: "L", Version: 2}, nil
+		},
+		StatsFn: func([]string) (map[string]*domain.ListStats, error) {
+			return map[string]*domain.ListStats{"a": {ListID: "a", Total: 3, Pending: 1, Completed: 2}}, nil
+		},
+	})
+	app.Get("/lists/:id", h.GetTaskList)
+
+	req := httptest.NewRequest("GET", "/lists/a", nil)
+	req.Header.Set("If-None-Match", `"1-3-1-0-2"`)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK || resp.Header.Get("ETag") != `"2-3-1-0-2"` {
+		t.Fatalf("expected 200 with ETag \"2-3-1-0-2\", got %d %q", resp.StatusCode, resp.Header.Get("ETag"))
+	}
+
+	req = httptest.NewRequest("GET", "/lists/a", nil)
+	req.Header.Set("If-None-Match", `W/"2-3-1-0-2"`)
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotModified {
+		t.Errorf("expected status 304, got %d", resp.StatusCode)
+	}
+}
+
+func TestUpdateTaskList_IfMatchConflict(t *testing.T) {
+	app := newTestApp()
+	h := NewTaskListHandler(&mockTaskListService{
+		UpdateIfMatchFn: func(id string, version int, name, description string) (*domain.TaskList, error) {
+			return nil, domain.ErrVersionConflict
+		},
+	})
+	app.Put("/lists/:id", h.UpdateTaskList)
+	req := httptest.NewRequest("PUT", "/lists/a", strings.NewReader(`{"name":"N"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1-0-0-0-0"`)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusPreconditionFailed {
+		t.Errorf("expected status 412, got %d", resp.StatusCode)
+	}
+}
+
+func TestPatchTaskList_ClearsDescription(t *testing.T) {
+	var got domain.TaskListPatch
+	app := newTestApp()
+	h := NewTaskListHandler(&mockTaskListService{
+		PatchFn: func(id string, version int, patch domain.TaskListPatch) (*domain.TaskList, error) {
+			got = patch
+			return &domain.TaskList{ID: id, Name: "L", Version: 2}, nil
+		},
+	})
+	app.Patch("/lists/:id", h.PatchTaskList)
+	req := httptest.NewRequest("PATCH", "/lists/a", strings.NewReader(`{"description":null}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if got.Name.Set || !got.Description.Set || !got.Description.Null {
+		t.Errorf("unexpected patch: %+v", got)
+	}
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// SaveTemplateRequest represents the request body for saving a task list as a template.
+type SaveTemplateRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// InstantiateTemplateRequest represents the request body for creating a task list from a template.
+type InstantiateTemplateRequest struct {
+	Variables map[string]string `json:"variables" openapi:"nullable"`
+}
+
+// TemplateItemResponse represents a task captured in a template.
+type TemplateItemResponse struct {
+	ID            string   `json:"id"`
+	ParentItemID  string   `json:"parent_item_id,omitempty"`
+	Position      int      `json:"position"`
+	Title         string   `json:"title"`
+	Description   string   `json:"description"`
+	Priority      string   `json:"priority"`
+	DueOffsetDays *int     `json:"due_offset_days,omitempty"`
+	Labels        []string `json:"labels"`
+}
+
+// TemplateResponse represents the response body for a template.
+type TemplateResponse struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+	Items       []TemplateItemResponse `json:"items,omitempty"`
+}
+
+// InstantiateTemplateResponse represents the task list and tasks created from a template.
+type InstantiateTemplateResponse struct {
+	List  TaskListResponse `json:"list"`
+	Tasks []TaskResponse   `json:"tasks"`
+}
+
+// newTemplateResponse maps a domain template to its response body.
+func newTemplateResponse(tpl *domain.TaskTemplate) TemplateResponse {
+	items := make([]TemplateItemResponse, len(tpl.Items))
+	for i, item := range tpl.Items {
+		labels := item.Labels
+		if labels == nil {
+			labels = []string{}
+		}
+		items[i] = TemplateItemResponse{
+			ID:            item.ID,
+			ParentItemID:  item.ParentItemID,
+			Position:      item.Position,
+			Title:         item.Title,
+			Description:   item.Description,
+			Priority:      item.Priority,
+			DueOffsetDays: item.DueOffsetDays,
+			Labels:        labels,
+		}
+	}
+
+	return TemplateResponse{
+		ID:          tpl.ID,
+		Name:        tpl.Name,
+		Description: tpl.Description,
+		CreatedAt:   tpl.CreatedAt,
+		UpdatedAt:   tpl.UpdatedAt,
+		Items:       items,
+	}
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+)
+
+// TemplateService define la interfaz para operaciones de plantillas.
+type TemplateService interface {
+	SaveFromList(listID, name, description string) (*domain.TaskTemplate, error)
+	GetAll() ([]*domain.TaskTemplate, error)
+	GetByID(id string) (*domain.TaskTemplate, error)
+	Delete(id string) error
+	Instantiate(templateID string, variables map[string]string, changedBy string) (*domain.TaskList, []*domain.Task, error)
+}
+
+// TemplateHandler maneja las solicitudes HTTP de plantillas de listas y tareas.
+type TemplateHandler struct {
+	service TemplateService
+}
+
+// NewTemplateHandler creates a new TemplateHandler instance.
+func NewTemplateHandler(service TemplateService) *TemplateHandler {
+	return &TemplateHandler{
+		service: service,
+	}
+}
+
+// SaveListAsTemplate captures an existing task list and its tasks as a template.
+func (h *TemplateHandler) SaveListAsTemplate(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req SaveTemplateRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	tpl, err := h.service.SaveFromList(id, req.Name, req.Description)
+	if err != nil {
+		return useCaseError(c, "SaveListAsTemplate", err, "Failed to save template")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(newTemplateResponse(tpl))
+}
+
+// GetTemplates retrieves all templates without their items.
+func (h *TemplateHandler) GetTemplates(c *fiber.Ctx) error {
+	templates, err := h.service.GetAll()
+	if err != nil {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"layer":  "handler",
+			"method": "GetTemplates",
+			"error":  err.Error(),
+		}).Error("Failed to get templates")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get templates")
+	}
+
+	responses := make([]TemplateResponse, len(templates))
+	for i, tpl := range templates {
+		responses[i] = newTemplateResponse(tpl)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses)
+}
+
+// GetTemplate retrieves a template with its items.
+func (h *TemplateHandler) GetTemplate(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	tpl, err := h.service.GetByID(id)
+	if err != nil {
+		return useCaseError(c, "GetTemplate", err, "Failed to get template")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newTemplateResponse(tpl))
+}
+
+// DeleteTemplate removes a template.
+func (h *TemplateHandler) DeleteTemplate(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.service.Delete(id); err != nil {
+		return useCaseError(c, "DeleteTemplate", err, "Failed to delete template")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// InstantiateTemplate creates a new task list with its tasks from a template,
+// replacing {{variable}} placeholders with the given variables.
+func (h *TemplateHandler) InstantiateTemplate(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	var req InstantiateTemplateRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	list, tasks, err := h.service.Instantiate(id, req.Variables, CurrentUserID(c))
+	if err != nil {
+		return useCaseError(c, "InstantiateTemplate", err, "Failed to instantiate template")
+	}
+
+	response := InstantiateTemplateResponse{
+		List: TaskListResponse{
+			ID:          list.ID,
+			Name:        list.Name,
+			Description: list.Description,
+			CreatedAt:   list.CreatedAt,
+			UpdatedAt:   list.UpdatedAt,
+		},
+		Tasks: make([]TaskResponse, len(tasks)),
+	}
+	for i, t := range tasks {
+		response.Tasks[i] = newTaskResponse(t)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockTemplateService struct {
+	SaveFromListFn func(listID, name, description string) (*domain.TaskTemplate, error)
+	GetByIDFn      func(id string) (*domain.TaskTemplate, error)
+	InstantiateFn  func(templateID string, variables map[string]string) (*domain.TaskList, []*domain.Task, error)
+}
+
+func (m *mockTemplateService) SaveFromList(listID, name, description string) (*domain.TaskTemplate, error) {
+	return m.SaveFromListFn(listID, name, description)
+}
+func (m *mockTemplateService) GetAll() ([]*domain.TaskTemplate, error) { return nil, nil }
+func (m *mockTemplateService) GetByID(id string) (*domain.TaskTemplate, error) {
+	return m.GetByIDFn(id)
+}
+func (m *mockTemplateService) Delete(string) error { return nil }
+func (m *mockTemplateService) Instantiate(templateID string, variables map[string]string, _ string) (*domain.TaskList, []*domain.Task, error) {
+	return m.InstantiateFn(templateID, variables)
+}
+
+func TestSaveListAsTemplate_Created(t *testing.T) {
+	app := newTestApp()
+	h := NewTemplateHandler(&mockTemplateService{
+		SaveFromListFn: func(listID, name, _ string) (*domain.TaskTemplate, error) {
+			return &domain.TaskTemplate{ID: "t", Name: name}, nil
+		},
+	})
+	app.Post("/lists/:id/template", h.SaveListAsTemplate)
+	req := httptest.NewRequest("POST", "/lists/1/template", strings.NewReader(`{"name":"Sprint"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Errorf("expected 201, got %d", resp.StatusCode)
+	}
+}
+
+func TestGetTemplate_NotFound(t *testing.T) {
+	app := newTestApp()
+	h := NewTemplateHandler(&mockTemplateService{
+		GetByIDFn: func(string) (*domain.TaskTemplate, error) { return nil, domain.ErrTemplateNotFound },
+	})
+	app.Get("/templates/:id", h.GetTemplate)
+	resp, err := app.Test(httptest.NewRequest("GET", "/templates/1", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestInstantiateTemplate_PassesVariables(t *testing.T) {
+	app := newTestApp()
+	var got map[string]string
+	h := NewTemplateHandler(&mockTemplateService{
+		InstantiateFn: func(_ string, variables map[string]string) (*domain.TaskList, []*domain.Task, error) {
+			got = variables
+			return &domain.TaskList{ID: "l"}, []*domain.Task{{ID: "1", ListID: "l"}}, nil
+		},
+	})
+	app.Post("/templates/:id/instantiate", h.InstantiateTemplate)
+	req := httptest.NewRequest("POST", "/templates/t/instantiate", strings.NewReader(`{"variables":{"name":"Ana"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Errorf("expected 201, got %d", resp.StatusCode)
+	}
+	if got["name"] != "Ana" {
+		t.Errorf("expected variables to be passed, got %v", got)
+	}
+}
+
+func TestInstantiateTemplate_MissingVariables(t *testing.T) {
+	app := newTestApp()
+	h := NewTemplateHandler(&mockTemplateService{
+		InstantiateFn: func(string, map[string]string) (*domain.TaskList, []*domain.Task, error) {
+			return nil, nil, domain.NewInvalidError("variables", "missing_template_variables", "missing template variables: name")
+		},
+	})
+	app.Post("/templates/:id/instantiate", h.InstantiateTemplate)
+	resp, err := app.Test(httptest.NewRequest("POST", "/templates/t/instantiate", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected 400, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,27 @@
+package http
+
+import "time"
+
+// TrashedTaskResponse represents a soft deleted task in the trash listing.
+type TrashedTaskResponse struct {
+	ID        string    `json:"id"`
+	ListID    string    `json:"list_id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	Priority  string    `json:"priority"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// TrashedTaskListResponse represents a soft deleted task list in the trash listing.
+type TrashedTaskListResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	DeletedAt   time.Time `json:"deleted_at"`
+}
+
+// TrashResponse represents the response body for the trash listing.
+type TrashResponse struct {
+	Lists []TrashedTaskListResponse `json:"lists"`
+	Tasks []TrashedTaskResponse     `json:"tasks"`
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+)
+
+// TrashService define la interfaz para operaciones sobre la papelera.
+type TrashService interface {
+	GetDeletedTasks() ([]*domain.Task, error)
+	GetDeletedLists() ([]*domain.TaskList, error)
+	RestoreTask(id, changedBy string) error
+	RestoreList(id, changedBy string) error
+}
+
+// TrashHandler maneja las solicitudes HTTP de la papelera.
+type TrashHandler struct {
+	service TrashService
+}
+
+// NewTrashHandler creates a new TrashHandler instance.
+func NewTrashHandler(service TrashService) *TrashHandler {
+	return &TrashHandler{
+		service: service,
+	}
+}
+
+// GetTrash lists all soft deleted task lists and tasks.
+func (h *TrashHandler) GetTrash(c *fiber.Ctx) error {
+	lists, err := h.service.GetDeletedLists()
+	if err != nil {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"layer":  "handler",
+			"method": "GetTrash",
+			"error":  err.Error(),
+		}).Error("Failed to get deleted lists")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get trash")
+	}
+
+	tasks, err := h.service.GetDeletedTasks()
+	if err != nil {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"layer":  "handler",
+			"method": "GetTrash",
+			"error":  err.Error(),
+		}).Error("Failed to get deleted tasks")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get trash")
+	}
+
+	response := TrashResponse{
+		Lists: make([]TrashedTaskListResponse, 0, len(lists)),
+		Tasks: make([]TrashedTaskResponse, 0, len(tasks)),
+	}
+	for _, l := range lists {
+		item := TrashedTaskListResponse{
+			ID:          l.ID,
+			Name:        l.Name,
+			Description: l.Description,
+		}
+		if l.DeletedAt != nil {
+			item.DeletedAt = *l.DeletedAt
+		}
+		response.Lists = append(response.Lists, item)
+	}
+	for _, t := range tasks {
+		item := TrashedTaskResponse{
+			ID:       t.ID,
+			ListID:   t.ListID,
+			Title:    t.Title,
+			Status:   t.Status,
+			Priority: t.Priority,
+		}
+		if t.DeletedAt != nil {
+			item.DeletedAt = *t.DeletedAt
+		}
+		response.Tasks = append(response.Tasks, item)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// RestoreTask restores a task from the trash.
+func (h *TrashHandler) RestoreTask(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.service.RestoreTask(id, CurrentUserID(c)); err != nil {
+		return useCaseError(c, "RestoreTask", err, "Failed to restore task")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// RestoreTaskList restores a task list and the tasks deleted with it.
+func (h *TrashHandler) RestoreTaskList(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	if err := h.service.RestoreList(id, CurrentUserID(c)); err != nil {
+		return useCaseError(c, "RestoreTaskList", err, "Failed to restore task list")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockTrashService struct {
+	GetDeletedTasksFn func() ([]*domain.Task, error)
+	GetDeletedListsFn func() ([]*domain.TaskList, error)
+	RestoreTaskFn     func(id string) error
+	RestoreListFn     func(id string) error
+}
+
+func (m *mockTrashService) GetDeletedTasks() ([]*domain.Task, error) {
+	if m.GetDeletedTasksFn != nil {
+		return m.GetDeletedTasksFn()
+	}
+	return nil, nil
+}
+func (m *mockTrashService) GetDeletedLists() ([]*domain.TaskList, error) {
+	if m.GetDeletedListsFn != nil {
+		return m.GetDeletedListsFn()
+	}
+	return nil, nil
+}
+func (m *mockTrashService) RestoreTask(id, _ string) error {
+	if m.RestoreTaskFn != nil {
+		return m.RestoreTaskFn(id)
+	}
+	return nil
+}
+func (m *mockTrashService) RestoreList(id, _ string) error {
+	if m.RestoreListFn != nil {
+		return m.RestoreListFn(id)
+	}
+	return nil
+}
+
+func TestGetTrash_Success(t *testing.T) {
+	app := newTestApp()
+	now := time.Now()
+	h := NewTrashHandler(&mockTrashService{
+		GetDeletedListsFn: func() ([]*domain.TaskList, error) {
+			return []*domain.TaskList{{ID: "l1", Name: "L", DeletedAt: &now}}, nil
+		},
+		GetDeletedTasksFn: func() ([]*domain.Task, error) {
+			return []*domain.Task{{ID: "t1", ListID: "l1", DeletedAt: &now}, {ID: "t2", ListID: "l1", DeletedAt: &now}}, nil
+		},
+	})
+	app.Get("/trash", h.GetTrash)
+	resp, err := app.Test(httptest.NewRequest("GET", "/trash", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var body TrashResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("error decoding body: %v", err)
+	}
+	if len(body.Lists) != 1 || len(body.Tasks) != 2 {
+		t.Errorf("expected 1 list and 2 tasks, got %d and %d", len(body.Lists), len(body.Tasks))
+	}
+}
+
+func TestGetTrash_Error(t *testing.T) {
+	app := newTestApp()
+	h := NewTrashHandler(&mockTrashService{
+		GetDeletedListsFn: func() ([]*domain.TaskList, error) { return nil, errors.New("db error") },
+	})
+	app.Get("/trash", h.GetTrash)
+	resp, err := app.Test(httptest.NewRequest("GET", "/trash", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", resp.StatusCode)
+	}
+}
+
+func TestRestoreTask_ListDeleted(t *testing.T) {
+	app := newTestApp()
+	h := NewTrashHandler(&mockTrashService{
+		RestoreTaskFn: func(id string) error { return domain.ErrTaskListDeleted },
+	})
+	app.Post("/trash/tasks/:id/restore", h.RestoreTask)
+	resp, err := app.Test(httptest.NewRequest("POST", "/trash/tasks/1/restore", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("expected 409, got %d", resp.StatusCode)
+	}
+}
+
+func TestRestoreTaskList_NotFound(t *testing.T) {
+	app := newTestApp()
+	h := NewTrashHandler(&mockTrashService{
+		RestoreListFn: func(id string) error { return domain.ErrTaskListNotInTrash },
+	})
+	app.Post("/trash/lists/:id/restore", h.RestoreTaskList)
+	resp, err := app.Test(httptest.NewRequest("POST", "/trash/lists/1/restore", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestRestoreTaskList_Success(t *testing.T) {
+	app := newTestApp()
+	h := NewTrashHandler(&mockTrashService{})
+	app.Post("/trash/lists/:id/restore", h.RestoreTaskList)
+	resp, err := app.Test(httptest.NewRequest("POST", "/trash/lists/1/restore", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNoContent {
+		t.Errorf("expected 204, got %d", resp.StatusCode)
+	}
+}
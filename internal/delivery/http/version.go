@@ -0,0 +1,137 @@
+package http
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	apiV1 = "v1"
+	apiV2 = "v2"
+
+	apiVersionLocal = "apiVersion"
+	// vendorMediaTypePrefix is followed by the version and +json in the Accept header of an
+	// unversioned request, e.g. application/vnd.tasks.v2+json.
+	vendorMediaTypePrefix = "application/vnd.tasks."
+
+	httpDateFormat = "Mon, 02 Jan 2006 15:04:05 GMT"
+)
+
+// APIVersioning holds the dates announced on the responses of the deprecated v1 routes.
+// A zero date omits its header.
+type APIVersioning struct {
+	// V1DeprecatedAt is sent in the Deprecation header (RFC 9745).
+	V1DeprecatedAt time.Time
+	// V1Sunset is when v1 stops being served, sent in the Sunset header (RFC 8594).
+	V1Sunset time.Time
+}
+
+// Envelope wraps a resource in the v2 responses.
+type Envelope struct {
+	Data interface{} `json:"data"`
+}
+
+// apiVersion returns the API version a request is served with, v1 unless a version route
+// or the Accept header chose another one. Before the version handler of the route runs,
+// e.g. when a middleware rejects the request, it is the version of the path.
+func apiVersion(c *fiber.Ctx) string {
+	if version, ok := c.Locals(apiVersionLocal).(string); ok {
+		return version
+	}
+	if path := strings.ToLower(c.Path()); path == "/api/"+apiV2 || strings.HasPrefix(path, "/api/"+apiV2+"/") {
+		return apiV2
+	}
+	return apiV1
+}
+
+// sendResource writes a resource in the representation of the API version of the
+// request: as is in v1 and wrapped in an Envelope in v2.
+func sendResource(c *fiber.Ctx, status int, resource interface{}) error {
+	if apiVersion(c) == apiV2 {
+		return c.Status(status).JSON(Envelope{Data: resource})
+	}
+	return c.Status(status).JSON(resource)
+}
+
+// negotiate chooses the version of a request to an unversioned route from its Accept
+// header. It responds 406 when the header only asks for versions that do not exist.
+func (v APIVersioning) negotiate(c *fiber.Ctx) error {
+	c.Vary(fiber.HeaderAccept)
+
+	version, err := acceptedVersion(c.Get(fiber.HeaderAccept))
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotAcceptable, err.Error())
+	}
+	if version == apiV2 {
+		return useV2(c)
+	}
+	return v.useV1(c)
+}
+
+// useV1 serves a request as v1 and announces its deprecation and successor.
+func (v APIVersioning) useV1(c *fiber.Ctx) error {
+	c.Locals(apiVersionLocal, apiV1)
+
+	if !v.V1DeprecatedAt.IsZero() {
+		c.Set("Deprecation", "@"+strconv.FormatInt(v.V1DeprecatedAt.Unix(), 10))
+	}
+	if !v.V1Sunset.IsZero() {
+		c.Set("Sunset", v.V1Sunset.UTC().Format(httpDateFormat))
+	}
+	c.Append(fiber.HeaderLink, "<"+successorPath(c.Path())+`>; rel="successor-version"`)
+
+	return c.Next()
+}
+
+// useV2 serves a request as v2.
+func useV2(c *fiber.Ctx) error {
+	c.Locals(apiVersionLocal, apiV2)
+	return c.Next()
+}
+
+// successorPath returns the v2 path of a v1 path, versioned or not.
+func successorPath(path string) string {
+	rest, ok := strings.CutPrefix(path, "/api/v1")
+	if !ok {
+		rest = strings.TrimPrefix(path, "/api")
+	}
+	return "/api/" + apiV2 + rest
+}
+
+// acceptedVersion returns the version asked for by the vendor media types of an Accept
+// header, or an empty string when it has none. Versions that do not exist are skipped, and
+// are an error only when no other acceptable version or media type is listed.
+func acceptedVersion(accept string) (string, error) {
+	if accept == "" {
+		return "", nil
+	}
+
+	unsupported := ""
+	otherTypes := false
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, _ := strings.Cut(part, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+		version, ok := strings.CutPrefix(mediaType, vendorMediaTypePrefix)
+		if !ok {
+			otherTypes = otherTypes || mediaType != ""
+			continue
+		}
+		version = strings.TrimSuffix(version, "+json")
+		switch version {
+		case apiV1, apiV2:
+			return version, nil
+		default:
+			unsupported = version
+		}
+	}
+
+	if unsupported != "" && !otherTypes {
+		return "", errors.New("unsupported API version: " + unsupported)
+	}
+	return "", nil
+}
@@ -0,0 +1,260 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func newVersionedApp(t *testing.T) (*fiber.App, string) {
+	t.Helper()
+	tasks := NewTaskHandler(&mockTaskService{
+		GetByIDFn: func(id string) (*domain.Task, error) {
+			if id == "missing" {
+				return nil, domain.ErrTaskNotFound
+			}
+			return &domain.Task{ID: id, Title: "Tarea", Status: "pending", Priority: "medium", Version: 1}, nil
+		},
+	})
+	versioning := APIVersioning{
+		V1DeprecatedAt: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		V1Sunset:       time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC),
+	}
+
+	app := newTestApp()
+	RegisterRoutes(app, tasks, NewTaskListHandler(nil), versioning)
+
+	token, err := GenerateJWT("user-1")
+	if err != nil {
+		t.Fatalf("error generando token: %v", err)
+	}
+	return app, token
+}
+
+func TestRegisterRoutes_Versions(t *testing.T) {
+	app, token := newVersionedApp(t)
+
+	cases := []struct {
+		name           string
+		path           string
+		accept         string
+		wantStatus     int
+		wantEnvelope   bool
+		wantDeprecated bool
+		wantLink       string
+	}{
+		{"unversioned", "/api/tasks/t1", "", fiber.StatusOK, false, true, `</api/v2/tasks/t1>; rel="successor-version"`},
+		{"v1 path", "/api/v1/tasks/t1", "", fiber.StatusOK, false, true, `</api/v2/tasks/t1>; rel="successor-version"`},
+		{"v2 path", "/api/v2/tasks/t1", "", fiber.StatusOK, true, false, ""},
+		{"v2 Accept", "/api/tasks/t1", "application/vnd.tasks.v2+json", fiber.StatusOK, true, false, ""},
+		{"v1 Accept", "/api/tasks/t1", "application/vnd.tasks.v1+json, application/json;q=0.5", fiber.StatusOK, false, true, `</api/v2/tasks/t1>; rel="successor-version"`},
+		{"unknown version with fallback", "/api/tasks/t1", "application/vnd.tasks.v9+json, application/json", fiber.StatusOK, false, true, `</api/v2/tasks/t1>; rel="successor-version"`},
+		{"unknown version", "/api/tasks/t1", "application/vnd.tasks.v9+json", fiber.StatusNotAcceptable, false, false, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.path, http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+token)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("error ejecutando app.Test: %v", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+
+			deprecation, sunset := resp.Header.Get("Deprecation"), resp.Header.Get("Sunset")
+			if tc.wantDeprecated {
+				if deprecation != "@1792368000" || sunset != "Fri, 30 Apr 2027 00:00:00 GMT" {
+					t.Errorf("unexpected deprecation headers: Deprecation=%q Sunset=%q", deprecation, sunset)
+				}
+			} else if deprecation != "" || sunset != "" {
+				t.Errorf("expected no deprecation headers, got Deprecation=%q Sunset=%q", deprecation, sunset)
+			}
+			if link := resp.Header.Get("Link"); link != tc.wantLink {
+				t.Errorf("expected Link %q, got %q", tc.wantLink, link)
+			}
+			if resp.StatusCode != fiber.StatusOK {
+				return
+			}
+
+			var body map[string]interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+			task := body
+			if tc.wantEnvelope {
+				data, ok := body["data"].(map[string]interface{})
+				if !ok {
+					t.Fatalf("expected the task under data, got %v", body)
+				}
+				task = data
+			}
+			if task["id"] != "t1" {
+				t.Errorf("expected task t1, got %v", body)
+			}
+		})
+	}
+}
+
+// v1 keeps the error body it had before problem details; only v2 returns them.
+func TestRegisterRoutes_ErrorShapes(t *testing.T) {
+	app, token := newVersionedApp(t)
+
+	cases := []struct {
+		name        string
+		path        string
+		accept      string
+		token       bool
+		wantStatus  int
+		wantProblem bool
+		wantLegacy  string
+	}{
+		{"unversioned", "/api/tasks/missing", "", true, fiber.StatusNotFound, false, `{"error":"task not found"}`},
+		{"v1 path", "/api/v1/tasks/missing", "", true, fiber.StatusNotFound, false, `{"error":"task not found"}`},
+		{"v1 without token", "/api/v1/tasks/t1", "", false, fiber.StatusUnauthorized, false, `{"error":"Missing or invalid Authorization header"}`},
+		{"unknown version", "/api/tasks/t1", "application/vnd.tasks.v9+json", true, fiber.StatusNotAcceptable, false, `{"error":"unsupported API version: v9"}`},
+		{"v2 Accept", "/api/tasks/missing", "application/vnd.tasks.v2+json", true, fiber.StatusNotFound, true, ""},
+		{"v2 path", "/api/v2/tasks/missing", "", true, fiber.StatusNotFound, true, ""},
+		{"v2 without token", "/api/v2/tasks/t1", "", false, fiber.StatusUnauthorized, true, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.path, http.NoBody)
+			if tc.token {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("error ejecutando app.Test: %v", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+			raw, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			contentType := resp.Header.Get(fiber.HeaderContentType)
+			if !tc.wantProblem {
+				if contentType != fiber.MIMEApplicationJSON || string(raw) != tc.wantLegacy {
+					t.Errorf("expected %s, got %q with %s", tc.wantLegacy, contentType, raw)
+				}
+				return
+			}
+			var problem Problem
+			if err := json.Unmarshal(raw, &problem); err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+			if contentType != problemContentType || problem.Status != tc.wantStatus || problem.Code == "" {
+				t.Errorf("expected a problem, got %q with %s", contentType, raw)
+			}
+		})
+	}
+}
+
+func TestRegisterRoutes_VaryAccept(t *testing.T) {
+	app, token := newVersionedApp(t)
+
+	req := httptest.NewRequest("GET", "/api/tasks/t1", http.NoBody)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if vary := resp.Header.Get("Vary"); vary != "Accept" {
+		t.Errorf("expected Vary: Accept, got %q", vary)
+	}
+}
+
+func TestSuccessorPath(t *testing.T) {
+	cases := map[string]string{
+		"/api/tasks":             "/api/v2/tasks",
+		"/api/v1/lists/l1":       "/api/v2/lists/l1",
+		"/api/lists/l1/tasks/t1": "/api/v2/lists/l1/tasks/t1",
+	}
+	for path, want := range cases {
+		if got := successorPath(path); got != want {
+			t.Errorf("%s: expected %s, got %s", path, want, got)
+		}
+	}
+}
+
+// The route groups other than tasks and lists are not versioned: they are only served
+// under /api and their responses ignore the version asked for in Accept.
+func TestUnversionedRouteGroups(t *testing.T) {
+	all := newTestApp()
+	RegisterRoutes(all, nil, nil, APIVersioning{})
+	registerRouteGroups(all)
+	for _, route := range all.GetRoutes(true) {
+		for _, prefix := range []string{"/api/" + apiV1 + "/", "/api/" + apiV2 + "/"} {
+			if rest, ok := strings.CutPrefix(route.Path, prefix); ok && !strings.HasPrefix(rest, "tasks") && !strings.HasPrefix(rest, "lists") {
+				t.Errorf("unexpected versioned route %s %s", route.Method, route.Path)
+			}
+		}
+	}
+
+	app, token := newVersionedApp(t)
+	RegisterTrashRoutes(app, NewTrashHandler(&mockTrashService{}))
+
+	cases := []struct {
+		name       string
+		path       string
+		accept     string
+		wantStatus int
+	}{
+		{"unversioned", "/api/trash", "", fiber.StatusOK},
+		{"v2 Accept", "/api/trash", "application/vnd.tasks.v2+json", fiber.StatusOK},
+		{"unknown version", "/api/trash", "application/vnd.tasks.v9+json", fiber.StatusOK},
+		{"v1 path", "/api/v1/trash", "", fiber.StatusNotFound},
+		{"v2 path", "/api/v2/trash", "", fiber.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.path, http.NoBody)
+			req.Header.Set("Authorization", "Bearer "+token)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("error ejecutando app.Test: %v", err)
+			}
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d", tc.wantStatus, resp.StatusCode)
+			}
+			if resp.StatusCode != fiber.StatusOK {
+				return
+			}
+
+			if deprecation, link := resp.Header.Get("Deprecation"), resp.Header.Get("Link"); deprecation != "" || link != "" {
+				t.Errorf("expected no deprecation headers, got Deprecation=%q Link=%q", deprecation, link)
+			}
+			var body map[string]interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+			if _, ok := body["lists"]; !ok {
+				t.Errorf("expected the trash without an envelope, got %v", body)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// ViewRequest represents the request body for creating or updating a saved view.
+type ViewRequest struct {
+	Name    string   `json:"name"`
+	ListID  string   `json:"list_id"`
+	Filter  string   `json:"filter"`
+	Sort    string   `json:"sort"`
+	GroupBy string   `json:"group_by"`
+	Fields  []string `json:"fields"`
+}
+
+// ViewResponse represents the response body for a saved view.
+type ViewResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   string    `json:"owner_id"`
+	ListID    string    `json:"list_id,omitempty"`
+	Shared    bool      `json:"shared"`
+	Filter    string    `json:"filter"`
+	Sort      string    `json:"sort"`
+	GroupBy   string    `json:"group_by,omitempty"`
+	Fields    []string  `json:"fields"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// toSavedView maps the request body to the view definition passed to the service.
+func (r ViewRequest) toSavedView() *domain.SavedView {
+	return &domain.SavedView{
+		Name:    r.Name,
+		ListID:  r.ListID,
+		Filter:  r.Filter,
+		Sort:    r.Sort,
+		GroupBy: r.GroupBy,
+		Fields:  r.Fields,
+	}
+}
+
+// newViewResponse maps a domain saved view to its response body.
+func newViewResponse(v *domain.SavedView) ViewResponse {
+	fields := v.Fields
+	if fields == nil {
+		fields = []string{}
+	}
+
+	return ViewResponse{
+		ID:        v.ID,
+		Name:      v.Name,
+		OwnerID:   v.OwnerID,
+		ListID:    v.ListID,
+		Shared:    v.ListID != "",
+		Filter:    v.Filter,
+		Sort:      v.Sort,
+		GroupBy:   v.GroupBy,
+		Fields:    fields,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+	}
+}
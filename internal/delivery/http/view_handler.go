@@ -0,0 +1,163 @@
+package http
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// ViewService define la interfaz para operaciones de vistas guardadas.
+type ViewService interface {
+	Create(userID string, input *domain.SavedView) (*domain.SavedView, error)
+	GetVisible(userID, listID string) ([]*domain.SavedView, error)
+	GetByID(id, userID string) (*domain.SavedView, error)
+	Update(id, userID string, input *domain.SavedView) (*domain.SavedView, error)
+	Delete(id, userID string) error
+	Run(view *domain.SavedView, page domain.PageRequest) (*domain.Page[*domain.Task], error)
+}
+
+// ViewHandler maneja las solicitudes HTTP de vistas guardadas.
+type ViewHandler struct {
+	service ViewService
+}
+
+// NewViewHandler creates a new ViewHandler instance.
+func NewViewHandler(service ViewService) *ViewHandler {
+	return &ViewHandler{
+		service: service,
+	}
+}
+
+// CreateView saves a new view owned by the authenticated user.
+func (h *ViewHandler) CreateView(c *fiber.Ctx) error {
+	var req ViewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+	if err := checkViewFields(req.Fields); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	v, err := h.service.Create(CurrentUserID(c), req.toSavedView())
+	if err != nil {
+		return useCaseError(c, "CreateView", err, "Failed to create view")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(newViewResponse(v))
+}
+
+// GetViews retrieves the views of the authenticated user and the views shared with lists,
+// only those shared with one list when list_id is given.
+func (h *ViewHandler) GetViews(c *fiber.Ctx) error {
+	views, err := h.service.GetVisible(CurrentUserID(c), c.Query("list_id"))
+	if err != nil {
+		return useCaseError(c, "GetViews", err, "Failed to get views")
+	}
+
+	responses := make([]ViewResponse, len(views))
+	for i, v := range views {
+		responses[i] = newViewResponse(v)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses)
+}
+
+// GetView retrieves a saved view by its ID.
+func (h *ViewHandler) GetView(c *fiber.Ctx) error {
+	v, err := h.service.GetByID(c.Params("id"), CurrentUserID(c))
+	if err != nil {
+		return useCaseError(c, "GetView", err, "Failed to get view")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newViewResponse(v))
+}
+
+// UpdateView replaces the definition of a view owned by the authenticated user.
+func (h *ViewHandler) UpdateView(c *fiber.Ctx) error {
+	var req ViewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+	if err := checkViewFields(req.Fields); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	v, err := h.service.Update(c.Params("id"), CurrentUserID(c), req.toSavedView())
+	if err != nil {
+		return useCaseError(c, "UpdateView", err, "Failed to update view")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newViewResponse(v))
+}
+
+// DeleteView removes a view owned by the authenticated user.
+func (h *ViewHandler) DeleteView(c *fiber.Ctx) error {
+	if err := h.service.Delete(c.Params("id"), CurrentUserID(c)); err != nil {
+		return useCaseError(c, "DeleteView", err, "Failed to delete view")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetViewTasks runs a saved view and returns one page of its tasks in the same shape as
+// GetTasks. The view's sort and fields apply unless sort or fields are given.
+func (h *ViewHandler) GetViewTasks(c *fiber.Ctx) error {
+	v, err := h.service.GetByID(c.Params("id"), CurrentUserID(c))
+	if err != nil {
+		return useCaseError(c, "GetViewTasks", err, "Failed to run view")
+	}
+
+	fields := v.Fields
+	if c.Query("fields") != "" {
+		if fields, err = parseFields(c, taskFields); err != nil {
+			return err
+		}
+	}
+	if len(fields) == 0 {
+		fields = nil
+	}
+
+	defaultSort := v.Sort
+	if defaultSort == "" {
+		defaultSort = "-created_at"
+	}
+
+	page, err := parsePageRequest(c, defaultSort)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.service.Run(v, page)
+	if err != nil {
+		return useCaseError(c, "GetViewTasks", err, "Failed to run view")
+	}
+
+	responses := make([]TaskResponse, len(result.Items))
+	for i, t := range result.Items {
+		responses[i] = newTaskResponse(t)
+	}
+
+	data, err := selectFields(responses, fields)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to run view")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(PageResponse{
+		Data:       data,
+		NextCursor: encodeCursor(c.Query("sort", defaultSort), result.Next),
+		Total:      result.Total,
+	})
+}
+
+// checkViewFields verifies that the fields of a view are task fields.
+func checkViewFields(fields []string) error {
+	for _, field := range fields {
+		if !taskFields[field] {
+			return errors.New("unknown field: " + field)
+		}
+	}
+
+	return nil
+}
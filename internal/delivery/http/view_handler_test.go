@@ -0,0 +1,146 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockViewService struct {
+	CreateFn     func(userID string, input *domain.SavedView) (*domain.SavedView, error)
+	GetVisibleFn func(userID, listID string) ([]*domain.SavedView, error)
+	GetByIDFn    func(id, userID string) (*domain.SavedView, error)
+	UpdateFn     func(id, userID string, input *domain.SavedView) (*domain.SavedView, error)
+	DeleteFn     func(id, userID string) error
+	RunFn        func(view *domain.SavedView, page domain.PageRequest) (*domain.Page[*domain.Task], error)
+}
+
+func (m *mockViewService) Create(userID string, input *domain.SavedView) (*domain.SavedView, error) {
+	return m.CreateFn(userID, input)
+}
+func (m *mockViewService) GetVisible(userID, listID string) ([]*domain.SavedView, error) {
+	return m.GetVisibleFn(userID, listID)
+}
+func (m *mockViewService) GetByID(id, userID string) (*domain.SavedView, error) {
+	return m.GetByIDFn(id, userID)
+}
+func (m *mockViewService) Update(id, userID string, input *domain.SavedView) (*domain.SavedView, error) {
+	return m.UpdateFn(id, userID, input)
+}
+func (m *mockViewService) Delete(id, userID string) error { return m.DeleteFn(id, userID) }
+func (m *mockViewService) Run(view *domain.SavedView, page domain.PageRequest) (*domain.Page[*domain.Task], error) {
+	return m.RunFn(view, page)
+}
+
+func TestCreateView_UnknownField(t *testing.T) {
+	app := newTestApp()
+	h := NewViewHandler(&mockViewService{})
+	app.Post("/views", h.CreateView)
+	req := httptest.NewRequest("POST", "/views", strings.NewReader(`{"name":"v","fields":["title","secret"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestCreateView_InvalidFilter(t *testing.T) {
+	app := newTestApp()
+	h := NewViewHandler(&mockViewService{
+		CreateFn: func(string, *domain.SavedView) (*domain.SavedView, error) {
+			return nil, domain.NewInvalidError("filter", "invalid_filter", `invalid filter at position 1 near "owner": unknown field`)
+		},
+	})
+	app.Post("/views", h.CreateView)
+	req := httptest.NewRequest("POST", "/views", strings.NewReader(`{"name":"v","filter":"owner = me"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("expected 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestUpdateView_NotOwned(t *testing.T) {
+	app := newTestApp()
+	h := NewViewHandler(&mockViewService{
+		UpdateFn: func(string, string, *domain.SavedView) (*domain.SavedView, error) {
+			return nil, domain.ErrViewNotOwned
+		},
+	})
+	app.Put("/views/:id", h.UpdateView)
+	req := httptest.NewRequest("PUT", "/views/v1", strings.NewReader(`{"name":"v"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Errorf("expected 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestGetViewTasks_UsesViewSortAndFields(t *testing.T) {
+	app := newTestApp()
+	var gotPage domain.PageRequest
+	h := NewViewHandler(&mockViewService{
+		GetByIDFn: func(id, userID string) (*domain.SavedView, error) {
+			return &domain.SavedView{ID: id, Name: "v", Sort: "-priority", Fields: []string{"title"}}, nil
+		},
+		RunFn: func(_ *domain.SavedView, page domain.PageRequest) (*domain.Page[*domain.Task], error) {
+			gotPage = page
+			return &domain.Page[*domain.Task]{
+				Items: []*domain.Task{{ID: "1", Title: "A", Status: "pending"}},
+				Next:  &domain.Cursor{Value: "3", ID: "1"},
+			}, nil
+		},
+	})
+	app.Get("/views/:id/tasks", h.GetViewTasks)
+	resp, err := app.Test(httptest.NewRequest("GET", "/views/v1/tasks?limit=1", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if gotPage.Sort != "priority" || !gotPage.Desc || gotPage.Limit != 1 {
+		t.Errorf("expected the view sort, got %+v", gotPage)
+	}
+
+	var body struct {
+		Data       []map[string]interface{} `json:"data"`
+		NextCursor *string                  `json:"next_cursor"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	if len(body.Data) != 1 || len(body.Data[0]) != 2 || body.NextCursor == nil {
+		t.Errorf("expected id and title with a next cursor, got %+v", body)
+	}
+}
+
+func TestGetViewTasks_NotFound(t *testing.T) {
+	app := newTestApp()
+	h := NewViewHandler(&mockViewService{
+		GetByIDFn: func(string, string) (*domain.SavedView, error) { return nil, domain.ErrViewNotFound },
+	})
+	app.Get("/views/:id/tasks", h.GetViewTasks)
+	resp, err := app.Test(httptest.NewRequest("GET", "/views/v1/tasks", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected 404, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// WebhookRequest represents the request body for creating or updating a webhook.
+type WebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	ListID string   `json:"list_id"`
+	Secret string   `json:"secret"`
+	Active *bool    `json:"active" openapi:"nullable"`
+}
+
+// WebhookResponse represents the response body for a webhook. The secret is only included
+// when the webhook is created.
+type WebhookResponse struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	ListID    string    `json:"list_id,omitempty"`
+	Active    bool      `json:"active"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookDeliveryResponse represents a delivery in the delivery log of a webhook.
+type WebhookDeliveryResponse struct {
+	ID             string     `json:"id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
+
+// WebhookAttemptResponse represents one attempt to send a delivery.
+type WebhookAttemptResponse struct {
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookDeliveryDetailResponse represents a delivery with its payload and the log of its attempts.
+type WebhookDeliveryDetailResponse struct {
+	WebhookDeliveryResponse
+	Payload    json.RawMessage          `json:"payload"`
+	AttemptLog []WebhookAttemptResponse `json:"attempt_log"`
+}
+
+// toWebhook maps the request body to the webhook settings passed to the service. A
+// webhook is active unless the request says otherwise.
+func (r WebhookRequest) toWebhook() *domain.WebhookSubscription {
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+
+	return &domain.WebhookSubscription{
+		URL:        r.URL,
+		EventTypes: r.Events,
+		ListID:     r.ListID,
+		Secret:     r.Secret,
+		Active:     active,
+	}
+}
+
+// newWebhookResponse maps a domain webhook to its response body, without its secret.
+func newWebhookResponse(w *domain.WebhookSubscription) WebhookResponse {
+	events := w.EventTypes
+	if events == nil {
+		events = []string{}
+	}
+
+	return WebhookResponse{
+		ID:        w.ID,
+		URL:       w.URL,
+		Events:    events,
+		ListID:    w.ListID,
+		Active:    w.Active,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+// newWebhookDeliveryResponse maps a domain delivery to its response body.
+func newWebhookDeliveryResponse(d *domain.WebhookDelivery) WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+	if d.Status == domain.DeliveryPending {
+		next := d.NextAttemptAt
+		response.NextAttemptAt = &next
+	}
+
+	return response
+}
+
+// newWebhookDeliveryDetailResponse maps a domain delivery and its attempts to a response body.
+func newWebhookDeliveryDetailResponse(d *domain.WebhookDelivery, attempts []*domain.WebhookAttempt) WebhookDeliveryDetailResponse {
+	log := make([]WebhookAttemptResponse, len(attempts))
+	for i, a := range attempts {
+		log[i] = WebhookAttemptResponse{
+			Attempt:    a.Attempt,
+			StatusCode: a.StatusCode,
+			Error:      a.Error,
+			DurationMS: a.Duration.Milliseconds(),
+			CreatedAt:  a.CreatedAt,
+		}
+	}
+
+	return WebhookDeliveryDetailResponse{
+		WebhookDeliveryResponse: newWebhookDeliveryResponse(d),
+		Payload:                 d.Payload,
+		AttemptLog:              log,
+	}
+}
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// WebhookService define la interfaz para operaciones de webhooks.
+type WebhookService interface {
+	CreateSubscription(userID string, input *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	GetSubscriptions(userID string) ([]*domain.WebhookSubscription, error)
+	GetSubscription(id, userID string) (*domain.WebhookSubscription, error)
+	UpdateSubscription(id, userID string, input *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	DeleteSubscription(id, userID string) error
+	GetDeliveries(id, userID, status string) ([]*domain.WebhookDelivery, error)
+	GetDelivery(id, userID, deliveryID string) (*domain.WebhookDelivery, []*domain.WebhookAttempt, error)
+	Redeliver(id, userID, deliveryID string) (*domain.WebhookDelivery, error)
+}
+
+// WebhookHandler maneja las solicitudes HTTP de webhooks.
+type WebhookHandler struct {
+	service WebhookService
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance.
+func NewWebhookHandler(service WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+	}
+}
+
+// CreateWebhook subscribes a URL to events on behalf of the authenticated user. The
+// response is the only one that includes the secret used to sign deliveries.
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	var req WebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	w, err := h.service.CreateSubscription(CurrentUserID(c), req.toWebhook())
+	if err != nil {
+		return useCaseError(c, "CreateWebhook", err, "Failed to create webhook")
+	}
+
+	response := newWebhookResponse(w)
+	response.Secret = w.Secret
+	return c.Status(fiber.StatusCreated).JSON(response)
+}
+
+// GetWebhooks retrieves the webhooks of the authenticated user.
+func (h *WebhookHandler) GetWebhooks(c *fiber.Ctx) error {
+	webhooks, err := h.service.GetSubscriptions(CurrentUserID(c))
+	if err != nil {
+		return useCaseError(c, "GetWebhooks", err, "Failed to get webhooks")
+	}
+
+	responses := make([]WebhookResponse, len(webhooks))
+	for i, w := range webhooks {
+		responses[i] = newWebhookResponse(w)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses)
+}
+
+// GetWebhook retrieves a webhook by its ID.
+func (h *WebhookHandler) GetWebhook(c *fiber.Ctx) error {
+	w, err := h.service.GetSubscription(c.Params("id"), CurrentUserID(c))
+	if err != nil {
+		return useCaseError(c, "GetWebhook", err, "Failed to get webhook")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newWebhookResponse(w))
+}
+
+// UpdateWebhook replaces the settings of a webhook. The secret is kept unless a new one is given.
+func (h *WebhookHandler) UpdateWebhook(c *fiber.Ctx) error {
+	var req WebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	w, err := h.service.UpdateSubscription(c.Params("id"), CurrentUserID(c), req.toWebhook())
+	if err != nil {
+		return useCaseError(c, "UpdateWebhook", err, "Failed to update webhook")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newWebhookResponse(w))
+}
+
+// DeleteWebhook removes a webhook and its delivery log.
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	if err := h.service.DeleteSubscription(c.Params("id"), CurrentUserID(c)); err != nil {
+		return useCaseError(c, "DeleteWebhook", err, "Failed to delete webhook")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetWebhookDeliveries retrieves the most recent deliveries of a webhook, only those with
+// the given status when status is set.
+func (h *WebhookHandler) GetWebhookDeliveries(c *fiber.Ctx) error {
+	deliveries, err := h.service.GetDeliveries(c.Params("id"), CurrentUserID(c), c.Query("status"))
+	if err != nil {
+		return useCaseError(c, "GetWebhookDeliveries", err, "Failed to get webhook deliveries")
+	}
+
+	responses := make([]WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		responses[i] = newWebhookDeliveryResponse(d)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(responses)
+}
+
+// GetWebhookDelivery retrieves a delivery with its payload and the log of its attempts.
+func (h *WebhookHandler) GetWebhookDelivery(c *fiber.Ctx) error {
+	d, attempts, err := h.service.GetDelivery(c.Params("id"), CurrentUserID(c), c.Params("deliveryId"))
+	if err != nil {
+		return useCaseError(c, "GetWebhookDelivery", err, "Failed to get webhook delivery")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(newWebhookDeliveryDetailResponse(d, attempts))
+}
+
+// RedeliverWebhookDelivery queues a delivery to be sent again, including dead letters.
+func (h *WebhookHandler) RedeliverWebhookDelivery(c *fiber.Ctx) error {
+	d, err := h.service.Redeliver(c.Params("id"), CurrentUserID(c), c.Params("deliveryId"))
+	if err != nil {
+		return useCaseError(c, "RedeliverWebhookDelivery", err, "Failed to redeliver webhook delivery")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(newWebhookDeliveryResponse(d))
+}
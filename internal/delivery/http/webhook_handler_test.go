@@ -0,0 +1,177 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockWebhookService struct {
+	CreateSubscriptionFn func(userID string, input *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	GetSubscriptionsFn   func(userID string) ([]*domain.WebhookSubscription, error)
+	GetSubscriptionFn    func(id, userID string) (*domain.WebhookSubscription, error)
+	UpdateSubscriptionFn func(id, userID string, input *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	DeleteSubscriptionFn func(id, userID string) error
+	GetDeliveriesFn      func(id, userID, status string) ([]*domain.WebhookDelivery, error)
+	GetDeliveryFn        func(id, userID, deliveryID string) (*domain.WebhookDelivery, []*domain.WebhookAttempt, error)
+	RedeliverFn          func(id, userID, deliveryID string) (*domain.WebhookDelivery, error)
+}
+
+func (m *mockWebhookService) CreateSubscription(userID string, input *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	return m.CreateSubscriptionFn(userID, input)
+}
+func (m *mockWebhookService) GetSubscriptions(userID string) ([]*domain.WebhookSubscription, error) {
+	return m.GetSubscriptionsFn(userID)
+}
+func (m *mockWebhookService) GetSubscription(id, userID string) (*domain.WebhookSubscription, error) {
+	return m.GetSubscriptionFn(id, userID)
+}
+func (m *mockWebhookService) UpdateSubscription(id, userID string, input *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	return m.UpdateSubscriptionFn(id, userID, input)
+}
+func (m *mockWebhookService) DeleteSubscription(id, userID string) error {
+	return m.DeleteSubscriptionFn(id, userID)
+}
+func (m *mockWebhookService) GetDeliveries(id, userID, status string) ([]*domain.WebhookDelivery, error) {
+	return m.GetDeliveriesFn(id, userID, status)
+}
+func (m *mockWebhookService) GetDelivery(id, userID, deliveryID string) (*domain.WebhookDelivery, []*domain.WebhookAttempt, error) {
+	return m.GetDeliveryFn(id, userID, deliveryID)
+}
+func (m *mockWebhookService) Redeliver(id, userID, deliveryID string) (*domain.WebhookDelivery, error) {
+	return m.RedeliverFn(id, userID, deliveryID)
+}
+
+func TestCreateWebhook_ReturnsSecret(t *testing.T) {
+	var input *domain.WebhookSubscription
+	app := newTestApp()
+	h := NewWebhookHandler(&mockWebhookService{
+		CreateSubscriptionFn: func(_ string, in *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+			input = in
+			return &domain.WebhookSubscription{ID: "w1", URL: in.URL, EventTypes: in.EventTypes, Secret: "s3cr3t-s3cr3t-s3cr3t", Active: in.Active}, nil
+		},
+	})
+	app.Post("/webhooks", h.CreateWebhook)
+	req := httptest.NewRequest("POST", "/webhooks", strings.NewReader(`{"url":"https://example.com/hook","events":["task.created"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	}
+	var body WebhookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("error decodificando respuesta: %v", err)
+	}
+	if body.Secret != "s3cr3t-s3cr3t-s3cr3t" || !body.Active || !input.Active {
+		t.Errorf("expected an active webhook with its secret, got %+v", body)
+	}
+}
+
+func TestGetWebhook_HidesSecret(t *testing.T) {
+	app := newTestApp()
+	h := NewWebhookHandler(&mockWebhookService{
+		GetSubscriptionFn: func(id, _ string) (*domain.WebhookSubscription, error) {
+			return &domain.WebhookSubscription{ID: id, URL: "https://example.com", Secret: "s3cr3t-s3cr3t-s3cr3t"}, nil
+		},
+	})
+	app.Get("/webhooks/:id", h.GetWebhook)
+	resp, err := app.Test(httptest.NewRequest("GET", "/webhooks/w1", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("error decodificando respuesta: %v", err)
+	}
+	if _, ok := body["secret"]; ok {
+		t.Errorf("expected no secret, got %v", body)
+	}
+}
+
+func TestWebhookHandler_Errors(t *testing.T) {
+	cases := map[error]int{
+		domain.ErrWebhookNotOwned: fiber.StatusForbidden,
+		domain.ErrWebhookNotFound: fiber.StatusNotFound,
+		domain.NewInvalidError("url", "invalid_url", "invalid url: must be http"):            fiber.StatusBadRequest,
+		domain.NewInvalidError("events", "invalid_event_type", "invalid event type: task.x"): fiber.StatusBadRequest,
+		domain.NewInvalidError("secret", "invalid_secret", "secret must be at least 16"):     fiber.StatusBadRequest,
+		errors.New("db error"): fiber.StatusInternalServerError,
+	}
+	for updateErr, expected := range cases {
+		app := newTestApp()
+		h := NewWebhookHandler(&mockWebhookService{
+			UpdateSubscriptionFn: func(string, string, *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+				return nil, updateErr
+			},
+		})
+		app.Put("/webhooks/:id", h.UpdateWebhook)
+		req := httptest.NewRequest("PUT", "/webhooks/w1", strings.NewReader(`{"url":"https://example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("error ejecutando app.Test: %v", err)
+		}
+		if resp.StatusCode != expected {
+			t.Errorf("%s: expected %d, got %d", updateErr, expected, resp.StatusCode)
+		}
+	}
+}
+
+func TestGetWebhookDelivery_AttemptLog(t *testing.T) {
+	app := newTestApp()
+	h := NewWebhookHandler(&mockWebhookService{
+		GetDeliveryFn: func(_, _, deliveryID string) (*domain.WebhookDelivery, []*domain.WebhookAttempt, error) {
+			return &domain.WebhookDelivery{ID: deliveryID, Status: domain.DeliveryDead, Attempts: 2, Payload: []byte(`{"id":"e1"}`)},
+				[]*domain.WebhookAttempt{
+					{Attempt: 1, StatusCode: 500, Error: "unexpected status 500", Duration: 30 * time.Millisecond},
+					{Attempt: 2, Error: "connection refused"},
+				}, nil
+		},
+	})
+	app.Get("/webhooks/:id/deliveries/:deliveryId", h.GetWebhookDelivery)
+	resp, err := app.Test(httptest.NewRequest("GET", "/webhooks/w1/deliveries/d1", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	var body WebhookDeliveryDetailResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("error decodificando respuesta: %v", err)
+	}
+	if body.ID != "d1" || body.Status != domain.DeliveryDead || string(body.Payload) != `{"id":"e1"}` {
+		t.Errorf("unexpected delivery: %+v", body)
+	}
+	if len(body.AttemptLog) != 2 || body.AttemptLog[0].DurationMS != 30 || body.AttemptLog[1].Error != "connection refused" {
+		t.Errorf("unexpected attempt log: %+v", body.AttemptLog)
+	}
+	if body.NextAttemptAt != nil {
+		t.Errorf("expected no next attempt for a dead letter, got %v", body.NextAttemptAt)
+	}
+}
+
+func TestRedeliverWebhookDelivery(t *testing.T) {
+	app := newTestApp()
+	h := NewWebhookHandler(&mockWebhookService{
+		RedeliverFn: func(_, _, deliveryID string) (*domain.WebhookDelivery, error) {
+			return &domain.WebhookDelivery{ID: deliveryID, Status: domain.DeliveryPending, NextAttemptAt: time.Now()}, nil
+		},
+	})
+	app.Post("/webhooks/:id/deliveries/:deliveryId/redeliver", h.RedeliverWebhookDelivery)
+	resp, err := app.Test(httptest.NewRequest("POST", "/webhooks/w1/deliveries/d1/redeliver", http.NoBody))
+	if err != nil {
+		t.Fatalf("error ejecutando app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusAccepted {
+		t.Errorf("expected 202, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+// MaxBulkItems caps the number of tasks a single bulk operation can touch.
+const MaxBulkItems = 500
+
+// Bulk task actions.
+const (
+	BulkCreate = "create"
+	BulkUpdate = "update"
+	BulkMove   = "move"
+	BulkLabel  = "label"
+	BulkDelete = "delete"
+)
+
+// BulkRequest describes an operation applied to many tasks at once. Create takes Tasks;
+// the other actions target either IDs or the tasks matching a Filter expression.
+type BulkRequest struct {
+	Action string
+	IDs    []string
+	Filter string
+	// Atomic applies every change in a single transaction, so that either all of them or
+	// none are written. Otherwise each item is applied on its own (best effort).
+	Atomic bool
+
+	// Tasks are the tasks to create.
+	Tasks []NewTask
+	// Fields are the changes of an update.
+	Fields TaskPatch
+	// ListID is the destination of a move.
+	ListID string
+	// AddLabels and RemoveLabels are the changes of a label action.
+	AddLabels    []string
+	RemoveLabels []string
+}
+
+// NewTask holds the fields of a task to create.
+type NewTask struct {
+	// ID is the id chosen by an offline client for the task; one is generated when empty.
+	ID          string
+	ListID      string
+	Title       string
+	Description string
+	Priority    string
+	// Status, DueDate and Labels are only set by offline clients, which create tasks with
+	// all of their fields. Status defaults to pending.
+	Status  string
+	DueDate *time.Time
+	Labels  []string
+}
+
+// BulkChange is one write of a bulk operation: Op is create, update or delete.
+type BulkChange struct {
+	Op   string
+	Task *Task
+}
+
+// BulkItemResult is the outcome of one item of a bulk operation, in request order. Task
+// is the written task and Err the reason the item was not applied.
+type BulkItemResult struct {
+	Index int
+	ID    string
+	Task  *Task
+	Err   error
+}
+
+// BulkResult is the outcome of a bulk operation. Applied is false when an atomic
+// operation was rolled back.
+type BulkResult struct {
+	Items   []BulkItemResult
+	Applied bool
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// ChecklistItem is an ordered step of a task that is too small to be a subtask.
+type ChecklistItem struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"task_id"`
+	Position  int       `json:"position"`
+	Title     string    `json:"title"`
+	Done      bool      `json:"done"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,104 @@
+package domain
+
+// ErrorKind classifies an Error by what the caller can do about it.
+type ErrorKind int
+
+const (
+	// ErrorInvalid means the input is not valid and the request should not be repeated as is.
+	ErrorInvalid ErrorKind = iota + 1
+	// ErrorNotFound means the resource does not exist.
+	ErrorNotFound
+	// ErrorConflict means the current state of the resource does not allow the change.
+	ErrorConflict
+	// ErrorForbidden means the resource belongs to another user.
+	ErrorForbidden
+	// ErrorGone means the resource existed but is no longer available.
+	ErrorGone
+)
+
+// Error is an error of the use cases that callers can act on. Code is a stable,
+// machine-readable identifier and Message is safe to show to clients; Field names the
+// input field an invalid error is about, if any.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Field   string
+	Message string
+	// Err is the error this one was built from, if any.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the error this one was built from.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an Error with the same code, so that errors.Is matches the
+// sentinel errors below.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// The errors the task and task list use cases return.
+var (
+	ErrTaskNotFound     = &Error{Kind: ErrorNotFound, Code: "task_not_found", Message: "task not found"}
+	ErrTaskListNotFound = &Error{Kind: ErrorNotFound, Code: "task_list_not_found", Message: "task list not found"}
+	ErrVersionConflict  = &Error{Kind: ErrorConflict, Code: "version_conflict", Message: "version conflict"}
+	ErrTaskListArchived = &Error{Kind: ErrorConflict, Code: "task_list_archived", Message: "task list is archived"}
+	ErrTaskExists       = &Error{Kind: ErrorConflict, Code: "task_exists", Message: "task already exists"}
+	ErrTaskListExists   = &Error{Kind: ErrorConflict, Code: "task_list_exists", Message: "task list already exists"}
+	ErrInvalidCursor    = &Error{Kind: ErrorInvalid, Code: "invalid_cursor", Field: "cursor", Message: "invalid cursor"}
+	// ErrRolledBack is the error of the valid items of an atomic bulk operation that were
+	// rolled back because another item failed.
+	ErrRolledBack = &Error{Kind: ErrorConflict, Code: "rolled_back", Message: "rolled back: another item failed"}
+)
+
+// The errors the trash and history use cases return.
+var (
+	ErrTaskNotInTrash     = &Error{Kind: ErrorNotFound, Code: "task_not_in_trash", Message: "task not found in trash"}
+	ErrTaskListNotInTrash = &Error{Kind: ErrorNotFound, Code: "task_list_not_in_trash", Message: "task list not found in trash"}
+	ErrTaskListDeleted    = &Error{Kind: ErrorConflict, Code: "task_list_deleted", Message: "task list is deleted"}
+	ErrRevisionNotFound   = &Error{Kind: ErrorNotFound, Code: "revision_not_found", Message: "revision not found"}
+)
+
+// The errors the template, checklist and sprint use cases return.
+var (
+	ErrTemplateNotFound      = &Error{Kind: ErrorNotFound, Code: "template_not_found", Message: "template not found"}
+	ErrChecklistItemNotFound = &Error{Kind: ErrorNotFound, Code: "checklist_item_not_found", Message: "checklist item not found"}
+	ErrSprintNotFound        = &Error{Kind: ErrorNotFound, Code: "sprint_not_found", Message: "sprint not found"}
+	ErrNextSprintNotFound    = &Error{Kind: ErrorNotFound, Code: "next_sprint_not_found", Message: "next sprint not found"}
+	ErrSprintClosed          = &Error{Kind: ErrorConflict, Code: "sprint_closed", Message: "sprint is closed"}
+	ErrSprintNotPlanned      = &Error{Kind: ErrorConflict, Code: "sprint_not_planned", Message: "sprint is not planned"}
+	ErrSprintNotActive       = &Error{Kind: ErrorConflict, Code: "sprint_not_active", Message: "sprint is not active"}
+)
+
+// The errors the view and webhook use cases return.
+var (
+	ErrViewNotFound            = &Error{Kind: ErrorNotFound, Code: "view_not_found", Message: "view not found"}
+	ErrViewNotOwned            = &Error{Kind: ErrorForbidden, Code: "view_not_owned", Message: "view not owned"}
+	ErrWebhookNotFound         = &Error{Kind: ErrorNotFound, Code: "webhook_not_found", Message: "webhook not found"}
+	ErrWebhookNotOwned         = &Error{Kind: ErrorForbidden, Code: "webhook_not_owned", Message: "webhook not owned"}
+	ErrWebhookDeliveryNotFound = &Error{Kind: ErrorNotFound, Code: "webhook_delivery_not_found", Message: "delivery not found"}
+)
+
+// The errors the sync and idempotency use cases return.
+var (
+	ErrSyncTokenExpired     = &Error{Kind: ErrorGone, Code: "sync_token_expired", Message: "sync token expired"}
+	ErrIdempotencyKeyReused = &Error{Kind: ErrorConflict, Code: "idempotency_key_reused", Message: "idempotency key reused with a different request"}
+	ErrIdempotencyKeyInUse  = &Error{Kind: ErrorConflict, Code: "idempotency_key_in_use", Message: "idempotency key in use by a request in progress"}
+)
+
+// NewInvalidError returns an invalid input error about a field.
+func NewInvalidError(field, code, message string) *Error {
+	return &Error{Kind: ErrorInvalid, Code: code, Field: field, Message: message}
+}
+
+// WrapInvalidError returns an invalid input error about a field whose message is that of err.
+func WrapInvalidError(field, code string, err error) *Error {
+	return &Error{Kind: ErrorInvalid, Code: code, Field: field, Message: err.Error(), Err: err}
+}
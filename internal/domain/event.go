@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event types published when tasks and task lists change.
+const (
+	EventTaskCreated = "task.created"
+	EventTaskUpdated = "task.updated"
+	EventTaskDeleted = "task.deleted"
+	EventListCreated = "list.created"
+	EventListUpdated = "list.updated"
+	EventListDeleted = "list.deleted"
+)
+
+// EventTypes are all the event types a webhook can subscribe to.
+var EventTypes = []string{
+	EventTaskCreated, EventTaskUpdated, EventTaskDeleted,
+	EventListCreated, EventListUpdated, EventListDeleted,
+}
+
+// Aggregates whose changes produce events.
+const (
+	AggregateTask = "task"
+	AggregateList = "list"
+)
+
+// Event is a change to a task or task list. Data holds the task or task list after the
+// change. Sequence grows with every event, so consumers can tell their order apart and
+// skip the ones they already received.
+type Event struct {
+	ID         string          `json:"id"`
+	Sequence   int64           `json:"sequence"`
+	Type       string          `json:"type"`
+	ListID     string          `json:"list_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// OutboxEntry is an event stored in the same transaction as the change it describes,
+// waiting to be published. The events of an aggregate are published in order: an entry
+// is only handed out once every earlier entry of the same aggregate was published.
+type OutboxEntry struct {
+	Event         Event
+	AggregateType string
+	AggregateID   string
+	Attempts      int
+	LastError     string
+}
+
+// EventStream is a live feed of events: Replay holds the events published since the last
+// one the client received, and Events delivers the new ones as they are published. Events
+// is closed when the client falls too far behind; Close releases the stream.
+type EventStream struct {
+	Replay []*Event
+	Events <-chan *Event
+	Close  func()
+}
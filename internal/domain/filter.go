@@ -0,0 +1,33 @@
+package domain
+
+// FilterNode is a node of a parsed and validated filter expression.
+type FilterNode interface {
+	filterNode()
+}
+
+// FilterLogical combines two expressions with "and" or "or".
+type FilterLogical struct {
+	Operator string
+	Left     FilterNode
+	Right    FilterNode
+}
+
+// FilterNot negates an expression.
+type FilterNot struct {
+	Operand FilterNode
+}
+
+// FilterComparison compares a field with one or more values. Operator is one of
+// =, !=, >, >=, <, <=, ~ (contains, case insensitive), in, "is null" and "is not null".
+// Values are strings, or time.Time for date fields.
+type FilterComparison struct {
+	Field    string
+	Operator string
+	Values   []interface{}
+	// DateOnly is set when a date field is compared with a day (YYYY-MM-DD) instead of an instant.
+	DateOnly bool
+}
+
+func (FilterLogical) filterNode()    {}
+func (FilterNot) filterNode()        {}
+func (FilterComparison) filterNode() {}
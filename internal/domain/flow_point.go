@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// FlowPoint counts the tasks of a list or sprint per status on a given day.
+type FlowPoint struct {
+	Date       time.Time `json:"date"`
+	Pending    int       `json:"pending"`
+	InProgress int       `json:"in_progress"`
+	Completed  int       `json:"completed"`
+	Total      int       `json:"total"`
+}
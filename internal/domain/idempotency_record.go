@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// IdempotencyRecord is a request made with an Idempotency-Key and, once it finished, the
+// response it got, so that retries with the same key get the same response instead of
+// repeating the request.
+type IdempotencyRecord struct {
+	UserID string
+	Key    string
+	// Fingerprint identifies the request: its method, path and body.
+	Fingerprint string
+	// StatusCode is 0 while the request is still being processed.
+	StatusCode int
+	Headers    map[string]string
+	Body       []byte
+
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	// LockedUntil is when a retry may take over the key of a request still in progress,
+	// which is assumed to have failed without releasing it.
+	LockedUntil time.Time
+}
+
+// Completed reports whether the response of the request has been stored.
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
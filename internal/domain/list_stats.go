@@ -0,0 +1,10 @@
+package domain
+
+// ListStats counts the live tasks of a task list per status.
+type ListStats struct {
+	ListID     string `json:"list_id"`
+	Total      int    `json:"total"`
+	Pending    int    `json:"pending"`
+	InProgress int    `json:"in_progress"`
+	Completed  int    `json:"completed"`
+}
@@ -0,0 +1,57 @@
+package domain
+
+// DefaultPageLimit is the page size used when none is requested.
+const DefaultPageLimit = 50
+
+// MaxPageLimit caps the page size a client can request.
+const MaxPageLimit = 200
+
+// PageRequest selects one page of a keyset paginated listing.
+type PageRequest struct {
+	// Limit is the maximum number of items of the page.
+	Limit int
+	// Sort is the whitelisted field the listing is ordered by.
+	Sort string
+	// Desc orders the listing in descending order.
+	Desc bool
+	// After is the position of the last item of the previous page, nil for the first page.
+	After *Cursor
+	// IncludeTotal requests the number of items matching the listing across all pages.
+	IncludeTotal bool
+}
+
+// NormalizeLimit applies the default page size and caps it to MaxPageLimit.
+func (p *PageRequest) NormalizeLimit() {
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+}
+
+// Cursor is the position of an item in a sorted listing: its sort value and its ID as a tie breaker.
+type Cursor struct {
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// Page is one page of a listing. Next is nil on the last page and Total is only set when requested.
+type Page[T any] struct {
+	Items []T
+	Next  *Cursor
+	Total *int
+}
+
+// TaskFilter selects the tasks of a listing.
+type TaskFilter struct {
+	ListID          string
+	Status          string
+	Priority        string
+	IncludeArchived bool
+	// Expression is a filter expression as written by the client, e.g.
+	// `status in (pending,in-progress) and title ~ "deploy"`.
+	Expression string
+	// Where is the parsed and validated Expression, nil when there is none.
+	Where FilterNode
+}
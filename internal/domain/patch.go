@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// PatchField is the change a partial update makes to one field. A field that is not Set
+// is left unchanged; Null asks to clear it, otherwise it takes Value.
+type PatchField[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+// TaskPatch is a partial update of a task.
+type TaskPatch struct {
+	ListID      PatchField[string]
+	Title       PatchField[string]
+	Description PatchField[string]
+	Status      PatchField[string]
+	Priority    PatchField[string]
+	DueDate     PatchField[time.Time]
+	Labels      PatchField[[]string]
+}
+
+// TaskListPatch is a partial update of a task list.
+type TaskListPatch struct {
+	Name        PatchField[string]
+	Description PatchField[string]
+}
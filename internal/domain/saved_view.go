@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// SavedView is a named task listing: a filter expression, a sort, a grouping and the
+// fields to show. It is private to its owner unless it is shared with a task list, in
+// which case everyone can use it and it only shows the tasks of that list.
+type SavedView struct {
+	ID      string
+	OwnerID string
+	ListID  string
+	Name    string
+	// Filter is a filter expression, see TaskFilter.Expression.
+	Filter string
+	// Sort is a sort field, prefixed with "-" for descending order.
+	Sort    string
+	GroupBy string
+	Fields  []string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
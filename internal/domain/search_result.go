@@ -0,0 +1,10 @@
+package domain
+
+// SearchResult is a task matching a full-text search, with its relevance and the
+// fragments of its title and description where the search terms appear.
+type SearchResult struct {
+	Task               *Task
+	Rank               float64
+	TitleSnippet       string
+	DescriptionSnippet string
+}
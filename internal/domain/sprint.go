@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Sprint groups tasks from any list within a time box. A milestone is a sprint of kind
+// "milestone" whose start date is optional.
+type Sprint struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Goal       string     `json:"goal"`
+	Kind       string     `json:"kind"`
+	Status     string     `json:"status"`
+	StartsAt   *time.Time `json:"starts_at,omitempty"`
+	EndsAt     *time.Time `json:"ends_at,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	ClosedAt   *time.Time `json:"closed_at,omitempty"`
+	RolledOver int        `json:"rolled_over"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// SprintSummary compares the work committed when a sprint started with what got done.
+type SprintSummary struct {
+	Committed          int `json:"committed"`
+	CommittedCompleted int `json:"committed_completed"`
+	Added              int `json:"added"`
+	Completed          int `json:"completed"`
+	Remaining          int `json:"remaining"`
+	Total              int `json:"total"`
+	RolledOver         int `json:"rolled_over"`
+}
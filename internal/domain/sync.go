@@ -0,0 +1,63 @@
+package domain
+
+// Entity types of the changes feed.
+const (
+	SyncTask = "task"
+	SyncList = "list"
+)
+
+// Operations of the changes feed: an upsert carries the current state of the entity and a
+// delete only its id.
+const (
+	SyncUpsert = "upsert"
+	SyncDelete = "delete"
+)
+
+// Outcomes of a change pushed by a client.
+const (
+	SyncApplied  = "applied"
+	SyncConflict = "conflict"
+)
+
+// SyncChange is one entry of the changes feed. Task or List holds the entity of an upsert.
+type SyncChange struct {
+	Sequence int64
+	Type     string
+	Op       string
+	ID       string
+	Task     *Task
+	List     *TaskList
+}
+
+// SyncFeed is a page of the changes feed in sequence order. Sequence is the position the
+// next page continues from, and HasMore tells whether it has changes already.
+type SyncFeed struct {
+	Changes  []*SyncChange
+	Sequence int64
+	HasMore  bool
+}
+
+// SyncPush is a change made by an offline client. Op is create, update or delete; the
+// fields of a create or update are given as a patch of the entity type. BaseVersion is
+// the version the client changed, so that changes made meanwhile are not overwritten.
+type SyncPush struct {
+	Type        string
+	Op          string
+	ID          string
+	BaseVersion int
+	TaskPatch   TaskPatch
+	ListPatch   TaskListPatch
+}
+
+// SyncPushResult is the outcome of a pushed change, in request order. A conflict carries
+// the current server copy in Task or List, which is nil when the entity was deleted. Err
+// is the reason a change was not applied.
+type SyncPushResult struct {
+	Index  int
+	Type   string
+	ID     string
+	Status string
+	Task   *Task
+	List   *TaskList
+	Err    error
+}
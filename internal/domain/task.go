@@ -5,12 +5,25 @@ import "time"
 
 // Task represents a task item with its properties and metadata.
 type Task struct {
-	ID          string    `json:"id"`
-	ListID      string    `json:"list_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	Priority    string    `json:"priority"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string     `json:"id"`
+	ListID      string     `json:"list_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Priority    string     `json:"priority"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	ParentID    string     `json:"parent_id,omitempty"`
+	Labels      []string   `json:"labels"`
+	SprintID    string     `json:"sprint_id,omitempty"`
+
+	// ChecklistTotal and ChecklistDone summarize the checklist items of the task.
+	ChecklistTotal int `json:"checklist_total"`
+	ChecklistDone  int `json:"checklist_done"`
+
+	// Version is incremented on every change and guards updates against lost writes.
+	Version int `json:"version"`
 }
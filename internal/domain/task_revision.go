@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// SystemUser is the ChangedBy of the revisions recorded by background jobs rather than by a
+// request.
+const SystemUser = "system"
+
+// TaskRevision is an immutable snapshot of a task taken after each change. TaskVersion is
+// the version the task had after the change.
+type TaskRevision struct {
+	ID            string    `json:"id"`
+	TaskID        string    `json:"task_id"`
+	Revision      int       `json:"revision"`
+	TaskVersion   int       `json:"task_version"`
+	ChangedBy     string    `json:"changed_by"`
+	ChangedAt     time.Time `json:"changed_at"`
+	ChangedFields []string  `json:"changed_fields"`
+	ListID        string    `json:"list_id"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	Status        string    `json:"status"`
+	Priority      string    `json:"priority"`
+	SprintID      string    `json:"sprint_id"`
+	Archived      bool      `json:"archived"`
+	Deleted       bool      `json:"deleted"`
+}
+
+// FieldChange describes how a single task field changed between two revisions.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
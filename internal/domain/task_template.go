@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// TaskTemplate is a reusable blueprint of a task list and its tasks.
+// Titles and descriptions may contain {{variable}} placeholders resolved on instantiation.
+type TaskTemplate struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	Items       []TemplateItem `json:"items"`
+}
+
+// TemplateItem is a task captured in a template. Due dates are stored as an offset in
+// days from the moment the template is instantiated.
+type TemplateItem struct {
+	ID            string   `json:"id"`
+	ParentItemID  string   `json:"parent_item_id,omitempty"`
+	Position      int      `json:"position"`
+	Title         string   `json:"title"`
+	Description   string   `json:"description"`
+	Priority      string   `json:"priority"`
+	DueOffsetDays *int     `json:"due_offset_days,omitempty"`
+	Labels        []string `json:"labels"`
+}
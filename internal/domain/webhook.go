@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// Webhook delivery statuses.
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	// DeliveryDead is the status of a delivery that failed every attempt. It is kept as a
+	// dead letter until it is redelivered.
+	DeliveryDead = "dead"
+)
+
+// WebhookSubscription is a URL that receives the events of the given types, of every list
+// or only of one. An empty EventTypes subscribes to every event type.
+type WebhookSubscription struct {
+	ID         string
+	OwnerID    string
+	URL        string
+	EventTypes []string
+	ListID     string
+	// Secret signs the deliveries so that the receiver can verify them.
+	Secret string
+	Active bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WebhookDelivery is an event to send to a webhook and the state of its delivery.
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	// Payload is the request body, kept as sent so that redeliveries are identical.
+	Payload        []byte
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeliveredAt *time.Time
+}
+
+// WebhookAttempt is one request made to deliver an event to a webhook.
+type WebhookAttempt struct {
+	ID         string
+	DeliveryID string
+	Attempt    int
+	// StatusCode is 0 when no response was received.
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
@@ -0,0 +1,77 @@
+// Package broker provides message broker implementations.
+package broker
+
+import (
+	"strings"
+	"sync"
+)
+
+// LocalBroker is an in-memory stand-in for a NATS-style broker, for development and tests.
+// Subjects are dot separated tokens; a subscription may use "*" to match any single token
+// and a trailing ">" to match one or more tokens. Messages are delivered synchronously and
+// only to the subscribers of the same process.
+type LocalBroker struct {
+	mu            sync.RWMutex
+	subscriptions map[int]localSubscription
+	nextID        int
+}
+
+type localSubscription struct {
+	pattern []string
+	handler func(subject string, data []byte)
+}
+
+// NewLocalBroker creates and returns a new LocalBroker without subscribers.
+func NewLocalBroker() *LocalBroker {
+	return &LocalBroker{
+		subscriptions: map[int]localSubscription{},
+	}
+}
+
+// Subscribe registers a handler for the messages published on subjects matching the given
+// subject. The returned function removes the subscription.
+func (b *LocalBroker) Subscribe(subject string, handler func(subject string, data []byte)) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.subscriptions[id] = localSubscription{pattern: strings.Split(subject, "."), handler: handler}
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscriptions, id)
+	}
+}
+
+// Publish delivers a message to every matching subscription. Messages without subscribers
+// are dropped, as a broker would.
+func (b *LocalBroker) Publish(subject string, data []byte) error {
+	tokens := strings.Split(subject, ".")
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subscriptions {
+		if subjectMatches(sub.pattern, tokens) {
+			sub.handler(subject, data)
+		}
+	}
+
+	return nil
+}
+
+// subjectMatches reports whether the tokens of a subject match the tokens of a pattern.
+func subjectMatches(pattern, tokens []string) bool {
+	for i, p := range pattern {
+		if p == ">" {
+			return i == len(pattern)-1 && len(tokens) > i
+		}
+		if i >= len(tokens) || (p != "*" && p != tokens[i]) {
+			return false
+		}
+	}
+
+	return len(pattern) == len(tokens)
+}
@@ -0,0 +1,45 @@
+package broker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLocalBroker_SubjectMatching(t *testing.T) {
+	cases := map[string]map[string]bool{
+		"tasks.task.created": {"tasks.task.created": true, "tasks.task.updated": false, "tasks.task": false},
+		"tasks.*.created":    {"tasks.task.created": true, "tasks.list.created": true, "tasks.task.deleted": false},
+		"tasks.>":            {"tasks.task.created": true, "tasks.list": true, "tasks": false},
+		"tasks.task.*":       {"tasks.task.created": true, "tasks.task.created.extra": false},
+	}
+	for pattern, subjects := range cases {
+		for subject, expected := range subjects {
+			if matched := subjectMatches(strings.Split(pattern, "."), strings.Split(subject, ".")); matched != expected {
+				t.Errorf("%s on %s: expected %v, got %v", pattern, subject, expected, matched)
+			}
+		}
+	}
+}
+
+func TestLocalBroker_PublishAndUnsubscribe(t *testing.T) {
+	b := NewLocalBroker()
+	var received []string
+	unsubscribe := b.Subscribe("tasks.task.*", func(subject string, data []byte) {
+		received = append(received, subject+" "+string(data))
+	})
+
+	if err := b.Publish("tasks.task.created", []byte("1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := b.Publish("tasks.list.created", []byte("2")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unsubscribe()
+	if err := b.Publish("tasks.task.deleted", []byte("3")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(received) != 1 || received[0] != "tasks.task.created 1" {
+		t.Errorf("expected only the first message, got %v", received)
+	}
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+)
+
+const (
+	listenerMinReconnect = 10 * time.Second
+	listenerMaxReconnect = time.Minute
+	// listenerPingInterval is how often an idle listener checks its connection.
+	listenerPingInterval = 90 * time.Second
+)
+
+// Listen subscribes to a PostgreSQL notification channel on a dedicated connection, using
+// the same environment variables as NewPostgresDB, and calls handle with the payload of
+// every notification. The connection is re-established after failures; since notifications
+// sent meanwhile are lost, handle is then called with an empty payload.
+// The returned function stops listening.
+func Listen(channel string, handle func(payload string)) (func(), error) {
+	listener := pq.NewListener(dataSourceName(), listenerMinReconnect, listenerMaxReconnect,
+		func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				logger.GetLogger().WithFields(map[string]interface{}{
+					"layer":   "db",
+					"method":  "Listen",
+					"channel": channel,
+					"error":   err.Error(),
+				}).Error("Notification listener connection failed")
+			}
+		})
+	if err := listener.Listen(channel); err != nil {
+		_ = listener.Close() //nolint:errcheck
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(listenerPingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case notification := <-listener.Notify:
+				// A nil notification signals that the connection was re-established.
+				if notification == nil {
+					handle("")
+					continue
+				}
+				handle(notification.Extra)
+			case <-ticker.C:
+				go func() {
+					_ = listener.Ping() //nolint:errcheck
+				}()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		_ = listener.Close() //nolint:errcheck
+	}, nil
+}
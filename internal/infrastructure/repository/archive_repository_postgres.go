@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// PostgresArchiveRepository is a PostgreSQL implementation of the archive repository.
+type PostgresArchiveRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresArchiveRepository creates a new PostgresArchiveRepository instance.
+func NewPostgresArchiveRepository(db *sql.DB) *PostgresArchiveRepository {
+	return &PostgresArchiveRepository{
+		db: db,
+	}
+}
+
+// ArchiveTask marks a task as archived. Archiving an already archived task keeps its original timestamp.
+// Tasks of an archived list cannot be changed. A task.updated event is recorded in the same transaction,
+// and the revision is attributed to changedBy.
+func (r *PostgresArchiveRepository) ArchiveTask(id string, at time.Time, changedBy string) error {
+	query := `UPDATE tasks SET archived_at = COALESCE(archived_at, $2) WHERE id = $1 AND deleted_at IS NULL`
+
+	return inTxAs(r.db, changedBy, func(tx *sql.Tx) error {
+		if err := ensureTasksWritable(tx, []string{id}); err != nil {
+			return err
+		}
+		if err := execSingle(tx, query, domain.ErrTaskNotFound, id, at); err != nil {
+			return err
+		}
+		return insertTaskEvents(tx, domain.EventTaskUpdated, []string{id})
+	})
+}
+
+// UnarchiveTask clears the archived mark of a task. Tasks of an archived list cannot be changed.
+// A task.updated event is recorded in the same transaction, and the revision is attributed to changedBy.
+func (r *PostgresArchiveRepository) UnarchiveTask(id, changedBy string) error {
+	query := `UPDATE tasks SET archived_at = NULL WHERE id = $1 AND deleted_at IS NULL`
+
+	return inTxAs(r.db, changedBy, func(tx *sql.Tx) error {
+		if err := ensureTasksWritable(tx, []string{id}); err != nil {
+			return err
+		}
+		if err := execSingle(tx, query, domain.ErrTaskNotFound, id); err != nil {
+			return err
+		}
+		return insertTaskEvents(tx, domain.EventTaskUpdated, []string{id})
+	})
+}
+
+// ArchiveList marks a task list as archived. A list.updated event is recorded in the same transaction.
+func (r *PostgresArchiveRepository) ArchiveList(id string, at time.Time) error {
+	query := `UPDATE task_lists SET archived_at = COALESCE(archived_at, $2) WHERE id = $1 AND deleted_at IS NULL`
+
+	return inTx(r.db, func(tx *sql.Tx) error {
+		if err := execSingle(tx, query, domain.ErrTaskListNotFound, id, at); err != nil {
+			return err
+		}
+		return insertListEvent(tx, domain.EventListUpdated, id)
+	})
+}
+
+// UnarchiveList clears the archived mark of a task list. A list.updated event is recorded in the
+// same transaction.
+func (r *PostgresArchiveRepository) UnarchiveList(id string) error {
+	query := `UPDATE task_lists SET archived_at = NULL WHERE id = $1 AND deleted_at IS NULL`
+
+	return inTx(r.db, func(tx *sql.Tx) error {
+		if err := execSingle(tx, query, domain.ErrTaskListNotFound, id); err != nil {
+			return err
+		}
+		return insertListEvent(tx, domain.EventListUpdated, id)
+	})
+}
+
+// ArchiveCompletedInList archives all completed tasks of a list and returns how many were archived.
+// The list must be live and not archived. A task.updated event for each archived task is recorded
+// in the same transaction, and the revisions are attributed to changedBy.
+func (r *PostgresArchiveRepository) ArchiveCompletedInList(listID string, at time.Time, changedBy string) (count int64, err error) {
+	query := `UPDATE tasks SET archived_at = $2
+	          WHERE list_id = $1 AND status = 'completed' AND archived_at IS NULL AND deleted_at IS NULL
+	          RETURNING id`
+
+	err = inTxAs(r.db, changedBy, func(tx *sql.Tx) error {
+		if err := ensureListWritable(tx, listID); err != nil {
+			return err
+		}
+		count, err = changeTasks(tx, domain.EventTaskUpdated, query, listID, at)
+		return err
+	})
+
+	return count, err
+}
+
+// ArchiveCompletedBefore archives all tasks completed before the given time and returns how many were archived.
+// A task.updated event for each archived task is recorded in the same transaction, and the
+// revisions are attributed to changedBy.
+func (r *PostgresArchiveRepository) ArchiveCompletedBefore(before, at time.Time, changedBy string) (count int64, err error) {
+	query := `UPDATE tasks SET archived_at = $2
+	          WHERE status = 'completed' AND completed_at < $1 AND archived_at IS NULL AND deleted_at IS NULL
+	          RETURNING id`
+
+	err = inTxAs(r.db, changedBy, func(tx *sql.Tx) error {
+		count, err = changeTasks(tx, domain.EventTaskUpdated, query, before, at)
+		return err
+	})
+
+	return count, err
+}
+
+// execSingle runs a statement that must change exactly one row and returns notFound if it changed none.
+func execSingle(db sqlExecutor, query string, notFound error, args ...interface{}) error {
+	result, err := db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return notFound
+	}
+
+	return nil
+}
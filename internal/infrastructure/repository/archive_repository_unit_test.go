@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func TestPostgresArchiveRepository_ArchiveTask_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresArchiveRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("FOR SHARE OF l").WillReturnRows(sqlmock.NewRows([]string{"archived"}).AddRow(false))
+	mock.ExpectExec("UPDATE tasks SET archived_at").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	err = r.ArchiveTask("no-task", time.Now(), "")
+	if err == nil || err.Error() != "task not found" {
+		t.Errorf("esperado error de tarea no encontrada, obtuve %v", err)
+	}
+}
+
+func TestPostgresArchiveRepository_UnarchiveTask_ListArchived(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresArchiveRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("FOR SHARE OF l").WillReturnRows(sqlmock.NewRows([]string{"archived"}).AddRow(true))
+	mock.ExpectRollback()
+
+	if err := r.UnarchiveTask("1", ""); !errors.Is(err, domain.ErrTaskListArchived) {
+		t.Errorf("esperado error de lista archivada, obtuve %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresArchiveRepository_ArchiveCompletedInList(t *testing.T) {
+	at := time.Now()
+	cases := []struct {
+		name      string
+		list      *sqlmock.Rows
+		wantErr   error
+		wantCount int64
+	}{
+		{"live list", sqlmock.NewRows([]string{"archived"}).AddRow(false), nil, 1},
+		{"archived list", sqlmock.NewRows([]string{"archived"}).AddRow(true), domain.ErrTaskListArchived, 0},
+		{"trashed list", sqlmock.NewRows([]string{"archived"}), domain.ErrTaskListNotFound, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("error creating sqlmock: %v", err)
+			}
+			r := NewPostgresArchiveRepository(db)
+			mock.ExpectBegin()
+			mock.ExpectExec("SELECT set_config\\('app.user_id', \\$1, true\\)").WithArgs("user-1").WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectQuery("SELECT archived_at IS NOT NULL FROM task_lists WHERE id = \\$1 AND deleted_at IS NULL FOR SHARE").
+				WithArgs("l1").WillReturnRows(tc.list)
+			if tc.wantErr == nil {
+				mock.ExpectQuery("UPDATE tasks SET archived_at = \\$2 WHERE list_id = \\$1 AND status = 'completed'").
+					WithArgs("l1", at).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+				expectTaskEvent(mock, "1", "task.updated")
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			count, err := r.ArchiveCompletedInList("l1", at, "user-1")
+			if !errors.Is(err, tc.wantErr) || count != tc.wantCount {
+				t.Errorf("esperado %d y %v, obtuve %d y %v", tc.wantCount, tc.wantErr, count, err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expectativas no cumplidas: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresArchiveRepository_ArchiveCompletedBefore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresArchiveRepository(db)
+	before, at := time.Now().Add(-time.Hour), time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE tasks SET archived_at = \\$2 WHERE status = 'completed' AND completed_at < \\$1").
+		WithArgs(before, at).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1").AddRow("2"))
+	expectTaskEvent(mock, "1", "task.updated")
+	expectTaskEvent(mock, "2", "task.updated")
+	mock.ExpectCommit()
+	count, err := r.ArchiveCompletedBefore(before, at, "")
+	if err != nil || count != 2 {
+		t.Errorf("esperado 2 tareas archivadas, obtuve %d, err: %v", count, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresArchiveRepository_ArchiveList_RecordsEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresArchiveRepository(db)
+	at := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE task_lists SET archived_at").WithArgs("l1", at).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectListEvent(mock, "l1", "list.updated")
+	mock.ExpectCommit()
+
+	if err := r.ArchiveList("l1", at); err != nil {
+		t.Fatalf("no se esperaba error en ArchiveList: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// PostgresChecklistRepository is a PostgreSQL implementation of the checklist repository.
+type PostgresChecklistRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresChecklistRepository creates a new PostgresChecklistRepository instance.
+func NewPostgresChecklistRepository(db *sql.DB) *PostgresChecklistRepository {
+	return &PostgresChecklistRepository{
+		db: db,
+	}
+}
+
+// Create appends an item at the end of the checklist of its task. The position is
+// assigned by the database and written back into item.
+func (r *PostgresChecklistRepository) Create(item *domain.ChecklistItem) error {
+	query := `INSERT INTO task_checklist_items (id, task_id, position, title, done, created_at, updated_at)
+	          SELECT $1, $2, COALESCE(MAX(position), -1) + 1, $3, $4, $5, $6
+	          FROM task_checklist_items WHERE task_id = $2
+	          RETURNING position`
+
+	return inTx(r.db, func(tx *sql.Tx) error {
+		if err := lockChecklist(tx, item.TaskID); err != nil {
+			return err
+		}
+		return tx.QueryRow(query, item.ID, item.TaskID, item.Title, item.Done, item.CreatedAt, item.UpdatedAt).Scan(&item.Position)
+	})
+}
+
+// lockChecklist locks the row of a task until the transaction ends, so that concurrent
+// writes to its checklist take their positions one after the other.
+func lockChecklist(tx *sql.Tx, taskID string) error {
+	var id string
+	err := tx.QueryRow(`SELECT id FROM tasks WHERE id = $1 FOR UPDATE`, taskID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return domain.ErrTaskNotFound
+	}
+
+	return err
+}
+
+// GetByTaskID retrieves the checklist of a task ordered by position.
+func (r *PostgresChecklistRepository) GetByTaskID(taskID string) ([]*domain.ChecklistItem, error) {
+	query := `SELECT id, task_id, position, title, done, created_at, updated_at
+	          FROM task_checklist_items WHERE task_id = $1 ORDER BY position ASC`
+
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	items := []*domain.ChecklistItem{}
+	for rows.Next() {
+		item := &domain.ChecklistItem{}
+		if err := rows.Scan(&item.ID, &item.TaskID, &item.Position, &item.Title, &item.Done, &item.CreatedAt, &item.UpdatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// GetByID retrieves a checklist item of a task.
+func (r *PostgresChecklistRepository) GetByID(taskID, id string) (*domain.ChecklistItem, error) {
+	query := `SELECT id, task_id, position, title, done, created_at, updated_at
+	          FROM task_checklist_items WHERE id = $1 AND task_id = $2`
+
+	item := &domain.ChecklistItem{}
+	err := r.db.QueryRow(query, id, taskID).Scan(&item.ID, &item.TaskID, &item.Position, &item.Title, &item.Done, &item.CreatedAt, &item.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrChecklistItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// Update stores the title and done state of a checklist item.
+func (r *PostgresChecklistRepository) Update(item *domain.ChecklistItem) error {
+	query := `UPDATE task_checklist_items SET title = $3, done = $4, updated_at = $5
+	          WHERE id = $1 AND task_id = $2`
+
+	return r.execItem(query, item.ID, item.TaskID, item.Title, item.Done, item.UpdatedAt)
+}
+
+// Delete removes a checklist item.
+func (r *PostgresChecklistRepository) Delete(taskID, id string) error {
+	return r.execItem(`DELETE FROM task_checklist_items WHERE id = $1 AND task_id = $2`, id, taskID)
+}
+
+// Reorder assigns positions following the order of ids in a single transaction.
+func (r *PostgresChecklistRepository) Reorder(taskID string, ids []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	if err := lockChecklist(tx, taskID); err != nil {
+		return err
+	}
+
+	for position, id := range ids {
+		if _, err := tx.Exec(`UPDATE task_checklist_items SET position = $3 WHERE id = $1 AND task_id = $2`, id, taskID, position); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ConvertToTask inserts the task and removes the checklist item in a single transaction, which
+// also records the task.created event. The revision of the task is attributed to changedBy.
+func (r *PostgresChecklistRepository) ConvertToTask(item *domain.ChecklistItem, task *domain.Task, changedBy string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	if err := setChangedBy(tx, changedBy); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`DELETE FROM task_checklist_items WHERE id = $1 AND task_id = $2`, item.ID, item.TaskID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrChecklistItemNotFound
+	}
+
+	_, err = tx.Exec(`INSERT INTO tasks (id, list_id, title, description, status, priority, created_at, updated_at, completed_at, parent_id)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CASE WHEN $5 = 'completed' THEN $7::TIMESTAMP END, NULLIF($9, ''))`,
+		task.ID, task.ListID, task.Title, task.Description, task.Status, task.Priority, task.CreatedAt, task.UpdatedAt, task.ParentID)
+	if err != nil {
+		return err
+	}
+
+	if err := insertTaskEvents(tx, domain.EventTaskCreated, []string{task.ID}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgresChecklistRepository) execItem(query string, args ...interface{}) error {
+	result, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrChecklistItemNotFound
+	}
+
+	return nil
+}
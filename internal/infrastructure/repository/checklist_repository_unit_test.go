@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func TestPostgresChecklistRepository_Create_AssignsPosition(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresChecklistRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM tasks WHERE id = \\$1 FOR UPDATE").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery("INSERT INTO task_checklist_items").WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(2))
+	mock.ExpectCommit()
+
+	item := &domain.ChecklistItem{ID: "a", TaskID: "1", Title: "step", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := r.Create(item); err != nil {
+		t.Fatalf("no se esperaba error en Create: %v", err)
+	}
+	if item.Position != 2 {
+		t.Errorf("esperada posición 2, obtuve %d", item.Position)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresChecklistRepository_Create_TaskNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresChecklistRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM tasks WHERE id = \\$1 FOR UPDATE").WithArgs("1").WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	err = r.Create(&domain.ChecklistItem{ID: "a", TaskID: "1", Title: "step"})
+	if !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Errorf("esperado error de tarea no encontrada, obtuve %v", err)
+	}
+}
+
+func TestPostgresChecklistRepository_Reorder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresChecklistRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM tasks WHERE id = \\$1 FOR UPDATE").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectExec("UPDATE task_checklist_items SET position").WithArgs("b", "1", 0).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE task_checklist_items SET position").WithArgs("a", "1", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := r.Reorder("1", []string{"b", "a"}); err != nil {
+		t.Fatalf("no se esperaba error en Reorder: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresChecklistRepository_ConvertToTask_ItemNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresChecklistRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM task_checklist_items").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err = r.ConvertToTask(&domain.ChecklistItem{ID: "a", TaskID: "1"}, &domain.Task{ID: "2"}, "")
+	if err == nil || err.Error() != "checklist item not found" {
+		t.Errorf("esperado error de elemento no encontrado, obtuve %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// revisionColumns is the column list read by every revision query, in the order expected by scanRevision.
+const revisionColumns = `id, task_id, revision, task_version, changed_by, changed_at, changed_fields,
+	          COALESCE(list_id, ''), title, description, status, priority, COALESCE(sprint_id, ''), archived, deleted`
+
+// PostgresHistoryRepository is a PostgreSQL implementation of the task history repository.
+// Revisions are written by the tasks_revision_trigger in the same transaction as the change
+// of the task, with the user set by the writer, so this repository only reads them.
+type PostgresHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresHistoryRepository creates a new PostgresHistoryRepository instance.
+func NewPostgresHistoryRepository(db *sql.DB) *PostgresHistoryRepository {
+	return &PostgresHistoryRepository{
+		db: db,
+	}
+}
+
+// GetByRevision retrieves a specific revision of a task.
+func (r *PostgresHistoryRepository) GetByRevision(taskID string, revision int) (*domain.TaskRevision, error) {
+	query := `SELECT ` + revisionColumns + `
+	          FROM task_revisions WHERE task_id = $1 AND revision = $2`
+
+	return scanRevision(r.db.QueryRow(query, taskID, revision))
+}
+
+// GetByTaskID retrieves all revisions of a task in ascending revision order.
+func (r *PostgresHistoryRepository) GetByTaskID(taskID string) ([]*domain.TaskRevision, error) {
+	query := `SELECT ` + revisionColumns + `
+	          FROM task_revisions WHERE task_id = $1 ORDER BY revision ASC`
+
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	revisions := []*domain.TaskRevision{}
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, rows.Err()
+}
+
+// scanRevision reads a revision selected with revisionColumns.
+func scanRevision(row rowScanner) (*domain.TaskRevision, error) {
+	rev := &domain.TaskRevision{}
+	err := row.Scan(&rev.ID, &rev.TaskID, &rev.Revision, &rev.TaskVersion, &rev.ChangedBy, &rev.ChangedAt, pq.Array(&rev.ChangedFields),
+		&rev.ListID, &rev.Title, &rev.Description, &rev.Status, &rev.Priority, &rev.SprintID, &rev.Archived, &rev.Deleted)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rev, nil
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestPostgresHistoryRepository_GetByRevision(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresHistoryRepository(db)
+	columns := []string{"id", "task_id", "revision", "task_version", "changed_by", "changed_at", "changed_fields",
+		"list_id", "title", "description", "status", "priority", "sprint_id", "archived", "deleted"}
+	mock.ExpectQuery("FROM task_revisions WHERE task_id = \\$1 AND revision = \\$2").
+		WithArgs("1", 3).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("r", "1", 3, 4, "user-1", time.Now(), "{title}", "l", "t", "", "pending", "low", "", true, false))
+
+	rev, err := r.GetByRevision("1", 3)
+	if err != nil {
+		t.Fatalf("no se esperaba error en GetByRevision: %v", err)
+	}
+	if rev.Revision != 3 || rev.ChangedBy != "user-1" || !rev.Archived || len(rev.ChangedFields) != 1 {
+		t.Errorf("revisión inesperada: %+v", rev)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresHistoryRepository_GetByRevision_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresHistoryRepository(db)
+	mock.ExpectQuery("FROM task_revisions WHERE task_id = \\$1 AND revision = \\$2").WithArgs("1", 2).WillReturnError(sql.ErrNoRows)
+	_, err = r.GetByRevision("1", 2)
+	if err == nil || err.Error() != "revision not found" {
+		t.Errorf("esperado error de revisión no encontrada, obtuve %v", err)
+	}
+}
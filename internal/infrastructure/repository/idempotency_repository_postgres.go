@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// PostgresIdempotencyRepository is a PostgreSQL implementation of the idempotency key repository.
+type PostgresIdempotencyRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresIdempotencyRepository creates a new PostgresIdempotencyRepository instance.
+func NewPostgresIdempotencyRepository(db *sql.DB) *PostgresIdempotencyRepository {
+	return &PostgresIdempotencyRepository{
+		db: db,
+	}
+}
+
+// Reserve stores a new record for a key, unless the user already has an unexpired record
+// for it. It returns nil when the key was reserved and the existing record otherwise. An
+// expired record is replaced as if the key had never been used, and so is the record of
+// the same request still in progress once its lock has passed.
+func (r *PostgresIdempotencyRepository) Reserve(record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+	query := `INSERT INTO idempotency_keys (user_id, key, fingerprint, created_at, expires_at, locked_until)
+	          VALUES ($1, $2, $3, $4, $5, $6)
+	          ON CONFLICT (user_id, key) DO UPDATE
+	          SET fingerprint = EXCLUDED.fingerprint, status_code = NULL, headers = '{}', body = NULL,
+	              created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at, locked_until = EXCLUDED.locked_until
+	          WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+	             OR (idempotency_keys.status_code IS NULL AND idempotency_keys.fingerprint = EXCLUDED.fingerprint
+	                 AND idempotency_keys.locked_until <= EXCLUDED.created_at)
+	          RETURNING key`
+
+	var key string
+	err := r.db.QueryRow(query, record.UserID, record.Key, record.Fingerprint, record.CreatedAt, record.ExpiresAt, record.LockedUntil).Scan(&key)
+	if err == nil {
+		return nil, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	return r.get(record.UserID, record.Key)
+}
+
+func (r *PostgresIdempotencyRepository) get(userID, key string) (*domain.IdempotencyRecord, error) {
+	query := `SELECT user_id, key, fingerprint, COALESCE(status_code, 0), headers, body, created_at, expires_at, locked_until
+	          FROM idempotency_keys WHERE user_id = $1 AND key = $2`
+
+	record := &domain.IdempotencyRecord{}
+	var headers []byte
+	var lockedUntil sql.NullTime
+	err := r.db.QueryRow(query, userID, key).Scan(&record.UserID, &record.Key, &record.Fingerprint, &record.StatusCode,
+		&headers, &record.Body, &record.CreatedAt, &record.ExpiresAt, &lockedUntil)
+	if err != nil {
+		return nil, err
+	}
+	record.LockedUntil = lockedUntil.Time
+	if err := json.Unmarshal(headers, &record.Headers); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Complete stores the response of the request a key was reserved for. If a retry took the key
+// over and completed first, its response is kept.
+func (r *PostgresIdempotencyRepository) Complete(record *domain.IdempotencyRecord) error {
+	headers, err := json.Marshal(record.Headers)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE idempotency_keys SET status_code = $3, headers = $4, body = $5, locked_until = NULL
+	          WHERE user_id = $1 AND key = $2 AND status_code IS NULL`
+
+	_, err = r.db.Exec(query, record.UserID, record.Key, record.StatusCode, headers, record.Body)
+	return err
+}
+
+// Release deletes the reservation of a key whose request did not complete, so that it can be retried.
+func (r *PostgresIdempotencyRepository) Release(userID, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2 AND status_code IS NULL`
+
+	_, err := r.db.Exec(query, userID, key)
+	return err
+}
+
+// PurgeExpiredBefore deletes the records that expired before the given time.
+func (r *PostgresIdempotencyRepository) PurgeExpiredBefore(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM idempotency_keys WHERE expires_at <= $1`, before)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func TestPostgresIdempotencyRepository_Reserve_NewKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresIdempotencyRepository(db)
+	now := time.Now()
+	mock.ExpectQuery("INSERT INTO idempotency_keys").
+		WithArgs("u1", "k1", "abc", now, now.Add(time.Hour), now.Add(time.Minute)).
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("k1"))
+
+	existing, err := r.Reserve(&domain.IdempotencyRecord{UserID: "u1", Key: "k1", Fingerprint: "abc", CreatedAt: now, ExpiresAt: now.Add(time.Hour), LockedUntil: now.Add(time.Minute)})
+	if err != nil || existing != nil {
+		t.Errorf("se esperaba reservar la clave, obtuve %+v, %v", existing, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresIdempotencyRepository_Reserve_ExistingKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresIdempotencyRepository(db)
+	now := time.Now()
+	mock.ExpectQuery("INSERT INTO idempotency_keys").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("FROM idempotency_keys WHERE user_id = \\$1 AND key = \\$2").WithArgs("u1", "k1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "key", "fingerprint", "status_code", "headers", "body", "created_at", "expires_at", "locked_until"}).
+			AddRow("u1", "k1", "abc", 201, []byte(`{"Content-Type":"application/json"}`), []byte(`{"id":"t1"}`), now, now.Add(time.Hour), nil))
+
+	existing, err := r.Reserve(&domain.IdempotencyRecord{UserID: "u1", Key: "k1", Fingerprint: "abc", CreatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("no se esperaba error en Reserve: %v", err)
+	}
+	if existing == nil || existing.StatusCode != 201 || existing.Headers["Content-Type"] != "application/json" || string(existing.Body) != `{"id":"t1"}` {
+		t.Errorf("registro inesperado: %+v", existing)
+	}
+}
+
+func TestPostgresIdempotencyRepository_Reserve_TakesOverExpiredLock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresIdempotencyRepository(db)
+	mock.ExpectQuery("OR \\(idempotency_keys.status_code IS NULL AND idempotency_keys.fingerprint = EXCLUDED.fingerprint\\s+AND idempotency_keys.locked_until <= EXCLUDED.created_at\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("k1"))
+
+	now := time.Now()
+	existing, err := r.Reserve(&domain.IdempotencyRecord{UserID: "u1", Key: "k1", Fingerprint: "abc", CreatedAt: now, ExpiresAt: now.Add(time.Hour), LockedUntil: now.Add(time.Minute)})
+	if err != nil || existing != nil {
+		t.Errorf("se esperaba retomar la clave, obtuve %+v, %v", existing, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresIdempotencyRepository_Release_OnlyInProgress(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresIdempotencyRepository(db)
+	mock.ExpectExec("DELETE FROM idempotency_keys WHERE user_id = \\$1 AND key = \\$2 AND status_code IS NULL").
+		WithArgs("u1", "k1").WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := r.Release("u1", "k1"); err != nil {
+		t.Errorf("no se esperaba error en Release: %v", err)
+	}
+}
+
+func TestPostgresIdempotencyRepository_PurgeExpiredBefore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresIdempotencyRepository(db)
+	now := time.Now()
+	mock.ExpectExec("DELETE FROM idempotency_keys WHERE expires_at <= \\$1").WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	purged, err := r.PurgeExpiredBefore(now)
+	if err != nil || purged != 3 {
+		t.Errorf("se esperaban 3 claves purgadas, obtuve %d, %v", purged, err)
+	}
+}
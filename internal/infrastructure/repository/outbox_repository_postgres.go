@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// errOutboxEventNotFound is returned when the event to mark no longer exists.
+var errOutboxEventNotFound = errors.New("outbox event not found")
+
+// PostgresOutboxRepository is a PostgreSQL implementation of the event outbox.
+type PostgresOutboxRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresOutboxRepository creates a new PostgresOutboxRepository instance.
+func NewPostgresOutboxRepository(db *sql.DB) *PostgresOutboxRepository {
+	return &PostgresOutboxRepository{
+		db: db,
+	}
+}
+
+// insertOutboxEvent records an event about an aggregate. It runs on the transaction of the
+// change it describes, so that the event is stored if and only if the change is.
+func insertOutboxEvent(db sqlExecutor, aggregateType, aggregateID, eventType, listID string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, list_id, payload, occurred_at, available_at)
+	          VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $7)`
+
+	_, err = db.Exec(query, uuid.New().String(), aggregateType, aggregateID, eventType, listID, payload, time.Now())
+	return err
+}
+
+// insertTaskEvents records an event of the given type for each of the tasks, as they are
+// after the change it describes. It runs on the transaction of the change.
+func insertTaskEvents(db sqlExecutor, eventType string, ids []string) error {
+	for _, id := range ids {
+		task, err := scanTask(db.QueryRow(`SELECT `+taskColumns+` FROM tasks WHERE id = $1`, id))
+		if err != nil {
+			return err
+		}
+		if err := insertOutboxEvent(db, domain.AggregateTask, task.ID, eventType, task.ListID, task); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// changeTasks runs a statement that changes tasks and returns their ids, and records an event
+// of the given type for each of them in the same transaction. It returns how many tasks changed.
+func changeTasks(tx *sql.Tx, eventType, query string, args ...interface{}) (int64, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close() //nolint:errcheck
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Close(); err != nil {
+		return 0, err
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	return int64(len(ids)), insertTaskEvents(tx, eventType, ids)
+}
+
+// insertListEvent records an event of the given type for a task list, as it is after the
+// change the event describes. It runs on the transaction of the change.
+func insertListEvent(db sqlExecutor, eventType, id string) error {
+	list := &domain.TaskList{}
+	err := db.QueryRow(`SELECT id, name, description, created_at, updated_at, archived_at, version FROM task_lists WHERE id = $1`, id).
+		Scan(&list.ID, &list.Name, &list.Description, &list.CreatedAt, &list.UpdatedAt, &list.ArchivedAt, &list.Version)
+	if err != nil {
+		return err
+	}
+
+	return insertOutboxEvent(db, domain.AggregateList, id, eventType, id, list)
+}
+
+// ClaimPending retrieves up to limit unpublished events available at now, ordered by
+// sequence, and hides them until leaseUntil so that no other relay publishes them at the
+// same time. Only the oldest unpublished event of each aggregate is claimed, so the events
+// of an aggregate are never published out of order.
+func (r *PostgresOutboxRepository) ClaimPending(now, leaseUntil time.Time, limit int) ([]*domain.OutboxEntry, error) {
+	query := `UPDATE outbox_events SET available_at = $2
+	          WHERE sequence IN (
+	              SELECT o.sequence FROM outbox_events o
+	              WHERE o.published_at IS NULL AND o.available_at <= $1
+	                AND NOT EXISTS (
+	                    SELECT 1 FROM outbox_events p
+	                    WHERE p.aggregate_type = o.aggregate_type AND p.aggregate_id = o.aggregate_id
+	                      AND p.published_at IS NULL AND p.sequence < o.sequence
+	                )
+	              ORDER BY o.sequence ASC
+	              LIMIT $3
+	              FOR UPDATE SKIP LOCKED
+	          )
+	          RETURNING sequence, id, aggregate_type, aggregate_id, event_type, COALESCE(list_id, ''), payload, occurred_at,
+	                    attempts, last_error`
+
+	rows, err := r.db.Query(query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	entries := []*domain.OutboxEntry{}
+	for rows.Next() {
+		e := &domain.OutboxEntry{}
+		var payload []byte
+		if err := rows.Scan(&e.Event.Sequence, &e.Event.ID, &e.AggregateType, &e.AggregateID, &e.Event.Type, &e.Event.ListID,
+			&payload, &e.Event.OccurredAt, &e.Attempts, &e.LastError); err != nil {
+			return nil, err
+		}
+		e.Event.Data = payload
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING does not keep the order of the subquery.
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Event.Sequence < entries[j].Event.Sequence })
+
+	return entries, nil
+}
+
+// MarkPublished records that an event reached every sink.
+func (r *PostgresOutboxRepository) MarkPublished(sequence int64, publishedAt time.Time) error {
+	result, err := r.db.Exec(`UPDATE outbox_events SET published_at = $2, last_error = '' WHERE sequence = $1`, sequence, publishedAt)
+	if err != nil {
+		return err
+	}
+
+	return expectRows(result, errOutboxEventNotFound)
+}
+
+// MarkFailed records a failed attempt to publish an event and keeps it, and the later
+// events of its aggregate, waiting until nextAttemptAt.
+func (r *PostgresOutboxRepository) MarkFailed(sequence int64, lastError string, nextAttemptAt time.Time) error {
+	query := `UPDATE outbox_events SET attempts = attempts + 1, last_error = $2, available_at = $3
+	          WHERE sequence = $1`
+
+	result, err := r.db.Exec(query, sequence, lastError, nextAttemptAt)
+	if err != nil {
+		return err
+	}
+
+	return expectRows(result, errOutboxEventNotFound)
+}
+
+// PurgePublishedBefore deletes the events published before cutoff and returns how many
+// were deleted.
+func (r *PostgresOutboxRepository) PurgePublishedBefore(cutoff time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM outbox_events WHERE published_at < $1`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// eventColumns is the column list read by the event queries, in the order expected by scanEvent.
+const eventColumns = `sequence, id, event_type, COALESCE(list_id, ''), payload, occurred_at`
+
+func scanEvent(row rowScanner) (*domain.Event, error) {
+	event := &domain.Event{}
+	var payload []byte
+	if err := row.Scan(&event.Sequence, &event.ID, &event.Type, &event.ListID, &payload, &event.OccurredAt); err != nil {
+		return nil, err
+	}
+	event.Data = payload
+	return event, nil
+}
+
+// GetEvent retrieves an event by its sequence. Sinks see an event before it is marked as
+// published, so the event is returned whether it already is or not.
+func (r *PostgresOutboxRepository) GetEvent(sequence int64) (*domain.Event, error) {
+	query := `SELECT ` + eventColumns + ` FROM outbox_events WHERE sequence = $1`
+
+	event, err := scanEvent(r.db.QueryRow(query, sequence))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("event not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// GetPublishedEventsAfter retrieves up to limit published events with a sequence greater
+// than the given one, in order, optionally only those about the given list.
+func (r *PostgresOutboxRepository) GetPublishedEventsAfter(sequence int64, listID string, limit int) ([]*domain.Event, error) {
+	query := `SELECT ` + eventColumns + ` FROM outbox_events
+	          WHERE sequence > $1 AND published_at IS NOT NULL AND ($2 = '' OR list_id = $2)
+	          ORDER BY sequence ASC
+	          LIMIT $3`
+
+	rows, err := r.db.Query(query, sequence, listID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	events := []*domain.Event{}
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// PostgresEventNotifier is an outbox sink that announces every published event with a
+// PostgreSQL NOTIFY, so that every instance listening on the channel learns about it. The
+// payload is the sequence of the event, since notifications are limited to 8000 bytes.
+type PostgresEventNotifier struct {
+	db      *sql.DB
+	channel string
+}
+
+// NewPostgresEventNotifier creates a new PostgresEventNotifier notifying on the given channel.
+func NewPostgresEventNotifier(db *sql.DB, channel string) *PostgresEventNotifier {
+	return &PostgresEventNotifier{
+		db:      db,
+		channel: channel,
+	}
+}
+
+// Publish sends the notification of an event.
+func (n *PostgresEventNotifier) Publish(event *domain.Event) error {
+	_, err := n.db.Exec(`SELECT pg_notify($1, $2)`, n.channel, strconv.FormatInt(event.Sequence, 10))
+	return err
+}
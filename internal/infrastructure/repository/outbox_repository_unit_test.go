@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func TestPostgresOutboxRepository_ClaimPending_OldestPerAggregate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresOutboxRepository(db)
+	now := time.Now()
+	lease := now.Add(time.Minute)
+	columns := []string{"sequence", "id", "aggregate_type", "aggregate_id", "event_type", "list_id", "payload", "occurred_at", "attempts", "last_error"}
+	mock.ExpectQuery("UPDATE outbox_events SET available_at = \\$2(.|\\n)*NOT EXISTS(.|\\n)*p.sequence < o.sequence(.|\\n)*FOR UPDATE SKIP LOCKED").
+		WithArgs(now, lease, 100).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(9, "e9", "list", "l1", "list.updated", "l1", []byte(`{"id":"l1"}`), now, 0, "").
+			AddRow(4, "e4", "task", "t1", "task.created", "", []byte(`{"id":"t1"}`), now, 2, "broker: unavailable"))
+
+	entries, err := r.ClaimPending(now, lease, 100)
+	if err != nil {
+		t.Fatalf("no se esperaba error en ClaimPending: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Event.Sequence != 4 || entries[1].Event.Sequence != 9 {
+		t.Fatalf("esperados los eventos ordenados por secuencia, obtuve %+v", entries)
+	}
+	first := entries[0]
+	if first.AggregateType != domain.AggregateTask || first.Event.Type != domain.EventTaskCreated || first.Attempts != 2 ||
+		string(first.Event.Data) != `{"id":"t1"}` {
+		t.Errorf("evento inesperado: %+v", first)
+	}
+}
+
+func TestPostgresOutboxRepository_MarkFailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresOutboxRepository(db)
+	next := time.Now().Add(time.Second)
+	mock.ExpectExec("UPDATE outbox_events SET attempts = attempts \\+ 1").
+		WithArgs(int64(4), "broker: unavailable", next).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE outbox_events SET attempts = attempts \\+ 1").
+		WithArgs(int64(5), "broker: unavailable", next).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := r.MarkFailed(4, "broker: unavailable", next); err != nil {
+		t.Errorf("no se esperaba error en MarkFailed: %v", err)
+	}
+	if err := r.MarkFailed(5, "broker: unavailable", next); err == nil || err.Error() != "outbox event not found" {
+		t.Errorf("esperado error de evento no encontrado, obtuve %v", err)
+	}
+}
+
+func TestPostgresOutboxRepository_PurgePublishedBefore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresOutboxRepository(db)
+	cutoff := time.Now()
+	mock.ExpectExec("DELETE FROM outbox_events WHERE published_at < \\$1").WithArgs(cutoff).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	purged, err := r.PurgePublishedBefore(cutoff)
+	if err != nil || purged != 3 {
+		t.Errorf("esperados 3 eventos purgados, obtuve %d, %v", purged, err)
+	}
+}
+
+func TestPostgresOutboxRepository_GetEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresOutboxRepository(db)
+	columns := []string{"sequence", "id", "event_type", "list_id", "payload", "occurred_at"}
+	mock.ExpectQuery("SELECT sequence, id, event_type(.|\\n)*FROM outbox_events WHERE sequence = \\$1").
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(7, "e7", "task.updated", "l1", []byte(`{"id":"t1"}`), time.Now()))
+	mock.ExpectQuery("SELECT sequence, id, event_type(.|\\n)*FROM outbox_events WHERE sequence = \\$1").
+		WithArgs(int64(8)).WillReturnRows(sqlmock.NewRows(columns))
+
+	event, err := r.GetEvent(7)
+	if err != nil || event.Sequence != 7 || event.ListID != "l1" || string(event.Data) != `{"id":"t1"}` {
+		t.Errorf("evento inesperado: %+v, %v", event, err)
+	}
+	if _, err := r.GetEvent(8); err == nil || err.Error() != "event not found" {
+		t.Errorf("esperado error de evento no encontrado, obtuve %v", err)
+	}
+}
+
+func TestPostgresOutboxRepository_GetPublishedEventsAfter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresOutboxRepository(db)
+	now := time.Now()
+	mock.ExpectQuery("WHERE sequence > \\$1 AND published_at IS NOT NULL AND \\(\\$2 = '' OR list_id = \\$2\\)(.|\\n)*ORDER BY sequence ASC").
+		WithArgs(int64(41), "l1", 1000).
+		WillReturnRows(sqlmock.NewRows([]string{"sequence", "id", "event_type", "list_id", "payload", "occurred_at"}).
+			AddRow(42, "e42", "task.created", "l1", []byte(`{}`), now).
+			AddRow(45, "e45", "list.updated", "l1", []byte(`{}`), now))
+
+	events, err := r.GetPublishedEventsAfter(41, "l1", 1000)
+	if err != nil {
+		t.Fatalf("no se esperaba error en GetPublishedEventsAfter: %v", err)
+	}
+	if len(events) != 2 || events[0].Sequence != 42 || events[1].Type != domain.EventListUpdated {
+		t.Errorf("eventos inesperados: %+v", events)
+	}
+}
+
+func TestPostgresEventNotifier_Publish(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	n := NewPostgresEventNotifier(db, "task_events")
+	mock.ExpectExec("SELECT pg_notify\\(\\$1, \\$2\\)").WithArgs("task_events", "42").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := n.Publish(&domain.Event{Sequence: 42}); err != nil {
+		t.Errorf("no se esperaba error en Publish: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresTaskListRepository_Update_RecordsEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTaskListRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE task_lists SET name").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "list", "l1", "list.updated", "l1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	list := &domain.TaskList{ID: "l1", Name: "Lista", UpdatedAt: time.Now(), Version: 2}
+	if err := r.Update(list); err != nil {
+		t.Fatalf("no se esperaba error en Update: %v", err)
+	}
+	if list.Version != 3 {
+		t.Errorf("esperada versión 3, obtuve %d", list.Version)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+// expectTaskEvent expects the task to be read back and its event recorded.
+func expectTaskEvent(mock sqlmock.Sqlmock, id, eventType string) {
+	now := time.Now()
+	mock.ExpectQuery("FROM tasks WHERE id = \\$1").WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(taskColumnNames).AddRow(id, "l1", "t", "", "pending", "low", now, now, nil, nil, "", "{}", "", 0, 0, 2))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "task", id, eventType, "l1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+// expectListEvent expects the list to be read back and its event recorded.
+func expectListEvent(mock sqlmock.Sqlmock, id, eventType string) {
+	mock.ExpectQuery("FROM task_lists WHERE id = \\$1").WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "created_at", "updated_at", "archived_at", "version"}).
+			AddRow(id, "Lista", "", time.Now(), time.Now(), nil, 2))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "list", id, eventType, id, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// cursorTimeLayout is the layout of the timestamp values of a cursor, with the
+// microsecond precision of Postgres.
+const cursorTimeLayout = "2006-01-02T15:04:05.999999"
+
+// sortColumn describes how a whitelisted sort field is ordered in SQL, how cursor values
+// are cast back for comparison and how the cursor value of an item is obtained.
+type sortColumn[T any] struct {
+	expr  string
+	cast  string
+	value func(item T) string
+}
+
+// keyset appends the cursor condition, ORDER BY and LIMIT of a page to a query whose
+// WHERE clause is already built. The id column breaks ties so that the order is total.
+// One extra row is requested to detect whether a following page exists. A cursor whose
+// values do not have the types of the sort column and the id is domain.ErrInvalidCursor.
+func keyset[T any](query string, args []interface{}, columns map[string]sortColumn[T], page domain.PageRequest) (string, []interface{}, error) {
+	column, ok := columns[page.Sort]
+	if !ok {
+		return "", nil, errors.New("invalid sort field: " + page.Sort)
+	}
+
+	direction, comparison := "ASC", ">"
+	if page.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if page.After != nil {
+		if !validCursor(column.cast, page.After) {
+			return "", nil, domain.ErrInvalidCursor
+		}
+		query += fmt.Sprintf(` AND (%s, id) %s ($%d::%s, $%d)`, column.expr, comparison, len(args)+1, column.cast, len(args)+2)
+		args = append(args, page.After.Value, page.After.ID)
+	}
+
+	query += fmt.Sprintf(` ORDER BY %s %s, id %s LIMIT %d`, column.expr, direction, direction, page.Limit+1)
+
+	return query, args, nil
+}
+
+// validCursor reports whether the values of a cursor can be cast to the type of the sort
+// column and to a UUID, so that a tampered cursor is refused before reaching Postgres.
+func validCursor(cast string, cursor *domain.Cursor) bool {
+	if _, err := uuid.Parse(cursor.ID); err != nil {
+		return false
+	}
+
+	switch cast {
+	case "timestamp":
+		if cursor.Value == "infinity" {
+			return true
+		}
+		_, err := time.Parse(cursorTimeLayout, cursor.Value)
+		return err == nil
+	case "int":
+		_, err := strconv.ParseInt(cursor.Value, 10, 32)
+		return err == nil
+	}
+	return utf8.ValidString(cursor.Value) && !strings.ContainsRune(cursor.Value, 0)
+}
+
+// trimPage cuts the extra row requested by keyset and returns the cursor of the last
+// item when a following page exists.
+func trimPage[T any](items []T, columns map[string]sortColumn[T], page domain.PageRequest, id func(T) string) ([]T, *domain.Cursor) {
+	if len(items) <= page.Limit {
+		return items, nil
+	}
+
+	items = items[:page.Limit]
+	last := items[len(items)-1]
+
+	return items, &domain.Cursor{Value: columns[page.Sort].value(last), ID: id(last)}
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+var taskColumnNames = []string{"id", "list_id", "title", "description", "status", "priority", "created_at", "updated_at", "archived_at", "due_date", "parent_id", "labels", "sprint_id", "checklist_total", "checklist_done", "version"}
+
+func TestPostgresTaskRepository_List_KeysetAfterCursor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTaskRepository(db)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 123456000, time.UTC)
+	mock.ExpectQuery("FROM tasks WHERE deleted_at IS NULL AND archived_at IS NULL .+ AND status = \\$1 AND \\(title, id\\) < \\(\\$2::text, \\$3\\) ORDER BY title DESC, id DESC LIMIT 3").
+		WithArgs("pending", "M", "0b9c7a4e-5d2f-4a61-9e0e-2f8a1c3b6d90").
+		WillReturnRows(sqlmock.NewRows(taskColumnNames).
+			AddRow("3", "l", "C", "", "pending", "low", now, now, nil, nil, "", "{}", "", 0, 0, 1).
+			AddRow("2", "l", "B", "", "pending", "low", now, now, nil, nil, "", "{}", "", 0, 0, 1).
+			AddRow("1", "l", "A", "", "pending", "low", now, now, nil, nil, "", "{}", "", 0, 0, 1))
+
+	page, err := r.List(domain.TaskFilter{Status: "pending"}, domain.PageRequest{Limit: 2, Sort: "title", Desc: true, After: &domain.Cursor{Value: "M", ID: "0b9c7a4e-5d2f-4a61-9e0e-2f8a1c3b6d90"}})
+	if err != nil {
+		t.Fatalf("no se esperaba error en List: %v", err)
+	}
+	if len(page.Items) != 2 {
+		t.Fatalf("esperadas 2 tareas, obtuve %d", len(page.Items))
+	}
+	if page.Next == nil || page.Next.Value != "B" || page.Next.ID != "2" {
+		t.Errorf("cursor siguiente inesperado: %+v", page.Next)
+	}
+	if page.Total != nil {
+		t.Errorf("no se esperaba total, obtuve %d", *page.Total)
+	}
+}
+
+func TestPostgresTaskRepository_List_LastPageWithTotal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTaskRepository(db)
+	now := time.Now()
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks WHERE deleted_at IS NULL").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("ORDER BY created_at ASC, id ASC LIMIT 51").
+		WillReturnRows(sqlmock.NewRows(taskColumnNames).
+			AddRow("1", "l", "A", "", "pending", "low", now, now, nil, nil, "", "{}", "", 0, 0, 1))
+
+	page, err := r.List(domain.TaskFilter{IncludeArchived: true}, domain.PageRequest{Limit: 50, Sort: "created_at", IncludeTotal: true})
+	if err != nil {
+		t.Fatalf("no se esperaba error en List: %v", err)
+	}
+	if page.Next != nil {
+		t.Errorf("no se esperaba cursor en la última página, obtuve %+v", page.Next)
+	}
+	if page.Total == nil || *page.Total != 1 {
+		t.Errorf("esperado total 1, obtuve %v", page.Total)
+	}
+}
+
+func TestPostgresTaskListRepository_List_InvalidSort(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTaskListRepository(db)
+	if _, err := r.List(false, domain.PageRequest{Limit: 10, Sort: "priority"}); err == nil {
+		t.Error("esperado error por campo de orden inválido")
+	}
+}
+
+func TestPostgresTaskRepository_List_TamperedCursor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTaskRepository(db)
+	id := "0b9c7a4e-5d2f-4a61-9e0e-2f8a1c3b6d90"
+
+	for _, tc := range []struct {
+		sort   string
+		cursor domain.Cursor
+	}{
+		{"created_at", domain.Cursor{Value: "ayer", ID: id}},
+		{"due_date", domain.Cursor{Value: "2026-13-01T00:00:00", ID: id}},
+		{"priority", domain.Cursor{Value: "high", ID: id}},
+		{"priority", domain.Cursor{Value: "99999999999", ID: id}},
+		{"title", domain.Cursor{Value: "a\x00b", ID: id}},
+		{"title", domain.Cursor{Value: "M", ID: "9"}},
+	} {
+		cursor := tc.cursor
+		_, err := r.List(domain.TaskFilter{}, domain.PageRequest{Limit: 10, Sort: tc.sort, After: &cursor})
+		if !errors.Is(err, domain.ErrInvalidCursor) {
+			t.Errorf("%s %+v: esperado ErrInvalidCursor, obtuve %v", tc.sort, tc.cursor, err)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("no se esperaban consultas: %v", err)
+	}
+}
+
+func TestFormatCursorTime_KeepsMicroseconds(t *testing.T) {
+	got := formatCursorTime(time.Date(2026, 1, 2, 3, 4, 5, 123456789, time.UTC))
+	if got != "2026-01-02T03:04:05.123456" {
+		t.Errorf("formato de cursor inesperado: %s", got)
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// flowQuery counts snapshots per status for every day of the range, including days without
+// snapshots. Callers append the condition on the column the series is scoped by.
+const flowQuery = `SELECT d::date,
+	                 COUNT(s.task_id) FILTER (WHERE s.status = 'pending'),
+	                 COUNT(s.task_id) FILTER (WHERE s.status = 'in-progress'),
+	                 COUNT(s.task_id) FILTER (WHERE s.status = 'completed'),
+	                 COUNT(s.task_id)
+	          FROM generate_series($2::date, $3::date, INTERVAL '1 day') AS d
+	          LEFT JOIN task_status_snapshots s ON s.snapshot_date = d::date AND s.`
+
+// PostgresReportRepository is a PostgreSQL implementation of the report repository.
+type PostgresReportRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresReportRepository creates a new PostgresReportRepository instance.
+func NewPostgresReportRepository(db *sql.DB) *PostgresReportRepository {
+	return &PostgresReportRepository{
+		db: db,
+	}
+}
+
+// SnapshotStatuses records the status, list and sprint of every live task for the given day.
+// Running it again on the same day overwrites that day's snapshot.
+func (r *PostgresReportRepository) SnapshotStatuses(day time.Time) (int64, error) {
+	query := `INSERT INTO task_status_snapshots (snapshot_date, task_id, list_id, sprint_id, status)
+	          SELECT $1::date, id, list_id, sprint_id, status FROM tasks WHERE deleted_at IS NULL
+	          ON CONFLICT (snapshot_date, task_id)
+	          DO UPDATE SET list_id = EXCLUDED.list_id, sprint_id = EXCLUDED.sprint_id, status = EXCLUDED.status`
+
+	result, err := r.db.Exec(query, day)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// ListFlow counts the tasks of a list per status for every day in [from, to].
+func (r *PostgresReportRepository) ListFlow(listID string, from, to time.Time) ([]*domain.FlowPoint, error) {
+	return r.flow(flowQuery+`list_id = $1 GROUP BY d ORDER BY d`, listID, from, to)
+}
+
+// SprintFlow counts the tasks of a sprint per status for every day in [from, to].
+func (r *PostgresReportRepository) SprintFlow(sprintID string, from, to time.Time) ([]*domain.FlowPoint, error) {
+	return r.flow(flowQuery+`sprint_id = $1 GROUP BY d ORDER BY d`, sprintID, from, to)
+}
+
+func (r *PostgresReportRepository) flow(query, id string, from, to time.Time) ([]*domain.FlowPoint, error) {
+	rows, err := r.db.Query(query, id, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	points := []*domain.FlowPoint{}
+	for rows.Next() {
+		p := &domain.FlowPoint{}
+		if err := rows.Scan(&p.Date, &p.Pending, &p.InProgress, &p.Completed, &p.Total); err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+	}
+
+	return points, rows.Err()
+}
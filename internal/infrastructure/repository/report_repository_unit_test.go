@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestPostgresReportRepository_SnapshotStatuses(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresReportRepository(db)
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec("INSERT INTO task_status_snapshots .+ ON CONFLICT \\(snapshot_date, task_id\\) DO UPDATE").
+		WithArgs(day).WillReturnResult(sqlmock.NewResult(0, 5))
+
+	count, err := r.SnapshotStatuses(day)
+	if err != nil {
+		t.Fatalf("no se esperaba error en SnapshotStatuses: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("esperadas 5 tareas, obtuve %d", count)
+	}
+}
+
+func TestPostgresReportRepository_SprintFlow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresReportRepository(db)
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	mock.ExpectQuery("FROM generate_series\\(\\$2::date, \\$3::date, INTERVAL '1 day'\\) AS d .+ s.sprint_id = \\$1 GROUP BY d").
+		WithArgs("s1", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"d", "pending", "in_progress", "completed", "total"}).
+			AddRow(from, 3, 1, 0, 4).
+			AddRow(to, 1, 1, 2, 4))
+
+	points, err := r.SprintFlow("s1", from, to)
+	if err != nil {
+		t.Fatalf("no se esperaba error en SprintFlow: %v", err)
+	}
+	if len(points) != 2 || points[1].Completed != 2 || points[0].Pending != 3 {
+		t.Errorf("puntos inesperados: %+v", points)
+	}
+}
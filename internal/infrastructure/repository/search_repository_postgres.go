@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// headlineOptions mark the matched terms of the snippets with <mark>. Titles are
+// highlighted whole; descriptions are cut to the fragments around the matches.
+const (
+	titleHeadlineOptions       = `StartSel=<mark>, StopSel=</mark>, HighlightAll=true`
+	descriptionHeadlineOptions = `StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" … "`
+)
+
+// PostgresSearchRepository implements full-text search over tasks with tsvector columns.
+type PostgresSearchRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSearchRepository creates a new PostgresSearchRepository instance.
+func NewPostgresSearchRepository(db *sql.DB) *PostgresSearchRepository {
+	return &PostgresSearchRepository{db: db}
+}
+
+// Search returns the live tasks matching the query and the filter, ordered by relevance.
+// Accents are ignored both in the indexed text and in the query.
+func (r *PostgresSearchRepository) Search(query string, filter domain.TaskFilter, limit, offset int) ([]*domain.SearchResult, error) {
+	where, args, err := taskFilterWhere(filter)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, query, limit, offset)
+	n := len(args)
+
+	statement := fmt.Sprintf(`SELECT %s,
+	          ts_rank(search_vector, q.query) AS search_rank,
+	          ts_headline(s.language, title, q.query, '%s'),
+	          ts_headline(s.language, COALESCE(description, ''), q.query, '%s')
+	          FROM tasks, search_settings s, websearch_to_tsquery(s.language, immutable_unaccent($%d)) AS q(query)
+	          WHERE %s AND search_vector @@ q.query
+	          ORDER BY search_rank DESC, updated_at DESC, id
+	          LIMIT $%d OFFSET $%d`,
+		taskColumns, titleHeadlineOptions, descriptionHeadlineOptions, n-2, where, n-1, n)
+
+	rows, err := r.db.Query(statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	results := []*domain.SearchResult{}
+	for rows.Next() {
+		result := &domain.SearchResult{}
+		task, err := scanTask(withExtraColumns(rows, &result.Rank, &result.TitleSnippet, &result.DescriptionSnippet))
+		if err != nil {
+			return nil, err
+		}
+		result.Task = task
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+// SetLanguage stores the text search configuration and, when it changed, rebuilds the
+// search vector of every task with it.
+func (r *PostgresSearchRepository) SetLanguage(language string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	result, err := tx.Exec(`UPDATE search_settings SET language = $1::regconfig WHERE language <> $1::regconfig`, language)
+	if err != nil {
+		return err
+	}
+
+	changed, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if changed > 0 {
+		if _, err := tx.Exec(`UPDATE tasks SET search_vector = task_search_vector($1::regconfig, title, description)`, language); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// extraColumns scans the columns selected after taskColumns into extra destinations.
+type extraColumns struct {
+	row   rowScanner
+	extra []interface{}
+}
+
+func withExtraColumns(row rowScanner, extra ...interface{}) rowScanner {
+	return extraColumns{row: row, extra: extra}
+}
+
+func (e extraColumns) Scan(dest ...interface{}) error {
+	return e.row.Scan(append(dest, e.extra...)...)
+}
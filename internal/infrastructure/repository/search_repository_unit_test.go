@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func TestPostgresSearchRepository_Search_RanksAndSnippets(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresSearchRepository(db)
+	now := time.Now()
+	columns := append(append([]string{}, taskColumnNames...), "search_rank", "title_snippet", "description_snippet")
+	mock.ExpectQuery("websearch_to_tsquery\\(s.language, immutable_unaccent\\(\\$2\\)\\).+AND status = \\$1 AND search_vector @@ q.query ORDER BY search_rank DESC.+LIMIT \\$3 OFFSET \\$4").
+		WithArgs("pending", "cancion", 20, 0).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("1", "l", "Canción", "", "pending", "low", now, now, nil, nil, "", "{}", "", 0, 0, 1, 0.6, "<mark>Canción</mark>", ""))
+
+	results, err := r.Search("cancion", domain.TaskFilter{Status: "pending"}, 20, 0)
+	if err != nil {
+		t.Fatalf("no se esperaba error en Search: %v", err)
+	}
+	if len(results) != 1 || results[0].Task.ID != "1" || results[0].Rank != 0.6 || results[0].TitleSnippet != "<mark>Canción</mark>" {
+		t.Errorf("resultado inesperado: %+v", results)
+	}
+}
+
+func TestPostgresSearchRepository_SetLanguage_ReindexesWhenChanged(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresSearchRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE search_settings SET language").WithArgs("english").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE tasks SET search_vector = task_search_vector").WithArgs("english").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	if err := r.SetLanguage("english"); err != nil {
+		t.Fatalf("no se esperaba error en SetLanguage: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresSearchRepository_SetLanguage_Unchanged(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresSearchRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE search_settings SET language").WithArgs("spanish").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	if err := r.SetLanguage("spanish"); err != nil {
+		t.Fatalf("no se esperaba error en SetLanguage: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
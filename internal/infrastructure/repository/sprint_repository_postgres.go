@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+const sprintColumns = `id, name, goal, kind, status, starts_at, ends_at, started_at, closed_at, rolled_over, created_at, updated_at`
+
+// PostgresSprintRepository is a PostgreSQL implementation of the sprint repository.
+type PostgresSprintRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSprintRepository creates a new PostgresSprintRepository instance.
+func NewPostgresSprintRepository(db *sql.DB) *PostgresSprintRepository {
+	return &PostgresSprintRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new sprint into the database.
+func (r *PostgresSprintRepository) Create(s *domain.Sprint) error {
+	query := `INSERT INTO sprints (id, name, goal, kind, status, starts_at, ends_at, created_at, updated_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.Exec(query, s.ID, s.Name, s.Goal, s.Kind, s.Status, s.StartsAt, s.EndsAt, s.CreatedAt, s.UpdatedAt)
+	return err
+}
+
+// GetAll retrieves all sprints ordered by their end date.
+func (r *PostgresSprintRepository) GetAll() ([]*domain.Sprint, error) {
+	query := `SELECT ` + sprintColumns + ` FROM sprints ORDER BY ends_at ASC, created_at ASC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	sprints := []*domain.Sprint{}
+	for rows.Next() {
+		s, err := scanSprint(rows)
+		if err != nil {
+			return nil, err
+		}
+		sprints = append(sprints, s)
+	}
+
+	return sprints, rows.Err()
+}
+
+// GetByID retrieves a sprint by its ID.
+func (r *PostgresSprintRepository) GetByID(id string) (*domain.Sprint, error) {
+	query := `SELECT ` + sprintColumns + ` FROM sprints WHERE id = $1`
+
+	s, err := scanSprint(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrSprintNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Update stores the name, goal, kind and dates of a sprint.
+func (r *PostgresSprintRepository) Update(s *domain.Sprint) error {
+	query := `UPDATE sprints SET name = $2, goal = $3, kind = $4, starts_at = $5, ends_at = $6, updated_at = $7
+	          WHERE id = $1`
+
+	result, err := r.db.Exec(query, s.ID, s.Name, s.Goal, s.Kind, s.StartsAt, s.EndsAt, s.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return expectRows(result, domain.ErrSprintNotFound)
+}
+
+// Delete removes a sprint after unassigning its tasks. A task.updated event for each unassigned
+// task is recorded in the same transaction, and the revisions are attributed to changedBy.
+func (r *PostgresSprintRepository) Delete(id, changedBy string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	if err := setChangedBy(tx, changedBy); err != nil {
+		return err
+	}
+
+	_, err = changeTasks(tx, domain.EventTaskUpdated, `UPDATE tasks SET sprint_id = NULL WHERE sprint_id = $1 RETURNING id`, id)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`DELETE FROM sprints WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if err := expectRows(result, domain.ErrSprintNotFound); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// AssignTasks moves live tasks into the sprint and returns how many were assigned. Nothing is
+// assigned if any of the tasks belongs to an archived list. A task.updated event for each
+// assigned task is recorded in the same transaction, and the revisions are attributed to changedBy.
+func (r *PostgresSprintRepository) AssignTasks(sprintID string, taskIDs []string, changedBy string) (count int64, err error) {
+	query := `UPDATE tasks SET sprint_id = $1 WHERE id = ANY($2) AND deleted_at IS NULL RETURNING id`
+
+	err = inTxAs(r.db, changedBy, func(tx *sql.Tx) error {
+		if err := ensureTasksWritable(tx, taskIDs); err != nil {
+			return err
+		}
+
+		count, err = changeTasks(tx, domain.EventTaskUpdated, query, sprintID, pq.Array(taskIDs))
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// UnassignTask removes a task from the sprint. Tasks of an archived list cannot be changed.
+// A task.updated event is recorded in the same transaction, and the revision is attributed to changedBy.
+func (r *PostgresSprintRepository) UnassignTask(sprintID, taskID, changedBy string) error {
+	query := `UPDATE tasks SET sprint_id = NULL WHERE id = $1 AND sprint_id = $2 AND deleted_at IS NULL`
+
+	return inTxAs(r.db, changedBy, func(tx *sql.Tx) error {
+		if err := ensureTasksWritable(tx, []string{taskID}); err != nil {
+			return err
+		}
+
+		result, err := tx.Exec(query, taskID, sprintID)
+		if err != nil {
+			return err
+		}
+
+		if err := expectRows(result, domain.ErrTaskNotFound); err != nil {
+			return err
+		}
+		return insertTaskEvents(tx, domain.EventTaskUpdated, []string{taskID})
+	})
+}
+
+// GetTasks retrieves the live tasks of a sprint, oldest first.
+func (r *PostgresSprintRepository) GetTasks(sprintID string) ([]*domain.Task, error) {
+	query := `SELECT ` + taskColumns + `
+	          FROM tasks WHERE sprint_id = $1 AND deleted_at IS NULL ORDER BY created_at ASC`
+
+	rows, err := r.db.Query(query, sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTasks(rows)
+}
+
+// Start activates a planned sprint and records its current live tasks as committed.
+func (r *PostgresSprintRepository) Start(id string, at time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	result, err := tx.Exec(`UPDATE sprints SET status = 'active', started_at = $2, updated_at = $2
+	          WHERE id = $1 AND status = 'planned'`, id, at)
+	if err != nil {
+		return err
+	}
+	if err := expectRows(result, domain.ErrSprintNotPlanned); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`INSERT INTO sprint_commitments (sprint_id, task_id)
+	          SELECT $1, id FROM tasks WHERE sprint_id = $1 AND deleted_at IS NULL
+	          ON CONFLICT DO NOTHING`, id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Close closes an active sprint and moves its unfinished live tasks to nextID, or back to
+// the backlog when nextID is empty. It returns how many tasks were rolled over. A task.updated
+// event for each moved task is recorded in the same transaction, and the revisions are
+// attributed to changedBy.
+func (r *PostgresSprintRepository) Close(id, nextID string, at time.Time, changedBy string) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	if err := setChangedBy(tx, changedBy); err != nil {
+		return 0, err
+	}
+
+	rolled, err := changeTasks(tx, domain.EventTaskUpdated, `UPDATE tasks SET sprint_id = NULLIF($2, '')
+	          WHERE sprint_id = $1 AND status <> 'completed' AND deleted_at IS NULL RETURNING id`, id, nextID)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(`UPDATE sprints SET status = 'closed', closed_at = $2, rolled_over = $3, updated_at = $2
+	          WHERE id = $1 AND status = 'active'`, id, at, rolled)
+	if err != nil {
+		return 0, err
+	}
+	if err := expectRows(result, domain.ErrSprintNotActive); err != nil {
+		return 0, err
+	}
+
+	return rolled, tx.Commit()
+}
+
+// Summary counts committed, added and completed tasks of a sprint in a single query.
+func (r *PostgresSprintRepository) Summary(id string) (*domain.SprintSummary, error) {
+	query := `SELECT s.rolled_over,
+	                 (SELECT COUNT(*) FROM sprint_commitments c WHERE c.sprint_id = s.id),
+	                 COUNT(t.id) FILTER (WHERE c.task_id IS NOT NULL AND t.status = 'completed'),
+	                 COUNT(t.id) FILTER (WHERE c.task_id IS NULL AND s.started_at IS NOT NULL),
+	                 COUNT(t.id) FILTER (WHERE t.status = 'completed'),
+	                 COUNT(t.id)
+	          FROM sprints s
+	          LEFT JOIN tasks t ON t.sprint_id = s.id AND t.deleted_at IS NULL
+	          LEFT JOIN sprint_commitments c ON c.sprint_id = s.id AND c.task_id = t.id
+	          WHERE s.id = $1
+	          GROUP BY s.id, s.rolled_over`
+
+	summary := &domain.SprintSummary{}
+	err := r.db.QueryRow(query, id).Scan(&summary.RolledOver, &summary.Committed, &summary.CommittedCompleted,
+		&summary.Added, &summary.Completed, &summary.Total)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrSprintNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	summary.Remaining = summary.Total - summary.Completed
+
+	return summary, nil
+}
+
+func scanSprint(row rowScanner) (*domain.Sprint, error) {
+	s := &domain.Sprint{}
+	err := row.Scan(&s.ID, &s.Name, &s.Goal, &s.Kind, &s.Status, &s.StartsAt, &s.EndsAt,
+		&s.StartedAt, &s.ClosedAt, &s.RolledOver, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// expectRows returns notFound when the statement affected no rows.
+func expectRows(result sql.Result, notFound error) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return notFound
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func TestPostgresSprintRepository_Close_RollsOverUnfinished(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresSprintRepository(db)
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE tasks SET sprint_id = NULLIF\\(\\$2, ''\\) WHERE sprint_id = \\$1 AND status <> 'completed'").
+		WithArgs("s1", "s2").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1").AddRow("t2").AddRow("t3"))
+	expectTaskEvent(mock, "t1", "task.updated")
+	expectTaskEvent(mock, "t2", "task.updated")
+	expectTaskEvent(mock, "t3", "task.updated")
+	mock.ExpectExec("UPDATE sprints SET status = 'closed'").WithArgs("s1", now, int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	rolled, err := r.Close("s1", "s2", now, "")
+	if err != nil {
+		t.Fatalf("no se esperaba error en Close: %v", err)
+	}
+	if rolled != 3 {
+		t.Errorf("esperadas 3 tareas movidas, obtuve %d", rolled)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresSprintRepository_AssignTasks_ListArchived(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresSprintRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("FOR SHARE OF l").WillReturnRows(sqlmock.NewRows([]string{"archived"}).AddRow(true))
+	mock.ExpectRollback()
+
+	count, err := r.AssignTasks("s1", []string{"t1", "t2"}, "")
+	if !errors.Is(err, domain.ErrTaskListArchived) || count != 0 {
+		t.Errorf("esperado error de lista archivada sin asignaciones, obtuve %d, err: %v", count, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresSprintRepository_Start_NotPlanned(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresSprintRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE sprints SET status = 'active'").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	if err := r.Start("s1", time.Now()); err == nil || err.Error() != "sprint is not planned" {
+		t.Errorf("esperado error de sprint no planificado, obtuve %v", err)
+	}
+}
+
+func TestPostgresSprintRepository_Summary(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresSprintRepository(db)
+	mock.ExpectQuery("FROM sprints s LEFT JOIN tasks t").WithArgs("s1").
+		WillReturnRows(sqlmock.NewRows([]string{"rolled_over", "committed", "committed_completed", "added", "completed", "total"}).
+			AddRow(1, 5, 3, 2, 4, 7))
+
+	summary, err := r.Summary("s1")
+	if err != nil {
+		t.Fatalf("no se esperaba error en Summary: %v", err)
+	}
+	if summary.Committed != 5 || summary.Completed != 4 || summary.Remaining != 3 {
+		t.Errorf("resumen inesperado: %+v", summary)
+	}
+
+	mock.ExpectQuery("FROM sprints s LEFT JOIN tasks t").WithArgs("missing").WillReturnError(sql.ErrNoRows)
+	if _, err := r.Summary("missing"); err == nil || err.Error() != "sprint not found" {
+		t.Errorf("esperado error de sprint no encontrado, obtuve %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// PostgresSyncRepository is a PostgreSQL implementation of the changes feed repository.
+type PostgresSyncRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresSyncRepository creates a new PostgresSyncRepository instance.
+func NewPostgresSyncRepository(db *sql.DB) *PostgresSyncRepository {
+	return &PostgresSyncRepository{
+		db: db,
+	}
+}
+
+// GetChanges retrieves up to limit changes with a sequence greater than since, in order.
+// Tasks and lists in the trash are reported as deletions, as are those purged since, which
+// are only known by their tombstone. A first sync (since 0) skips deletions altogether.
+// Everything is read from one snapshot; "sync token expired" is returned when tombstones
+// the client has not seen were already purged.
+func (r *PostgresSyncRepository) GetChanges(since int64, limit int) (*domain.SyncFeed, error) {
+	tx, err := r.db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	var lastSequence, purgedSequence int64
+	if err := tx.QueryRow(`SELECT last_sequence, purged_sequence FROM sync_state`).Scan(&lastSequence, &purgedSequence); err != nil {
+		return nil, err
+	}
+	if since > 0 && since < purgedSequence {
+		return nil, domain.ErrSyncTokenExpired
+	}
+
+	feed, err := syncChanges(tx, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	if err := loadSyncEntities(tx, feed.Changes); err != nil {
+		return nil, err
+	}
+
+	// Every sequence up to last_sequence is committed in this snapshot, so a client that
+	// has read all the changes can continue from there.
+	feed.Sequence = lastSequence
+	if feed.HasMore {
+		feed.Sequence = feed.Changes[len(feed.Changes)-1].Sequence
+	}
+	if feed.Sequence < since {
+		feed.Sequence = since
+	}
+
+	return feed, tx.Commit()
+}
+
+// syncChanges reads the position of the changes in the feed, without their entities. One
+// extra row is requested to detect whether more changes follow.
+func syncChanges(tx *sql.Tx, since int64, limit int) (*domain.SyncFeed, error) {
+	query := `SELECT entity_type, id, change_seq, deleted FROM (
+	              SELECT 'task' AS entity_type, id, change_seq, deleted_at IS NOT NULL AS deleted FROM tasks WHERE change_seq > $1
+	              UNION ALL
+	              SELECT 'list', id, change_seq, deleted_at IS NOT NULL FROM task_lists WHERE change_seq > $1
+	              UNION ALL
+	              SELECT entity_type, entity_id, change_seq, TRUE FROM sync_tombstones WHERE change_seq > $1
+	          ) changes
+	          WHERE $1 > 0 OR NOT deleted
+	          ORDER BY change_seq ASC
+	          LIMIT $2`
+
+	rows, err := tx.Query(query, since, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	feed := &domain.SyncFeed{Changes: []*domain.SyncChange{}}
+	for rows.Next() {
+		change := &domain.SyncChange{Op: domain.SyncUpsert}
+		var deleted bool
+		if err := rows.Scan(&change.Type, &change.ID, &change.Sequence, &deleted); err != nil {
+			return nil, err
+		}
+		if deleted {
+			change.Op = domain.SyncDelete
+		}
+		feed.Changes = append(feed.Changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(feed.Changes) > limit {
+		feed.Changes = feed.Changes[:limit]
+		feed.HasMore = true
+	}
+
+	return feed, nil
+}
+
+// loadSyncEntities reads the current state of the tasks and lists upserted by the changes.
+func loadSyncEntities(tx *sql.Tx, changes []*domain.SyncChange) error {
+	var taskIDs, listIDs []string
+	for _, change := range changes {
+		if change.Op != domain.SyncUpsert {
+			continue
+		}
+		if change.Type == domain.SyncTask {
+			taskIDs = append(taskIDs, change.ID)
+		} else {
+			listIDs = append(listIDs, change.ID)
+		}
+	}
+
+	tasks := map[string]*domain.Task{}
+	if len(taskIDs) > 0 {
+		rows, err := tx.Query(`SELECT `+taskColumns+` FROM tasks WHERE id = ANY($1)`, pq.Array(taskIDs))
+		if err != nil {
+			return err
+		}
+		found, err := scanTasks(rows)
+		if err != nil {
+			return err
+		}
+		for _, task := range found {
+			tasks[task.ID] = task
+		}
+	}
+
+	lists := map[string]*domain.TaskList{}
+	if len(listIDs) > 0 {
+		rows, err := tx.Query(`SELECT id, name, description, created_at, updated_at, archived_at, version
+		          FROM task_lists WHERE id = ANY($1)`, pq.Array(listIDs))
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = rows.Close() //nolint:errcheck,gocritic
+		}()
+		for rows.Next() {
+			list := &domain.TaskList{}
+			if err := rows.Scan(&list.ID, &list.Name, &list.Description, &list.CreatedAt, &list.UpdatedAt, &list.ArchivedAt, &list.Version); err != nil {
+				return err
+			}
+			lists[list.ID] = list
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	for _, change := range changes {
+		if change.Op != domain.SyncUpsert {
+			continue
+		}
+		if change.Type == domain.SyncTask {
+			change.Task = tasks[change.ID]
+		} else {
+			change.List = lists[change.ID]
+		}
+	}
+
+	return nil
+}
+
+// PurgeTombstonesBefore permanently removes the tombstones of entities deleted before the
+// given time and returns how many were removed. Clients that have not synced since then
+// can no longer continue from their token and must sync from scratch.
+func (r *PostgresSyncRepository) PurgeTombstonesBefore(before time.Time) (int64, error) {
+	query := `WITH purged AS (
+	              DELETE FROM sync_tombstones WHERE deleted_at < $1 RETURNING change_seq
+	          )
+	          UPDATE sync_state SET purged_sequence = GREATEST(purged_sequence, (SELECT COALESCE(MAX(change_seq), 0) FROM purged))
+	          RETURNING (SELECT COUNT(*) FROM purged)`
+
+	var purged int64
+	if err := r.db.QueryRow(query, before).Scan(&purged); err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+var syncChangeColumns = []string{"entity_type", "id", "change_seq", "deleted"}
+
+func TestPostgresSyncRepository_GetChanges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresSyncRepository(db)
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT last_sequence, purged_sequence FROM sync_state").
+		WillReturnRows(sqlmock.NewRows([]string{"last_sequence", "purged_sequence"}).AddRow(30, 5))
+	mock.ExpectQuery("FROM tasks WHERE change_seq > \\$1(.|\\n)*FROM task_lists WHERE change_seq > \\$1(.|\\n)*FROM sync_tombstones(.|\\n)*ORDER BY change_seq ASC").
+		WithArgs(int64(10), 4).
+		WillReturnRows(sqlmock.NewRows(syncChangeColumns).
+			AddRow("list", "l1", 11, false).
+			AddRow("task", "t1", 12, false).
+			AddRow("task", "t2", 14, true))
+	mock.ExpectQuery("SELECT (.|\\n)* FROM tasks WHERE id = ANY\\(\\$1\\)").
+		WillReturnRows(sqlmock.NewRows(taskColumnNames).
+			AddRow("t1", "l1", "T", "", "pending", "medium", now, now, nil, nil, "", "{}", "", 0, 0, 3))
+	mock.ExpectQuery("SELECT id, name, description(.|\\n)*FROM task_lists WHERE id = ANY\\(\\$1\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "created_at", "updated_at", "archived_at", "version"}).
+			AddRow("l1", "List", "", now, now, nil, 2))
+	mock.ExpectCommit()
+
+	feed, err := r.GetChanges(10, 3)
+	if err != nil {
+		t.Fatalf("no se esperaba error en GetChanges: %v", err)
+	}
+	if len(feed.Changes) != 3 || feed.HasMore || feed.Sequence != 30 {
+		t.Fatalf("feed inesperado: %+v", feed)
+	}
+	if feed.Changes[0].List == nil || feed.Changes[0].List.Version != 2 || feed.Changes[1].Task == nil || feed.Changes[1].Task.Version != 3 {
+		t.Errorf("esperadas las entidades de los upserts, obtuve %+v %+v", feed.Changes[0], feed.Changes[1])
+	}
+	if deleted := feed.Changes[2]; deleted.Op != domain.SyncDelete || deleted.Task != nil {
+		t.Errorf("esperada la eliminación de t2, obtuve %+v", deleted)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresSyncRepository_GetChanges_HasMore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresSyncRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT last_sequence, purged_sequence FROM sync_state").
+		WillReturnRows(sqlmock.NewRows([]string{"last_sequence", "purged_sequence"}).AddRow(30, 0))
+	mock.ExpectQuery("WHERE \\$1 > 0 OR NOT deleted").
+		WithArgs(int64(0), 3).
+		WillReturnRows(sqlmock.NewRows(syncChangeColumns).
+			AddRow("task", "t1", 4, true).
+			AddRow("task", "t2", 6, true).
+			AddRow("task", "t3", 9, true))
+	mock.ExpectCommit()
+
+	feed, err := r.GetChanges(0, 2)
+	if err != nil {
+		t.Fatalf("no se esperaba error en GetChanges: %v", err)
+	}
+	if len(feed.Changes) != 2 || !feed.HasMore || feed.Sequence != 6 {
+		t.Errorf("esperada una página continuable desde 6, obtuve %+v", feed)
+	}
+}
+
+func TestPostgresSyncRepository_GetChanges_TokenExpired(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresSyncRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT last_sequence, purged_sequence FROM sync_state").
+		WillReturnRows(sqlmock.NewRows([]string{"last_sequence", "purged_sequence"}).AddRow(30, 20))
+	mock.ExpectRollback()
+
+	if _, err := r.GetChanges(10, 100); err == nil || err.Error() != "sync token expired" {
+		t.Errorf("esperado error de token expirado, obtuve %v", err)
+	}
+}
+
+func TestPostgresSyncRepository_PurgeTombstonesBefore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresSyncRepository(db)
+	cutoff := time.Now()
+	mock.ExpectQuery("DELETE FROM sync_tombstones WHERE deleted_at < \\$1(.|\\n)*UPDATE sync_state SET purged_sequence").
+		WithArgs(cutoff).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	purged, err := r.PurgeTombstonesBefore(cutoff)
+	if err != nil || purged != 4 {
+		t.Errorf("esperadas 4 lápidas purgadas, obtuve %d, %v", purged, err)
+	}
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func bulkChanges() []domain.BulkChange {
+	now := time.Now()
+	return []domain.BulkChange{
+		{Op: domain.BulkCreate, Task: &domain.Task{ID: "n1", ListID: "l1", Title: "t", Status: "pending", Priority: "low", CreatedAt: now, UpdatedAt: now}},
+		{Op: domain.BulkUpdate, Task: &domain.Task{ID: "t1", ListID: "l1", Title: "t", Status: "completed", Priority: "low", UpdatedAt: now, Version: 2}},
+		{Op: domain.BulkDelete, Task: &domain.Task{ID: "t2"}},
+	}
+}
+
+func TestPostgresTaskRepository_ApplyBulk_AtomicRollsBack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTaskRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO tasks").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("UPDATE tasks SET list_id").WillReturnRows(sqlmock.NewRows([]string{"version"}))
+	mock.ExpectQuery("SELECT 1 FROM tasks WHERE id = \\$1").WithArgs("t1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(1))
+	mock.ExpectRollback()
+
+	errs, err := r.ApplyBulk(bulkChanges(), true, "")
+	if err != nil {
+		t.Fatalf("no se esperaba error en ApplyBulk: %v", err)
+	}
+	if errs[0] != nil || errs[1] == nil || errs[1].Error() != "version conflict" || errs[2] != nil {
+		t.Errorf("esperado conflicto de versión solo en el segundo cambio, obtuve %v", errs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_ApplyBulk_BestEffort(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTaskRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO tasks").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").WithArgs(sqlmock.AnyArg(), "task", "n1", "task.created", "l1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE tasks SET list_id").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+	mock.ExpectExec("INSERT INTO outbox_events").WithArgs(sqlmock.AnyArg(), "task", "t1", "task.updated", "l1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE tasks SET deleted_at").WithArgs("t2", sqlmock.AnyArg(), 0).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	changes := bulkChanges()
+	errs, err := r.ApplyBulk(changes, false, "")
+	if err != nil {
+		t.Fatalf("no se esperaba error en ApplyBulk: %v", err)
+	}
+	if errs[0] != nil || errs[1] != nil || changes[1].Task.Version != 3 {
+		t.Errorf("esperados los dos primeros cambios aplicados, obtuve %v", errs)
+	}
+	if errs[2] == nil || errs[2].Error() != "task not found" {
+		t.Errorf("esperado task not found en el borrado, obtuve %v", errs[2])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
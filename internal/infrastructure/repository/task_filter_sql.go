@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// taskFilterColumns maps the fields of a filter expression to their SQL columns. It must
+// stay in sync with the whitelist of the task use case.
+var taskFilterColumns = map[string]string{
+	"status":      "status",
+	"priority":    "priority",
+	"title":       "title",
+	"description": "description",
+	"list_id":     "list_id",
+	"parent_id":   "parent_id",
+	"sprint_id":   "sprint_id",
+	"labels":      "labels",
+	"created_at":  "created_at",
+	"updated_at":  "updated_at",
+	"due_date":    "due_date",
+	"archived_at": "archived_at",
+}
+
+// compileFilter turns a filter expression into a SQL condition. Values are never
+// inlined: they are appended to args and referenced as $n placeholders.
+func compileFilter(node domain.FilterNode, args []interface{}) (string, []interface{}, error) {
+	switch n := node.(type) {
+	case domain.FilterLogical:
+		left, args, err := compileFilter(n.Left, args)
+		if err != nil {
+			return "", nil, err
+		}
+		right, args, err := compileFilter(n.Right, args)
+		if err != nil {
+			return "", nil, err
+		}
+		operator := "AND"
+		if n.Operator == "or" {
+			operator = "OR"
+		}
+		return "(" + left + " " + operator + " " + right + ")", args, nil
+
+	case domain.FilterNot:
+		operand, args, err := compileFilter(n.Operand, args)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT " + operand, args, nil
+
+	case domain.FilterComparison:
+		return compileComparison(n, args)
+	}
+
+	return "", nil, fmt.Errorf("unsupported filter node %T", node)
+}
+
+func compileComparison(c domain.FilterComparison, args []interface{}) (string, []interface{}, error) {
+	column, ok := taskFilterColumns[c.Field]
+	if !ok {
+		return "", nil, errors.New("unknown filter field: " + c.Field)
+	}
+
+	switch c.Operator {
+	case "is null":
+		return "(" + column + " IS NULL)", args, nil
+	case "is not null":
+		return "(" + column + " IS NOT NULL)", args, nil
+	}
+
+	if len(c.Values) == 0 {
+		return "", nil, errors.New("missing filter value for " + c.Field)
+	}
+
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if c.Field == "labels" {
+		if c.Operator == "in" {
+			values := make([]string, len(c.Values))
+			for i, v := range c.Values {
+				values[i] = fmt.Sprint(v)
+			}
+			return "(labels && " + placeholder(pq.Array(values)) + "::text[])", args, nil
+		}
+		return "(" + placeholder(c.Values[0]) + " = ANY(labels))", args, nil
+	}
+
+	if c.DateOnly {
+		column = "(" + column + ")::date"
+	}
+
+	switch c.Operator {
+	case "in":
+		placeholders := make([]string, len(c.Values))
+		for i, v := range c.Values {
+			placeholders[i] = placeholder(v)
+		}
+		return "(" + column + " IN (" + strings.Join(placeholders, ", ") + "))", args, nil
+	case "~":
+		return "(" + column + " ILIKE " + placeholder("%"+escapeLike(fmt.Sprint(c.Values[0]))+"%") + ")", args, nil
+	case "=", "!=", ">", ">=", "<", "<=":
+		value := c.Values[0]
+		if day, ok := value.(time.Time); ok && c.DateOnly {
+			value = day.Format("2006-01-02")
+		}
+		ref := placeholder(value)
+		if c.DateOnly {
+			ref += "::date"
+		}
+		return "(" + column + " " + sqlOperator(c.Operator) + " " + ref + ")", args, nil
+	}
+
+	return "", nil, errors.New("unsupported filter operator: " + c.Operator)
+}
+
+// sqlOperator maps a filter operator to SQL. != also matches NULL columns, as a client
+// filtering out one sprint expects to get the tasks without sprint.
+func sqlOperator(operator string) string {
+	if operator == "!=" {
+		return "IS DISTINCT FROM"
+	}
+	return operator
+}
+
+// escapeLike escapes the LIKE wildcards of a user value so that it matches literally.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
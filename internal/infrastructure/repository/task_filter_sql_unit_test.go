@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func TestCompileFilter_Parameterized(t *testing.T) {
+	node := domain.FilterLogical{
+		Operator: "or",
+		Left: domain.FilterLogical{
+			Operator: "and",
+			Left:     domain.FilterComparison{Field: "status", Operator: "in", Values: []interface{}{"pending", "in-progress"}},
+			Right:    domain.FilterNot{Operand: domain.FilterComparison{Field: "sprint_id", Operator: "!=", Values: []interface{}{"s1"}}},
+		},
+		Right: domain.FilterComparison{Field: "title", Operator: "~", Values: []interface{}{"50%_off"}},
+	}
+
+	sql, args, err := compileFilter(node, []interface{}{"high"})
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	want := `(((status IN ($2, $3)) AND NOT (sprint_id IS DISTINCT FROM $4)) OR (title ILIKE $5))`
+	if sql != want {
+		t.Errorf("SQL inesperado:\n%s\nesperado:\n%s", sql, want)
+	}
+	if len(args) != 5 || args[4] != `%50\%\_off%` {
+		t.Errorf("argumentos inesperados: %v", args)
+	}
+}
+
+func TestCompileFilter_DatesLabelsAndNull(t *testing.T) {
+	node := domain.FilterLogical{
+		Operator: "and",
+		Left: domain.FilterLogical{
+			Operator: "and",
+			Left:     domain.FilterComparison{Field: "updated_at", Operator: ">=", Values: []interface{}{time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}, DateOnly: true},
+			Right:    domain.FilterComparison{Field: "labels", Operator: "=", Values: []interface{}{"ops"}},
+		},
+		Right: domain.FilterComparison{Field: "due_date", Operator: "is null"},
+	}
+
+	sql, args, err := compileFilter(node, nil)
+	if err != nil {
+		t.Fatalf("no se esperaba error: %v", err)
+	}
+	want := `((((updated_at)::date >= $1::date) AND ($2 = ANY(labels))) AND (due_date IS NULL))`
+	if sql != want {
+		t.Errorf("SQL inesperado:\n%s\nesperado:\n%s", sql, want)
+	}
+	if len(args) != 2 || args[0] != "2026-01-01" {
+		t.Errorf("argumentos inesperados: %v", args)
+	}
+}
+
+func TestCompileFilter_UnknownField(t *testing.T) {
+	if _, _, err := compileFilter(domain.FilterComparison{Field: "password", Operator: "=", Values: []interface{}{"x"}}, nil); err == nil {
+		t.Error("esperado error por campo desconocido")
+	}
+}
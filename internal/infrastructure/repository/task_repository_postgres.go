@@ -3,8 +3,11 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
 	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
 
 	"github.com/G20-00/task-management-service-go/internal/domain"
 )
@@ -21,32 +24,38 @@ func NewPostgresTaskRepository(db *sql.DB) *PostgresTaskRepository {
 	}
 }
 
-// Create inserts a new task into the database.
-func (r *PostgresTaskRepository) Create(task *domain.Task) error {
-	query := `INSERT INTO tasks (id, list_id, title, description, status, priority, created_at, updated_at)
-	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+// taskColumns is the column list read by every task query, in the order expected by scanTask.
+const taskColumns = `id, list_id, title, description, status, priority, created_at, updated_at, archived_at, due_date, COALESCE(parent_id, ''), labels, COALESCE(sprint_id, ''),
+	          (SELECT COUNT(*) FROM task_checklist_items ci WHERE ci.task_id = tasks.id),
+	          (SELECT COUNT(*) FROM task_checklist_items ci WHERE ci.task_id = tasks.id AND ci.done), version`
 
-	_, err := r.db.Exec(query, task.ID, task.ListID, task.Title, task.Description, task.Status, task.Priority, task.CreatedAt, task.UpdatedAt)
-	return err
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
 }
 
-// GetAll retrieves all tasks from the database.
-func (r *PostgresTaskRepository) GetAll() ([]*domain.Task, error) {
-	query := `SELECT id, list_id, title, description, status, priority, created_at, updated_at 
-	          FROM tasks ORDER BY created_at DESC`
-
-	rows, err := r.db.Query(query)
+// scanTask reads a task selected with taskColumns.
+func scanTask(row rowScanner) (*domain.Task, error) {
+	task := &domain.Task{}
+	err := row.Scan(&task.ID, &task.ListID, &task.Title, &task.Description, &task.Status, &task.Priority,
+		&task.CreatedAt, &task.UpdatedAt, &task.ArchivedAt, &task.DueDate, &task.ParentID, pq.Array(&task.Labels), &task.SprintID,
+		&task.ChecklistTotal, &task.ChecklistDone, &task.Version)
 	if err != nil {
 		return nil, err
 	}
+	return task, nil
+}
+
+// scanTasks reads all tasks from rows selected with taskColumns and closes them.
+func scanTasks(rows *sql.Rows) ([]*domain.Task, error) {
 	defer func() {
 		_ = rows.Close() //nolint:errcheck,gocritic
 	}()
 
 	tasks := []*domain.Task{}
 	for rows.Next() {
-		task := &domain.Task{}
-		if err := rows.Scan(&task.ID, &task.ListID, &task.Title, &task.Description, &task.Status, &task.Priority, &task.CreatedAt, &task.UpdatedAt); err != nil {
+		task, err := scanTask(rows)
+		if err != nil {
 			return nil, err
 		}
 		tasks = append(tasks, task)
@@ -55,15 +64,101 @@ func (r *PostgresTaskRepository) GetAll() ([]*domain.Task, error) {
 	return tasks, rows.Err()
 }
 
+// sqlExecutor is satisfied by both *sql.DB and *sql.Tx, so that a write can run on its own
+// or as part of a transaction.
+type sqlExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// inTx runs fn in a transaction that is committed if fn succeeds and rolled back otherwise.
+func inTx(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// inTxAs runs fn like inTx, attributing the task revisions it records to changedBy.
+func inTxAs(db *sql.DB, changedBy string, fn func(tx *sql.Tx) error) error {
+	return inTx(db, func(tx *sql.Tx) error {
+		if err := setChangedBy(tx, changedBy); err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
+
+// setChangedBy attributes the task revisions recorded by the rest of tx to changedBy. The
+// tasks_revision_trigger reads it from app.user_id, which set_config with is_local set, the
+// same as SET LOCAL, keeps until the transaction ends. An empty changedBy sets nothing.
+func setChangedBy(tx *sql.Tx, changedBy string) error {
+	if changedBy == "" {
+		return nil
+	}
+	_, err := tx.Exec(`SELECT set_config('app.user_id', $1, true)`, changedBy)
+	return err
+}
+
+// Create inserts a new task into the database and records a task.created event in the
+// same transaction. Its revision is attributed to changedBy.
+func (r *PostgresTaskRepository) Create(task *domain.Task, changedBy string) error {
+	return r.applyInTx(domain.BulkChange{Op: domain.BulkCreate, Task: task}, changedBy)
+}
+
+func (r *PostgresTaskRepository) applyInTx(change domain.BulkChange, changedBy string) error {
+	return inTxAs(r.db, changedBy, func(tx *sql.Tx) error {
+		return applyTaskChange(tx, change)
+	})
+}
+
+func createTask(db sqlExecutor, task *domain.Task) error {
+	query := `INSERT INTO tasks (id, list_id, title, description, status, priority, created_at, updated_at, completed_at, due_date, labels)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CASE WHEN $5 = 'completed' THEN $7::TIMESTAMP END, $9, $10)`
+
+	_, err := db.Exec(query, task.ID, task.ListID, task.Title, task.Description, task.Status, task.Priority, task.CreatedAt, task.UpdatedAt,
+		task.DueDate, pq.Array(nonNilLabels(task.Labels)))
+	return err
+}
+
+// notArchivedCondition excludes archived tasks and tasks that belong to an archived list.
+const notArchivedCondition = ` AND archived_at IS NULL
+	          AND (list_id IS NULL OR list_id NOT IN (SELECT id FROM task_lists WHERE archived_at IS NOT NULL))`
+
+// GetAll retrieves all tasks from the database. Archived tasks are only included when includeArchived is true.
+func (r *PostgresTaskRepository) GetAll(includeArchived bool) ([]*domain.Task, error) {
+	query := `SELECT ` + taskColumns + `
+	          FROM tasks WHERE deleted_at IS NULL`
+	if !includeArchived {
+		query += notArchivedCondition
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTasks(rows)
+}
+
 // GetByID retrieves a single task by ID.
 func (r *PostgresTaskRepository) GetByID(id string) (*domain.Task, error) {
-	query := `SELECT id, list_id, title, description, status, priority, created_at, updated_at 
-	          FROM tasks WHERE id = $1`
+	query := `SELECT ` + taskColumns + `
+	          FROM tasks WHERE id = $1 AND deleted_at IS NULL`
 
-	task := &domain.Task{}
-	err := r.db.QueryRow(query, id).Scan(&task.ID, &task.ListID, &task.Title, &task.Description, &task.Status, &task.Priority, &task.CreatedAt, &task.UpdatedAt)
+	task, err := scanTask(r.db.QueryRow(query, id))
 	if err == sql.ErrNoRows {
-		return nil, errors.New("task not found")
+		return nil, domain.ErrTaskNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -72,32 +167,59 @@ func (r *PostgresTaskRepository) GetByID(id string) (*domain.Task, error) {
 	return task, nil
 }
 
-// Update modifies an existing task in the database.
-func (r *PostgresTaskRepository) Update(task *domain.Task) error {
-	query := `UPDATE tasks SET list_id = $2, title = $3, description = $4, status = $5, priority = $6, updated_at = $7
-	          WHERE id = $1`
+// Update modifies an existing task in the database. completed_at is stamped the first time
+// the task reaches the completed status and cleared when it leaves it.
+// The update only applies if the stored version is still task.Version; otherwise someone
+// else changed the task in the meantime and domain.ErrVersionConflict is returned. On success
+// task.Version holds the new version, which the database increments on every change.
+// A task.updated event is recorded in the same transaction, and the revision is attributed
+// to changedBy.
+func (r *PostgresTaskRepository) Update(task *domain.Task, changedBy string) error {
+	return r.applyInTx(domain.BulkChange{Op: domain.BulkUpdate, Task: task}, changedBy)
+}
 
-	result, err := r.db.Exec(query, task.ID, task.ListID, task.Title, task.Description, task.Status, task.Priority, task.UpdatedAt)
-	if err != nil {
-		return err
+func updateTask(db sqlExecutor, task *domain.Task) error {
+	query := `UPDATE tasks SET list_id = $2, title = $3, description = $4, status = $5, priority = $6, updated_at = $7,
+	          completed_at = CASE WHEN $5 = 'completed' THEN COALESCE(completed_at, $7) ELSE NULL END,
+	          due_date = $9, labels = $10
+	          WHERE id = $1 AND deleted_at IS NULL AND version = $8
+	          RETURNING version`
+
+	err := db.QueryRow(query, task.ID, task.ListID, task.Title, task.Description, task.Status, task.Priority, task.UpdatedAt,
+		task.Version, task.DueDate, pq.Array(nonNilLabels(task.Labels))).Scan(&task.Version)
+	if err == sql.ErrNoRows {
+		return versionConflictOrNotFound(db, `SELECT 1 FROM tasks WHERE id = $1 AND deleted_at IS NULL`, task.ID, domain.ErrTaskNotFound)
 	}
 
-	rows, err := result.RowsAffected()
+	return err
+}
+
+// versionConflictOrNotFound tells apart why a versioned update matched no row: the row
+// exists with another version, or it does not exist at all.
+func versionConflictOrNotFound(db sqlExecutor, existsQuery, id string, notFound error) error {
+	var exists int
+	err := db.QueryRow(existsQuery, id).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return notFound
+	}
 	if err != nil {
 		return err
 	}
-	if rows == 0 {
-		return errors.New("task not found")
-	}
 
-	return nil
+	return domain.ErrVersionConflict
 }
 
-// Delete removes a task from the database.
-func (r *PostgresTaskRepository) Delete(id string) error {
-	query := `DELETE FROM tasks WHERE id = $1`
+// Delete soft deletes a task, moving it to the trash until it is restored or purged, and
+// records a task.deleted event in the same transaction. A bulk delete of a task with a
+// version other than 0 only applies at that version. The revision is attributed to changedBy.
+func (r *PostgresTaskRepository) Delete(id, changedBy string) error {
+	return r.applyInTx(domain.BulkChange{Op: domain.BulkDelete, Task: &domain.Task{ID: id}}, changedBy)
+}
+
+func deleteTask(db sqlExecutor, task *domain.Task) error {
+	query := `UPDATE tasks SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL AND ($3 = 0 OR version = $3)`
 
-	result, err := r.db.Exec(query, id)
+	result, err := db.Exec(query, task.ID, time.Now(), task.Version)
 	if err != nil {
 		return err
 	}
@@ -106,17 +228,24 @@ func (r *PostgresTaskRepository) Delete(id string) error {
 	if err != nil {
 		return err
 	}
+	if rows == 0 && task.Version != 0 {
+		return versionConflictOrNotFound(db, `SELECT 1 FROM tasks WHERE id = $1 AND deleted_at IS NULL`, task.ID, domain.ErrTaskNotFound)
+	}
 	if rows == 0 {
-		return errors.New("task not found")
+		return domain.ErrTaskNotFound
 	}
 
 	return nil
 }
 
 // GetByFilters retrieves tasks filtered by status and/or priority.
-func (r *PostgresTaskRepository) GetByFilters(status, priority string) ([]*domain.Task, error) {
-	query := `SELECT id, list_id, title, description, status, priority, created_at, updated_at 
-	          FROM tasks WHERE 1=1`
+// Archived tasks are only included when includeArchived is true.
+func (r *PostgresTaskRepository) GetByFilters(status, priority string, includeArchived bool) ([]*domain.Task, error) {
+	query := `SELECT ` + taskColumns + `
+	          FROM tasks WHERE deleted_at IS NULL`
+	if !includeArchived {
+		query += notArchivedCondition
+	}
 	args := []interface{}{}
 	argCount := 1
 
@@ -137,25 +266,13 @@ func (r *PostgresTaskRepository) GetByFilters(status, priority string) ([]*domai
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		_ = rows.Close() //nolint:errcheck,gocritic
-	}()
-
-	tasks := []*domain.Task{}
-	for rows.Next() {
-		task := &domain.Task{}
-		if err := rows.Scan(&task.ID, &task.ListID, &task.Title, &task.Description, &task.Status, &task.Priority, &task.CreatedAt, &task.UpdatedAt); err != nil {
-			return nil, err
-		}
-		tasks = append(tasks, task)
-	}
 
-	return tasks, rows.Err()
+	return scanTasks(rows)
 }
 
 // CountByListIDAndStatus counts tasks by list ID and status.
 func (r *PostgresTaskRepository) CountByListIDAndStatus(listID, status string) (int, error) {
-	query := `SELECT COUNT(*) FROM tasks WHERE list_id = $1 AND status = $2`
+	query := `SELECT COUNT(*) FROM tasks WHERE list_id = $1 AND status = $2 AND deleted_at IS NULL`
 
 	var count int
 	err := r.db.QueryRow(query, listID, status).Scan(&count)
@@ -165,3 +282,247 @@ func (r *PostgresTaskRepository) CountByListIDAndStatus(listID, status string) (
 
 	return count, nil
 }
+
+// IsListArchived reports whether the given task list is archived. Lists that do not exist
+// or are in the trash are domain.ErrTaskListNotFound, so that no task is written into them.
+func (r *PostgresTaskRepository) IsListArchived(listID string) (bool, error) {
+	query := `SELECT archived_at IS NOT NULL FROM task_lists WHERE id = $1 AND deleted_at IS NULL`
+
+	var archived bool
+	err := r.db.QueryRow(query, listID).Scan(&archived)
+	if err == sql.ErrNoRows {
+		return false, domain.ErrTaskListNotFound
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return archived, nil
+}
+
+// ensureTasksWritable returns domain.ErrTaskListArchived when any of the given tasks belongs to
+// an archived task list, the same guard the task service applies before every write. The lists
+// are locked so that none of them is archived before the caller's transaction ends.
+func ensureTasksWritable(db sqlExecutor, taskIDs []string) error {
+	query := `SELECT COALESCE(bool_or(archived), false) FROM (
+	          SELECT l.archived_at IS NOT NULL AS archived
+	          FROM task_lists l JOIN tasks t ON t.list_id = l.id
+	          WHERE t.id = ANY($1) FOR SHARE OF l) lists`
+
+	var archived bool
+	if err := db.QueryRow(query, pq.Array(taskIDs)).Scan(&archived); err != nil {
+		return err
+	}
+	if archived {
+		return domain.ErrTaskListArchived
+	}
+
+	return nil
+}
+
+// ensureListWritable returns domain.ErrTaskListNotFound when the list does not exist or is in
+// the trash and domain.ErrTaskListArchived when it is archived. The list is locked so that it
+// is neither archived nor deleted before the caller's transaction ends.
+func ensureListWritable(db sqlExecutor, listID string) error {
+	query := `SELECT archived_at IS NOT NULL FROM task_lists WHERE id = $1 AND deleted_at IS NULL FOR SHARE`
+
+	var archived bool
+	err := db.QueryRow(query, listID).Scan(&archived)
+	if err == sql.ErrNoRows {
+		return domain.ErrTaskListNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if archived {
+		return domain.ErrTaskListArchived
+	}
+
+	return nil
+}
+
+// GetByListID retrieves all tasks of a list, including archived ones, oldest first.
+func (r *PostgresTaskRepository) GetByListID(listID string) ([]*domain.Task, error) {
+	query := `SELECT ` + taskColumns + `
+	          FROM tasks WHERE list_id = $1 AND deleted_at IS NULL ORDER BY created_at ASC`
+
+	rows, err := r.db.Query(query, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTasks(rows)
+}
+
+// GetByListIDs retrieves the live tasks of several lists in a single query, oldest first.
+// Archived tasks are only included when includeArchived is true.
+func (r *PostgresTaskRepository) GetByListIDs(listIDs []string, includeArchived bool) ([]*domain.Task, error) {
+	query := `SELECT ` + taskColumns + `
+	          FROM tasks WHERE list_id = ANY($1) AND deleted_at IS NULL`
+	if !includeArchived {
+		query += ` AND archived_at IS NULL`
+	}
+	query += ` ORDER BY created_at ASC, id ASC`
+
+	rows, err := r.db.Query(query, pq.Array(listIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTasks(rows)
+}
+
+// priorityRank orders priorities from low to high. priorityRanks mirrors it for cursors.
+const priorityRank = `CASE priority WHEN 'low' THEN 1 WHEN 'medium' THEN 2 WHEN 'high' THEN 3 ELSE 0 END`
+
+var priorityRanks = map[string]int{"low": 1, "medium": 2, "high": 3}
+
+// taskSortColumns are the fields tasks can be sorted by. Tasks without due date sort last.
+var taskSortColumns = map[string]sortColumn[*domain.Task]{
+	"created_at": {expr: "created_at", cast: "timestamp", value: func(t *domain.Task) string { return formatCursorTime(t.CreatedAt) }},
+	"updated_at": {expr: "updated_at", cast: "timestamp", value: func(t *domain.Task) string { return formatCursorTime(t.UpdatedAt) }},
+	"priority":   {expr: priorityRank, cast: "int", value: func(t *domain.Task) string { return strconv.Itoa(priorityRanks[t.Priority]) }},
+	"due_date": {expr: "COALESCE(due_date, 'infinity'::timestamp)", cast: "timestamp", value: func(t *domain.Task) string {
+		if t.DueDate == nil {
+			return "infinity"
+		}
+		return formatCursorTime(*t.DueDate)
+	}},
+	"title": {expr: "title", cast: "text", value: func(t *domain.Task) string { return t.Title }},
+}
+
+// List retrieves one page of the tasks matching the filter in the requested order.
+func (r *PostgresTaskRepository) List(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error) {
+	where, args, err := taskFilterWhere(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.Page[*domain.Task]{}
+	if page.IncludeTotal {
+		total := 0
+		if err := r.db.QueryRow(`SELECT COUNT(*) FROM tasks WHERE `+where, args...).Scan(&total); err != nil {
+			return nil, err
+		}
+		result.Total = &total
+	}
+
+	query, args, err := keyset(`SELECT `+taskColumns+` FROM tasks WHERE `+where, args, taskSortColumns, page)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Items, result.Next = trimPage(tasks, taskSortColumns, page, func(t *domain.Task) string { return t.ID })
+
+	return result, nil
+}
+
+// taskFilterWhere builds the WHERE condition and arguments selecting the tasks of a filter.
+func taskFilterWhere(filter domain.TaskFilter) (string, []interface{}, error) {
+	where := `deleted_at IS NULL`
+	if !filter.IncludeArchived {
+		where += notArchivedCondition
+	}
+
+	args := []interface{}{}
+	if filter.ListID != "" {
+		args = append(args, filter.ListID)
+		where += ` AND list_id = $` + strconv.Itoa(len(args))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where += ` AND status = $` + strconv.Itoa(len(args))
+	}
+	if filter.Priority != "" {
+		args = append(args, filter.Priority)
+		where += ` AND priority = $` + strconv.Itoa(len(args))
+	}
+	if filter.Where != nil {
+		condition, conditionArgs, err := compileFilter(filter.Where, args)
+		if err != nil {
+			return "", nil, err
+		}
+		where += ` AND ` + condition
+		args = conditionArgs
+	}
+
+	return where, args, nil
+}
+
+// formatCursorTime keeps microsecond precision, which is what PostgreSQL timestamps store.
+func formatCursorTime(t time.Time) string {
+	return t.UTC().Format(cursorTimeLayout)
+}
+
+// ApplyBulk writes the changes of a bulk operation and returns the error of each one. When
+// atomic, the changes run in a single transaction that stops at the first failure and is
+// rolled back; otherwise every change is written in its own transaction. The revisions are
+// attributed to changedBy.
+func (r *PostgresTaskRepository) ApplyBulk(changes []domain.BulkChange, atomic bool, changedBy string) ([]error, error) {
+	errs := make([]error, len(changes))
+	if !atomic {
+		for i, change := range changes {
+			errs[i] = r.applyInTx(change, changedBy)
+		}
+		return errs, nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	if err := setChangedBy(tx, changedBy); err != nil {
+		return nil, err
+	}
+
+	for i, change := range changes {
+		if errs[i] = applyTaskChange(tx, change); errs[i] != nil {
+			return errs, nil
+		}
+	}
+
+	return errs, tx.Commit()
+}
+
+// applyTaskChange writes a change to a task together with the outbox event that announces
+// it. It should run in a transaction so that both are stored or neither is.
+func applyTaskChange(db sqlExecutor, change domain.BulkChange) error {
+	task := change.Task
+	switch change.Op {
+	case domain.BulkCreate:
+		if err := createTask(db, task); err != nil {
+			return err
+		}
+		return insertOutboxEvent(db, domain.AggregateTask, task.ID, domain.EventTaskCreated, task.ListID, task)
+	case domain.BulkUpdate:
+		if err := updateTask(db, task); err != nil {
+			return err
+		}
+		return insertOutboxEvent(db, domain.AggregateTask, task.ID, domain.EventTaskUpdated, task.ListID, task)
+	case domain.BulkDelete:
+		if err := deleteTask(db, task); err != nil {
+			return err
+		}
+		deleted, err := scanTask(db.QueryRow(`SELECT `+taskColumns+` FROM tasks WHERE id = $1`, task.ID))
+		if err != nil {
+			return err
+		}
+		return insertOutboxEvent(db, domain.AggregateTask, deleted.ID, domain.EventTaskDeleted, deleted.ListID, deleted)
+	}
+
+	return fmt.Errorf("unsupported bulk change %q", change.Op)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 
 	"github.com/G20-00/task-management-service-go/internal/domain"
 )
@@ -21,12 +22,20 @@ func TestPostgresTaskRepository_Create_Success(t *testing.T) {
 		t.Fatalf("error creating sqlmock: %v", err)
 	}
 	r := NewPostgresTaskRepository(db)
+	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO tasks").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "task", "1", "task.created", "1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 	task := &domain.Task{ID: "1", ListID: "1", Title: "t", Status: "pending", Priority: "medium", CreatedAt: time.Now(), UpdatedAt: time.Now()}
-	err = r.Create(task)
+	err = r.Create(task, "")
 	if err != nil {
 		t.Errorf("no se esperaba error en Create: %v", err)
 	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
 }
 
 func TestPostgresTaskRepository_Update_NotFound(t *testing.T) {
@@ -36,11 +45,32 @@ func TestPostgresTaskRepository_Update_NotFound(t *testing.T) {
 		t.Fatalf("error creating sqlmock: %v", err)
 	}
 	r := NewPostgresTaskRepository(db)
-	mock.ExpectExec("UPDATE tasks SET").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE tasks SET").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT 1 FROM tasks WHERE id = \\$1").WithArgs("1").WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
 	task := &domain.Task{ID: "1", ListID: "1", Title: "t", Status: "pending", Priority: "medium", CreatedAt: time.Now(), UpdatedAt: time.Now()}
-	err = r.Update(task)
-	if err == nil {
-		t.Error("esperado error por tarea no encontrada en Update")
+	err = r.Update(task, "")
+	if err == nil || err.Error() != "task not found" {
+		t.Errorf("esperado error por tarea no encontrada en Update, obtenido %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_Update_VersionConflict(t *testing.T) {
+	var err error
+	db, mock, err = sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTaskRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE tasks SET .+ AND version = \\$8").WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery("SELECT 1 FROM tasks WHERE id = \\$1").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(1))
+	mock.ExpectRollback()
+	task := &domain.Task{ID: "1", ListID: "1", Title: "t", Status: "pending", Priority: "medium", UpdatedAt: time.Now(), Version: 2}
+	err = r.Update(task, "")
+	if err == nil || err.Error() != "version conflict" {
+		t.Errorf("esperado conflicto de versión en Update, obtenido %v", err)
 	}
 }
 
@@ -51,8 +81,10 @@ func TestPostgresTaskRepository_Delete_NotFound(t *testing.T) {
 		t.Fatalf("error creating sqlmock: %v", err)
 	}
 	r := NewPostgresTaskRepository(db)
-	mock.ExpectExec("DELETE FROM tasks").WillReturnResult(sqlmock.NewResult(0, 0))
-	err = r.Delete("no-task")
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE tasks SET deleted_at").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	err = r.Delete("no-task", "")
 	if err == nil {
 		t.Error("esperado error por tarea no encontrada en Delete")
 	}
@@ -66,11 +98,11 @@ func TestPostgresTaskRepository_GetAll_Success(t *testing.T) {
 	}
 	r := NewPostgresTaskRepository(db)
 
-	rows := sqlmock.NewRows([]string{"id", "list_id", "title", "description", "status", "priority", "created_at", "updated_at"}).
-		AddRow("1", "1", "t", "desc", "pending", "medium", time.Now(), time.Now())
-	mock.ExpectQuery("SELECT id, list_id, title, description, status, priority, created_at, updated_at FROM tasks ORDER BY created_at DESC").WillReturnRows(rows)
+	rows := sqlmock.NewRows([]string{"id", "list_id", "title", "description", "status", "priority", "created_at", "updated_at", "archived_at", "due_date", "parent_id", "labels", "sprint_id", "checklist_total", "checklist_done", "version"}).
+		AddRow("1", "1", "t", "desc", "pending", "medium", time.Now(), time.Now(), nil, nil, "", "{}", "", 0, 0, 1)
+	mock.ExpectQuery("SELECT id, list_id, title, description, status, priority, created_at, updated_at, archived_at, due_date, COALESCE\\(parent_id, ''\\), labels, .+ FROM tasks WHERE deleted_at IS NULL AND archived_at IS NULL").WillReturnRows(rows)
 
-	tasks, err := r.GetAll()
+	tasks, err := r.GetAll(false)
 	if err != nil {
 		t.Errorf("no se esperaba error en GetAll: %v", err)
 	}
@@ -87,9 +119,9 @@ func TestPostgresTaskRepository_GetByID_Success(t *testing.T) {
 	}
 	r := NewPostgresTaskRepository(db)
 
-	row := sqlmock.NewRows([]string{"id", "list_id", "title", "description", "status", "priority", "created_at", "updated_at"}).
-		AddRow("1", "1", "t", "desc", "pending", "medium", time.Now(), time.Now())
-	mock.ExpectQuery("SELECT id, list_id, title, description, status, priority, created_at, updated_at FROM tasks WHERE id = \\$1").WithArgs("1").WillReturnRows(row)
+	row := sqlmock.NewRows([]string{"id", "list_id", "title", "description", "status", "priority", "created_at", "updated_at", "archived_at", "due_date", "parent_id", "labels", "sprint_id", "checklist_total", "checklist_done", "version"}).
+		AddRow("1", "1", "t", "desc", "pending", "medium", time.Now(), time.Now(), nil, nil, "", "{}", "", 0, 0, 1)
+	mock.ExpectQuery("SELECT id, list_id, title, description, status, priority, created_at, updated_at, archived_at, due_date, COALESCE\\(parent_id, ''\\), labels, .+ FROM tasks WHERE id = \\$1").WithArgs("1").WillReturnRows(row)
 
 	task, err := r.GetByID("1")
 	if err != nil {
@@ -108,12 +140,23 @@ func TestPostgresTaskRepository_Update_Success(t *testing.T) {
 	}
 	r := NewPostgresTaskRepository(db)
 
-	mock.ExpectExec("UPDATE tasks SET").WillReturnResult(sqlmock.NewResult(0, 1))
-	task := &domain.Task{ID: "1", ListID: "1", Title: "t", Status: "pending", Priority: "medium", CreatedAt: time.Now(), UpdatedAt: time.Now()}
-	err = r.Update(task)
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE tasks SET").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(4))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "task", "1", "task.updated", "1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	task := &domain.Task{ID: "1", ListID: "1", Title: "t", Status: "pending", Priority: "medium", CreatedAt: time.Now(), UpdatedAt: time.Now(), Version: 3}
+	err = r.Update(task, "")
 	if err != nil {
 		t.Errorf("no se esperaba error en Update: %v", err)
 	}
+	if task.Version != 4 {
+		t.Errorf("esperada versión 4 tras Update, obtenida %d", task.Version)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
 }
 
 func TestPostgresTaskRepository_Update_ErrorRowsAffected(t *testing.T) {
@@ -124,9 +167,11 @@ func TestPostgresTaskRepository_Update_ErrorRowsAffected(t *testing.T) {
 	}
 	r := NewPostgresTaskRepository(db)
 
-	mock.ExpectExec("UPDATE tasks SET").WillReturnError(errors.New("fail"))
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE tasks SET").WillReturnError(errors.New("fail"))
+	mock.ExpectRollback()
 	task := &domain.Task{ID: "1", ListID: "1", Title: "t", Status: "pending", Priority: "medium", CreatedAt: time.Now(), UpdatedAt: time.Now()}
-	err = r.Update(task)
+	err = r.Update(task, "")
 	if err == nil {
 		t.Error("esperado error en Update por error de base de datos")
 	}
@@ -140,11 +185,22 @@ func TestPostgresTaskRepository_Delete_Success(t *testing.T) {
 	}
 	r := NewPostgresTaskRepository(db)
 
-	mock.ExpectExec("DELETE FROM tasks").WillReturnResult(sqlmock.NewResult(0, 1))
-	err = r.Delete("1")
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE tasks SET deleted_at").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT id, list_id, .+ FROM tasks WHERE id = \\$1").WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "title", "description", "status", "priority", "created_at", "updated_at", "archived_at", "due_date", "parent_id", "labels", "sprint_id", "checklist_total", "checklist_done", "version"}).
+			AddRow("1", "l1", "t", "desc", "pending", "medium", time.Now(), time.Now(), nil, nil, "", "{}", "", 0, 0, 2))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "task", "1", "task.deleted", "l1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	err = r.Delete("1", "")
 	if err != nil {
 		t.Errorf("no se esperaba error en Delete: %v", err)
 	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
 }
 
 func TestPostgresTaskRepository_GetByFilters_StatusAndPriority(t *testing.T) {
@@ -155,11 +211,11 @@ func TestPostgresTaskRepository_GetByFilters_StatusAndPriority(t *testing.T) {
 	}
 	r := NewPostgresTaskRepository(db)
 
-	rows := sqlmock.NewRows([]string{"id", "list_id", "title", "description", "status", "priority", "created_at", "updated_at"}).
-		AddRow("1", "1", "t", "desc", "pending", "medium", time.Now(), time.Now())
-	mock.ExpectQuery(`(?s)SELECT id, list_id, title, description, status, priority, created_at, updated_at FROM tasks WHERE 1=1 AND status = \$1 AND priority = \$2 ORDER BY created_at DESC`).WillReturnRows(rows)
+	rows := sqlmock.NewRows([]string{"id", "list_id", "title", "description", "status", "priority", "created_at", "updated_at", "archived_at", "due_date", "parent_id", "labels", "sprint_id", "checklist_total", "checklist_done", "version"}).
+		AddRow("1", "1", "t", "desc", "pending", "medium", time.Now(), time.Now(), nil, nil, "", "{}", "", 0, 0, 1)
+	mock.ExpectQuery(`(?s)SELECT id, list_id, title, description, status, priority, created_at, updated_at, archived_at, due_date, COALESCE\(parent_id, ''\), labels, .+ FROM tasks WHERE deleted_at IS NULL AND status = \$1 AND priority = \$2 ORDER BY created_at DESC`).WillReturnRows(rows)
 
-	tasks, err := r.GetByFilters("pending", "medium")
+	tasks, err := r.GetByFilters("pending", "medium", true)
 	if err != nil {
 		t.Errorf("no se esperaba error en GetByFilters: %v", err)
 	}
@@ -176,18 +232,18 @@ func TestPostgresTaskRepository_CountByListIDAndStatus(t *testing.T) {
 	}
 	r := NewPostgresTaskRepository(db)
 
-	expectedSQL := `SELECT id, list_id, title, description, status, priority, created_at, updated_at 
-		 FROM tasks WHERE 1=1 AND status = \$1 AND priority = \$2 ORDER BY created_at DESC`
+	expectedSQL := `SELECT id, list_id, title, description, status, priority, created_at, updated_at, archived_at, due_date, COALESCE\(parent_id, ''\), labels, .+
+		 FROM tasks WHERE deleted_at IS NULL AND status = \$1 AND priority = \$2 ORDER BY created_at DESC`
 
 	rows := sqlmock.NewRows([]string{
-		"id", "list_id", "title", "description", "status", "priority", "created_at", "updated_at",
+		"id", "list_id", "title", "description", "status", "priority", "created_at", "updated_at", "archived_at", "due_date", "parent_id", "labels", "sprint_id", "checklist_total", "checklist_done", "version",
 	}).AddRow(
-		"1", "1", "Task A", "Description A", "pending", "high", time.Now(), time.Now(),
+		"1", "1", "Task A", "Description A", "pending", "high", time.Now(), time.Now(), nil, nil, "", "{}", "", 0, 0, 1,
 	)
 
 	mock.ExpectQuery(expectedSQL).WithArgs("pending", "high").WillReturnRows(rows)
 
-	tasks, err := r.GetByFilters("pending", "high")
+	tasks, err := r.GetByFilters("pending", "high", true)
 	if err != nil {
 		t.Errorf("no se esperaba error en GetByFilters: %v", err)
 	}
@@ -195,3 +251,26 @@ func TestPostgresTaskRepository_CountByListIDAndStatus(t *testing.T) {
 		t.Errorf("esperado 1 tarea, obtuve %d", len(tasks))
 	}
 }
+
+func TestPostgresTaskRepository_GetByListIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creando sqlmock: %v", err)
+	}
+	r := NewPostgresTaskRepository(db)
+
+	rows := sqlmock.NewRows(taskColumnNames).
+		AddRow("1", "a", "t", "", "pending", "medium", time.Now(), time.Now(), nil, nil, "", "{}", "", 0, 0, 1).
+		AddRow("2", "b", "u", "", "completed", "low", time.Now(), time.Now(), nil, nil, "", "{}", "", 0, 0, 2)
+	mock.ExpectQuery(`(?s)FROM tasks WHERE list_id = ANY\(\$1\) AND deleted_at IS NULL AND archived_at IS NULL ORDER BY created_at ASC, id ASC`).
+		WithArgs(pq.Array([]string{"a", "b"})).
+		WillReturnRows(rows)
+
+	tasks, err := r.GetByListIDs([]string{"a", "b"}, false)
+	if err != nil {
+		t.Fatalf("no se esperaba error en GetByListIDs: %v", err)
+	}
+	if len(tasks) != 2 || tasks[1].ListID != "b" {
+		t.Errorf("tareas inesperadas: %+v", tasks)
+	}
+}
@@ -16,9 +16,11 @@ func TestPostgresTaskRepository_Create_Error(t *testing.T) {
 		t.Fatalf("error creating sqlmock: %v", err)
 	}
 	r := NewPostgresTaskRepository(db)
+	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO tasks").WillReturnError(errors.New("fail"))
+	mock.ExpectRollback()
 	task := &domain.Task{ID: "1", ListID: "1", Title: "t", Status: "pending", Priority: "medium"}
-	err = r.Create(task)
+	err = r.Create(task, "")
 	if err == nil {
 		t.Error("esperado error en Create")
 	}
@@ -30,10 +32,23 @@ func TestPostgresTaskRepository_GetByID_NotFound(t *testing.T) {
 		t.Fatalf("error creating sqlmock: %v", err)
 	}
 	r := NewPostgresTaskRepository(db)
-	mock.ExpectQuery("SELECT id, list_id, title, description, status, priority, created_at, updated_at FROM tasks WHERE id = \\$1").
+	mock.ExpectQuery("SELECT id, list_id, title, description, status, priority, created_at, updated_at, archived_at, due_date, COALESCE\\(parent_id, ''\\), labels, .+ FROM tasks WHERE id = \\$1").
 		WithArgs("no-task").WillReturnError(sql.ErrNoRows)
 	_, err = r.GetByID("no-task")
 	if err == nil {
 		t.Error("esperado error por no encontrado")
 	}
 }
+
+func TestPostgresTaskRepository_IsListArchived_TrashedList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTaskRepository(db)
+	mock.ExpectQuery("SELECT archived_at IS NOT NULL FROM task_lists WHERE id = \\$1 AND deleted_at IS NULL").
+		WithArgs("trashed-list").WillReturnError(sql.ErrNoRows)
+	if _, err := r.IsListArchived("trashed-list"); !errors.Is(err, domain.ErrTaskListNotFound) {
+		t.Errorf("esperado ErrTaskListNotFound para una lista en la papelera, obtuve %v", err)
+	}
+}
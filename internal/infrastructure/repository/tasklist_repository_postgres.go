@@ -2,7 +2,9 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
+	"time"
+
+	"github.com/lib/pq"
 
 	"github.com/G20-00/task-management-service-go/internal/domain"
 )
@@ -19,19 +21,28 @@ func NewPostgresTaskListRepository(db *sql.DB) *PostgresTaskListRepository {
 	}
 }
 
-// Create inserts a new task list into the database.
+// Create inserts a new task list into the database and records a list.created event in
+// the same transaction.
 func (r *PostgresTaskListRepository) Create(list *domain.TaskList) error {
 	query := `INSERT INTO task_lists (id, name, description, created_at, updated_at)
 	          VALUES ($1, $2, $3, $4, $5)`
 
-	_, err := r.db.Exec(query, list.ID, list.Name, list.Description, list.CreatedAt, list.UpdatedAt)
-	return err
+	return inTx(r.db, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(query, list.ID, list.Name, list.Description, list.CreatedAt, list.UpdatedAt); err != nil {
+			return err
+		}
+		return insertOutboxEvent(tx, domain.AggregateList, list.ID, domain.EventListCreated, list.ID, list)
+	})
 }
 
-// GetAll retrieves all task lists from the database.
-func (r *PostgresTaskListRepository) GetAll() ([]*domain.TaskList, error) {
-	query := `SELECT id, name, description, created_at, updated_at 
-	          FROM task_lists ORDER BY created_at DESC`
+// GetAll retrieves all task lists from the database. Archived lists are only included when includeArchived is true.
+func (r *PostgresTaskListRepository) GetAll(includeArchived bool) ([]*domain.TaskList, error) {
+	query := `SELECT id, name, description, created_at, updated_at, archived_at, version
+	          FROM task_lists WHERE deleted_at IS NULL`
+	if !includeArchived {
+		query += ` AND archived_at IS NULL`
+	}
+	query += ` ORDER BY created_at DESC`
 
 	rows, err := r.db.Query(query)
 	if err != nil {
@@ -44,7 +55,7 @@ func (r *PostgresTaskListRepository) GetAll() ([]*domain.TaskList, error) {
 	lists := []*domain.TaskList{}
 	for rows.Next() {
 		list := &domain.TaskList{}
-		if err := rows.Scan(&list.ID, &list.Name, &list.Description, &list.CreatedAt, &list.UpdatedAt); err != nil {
+		if err := rows.Scan(&list.ID, &list.Name, &list.Description, &list.CreatedAt, &list.UpdatedAt, &list.ArchivedAt, &list.Version); err != nil {
 			return nil, err
 		}
 		lists = append(lists, list)
@@ -55,13 +66,13 @@ func (r *PostgresTaskListRepository) GetAll() ([]*domain.TaskList, error) {
 
 // GetByID retrieves a single task list by ID.
 func (r *PostgresTaskListRepository) GetByID(id string) (*domain.TaskList, error) {
-	query := `SELECT id, name, description, created_at, updated_at 
-	          FROM task_lists WHERE id = $1`
+	query := `SELECT id, name, description, created_at, updated_at, archived_at, version
+	          FROM task_lists WHERE id = $1 AND deleted_at IS NULL`
 
 	list := &domain.TaskList{}
-	err := r.db.QueryRow(query, id).Scan(&list.ID, &list.Name, &list.Description, &list.CreatedAt, &list.UpdatedAt)
+	err := r.db.QueryRow(query, id).Scan(&list.ID, &list.Name, &list.Description, &list.CreatedAt, &list.UpdatedAt, &list.ArchivedAt, &list.Version)
 	if err == sql.ErrNoRows {
-		return nil, errors.New("task list not found")
+		return nil, domain.ErrTaskListNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -70,12 +81,74 @@ func (r *PostgresTaskListRepository) GetByID(id string) (*domain.TaskList, error
 	return list, nil
 }
 
-// Update modifies an existing task list in the database.
+// GetByIDs retrieves the live task lists with the given IDs in a single query. IDs that
+// match no list are omitted from the result.
+func (r *PostgresTaskListRepository) GetByIDs(ids []string) ([]*domain.TaskList, error) {
+	query := `SELECT id, name, description, created_at, updated_at, archived_at, version
+	          FROM task_lists WHERE id = ANY($1) AND deleted_at IS NULL`
+
+	rows, err := r.db.Query(query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	lists := []*domain.TaskList{}
+	for rows.Next() {
+		list := &domain.TaskList{}
+		if err := rows.Scan(&list.ID, &list.Name, &list.Description, &list.CreatedAt, &list.UpdatedAt, &list.ArchivedAt, &list.Version); err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+
+	return lists, rows.Err()
+}
+
+// Update modifies an existing task list in the database if its stored version is still
+// list.Version, returning domain.ErrVersionConflict otherwise. On success list.Version holds the
+// new version and a list.updated event is recorded in the same transaction.
 func (r *PostgresTaskListRepository) Update(list *domain.TaskList) error {
-	query := `UPDATE task_lists SET name = $2, description = $3, updated_at = $4 
-	          WHERE id = $1`
+	query := `UPDATE task_lists SET name = $2, description = $3, updated_at = $4
+	          WHERE id = $1 AND deleted_at IS NULL AND version = $5
+	          RETURNING version`
 
-	result, err := r.db.Exec(query, list.ID, list.Name, list.Description, list.UpdatedAt)
+	return inTx(r.db, func(tx *sql.Tx) error {
+		err := tx.QueryRow(query, list.ID, list.Name, list.Description, list.UpdatedAt, list.Version).Scan(&list.Version)
+		if err == sql.ErrNoRows {
+			return versionConflictOrNotFound(tx, `SELECT 1 FROM task_lists WHERE id = $1 AND deleted_at IS NULL`, list.ID, domain.ErrTaskListNotFound)
+		}
+		if err != nil {
+			return err
+		}
+		return insertOutboxEvent(tx, domain.AggregateList, list.ID, domain.EventListUpdated, list.ID, list)
+	})
+}
+
+// Delete soft deletes a task list together with its live tasks. Both share the same
+// deleted_at timestamp so that restoring the list brings back exactly those tasks. A
+// task.deleted event for each task and a list.deleted event are recorded in the same transaction.
+// A version other than 0 makes the delete conditional on the list still being at that version.
+// The revisions of the tasks are attributed to changedBy.
+func (r *PostgresTaskListRepository) Delete(id string, version int, changedBy string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	if err := setChangedBy(tx, changedBy); err != nil {
+		return err
+	}
+
+	deletedAt := time.Now()
+
+	result, err := tx.Exec(`UPDATE task_lists SET deleted_at = $2
+	          WHERE id = $1 AND deleted_at IS NULL AND ($3 = 0 OR version = $3)`, id, deletedAt, version)
 	if err != nil {
 		return err
 	}
@@ -84,29 +157,109 @@ func (r *PostgresTaskListRepository) Update(list *domain.TaskList) error {
 	if err != nil {
 		return err
 	}
+	if rows == 0 && version != 0 {
+		return versionConflictOrNotFound(tx, `SELECT 1 FROM task_lists WHERE id = $1 AND deleted_at IS NULL`, id, domain.ErrTaskListNotFound)
+	}
 	if rows == 0 {
-		return errors.New("task list not found")
+		return domain.ErrTaskListNotFound
+	}
+
+	_, err = changeTasks(tx, domain.EventTaskDeleted,
+		`UPDATE tasks SET deleted_at = $2 WHERE list_id = $1 AND deleted_at IS NULL RETURNING id`, id, deletedAt)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	if err := insertListEvent(tx, domain.EventListDeleted, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-// Delete removes a task list from the database.
-func (r *PostgresTaskListRepository) Delete(id string) error {
-	query := `DELETE FROM task_lists WHERE id = $1`
+// GetStats counts the live tasks per status of the given lists in a single grouped query.
+// Archived tasks are counted; lists without tasks are omitted from the result.
+func (r *PostgresTaskListRepository) GetStats(listIDs []string) (map[string]*domain.ListStats, error) {
+	query := `SELECT list_id,
+	                 COUNT(*),
+	                 COUNT(*) FILTER (WHERE status = 'pending'),
+	                 COUNT(*) FILTER (WHERE status = 'in-progress'),
+	                 COUNT(*) FILTER (WHERE status = 'completed')
+	          FROM tasks
+	          WHERE list_id = ANY($1) AND deleted_at IS NULL
+	          GROUP BY list_id`
 
-	result, err := r.db.Exec(query, id)
+	rows, err := r.db.Query(query, pq.Array(listIDs))
 	if err != nil {
-		return err
+		return nil, err
 	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
 
-	rows, err := result.RowsAffected()
+	stats := make(map[string]*domain.ListStats, len(listIDs))
+	for rows.Next() {
+		st := &domain.ListStats{}
+		if err := rows.Scan(&st.ListID, &st.Total, &st.Pending, &st.InProgress, &st.Completed); err != nil {
+			return nil, err
+		}
+		stats[st.ListID] = st
+	}
+
+	return stats, rows.Err()
+}
+
+// taskListSortColumns are the fields task lists can be sorted by.
+var taskListSortColumns = map[string]sortColumn[*domain.TaskList]{
+	"created_at": {expr: "created_at", cast: "timestamp", value: func(l *domain.TaskList) string { return formatCursorTime(l.CreatedAt) }},
+	"updated_at": {expr: "updated_at", cast: "timestamp", value: func(l *domain.TaskList) string { return formatCursorTime(l.UpdatedAt) }},
+	"name":       {expr: "name", cast: "text", value: func(l *domain.TaskList) string { return l.Name }},
+}
+
+// List retrieves one page of task lists in the requested order. Archived lists are only
+// included when includeArchived is true.
+func (r *PostgresTaskListRepository) List(includeArchived bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error) {
+	where := `deleted_at IS NULL`
+	if !includeArchived {
+		where += ` AND archived_at IS NULL`
+	}
+
+	result := &domain.Page[*domain.TaskList]{}
+	if page.IncludeTotal {
+		total := 0
+		if err := r.db.QueryRow(`SELECT COUNT(*) FROM task_lists WHERE ` + where).Scan(&total); err != nil {
+			return nil, err
+		}
+		result.Total = &total
+	}
+
+	query, args, err := keyset(`SELECT id, name, description, created_at, updated_at, archived_at, version
+	          FROM task_lists WHERE `+where, []interface{}{}, taskListSortColumns, page)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if rows == 0 {
-		return errors.New("task list not found")
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
 	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	lists := []*domain.TaskList{}
+	for rows.Next() {
+		list := &domain.TaskList{}
+		if err := rows.Scan(&list.ID, &list.Name, &list.Description, &list.CreatedAt, &list.UpdatedAt, &list.ArchivedAt, &list.Version); err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result.Items, result.Next = trimPage(lists, taskListSortColumns, page, func(l *domain.TaskList) string { return l.ID })
 
-	return nil
+	return result, nil
 }
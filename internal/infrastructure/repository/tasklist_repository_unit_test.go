@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+func TestPostgresTaskListRepository_GetStats_GroupsByList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTaskListRepository(db)
+	mock.ExpectQuery("FROM tasks WHERE list_id = ANY\\(\\$1\\) AND deleted_at IS NULL GROUP BY list_id").
+		WithArgs(pq.Array([]string{"a", "b"})).
+		WillReturnRows(sqlmock.NewRows([]string{"list_id", "total", "pending", "in_progress", "completed"}).
+			AddRow("a", 4, 1, 1, 2))
+
+	stats, err := r.GetStats([]string{"a", "b"})
+	if err != nil {
+		t.Fatalf("no se esperaba error en GetStats: %v", err)
+	}
+	if len(stats) != 1 || stats["a"].Total != 4 || stats["a"].Completed != 2 || stats["a"].InProgress != 1 {
+		t.Errorf("estadísticas inesperadas: %+v", stats)
+	}
+}
+
+func TestPostgresTaskListRepository_GetByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTaskListRepository(db)
+	now := time.Now()
+	mock.ExpectQuery("FROM task_lists WHERE id = ANY\\(\\$1\\) AND deleted_at IS NULL").
+		WithArgs(pq.Array([]string{"a", "b"})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "created_at", "updated_at", "archived_at", "version"}).
+			AddRow("a", "List", "", now, now, nil, 3))
+
+	lists, err := r.GetByIDs([]string{"a", "b"})
+	if err != nil {
+		t.Fatalf("no se esperaba error en GetByIDs: %v", err)
+	}
+	if len(lists) != 1 || lists[0].ID != "a" || lists[0].Version != 3 {
+		t.Errorf("listas inesperadas: %+v", lists)
+	}
+}
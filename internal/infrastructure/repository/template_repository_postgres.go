@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// PostgresTemplateRepository is a PostgreSQL implementation of the template repository.
+type PostgresTemplateRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTemplateRepository creates a new PostgresTemplateRepository instance.
+func NewPostgresTemplateRepository(db *sql.DB) *PostgresTemplateRepository {
+	return &PostgresTemplateRepository{
+		db: db,
+	}
+}
+
+// Create inserts a template together with its items in a single transaction.
+func (r *PostgresTemplateRepository) Create(tpl *domain.TaskTemplate) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	_, err = tx.Exec(`INSERT INTO task_templates (id, name, description, created_at, updated_at)
+	          VALUES ($1, $2, $3, $4, $5)`, tpl.ID, tpl.Name, tpl.Description, tpl.CreatedAt, tpl.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range tpl.Items {
+		_, err := tx.Exec(`INSERT INTO task_template_items (id, template_id, parent_item_id, position, title, description, priority, due_offset_days, labels)
+		          VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9)`,
+			item.ID, tpl.ID, item.ParentItemID, item.Position, item.Title, item.Description, item.Priority,
+			item.DueOffsetDays, pq.Array(nonNilLabels(item.Labels)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetAll retrieves all templates, newest first. Items are not loaded.
+func (r *PostgresTemplateRepository) GetAll() ([]*domain.TaskTemplate, error) {
+	query := `SELECT id, name, description, created_at, updated_at
+	          FROM task_templates ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	templates := []*domain.TaskTemplate{}
+	for rows.Next() {
+		tpl := &domain.TaskTemplate{}
+		if err := rows.Scan(&tpl.ID, &tpl.Name, &tpl.Description, &tpl.CreatedAt, &tpl.UpdatedAt); err != nil {
+			return nil, err
+		}
+		templates = append(templates, tpl)
+	}
+
+	return templates, rows.Err()
+}
+
+// GetByID retrieves a template with its items ordered by position.
+func (r *PostgresTemplateRepository) GetByID(id string) (*domain.TaskTemplate, error) {
+	query := `SELECT id, name, description, created_at, updated_at
+	          FROM task_templates WHERE id = $1`
+
+	tpl := &domain.TaskTemplate{}
+	err := r.db.QueryRow(query, id).Scan(&tpl.ID, &tpl.Name, &tpl.Description, &tpl.CreatedAt, &tpl.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`SELECT id, COALESCE(parent_item_id, ''), position, title, description, priority, due_offset_days, labels
+	          FROM task_template_items WHERE template_id = $1 ORDER BY position ASC`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	tpl.Items = []domain.TemplateItem{}
+	for rows.Next() {
+		item := domain.TemplateItem{}
+		var offset sql.NullInt64
+		if err := rows.Scan(&item.ID, &item.ParentItemID, &item.Position, &item.Title, &item.Description, &item.Priority,
+			&offset, pq.Array(&item.Labels)); err != nil {
+			return nil, err
+		}
+		if offset.Valid {
+			days := int(offset.Int64)
+			item.DueOffsetDays = &days
+		}
+		tpl.Items = append(tpl.Items, item)
+	}
+
+	return tpl, rows.Err()
+}
+
+// Delete removes a template and, through the foreign key, its items.
+func (r *PostgresTemplateRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM task_templates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+// Instantiate inserts a task list and all of its tasks in a single transaction, together with
+// their list.created and task.created events.
+// Tasks are inserted in order, so parents must precede their subtasks. Their revisions are
+// attributed to changedBy.
+func (r *PostgresTemplateRepository) Instantiate(list *domain.TaskList, tasks []*domain.Task, changedBy string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	if err := setChangedBy(tx, changedBy); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`INSERT INTO task_lists (id, name, description, created_at, updated_at)
+	          VALUES ($1, $2, $3, $4, $5)`, list.ID, list.Name, list.Description, list.CreatedAt, list.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if err := insertOutboxEvent(tx, domain.AggregateList, list.ID, domain.EventListCreated, list.ID, list); err != nil {
+		return err
+	}
+
+	for _, t := range tasks {
+		_, err := tx.Exec(`INSERT INTO tasks (id, list_id, title, description, status, priority, created_at, updated_at, due_date, parent_id, labels)
+		          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11)`,
+			t.ID, t.ListID, t.Title, t.Description, t.Status, t.Priority, t.CreatedAt, t.UpdatedAt,
+			t.DueDate, t.ParentID, pq.Array(nonNilLabels(t.Labels)))
+		if err != nil {
+			return err
+		}
+		if err := insertOutboxEvent(tx, domain.AggregateTask, t.ID, domain.EventTaskCreated, t.ListID, t); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// nonNilLabels avoids writing NULL into the NOT NULL labels columns.
+func nonNilLabels(labels []string) []string {
+	if labels == nil {
+		return []string{}
+	}
+	return labels
+}
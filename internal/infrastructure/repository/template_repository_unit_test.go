@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func TestPostgresTemplateRepository_Instantiate_SingleTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTemplateRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO task_lists").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "list", "l", "list.created", "l", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO tasks").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), "task", "1", "task.created", "l", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO tasks").WillReturnError(errors.New("boom"))
+	mock.ExpectRollback()
+
+	now := time.Now()
+	err = r.Instantiate(&domain.TaskList{ID: "l", CreatedAt: now, UpdatedAt: now}, []*domain.Task{
+		{ID: "1", ListID: "l", Title: "a", Status: "pending", Priority: "low"},
+		{ID: "2", ListID: "l", Title: "b", Status: "pending", Priority: "low", ParentID: "1"},
+	}, "")
+	if err == nil {
+		t.Error("esperado error al insertar la segunda tarea")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresTemplateRepository_GetByID_LoadsItems(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTemplateRepository(db)
+	mock.ExpectQuery("FROM task_templates WHERE id = \\$1").WithArgs("t").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "created_at", "updated_at"}).
+			AddRow("t", "Sprint", "", time.Now(), time.Now()))
+	mock.ExpectQuery("FROM task_template_items WHERE template_id = \\$1").WithArgs("t").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "parent_item_id", "position", "title", "description", "priority", "due_offset_days", "labels"}).
+			AddRow("i1", "", 0, "Plan", "", "high", 2, "{planning}").
+			AddRow("i2", "i1", 1, "Review", "", "low", nil, "{}"))
+
+	tpl, err := r.GetByID("t")
+	if err != nil {
+		t.Fatalf("no se esperaba error en GetByID: %v", err)
+	}
+	if len(tpl.Items) != 2 || tpl.Items[0].DueOffsetDays == nil || *tpl.Items[0].DueOffsetDays != 2 {
+		t.Fatalf("items inesperados: %+v", tpl.Items)
+	}
+	if tpl.Items[1].DueOffsetDays != nil || tpl.Items[1].ParentItemID != "i1" || len(tpl.Items[0].Labels) != 1 {
+		t.Errorf("item inesperado: %+v", tpl.Items[1])
+	}
+}
+
+func TestPostgresTemplateRepository_Delete_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTemplateRepository(db)
+	mock.ExpectExec("DELETE FROM task_templates").WithArgs("t").WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := r.Delete("t"); err == nil || err.Error() != "template not found" {
+		t.Errorf("esperado error de plantilla no encontrada, obtuve %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// PostgresTrashRepository is a PostgreSQL implementation of the trash repository.
+type PostgresTrashRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTrashRepository creates a new PostgresTrashRepository instance.
+func NewPostgresTrashRepository(db *sql.DB) *PostgresTrashRepository {
+	return &PostgresTrashRepository{
+		db: db,
+	}
+}
+
+// GetDeletedTasks retrieves all soft deleted tasks, most recently deleted first.
+func (r *PostgresTrashRepository) GetDeletedTasks() ([]*domain.Task, error) {
+	query := `SELECT id, list_id, title, description, status, priority, created_at, updated_at, deleted_at
+	          FROM tasks WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	tasks := []*domain.Task{}
+	for rows.Next() {
+		task := &domain.Task{}
+		if err := rows.Scan(&task.ID, &task.ListID, &task.Title, &task.Description, &task.Status, &task.Priority, &task.CreatedAt, &task.UpdatedAt, &task.DeletedAt); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
+
+// GetDeletedLists retrieves all soft deleted task lists, most recently deleted first.
+func (r *PostgresTrashRepository) GetDeletedLists() ([]*domain.TaskList, error) {
+	query := `SELECT id, name, description, created_at, updated_at, deleted_at
+	          FROM task_lists WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	lists := []*domain.TaskList{}
+	for rows.Next() {
+		list := &domain.TaskList{}
+		if err := rows.Scan(&list.ID, &list.Name, &list.Description, &list.CreatedAt, &list.UpdatedAt, &list.DeletedAt); err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+
+	return lists, rows.Err()
+}
+
+// RestoreTask brings a soft deleted task back. Tasks whose list is still in the
+// trash cannot be restored on their own; the list has to be restored instead. Tasks
+// of an archived list cannot be restored until the list is unarchived. A task.updated event
+// is recorded in the same transaction, and the revision is attributed to changedBy.
+func (r *PostgresTrashRepository) RestoreTask(id, changedBy string) error {
+	query := `SELECT l.deleted_at IS NOT NULL
+	          FROM tasks t LEFT JOIN task_lists l ON l.id = t.list_id
+	          WHERE t.id = $1 AND t.deleted_at IS NOT NULL
+	          FOR UPDATE OF t`
+
+	return inTxAs(r.db, changedBy, func(tx *sql.Tx) error {
+		var listDeleted sql.NullBool
+		err := tx.QueryRow(query, id).Scan(&listDeleted)
+		if err == sql.ErrNoRows {
+			return domain.ErrTaskNotInTrash
+		}
+		if err != nil {
+			return err
+		}
+		if listDeleted.Valid && listDeleted.Bool {
+			return domain.ErrTaskListDeleted
+		}
+		if err := ensureTasksWritable(tx, []string{id}); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(`UPDATE tasks SET deleted_at = NULL WHERE id = $1`, id); err != nil {
+			return err
+		}
+		return insertTaskEvents(tx, domain.EventTaskUpdated, []string{id})
+	})
+}
+
+// RestoreList brings a soft deleted task list back together with the tasks that
+// were deleted with it. Tasks deleted individually before the list stay in the trash.
+// A task.updated event for each restored task and a list.updated event are recorded in the
+// same transaction, and the revisions of the tasks are attributed to changedBy.
+func (r *PostgresTrashRepository) RestoreList(id, changedBy string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	if err := setChangedBy(tx, changedBy); err != nil {
+		return err
+	}
+
+	var deletedAt time.Time
+	err = tx.QueryRow(`SELECT deleted_at FROM task_lists WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE`, id).Scan(&deletedAt)
+	if err == sql.ErrNoRows {
+		return domain.ErrTaskListNotInTrash
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`UPDATE task_lists SET deleted_at = NULL WHERE id = $1`, id); err != nil {
+		return err
+	}
+
+	_, err = changeTasks(tx, domain.EventTaskUpdated,
+		`UPDATE tasks SET deleted_at = NULL WHERE list_id = $1 AND deleted_at = $2 RETURNING id`, id, deletedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := insertListEvent(tx, domain.EventListUpdated, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// PurgeDeletedBefore permanently removes tasks and task lists deleted before the given time.
+// It returns the number of tasks and lists removed.
+func (r *PostgresTrashRepository) PurgeDeletedBefore(before time.Time) (tasks, lists int64, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	// Subtasks of the purged tasks, live or deleted later, are kept as top-level tasks.
+	if _, err := tx.Exec(`UPDATE tasks SET parent_id = NULL
+	          WHERE parent_id IN (SELECT id FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < $1)
+	          AND NOT (deleted_at IS NOT NULL AND deleted_at < $1)`, before); err != nil {
+		return 0, 0, err
+	}
+
+	result, err := tx.Exec(`DELETE FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < $1`, before)
+	if err != nil {
+		return 0, 0, err
+	}
+	if tasks, err = result.RowsAffected(); err != nil {
+		return 0, 0, err
+	}
+
+	// Lists still referenced by any task are kept so the foreign key holds.
+	result, err = tx.Exec(`DELETE FROM task_lists l WHERE l.deleted_at IS NOT NULL AND l.deleted_at < $1
+	          AND NOT EXISTS (SELECT 1 FROM tasks t WHERE t.list_id = l.id)`, before)
+	if err != nil {
+		return 0, 0, err
+	}
+	if lists, err = result.RowsAffected(); err != nil {
+		return 0, 0, err
+	}
+
+	return tasks, lists, tx.Commit()
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func TestPostgresTrashRepository_RestoreTask_ListDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTrashRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT l.deleted_at IS NOT NULL").WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(true))
+	mock.ExpectRollback()
+	err = r.RestoreTask("1", "")
+	if err == nil || err.Error() != "task list is deleted" {
+		t.Errorf("esperado error de lista eliminada, obtuve %v", err)
+	}
+}
+
+func TestPostgresTrashRepository_RestoreTask_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTrashRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT l.deleted_at IS NOT NULL").WithArgs("1").WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+	err = r.RestoreTask("1", "")
+	if err == nil || err.Error() != "task not found in trash" {
+		t.Errorf("esperado error de no encontrado, obtuve %v", err)
+	}
+}
+
+func TestPostgresTrashRepository_RestoreTask_ListArchived(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTrashRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT l.deleted_at IS NOT NULL").WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"deleted"}).AddRow(false))
+	mock.ExpectQuery("FOR SHARE OF l").WillReturnRows(sqlmock.NewRows([]string{"archived"}).AddRow(true))
+	mock.ExpectRollback()
+
+	if err := r.RestoreTask("1", ""); !errors.Is(err, domain.ErrTaskListArchived) {
+		t.Errorf("esperado error de lista archivada, obtuve %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresTrashRepository_RestoreList_RestoresTasksDeletedWithList(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTrashRepository(db)
+	deletedAt := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT set_config\\('app.user_id', \\$1, true\\)").WithArgs("user-1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT deleted_at FROM task_lists").WithArgs("l1").
+		WillReturnRows(sqlmock.NewRows([]string{"deleted_at"}).AddRow(deletedAt))
+	mock.ExpectExec("UPDATE task_lists SET deleted_at = NULL").WithArgs("l1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("UPDATE tasks SET deleted_at = NULL WHERE list_id = \\$1 AND deleted_at = \\$2").
+		WithArgs("l1", deletedAt).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1").AddRow("t2"))
+	expectTaskEvent(mock, "t1", "task.updated")
+	expectTaskEvent(mock, "t2", "task.updated")
+	expectListEvent(mock, "l1", "list.updated")
+	mock.ExpectCommit()
+
+	if err := r.RestoreList("l1", "user-1"); err != nil {
+		t.Fatalf("no se esperaba error en RestoreList: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresTrashRepository_PurgeDeletedBefore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTrashRepository(db)
+	cutoff := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE tasks SET parent_id = NULL").WithArgs(cutoff).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM tasks WHERE deleted_at IS NOT NULL").WithArgs(cutoff).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec("DELETE FROM task_lists").WithArgs(cutoff).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tasks, lists, err := r.PurgeDeletedBefore(cutoff)
+	if err != nil {
+		t.Fatalf("no se esperaba error en PurgeDeletedBefore: %v", err)
+	}
+	if tasks != 4 || lists != 1 {
+		t.Errorf("esperado 4 tareas y 1 lista, obtuve %d y %d", tasks, lists)
+	}
+}
+
+func TestPostgresTrashRepository_PurgeDeletedBefore_DetachesLiveSubtasks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTrashRepository(db)
+	cutoff := time.Now()
+	// Un padre en la papelera con una subtarea viva: la subtarea se suelta antes de borrar al padre.
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE tasks SET parent_id = NULL\\s+WHERE parent_id IN \\(SELECT id FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < \\$1\\)").
+		WithArgs(cutoff).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM tasks WHERE deleted_at IS NOT NULL").WithArgs(cutoff).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM task_lists").WithArgs(cutoff).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	tasks, _, err := r.PurgeDeletedBefore(cutoff)
+	if err != nil {
+		t.Fatalf("no se esperaba error en PurgeDeletedBefore: %v", err)
+	}
+	if tasks != 1 {
+		t.Errorf("esperado 1 tarea purgada, obtuve %d", tasks)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresTaskListRepository_Delete_SoftDeletesTasks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTaskListRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE task_lists SET deleted_at").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("UPDATE tasks SET deleted_at").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1").AddRow("t2"))
+	expectTaskEvent(mock, "t1", "task.deleted")
+	expectTaskEvent(mock, "t2", "task.deleted")
+	expectListEvent(mock, "l1", "list.deleted")
+	mock.ExpectCommit()
+
+	if err := r.Delete("l1", 0, ""); err != nil {
+		t.Fatalf("no se esperaba error en Delete: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresTaskListRepository_Delete_VersionConflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresTaskListRepository(db)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE task_lists SET deleted_at = \\$2\\s+WHERE id = \\$1 AND deleted_at IS NULL AND \\(\\$3 = 0 OR version = \\$3\\)").
+		WithArgs("l1", sqlmock.AnyArg(), 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT 1 FROM task_lists WHERE id = \\$1").WithArgs("l1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(1))
+	mock.ExpectRollback()
+
+	if err := r.Delete("l1", 2, ""); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Errorf("esperado conflicto de versión, obtuve %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
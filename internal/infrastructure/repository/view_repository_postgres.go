@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+const viewColumns = `id, owner_id, COALESCE(list_id, ''), name, filter, sort, group_by, fields, created_at, updated_at`
+
+// PostgresViewRepository is a PostgreSQL implementation of the saved view repository.
+type PostgresViewRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresViewRepository creates a new PostgresViewRepository instance.
+func NewPostgresViewRepository(db *sql.DB) *PostgresViewRepository {
+	return &PostgresViewRepository{
+		db: db,
+	}
+}
+
+func scanView(row rowScanner) (*domain.SavedView, error) {
+	v := &domain.SavedView{}
+	err := row.Scan(&v.ID, &v.OwnerID, &v.ListID, &v.Name, &v.Filter, &v.Sort, &v.GroupBy, pq.Array(&v.Fields),
+		&v.CreatedAt, &v.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Create inserts a new saved view into the database.
+func (r *PostgresViewRepository) Create(v *domain.SavedView) error {
+	query := `INSERT INTO saved_views (id, owner_id, list_id, name, filter, sort, group_by, fields, created_at, updated_at)
+	          VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := r.db.Exec(query, v.ID, v.OwnerID, v.ListID, v.Name, v.Filter, v.Sort, v.GroupBy, pq.Array(v.Fields),
+		v.CreatedAt, v.UpdatedAt)
+	return err
+}
+
+// GetByID retrieves a saved view by its ID.
+func (r *PostgresViewRepository) GetByID(id string) (*domain.SavedView, error) {
+	query := `SELECT ` + viewColumns + ` FROM saved_views WHERE id = $1`
+
+	v, err := scanView(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrViewNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// GetVisible retrieves the views owned by the user and the views shared with any list,
+// restricted to one list when listID is not empty.
+func (r *PostgresViewRepository) GetVisible(userID, listID string) ([]*domain.SavedView, error) {
+	query := `SELECT ` + viewColumns + ` FROM saved_views
+	          WHERE (owner_id = $1 OR list_id IS NOT NULL)
+	          AND ($2 = '' OR list_id = $2)
+	          ORDER BY name ASC, created_at ASC`
+
+	rows, err := r.db.Query(query, userID, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	views := []*domain.SavedView{}
+	for rows.Next() {
+		v, err := scanView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, v)
+	}
+
+	return views, rows.Err()
+}
+
+// Update stores the definition of a saved view.
+func (r *PostgresViewRepository) Update(v *domain.SavedView) error {
+	query := `UPDATE saved_views SET list_id = NULLIF($2, ''), name = $3, filter = $4, sort = $5, group_by = $6,
+	          fields = $7, updated_at = $8
+	          WHERE id = $1`
+
+	result, err := r.db.Exec(query, v.ID, v.ListID, v.Name, v.Filter, v.Sort, v.GroupBy, pq.Array(v.Fields), v.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return expectRows(result, domain.ErrViewNotFound)
+}
+
+// Delete removes a saved view.
+func (r *PostgresViewRepository) Delete(id string) error {
+	result, err := r.db.Exec(`DELETE FROM saved_views WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return expectRows(result, domain.ErrViewNotFound)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestPostgresViewRepository_GetByID_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresViewRepository(db)
+	mock.ExpectQuery("FROM saved_views WHERE id = \\$1").WithArgs("v1").WillReturnError(sql.ErrNoRows)
+	_, err = r.GetByID("v1")
+	if err == nil || err.Error() != "view not found" {
+		t.Errorf("esperado error de vista no encontrada, obtuve %v", err)
+	}
+}
+
+func TestPostgresViewRepository_GetVisible(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresViewRepository(db)
+	now := time.Now()
+	mock.ExpectQuery("FROM saved_views\\s+WHERE \\(owner_id = \\$1 OR list_id IS NOT NULL\\)").
+		WithArgs("u1", "").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "list_id", "name", "filter", "sort", "group_by", "fields", "created_at", "updated_at"}).
+			AddRow("v1", "u1", "", "Mías", "priority = high", "-due_date", "status", "{title,status}", now, now))
+
+	views, err := r.GetVisible("u1", "")
+	if err != nil {
+		t.Fatalf("no se esperaba error en GetVisible: %v", err)
+	}
+	if len(views) != 1 || len(views[0].Fields) != 2 || views[0].Sort != "-due_date" {
+		t.Errorf("vistas inesperadas: %+v", views)
+	}
+}
+
+func TestPostgresViewRepository_Delete_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresViewRepository(db)
+	mock.ExpectExec("DELETE FROM saved_views").WithArgs("v1").WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := r.Delete("v1"); err == nil || err.Error() != "view not found" {
+		t.Errorf("esperado error de vista no encontrada, obtuve %v", err)
+	}
+}
@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+const webhookColumns = `id, owner_id, url, event_types, COALESCE(list_id, ''), secret, active, created_at, updated_at`
+
+const deliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	COALESCE(last_status_code, 0), last_error, created_at, updated_at, delivered_at`
+
+// PostgresWebhookRepository is a PostgreSQL implementation of the webhook repository.
+type PostgresWebhookRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresWebhookRepository creates a new PostgresWebhookRepository instance.
+func NewPostgresWebhookRepository(db *sql.DB) *PostgresWebhookRepository {
+	return &PostgresWebhookRepository{
+		db: db,
+	}
+}
+
+func scanWebhook(row rowScanner) (*domain.WebhookSubscription, error) {
+	w := &domain.WebhookSubscription{}
+	err := row.Scan(&w.ID, &w.OwnerID, &w.URL, pq.Array(&w.EventTypes), &w.ListID, &w.Secret, &w.Active,
+		&w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func scanDelivery(row rowScanner) (*domain.WebhookDelivery, error) {
+	d := &domain.WebhookDelivery{}
+	var deliveredAt sql.NullTime
+	err := row.Scan(&d.ID, &d.SubscriptionID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastStatusCode, &d.LastError, &d.CreatedAt, &d.UpdatedAt, &deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	return d, nil
+}
+
+// CreateSubscription inserts a new webhook subscription into the database.
+func (r *PostgresWebhookRepository) CreateSubscription(w *domain.WebhookSubscription) error {
+	query := `INSERT INTO webhook_subscriptions (id, owner_id, url, event_types, list_id, secret, active, created_at, updated_at)
+	          VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9)`
+
+	_, err := r.db.Exec(query, w.ID, w.OwnerID, w.URL, pq.Array(w.EventTypes), w.ListID, w.Secret, w.Active,
+		w.CreatedAt, w.UpdatedAt)
+	return err
+}
+
+// GetSubscription retrieves a webhook subscription by its ID.
+func (r *PostgresWebhookRepository) GetSubscription(id string) (*domain.WebhookSubscription, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhook_subscriptions WHERE id = $1`
+
+	w, err := scanWebhook(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// GetSubscriptionsByOwner retrieves the webhook subscriptions of a user.
+func (r *PostgresWebhookRepository) GetSubscriptionsByOwner(ownerID string) ([]*domain.WebhookSubscription, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhook_subscriptions WHERE owner_id = $1 ORDER BY created_at ASC`
+
+	return r.querySubscriptions(query, ownerID)
+}
+
+// GetSubscriptionsForEvent retrieves the active subscriptions that receive an event of
+// the given type about the given list.
+func (r *PostgresWebhookRepository) GetSubscriptionsForEvent(eventType, listID string) ([]*domain.WebhookSubscription, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhook_subscriptions
+	          WHERE active
+	          AND (cardinality(event_types) = 0 OR $1 = ANY(event_types))
+	          AND (list_id IS NULL OR list_id = $2)`
+
+	return r.querySubscriptions(query, eventType, listID)
+}
+
+func (r *PostgresWebhookRepository) querySubscriptions(query string, args ...interface{}) ([]*domain.WebhookSubscription, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	webhooks := []*domain.WebhookSubscription{}
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// UpdateSubscription stores the settings of a webhook subscription.
+func (r *PostgresWebhookRepository) UpdateSubscription(w *domain.WebhookSubscription) error {
+	query := `UPDATE webhook_subscriptions
+	          SET url = $2, event_types = $3, list_id = NULLIF($4, ''), secret = $5, active = $6, updated_at = $7
+	          WHERE id = $1`
+
+	result, err := r.db.Exec(query, w.ID, w.URL, pq.Array(w.EventTypes), w.ListID, w.Secret, w.Active, w.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return expectRows(result, domain.ErrWebhookNotFound)
+}
+
+// DeleteSubscription removes a webhook subscription together with its deliveries.
+func (r *PostgresWebhookRepository) DeleteSubscription(id string) error {
+	result, err := r.db.Exec(`DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return expectRows(result, domain.ErrWebhookNotFound)
+}
+
+// CreateDeliveries inserts the deliveries of an event in a single transaction. A webhook
+// that already has a delivery of the event is skipped.
+func (r *PostgresWebhookRepository) CreateDeliveries(deliveries []*domain.WebhookDelivery) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	query := `INSERT INTO webhook_deliveries (id, subscription_id, event_id, event_type, payload, status, attempts,
+	          next_attempt_at, created_at, updated_at)
+	          VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	          ON CONFLICT (subscription_id, event_id) DO NOTHING`
+
+	for _, d := range deliveries {
+		_, err := tx.Exec(query, d.ID, d.SubscriptionID, d.EventID, d.EventType, d.Payload, d.Status, d.Attempts,
+			d.NextAttemptAt, d.CreatedAt, d.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ClaimDueDeliveries retrieves up to limit pending deliveries due at now and postpones
+// them until leaseUntil, so that no other worker picks them while they are being sent.
+func (r *PostgresWebhookRepository) ClaimDueDeliveries(now, leaseUntil time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	query := `UPDATE webhook_deliveries SET next_attempt_at = $2
+	          WHERE id IN (
+	              SELECT id FROM webhook_deliveries
+	              WHERE status = 'pending' AND next_attempt_at <= $1
+	              ORDER BY next_attempt_at ASC
+	              LIMIT $3
+	              FOR UPDATE SKIP LOCKED
+	          )
+	          RETURNING ` + deliveryColumns
+
+	return r.queryDeliveries(query, now, leaseUntil, limit)
+}
+
+// GetDeliveries retrieves the most recent deliveries of a webhook, newest first,
+// optionally only those with the given status.
+func (r *PostgresWebhookRepository) GetDeliveries(subscriptionID, status string, limit int) ([]*domain.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries
+	          WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+	          ORDER BY created_at DESC
+	          LIMIT $3`
+
+	return r.queryDeliveries(query, subscriptionID, status, limit)
+}
+
+func (r *PostgresWebhookRepository) queryDeliveries(query string, args ...interface{}) ([]*domain.WebhookDelivery, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	deliveries := []*domain.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// GetDelivery retrieves a delivery by its ID.
+func (r *PostgresWebhookRepository) GetDelivery(id string) (*domain.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+
+	d, err := scanDelivery(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// GetAttempts retrieves the attempts made to send a delivery, in order.
+func (r *PostgresWebhookRepository) GetAttempts(deliveryID string) ([]*domain.WebhookAttempt, error) {
+	query := `SELECT id, delivery_id, attempt, COALESCE(status_code, 0), error, duration_ms, created_at
+	          FROM webhook_delivery_attempts WHERE delivery_id = $1 ORDER BY attempt ASC`
+
+	rows, err := r.db.Query(query, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck,gocritic
+	}()
+
+	attempts := []*domain.WebhookAttempt{}
+	for rows.Next() {
+		a := &domain.WebhookAttempt{}
+		var durationMS int64
+		if err := rows.Scan(&a.ID, &a.DeliveryID, &a.Attempt, &a.StatusCode, &a.Error, &durationMS, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		a.Duration = time.Duration(durationMS) * time.Millisecond
+		attempts = append(attempts, a)
+	}
+
+	return attempts, rows.Err()
+}
+
+// RecordAttempt stores an attempt to send a delivery together with the resulting state of
+// the delivery, in a single transaction.
+func (r *PostgresWebhookRepository) RecordAttempt(d *domain.WebhookDelivery, a *domain.WebhookAttempt) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck
+	}()
+
+	_, err = tx.Exec(`INSERT INTO webhook_delivery_attempts (id, delivery_id, attempt, status_code, error, duration_ms, created_at)
+	          VALUES ($1, $2, $3, NULLIF($4, 0), $5, $6, $7)`,
+		a.ID, a.DeliveryID, a.Attempt, a.StatusCode, a.Error, a.Duration.Milliseconds(), a.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE webhook_deliveries
+	          SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = NULLIF($5, 0), last_error = $6,
+	              updated_at = $7, delivered_at = $8
+	          WHERE id = $1`,
+		d.ID, d.Status, d.Attempts, d.NextAttemptAt, d.LastStatusCode, d.LastError, d.UpdatedAt, d.DeliveredAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ResetDelivery makes a delivery pending again with a new series of attempts starting at nextAttemptAt.
+func (r *PostgresWebhookRepository) ResetDelivery(id string, nextAttemptAt time.Time) error {
+	query := `UPDATE webhook_deliveries
+	          SET status = 'pending', attempts = 0, next_attempt_at = $2, updated_at = $2, delivered_at = NULL
+	          WHERE id = $1`
+
+	result, err := r.db.Exec(query, id, nextAttemptAt)
+	if err != nil {
+		return err
+	}
+
+	return expectRows(result, domain.ErrWebhookDeliveryNotFound)
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func TestPostgresWebhookRepository_GetSubscription_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresWebhookRepository(db)
+	mock.ExpectQuery("FROM webhook_subscriptions WHERE id = \\$1").WithArgs("w1").WillReturnError(sql.ErrNoRows)
+	_, err = r.GetSubscription("w1")
+	if err == nil || err.Error() != "webhook not found" {
+		t.Errorf("esperado error de webhook no encontrado, obtuve %v", err)
+	}
+}
+
+func TestPostgresWebhookRepository_GetSubscriptionsForEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresWebhookRepository(db)
+	now := time.Now()
+	mock.ExpectQuery("WHERE active\\s+AND \\(cardinality\\(event_types\\) = 0 OR \\$1 = ANY\\(event_types\\)\\)\\s+AND \\(list_id IS NULL OR list_id = \\$2\\)").
+		WithArgs("task.created", "l1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "owner_id", "url", "event_types", "list_id", "secret", "active", "created_at", "updated_at"}).
+			AddRow("w1", "u1", "https://example.com", "{task.created,task.updated}", "", "secret", true, now, now))
+
+	webhooks, err := r.GetSubscriptionsForEvent("task.created", "l1")
+	if err != nil {
+		t.Fatalf("no se esperaba error en GetSubscriptionsForEvent: %v", err)
+	}
+	if len(webhooks) != 1 || len(webhooks[0].EventTypes) != 2 || webhooks[0].ListID != "" {
+		t.Errorf("webhooks inesperados: %+v", webhooks)
+	}
+}
+
+func TestPostgresWebhookRepository_ClaimDueDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresWebhookRepository(db)
+	now := time.Now()
+	lease := now.Add(time.Minute)
+	mock.ExpectQuery("UPDATE webhook_deliveries SET next_attempt_at = \\$2(.|\\n)*FOR UPDATE SKIP LOCKED").
+		WithArgs(now, lease, 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts",
+			"next_attempt_at", "last_status_code", "last_error", "created_at", "updated_at", "delivered_at"}).
+			AddRow("d1", "w1", "e1", "task.created", []byte(`{}`), "pending", 1, lease, 500, "unexpected status 500", now, now, nil))
+
+	deliveries, err := r.ClaimDueDeliveries(now, lease, 50)
+	if err != nil {
+		t.Fatalf("no se esperaba error en ClaimDueDeliveries: %v", err)
+	}
+	if len(deliveries) != 1 || deliveries[0].LastStatusCode != 500 || deliveries[0].DeliveredAt != nil {
+		t.Errorf("entregas inesperadas: %+v", deliveries)
+	}
+}
+
+func TestPostgresWebhookRepository_RecordAttempt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresWebhookRepository(db)
+	now := time.Now()
+	delivery := &domain.WebhookDelivery{ID: "d1", Status: domain.DeliveryDelivered, Attempts: 1, NextAttemptAt: now,
+		LastStatusCode: 200, UpdatedAt: now, DeliveredAt: &now}
+	attempt := &domain.WebhookAttempt{ID: "a1", DeliveryID: "d1", Attempt: 1, StatusCode: 200, Duration: 25 * time.Millisecond, CreatedAt: now}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO webhook_delivery_attempts").
+		WithArgs("a1", "d1", 1, 200, "", int64(25), now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE webhook_deliveries").
+		WithArgs("d1", "delivered", 1, now, 200, "", now, &now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := r.RecordAttempt(delivery, attempt); err != nil {
+		t.Fatalf("no se esperaba error en RecordAttempt: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectativas no cumplidas: %v", err)
+	}
+}
+
+func TestPostgresWebhookRepository_ResetDelivery_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("error creating sqlmock: %v", err)
+	}
+	r := NewPostgresWebhookRepository(db)
+	now := time.Now()
+	mock.ExpectExec("UPDATE webhook_deliveries\\s+SET status = 'pending', attempts = 0").
+		WithArgs("d1", now).WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := r.ResetDelivery("d1", now); err == nil || err.Error() != "delivery not found" {
+		t.Errorf("esperado error de entrega no encontrada, obtuve %v", err)
+	}
+}
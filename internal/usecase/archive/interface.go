@@ -0,0 +1,14 @@
+// Package archive provides business logic for archiving tasks and task lists.
+package archive
+
+import "time"
+
+// Repository defines the interface for archive persistence operations.
+type Repository interface {
+	ArchiveTask(id string, at time.Time, changedBy string) error
+	UnarchiveTask(id, changedBy string) error
+	ArchiveList(id string, at time.Time) error
+	UnarchiveList(id string) error
+	ArchiveCompletedInList(listID string, at time.Time, changedBy string) (int64, error)
+	ArchiveCompletedBefore(before, at time.Time, changedBy string) (int64, error)
+}
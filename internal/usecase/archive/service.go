@@ -0,0 +1,107 @@
+package archive
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+// Service implements the archive business logic operations.
+type Service struct {
+	repo      Repository
+	autoAfter time.Duration
+}
+
+// NewService creates and returns a new archive Service instance.
+// Tasks completed for longer than autoAfter are archived by AutoArchive.
+func NewService(repo Repository, autoAfter time.Duration) *Service {
+	return &Service{
+		repo:      repo,
+		autoAfter: autoAfter,
+	}
+}
+
+// ArchiveTask archives a single task, hiding it from default task queries.
+func (s *Service) ArchiveTask(id, changedBy string) (err error) {
+	defer utils.RecoverPanic("service", "ArchiveTask", &err)
+
+	if id == "" {
+		return domain.NewInvalidError("id", "invalid_id", "id cannot be empty")
+	}
+
+	return s.repo.ArchiveTask(id, time.Now(), changedBy)
+}
+
+// UnarchiveTask brings an archived task back into default task queries.
+func (s *Service) UnarchiveTask(id, changedBy string) (err error) {
+	defer utils.RecoverPanic("service", "UnarchiveTask", &err)
+
+	if id == "" {
+		return domain.NewInvalidError("id", "invalid_id", "id cannot be empty")
+	}
+
+	return s.repo.UnarchiveTask(id, changedBy)
+}
+
+// ArchiveList archives a task list, making it and its tasks read-only.
+func (s *Service) ArchiveList(id string) (err error) {
+	defer utils.RecoverPanic("service", "ArchiveList", &err)
+
+	if id == "" {
+		return domain.NewInvalidError("id", "invalid_id", "id cannot be empty")
+	}
+
+	return s.repo.ArchiveList(id, time.Now())
+}
+
+// UnarchiveList makes an archived task list writable again.
+func (s *Service) UnarchiveList(id string) (err error) {
+	defer utils.RecoverPanic("service", "UnarchiveList", &err)
+
+	if id == "" {
+		return domain.NewInvalidError("id", "invalid_id", "id cannot be empty")
+	}
+
+	return s.repo.UnarchiveList(id)
+}
+
+// ArchiveCompletedInList archives every completed task of a list and returns how many were archived.
+func (s *Service) ArchiveCompletedInList(listID, changedBy string) (count int64, err error) {
+	defer utils.RecoverPanic("service", "ArchiveCompletedInList", &err)
+
+	if listID == "" {
+		return 0, domain.NewInvalidError("id", "invalid_id", "id cannot be empty")
+	}
+
+	return s.repo.ArchiveCompletedInList(listID, time.Now(), changedBy)
+}
+
+// AutoArchive archives tasks that have been completed for longer than the configured period.
+// The revisions it records are attributed to domain.SystemUser.
+func (s *Service) AutoArchive() (err error) {
+	defer utils.RecoverPanic("service", "AutoArchive", &err)
+
+	now := time.Now()
+	count, err := s.repo.ArchiveCompletedBefore(now.Add(-s.autoAfter), now, domain.SystemUser)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"layer":  "service",
+			"method": "AutoArchive",
+			"tasks":  count,
+		}).Info("Auto-archived completed tasks")
+	}
+
+	return nil
+}
+
+// StartAutoArchiveJob runs AutoArchive every interval in a background goroutine.
+// The returned function stops the job.
+func (s *Service) StartAutoArchiveJob(interval time.Duration) func() {
+	return utils.StartJob("auto-archive", interval, s.AutoArchive)
+}
@@ -0,0 +1,84 @@
+package archive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockRepo struct {
+	archivedTasks map[string]time.Time
+	before        time.Time
+	completed     int64
+	changedBy     string
+}
+
+func (m *mockRepo) ArchiveTask(id string, at time.Time, _ string) error {
+	if m.archivedTasks == nil {
+		m.archivedTasks = map[string]time.Time{}
+	}
+	m.archivedTasks[id] = at
+	return nil
+}
+func (m *mockRepo) UnarchiveTask(id, _ string) error {
+	delete(m.archivedTasks, id)
+	return nil
+}
+func (m *mockRepo) ArchiveList(string, time.Time) error { return nil }
+func (m *mockRepo) UnarchiveList(string) error          { return nil }
+func (m *mockRepo) ArchiveCompletedInList(string, time.Time, string) (int64, error) {
+	return m.completed, nil
+}
+func (m *mockRepo) ArchiveCompletedBefore(before, at time.Time, changedBy string) (int64, error) {
+	m.before = before
+	m.changedBy = changedBy
+	return m.completed, nil
+}
+
+func TestService_ArchiveTask(t *testing.T) {
+	repo := &mockRepo{}
+	s := NewService(repo, time.Hour)
+	if err := s.ArchiveTask("1", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := repo.archivedTasks["1"]; !ok {
+		t.Error("expected task 1 to be archived")
+	}
+	if err := s.UnarchiveTask("1", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := repo.archivedTasks["1"]; ok {
+		t.Error("expected task 1 to be unarchived")
+	}
+}
+
+func TestService_ArchiveTask_EmptyID(t *testing.T) {
+	s := NewService(&mockRepo{}, time.Hour)
+	if err := s.ArchiveTask("", ""); err == nil {
+		t.Error("expected error for empty id")
+	}
+}
+
+func TestService_ArchiveCompletedInList(t *testing.T) {
+	s := NewService(&mockRepo{completed: 3}, time.Hour)
+	count, err := s.ArchiveCompletedInList("list-1", "")
+	if err != nil || count != 3 {
+		t.Errorf("expected 3 archived tasks, got %d, err: %v", count, err)
+	}
+}
+
+func TestService_AutoArchive_UsesThreshold(t *testing.T) {
+	repo := &mockRepo{completed: 1}
+	s := NewService(repo, 7*24*time.Hour)
+	if err := s.AutoArchive(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := time.Now().Add(-7 * 24 * time.Hour)
+	if repo.before.Sub(expected).Abs() > time.Minute {
+		t.Errorf("expected threshold near %v, got %v", expected, repo.before)
+	}
+	if repo.changedBy != domain.SystemUser {
+		t.Errorf("expected auto-archived revisions attributed to %q, got %q", domain.SystemUser, repo.changedBy)
+	}
+}
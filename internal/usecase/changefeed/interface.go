@@ -0,0 +1,32 @@
+// Package changefeed lets offline clients download only the tasks and task lists that
+// changed since their last sync, and upload the changes they made while offline.
+package changefeed
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// Repository defines the interface for changes feed persistence operations.
+type Repository interface {
+	// GetChanges retrieves up to limit changes with a sequence greater than since, in order.
+	GetChanges(since int64, limit int) (*domain.SyncFeed, error)
+	PurgeTombstonesBefore(before time.Time) (int64, error)
+}
+
+// TaskService defines the task operations used to apply pushed changes.
+type TaskService interface {
+	GetByID(id string) (*domain.Task, error)
+	CreateWithID(input domain.NewTask, changedBy string) (*domain.Task, error)
+	Patch(id string, version int, patch domain.TaskPatch, changedBy string) (*domain.Task, error)
+	DeleteIfMatch(id string, version int, changedBy string) error
+}
+
+// ListService defines the task list operations used to apply pushed changes.
+type ListService interface {
+	GetByID(id string) (*domain.TaskList, error)
+	CreateWithID(id, name, description string) (*domain.TaskList, error)
+	Patch(id string, version int, patch domain.TaskListPatch) (*domain.TaskList, error)
+	DeleteIfMatch(id string, version int, changedBy string) error
+}
@@ -0,0 +1,244 @@
+package changefeed
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+const (
+	defaultLimit = 500
+	maxLimit     = 1000
+)
+
+// Service implements the changes feed operations.
+type Service struct {
+	repo      Repository
+	tasks     TaskService
+	lists     ListService
+	retention time.Duration
+}
+
+// NewService creates and returns a new changefeed Service instance. Tombstones of deleted
+// entities are kept for retention, which bounds how long a client can stay offline and
+// still sync incrementally.
+func NewService(repo Repository, tasks TaskService, lists ListService, retention time.Duration) *Service {
+	return &Service{
+		repo:      repo,
+		tasks:     tasks,
+		lists:     lists,
+		retention: retention,
+	}
+}
+
+// Changes retrieves the changes made after the given sequence, which is 0 for a first
+// sync. A limit of 0 takes the default; larger limits are capped.
+func (s *Service) Changes(since int64, limit int) (feed *domain.SyncFeed, err error) {
+	defer utils.RecoverPanic("service", "Changes", &err)
+
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	return s.repo.GetChanges(since, limit)
+}
+
+// Push applies the changes made by an offline client, in order. Each change is applied on
+// its own: one made to a version that is no longer current is not applied and reported as
+// a conflict together with the server copy, so that the client can merge them. The task
+// revisions the changes record are attributed to changedBy.
+func (s *Service) Push(changes []domain.SyncPush, changedBy string) (results []domain.SyncPushResult, err error) {
+	defer utils.RecoverPanic("service", "Push", &err)
+
+	if err := validatePush(changes); err != nil {
+		return nil, err
+	}
+
+	results = make([]domain.SyncPushResult, len(changes))
+	for i, change := range changes {
+		results[i] = domain.SyncPushResult{Index: i, Type: change.Type, ID: change.ID}
+		if change.Type == domain.SyncTask {
+			s.pushTask(change, changedBy, &results[i])
+		} else {
+			s.pushList(change, changedBy, &results[i])
+		}
+		if results[i].Status == "" && results[i].Err == nil {
+			results[i].Status = domain.SyncApplied
+		}
+	}
+
+	return results, nil
+}
+
+func validatePush(changes []domain.SyncPush) error {
+	if len(changes) == 0 {
+		return invalidBatch("changes cannot be empty")
+	}
+	if len(changes) > domain.MaxBulkItems {
+		return invalidBatch("at most %d changes are allowed", domain.MaxBulkItems)
+	}
+
+	for i, change := range changes {
+		if change.Type != domain.SyncTask && change.Type != domain.SyncList {
+			return invalidBatch("change %d: type must be task or list", i)
+		}
+		if change.ID == "" {
+			return invalidBatch("change %d: id is required", i)
+		}
+		switch change.Op {
+		case domain.BulkCreate:
+		case domain.BulkUpdate, domain.BulkDelete:
+			if change.BaseVersion <= 0 {
+				return invalidBatch("change %d: base_version is required", i)
+			}
+		default:
+			return invalidBatch("change %d: op must be create, update or delete", i)
+		}
+	}
+
+	return nil
+}
+
+// invalidBatch returns the error of a batch of pushed changes that cannot be applied.
+func invalidBatch(format string, args ...any) error {
+	return domain.NewInvalidError("changes", "invalid_sync_batch", "invalid sync batch: "+fmt.Sprintf(format, args...))
+}
+
+// pushTask applies a change to a task. An update of a deleted task is a conflict, while a
+// delete of a deleted task is already applied.
+func (s *Service) pushTask(change domain.SyncPush, changedBy string, result *domain.SyncPushResult) {
+	current, err := s.tasks.GetByID(change.ID)
+	if err != nil && !errors.Is(err, domain.ErrTaskNotFound) {
+		result.Err = err
+		return
+	}
+
+	switch change.Op {
+	case domain.BulkCreate:
+		if current != nil {
+			result.Status, result.Task, result.Err = domain.SyncConflict, current, domain.ErrTaskExists
+			return
+		}
+		result.Task, result.Err = s.createTask(change, changedBy)
+	case domain.BulkUpdate:
+		if current == nil {
+			result.Status, result.Err = domain.SyncConflict, domain.ErrTaskNotFound
+			return
+		}
+		if current.Version != change.BaseVersion {
+			result.Status, result.Task, result.Err = domain.SyncConflict, current, domain.ErrVersionConflict
+			return
+		}
+		result.Task, result.Err = s.tasks.Patch(change.ID, change.BaseVersion, change.TaskPatch, changedBy)
+	case domain.BulkDelete:
+		if current == nil {
+			return
+		}
+		if current.Version != change.BaseVersion {
+			result.Status, result.Task, result.Err = domain.SyncConflict, current, domain.ErrVersionConflict
+			return
+		}
+		result.Err = s.tasks.DeleteIfMatch(change.ID, change.BaseVersion, changedBy)
+	}
+
+	// The task changed between the check and the write.
+	if errors.Is(result.Err, domain.ErrVersionConflict) {
+		result.Status = domain.SyncConflict
+		result.Task, _ = s.tasks.GetByID(change.ID) //nolint:errcheck
+	}
+}
+
+// createTask creates a task with all the fields of a create change in a single write.
+func (s *Service) createTask(change domain.SyncPush, changedBy string) (*domain.Task, error) {
+	patch := change.TaskPatch
+	input := domain.NewTask{
+		ID:          change.ID,
+		ListID:      patch.ListID.Value,
+		Title:       patch.Title.Value,
+		Description: patch.Description.Value,
+		Priority:    patch.Priority.Value,
+		Status:      patch.Status.Value,
+		Labels:      patch.Labels.Value,
+	}
+	if patch.DueDate.Set && !patch.DueDate.Null {
+		due := patch.DueDate.Value
+		input.DueDate = &due
+	}
+
+	return s.tasks.CreateWithID(input, changedBy)
+}
+
+// pushList applies a change to a task list, the same way pushTask does for tasks.
+func (s *Service) pushList(change domain.SyncPush, changedBy string, result *domain.SyncPushResult) {
+	current, err := s.lists.GetByID(change.ID)
+	if err != nil && !errors.Is(err, domain.ErrTaskListNotFound) {
+		result.Err = err
+		return
+	}
+
+	switch change.Op {
+	case domain.BulkCreate:
+		if current != nil {
+			result.Status, result.List, result.Err = domain.SyncConflict, current, domain.ErrTaskListExists
+			return
+		}
+		result.List, result.Err = s.lists.CreateWithID(change.ID, change.ListPatch.Name.Value, change.ListPatch.Description.Value)
+	case domain.BulkUpdate:
+		if current == nil {
+			result.Status, result.Err = domain.SyncConflict, domain.ErrTaskListNotFound
+			return
+		}
+		if current.Version != change.BaseVersion {
+			result.Status, result.List, result.Err = domain.SyncConflict, current, domain.ErrVersionConflict
+			return
+		}
+		result.List, result.Err = s.lists.Patch(change.ID, change.BaseVersion, change.ListPatch)
+	case domain.BulkDelete:
+		if current == nil {
+			return
+		}
+		if current.Version != change.BaseVersion {
+			result.Status, result.List, result.Err = domain.SyncConflict, current, domain.ErrVersionConflict
+			return
+		}
+		result.Err = s.lists.DeleteIfMatch(change.ID, change.BaseVersion, changedBy)
+	}
+
+	if errors.Is(result.Err, domain.ErrVersionConflict) {
+		result.Status = domain.SyncConflict
+		result.List, _ = s.lists.GetByID(change.ID) //nolint:errcheck
+	}
+}
+
+// Purge permanently removes the tombstones older than the retention period.
+func (s *Service) Purge() (err error) {
+	defer utils.RecoverPanic("service", "Purge", &err)
+
+	purged, err := s.repo.PurgeTombstonesBefore(time.Now().Add(-s.retention))
+	if err != nil {
+		return err
+	}
+
+	if purged > 0 {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"layer":      "service",
+			"method":     "Purge",
+			"tombstones": purged,
+		}).Info("Purged expired sync tombstones")
+	}
+
+	return nil
+}
+
+// StartPurgeJob runs Purge every interval in a background goroutine.
+// The returned function stops the job.
+func (s *Service) StartPurgeJob(interval time.Duration) func() {
+	return utils.StartJob("sync-purge", interval, s.Purge)
+}
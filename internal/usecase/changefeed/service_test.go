@@ -0,0 +1,231 @@
+package changefeed
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockRepo struct {
+	GetChangesFn            func(since int64, limit int) (*domain.SyncFeed, error)
+	PurgeTombstonesBeforeFn func(before time.Time) (int64, error)
+}
+
+func (m *mockRepo) GetChanges(since int64, limit int) (*domain.SyncFeed, error) {
+	return m.GetChangesFn(since, limit)
+}
+func (m *mockRepo) PurgeTombstonesBefore(before time.Time) (int64, error) {
+	return m.PurgeTombstonesBeforeFn(before)
+}
+
+// mockTasks keeps tasks in memory and checks versions the way the task service does.
+type mockTasks struct {
+	tasks   map[string]*domain.Task
+	patched []domain.TaskPatch
+	deleted []string
+}
+
+func (m *mockTasks) GetByID(id string) (*domain.Task, error) {
+	if task, ok := m.tasks[id]; ok {
+		copied := *task
+		return &copied, nil
+	}
+	return nil, domain.ErrTaskNotFound
+}
+func (m *mockTasks) CreateWithID(input domain.NewTask, _ string) (*domain.Task, error) {
+	if input.Title == "" {
+		return nil, domain.NewInvalidError("title", "invalid_title", "title cannot be empty")
+	}
+	status := input.Status
+	if status == "" {
+		status = "pending"
+	}
+	task := &domain.Task{ID: input.ID, ListID: input.ListID, Title: input.Title, Status: status, Version: 1}
+	m.tasks[input.ID] = task
+	return task, nil
+}
+func (m *mockTasks) Patch(id string, version int, patch domain.TaskPatch, _ string) (*domain.Task, error) {
+	task, ok := m.tasks[id]
+	if !ok {
+		return nil, domain.ErrTaskNotFound
+	}
+	if task.Version != version {
+		return nil, domain.ErrVersionConflict
+	}
+	m.patched = append(m.patched, patch)
+	if patch.Status.Set {
+		task.Status = patch.Status.Value
+	}
+	task.Version++
+	return task, nil
+}
+func (m *mockTasks) DeleteIfMatch(id string, version int, _ string) error {
+	if m.tasks[id].Version != version {
+		return domain.ErrVersionConflict
+	}
+	m.deleted = append(m.deleted, id)
+	delete(m.tasks, id)
+	return nil
+}
+
+type mockLists struct {
+	lists map[string]*domain.TaskList
+}
+
+func (m *mockLists) GetByID(id string) (*domain.TaskList, error) {
+	if list, ok := m.lists[id]; ok {
+		return list, nil
+	}
+	return nil, domain.ErrTaskListNotFound
+}
+func (m *mockLists) CreateWithID(id, name, description string) (*domain.TaskList, error) {
+	list := &domain.TaskList{ID: id, Name: name, Description: description, Version: 1}
+	m.lists[id] = list
+	return list, nil
+}
+func (m *mockLists) Patch(id string, _ int, patch domain.TaskListPatch) (*domain.TaskList, error) {
+	list := m.lists[id]
+	list.Name = patch.Name.Value
+	list.Version++
+	return list, nil
+}
+func (m *mockLists) DeleteIfMatch(id string, _ int, _ string) error {
+	delete(m.lists, id)
+	return nil
+}
+
+func newTestService(tasks map[string]*domain.Task) (*Service, *mockTasks, *mockLists) {
+	mt := &mockTasks{tasks: tasks}
+	ml := &mockLists{lists: map[string]*domain.TaskList{"l1": {ID: "l1", Name: "List", Version: 2}}}
+	return NewService(&mockRepo{}, mt, ml, time.Hour), mt, ml
+}
+
+func TestService_Changes_Limit(t *testing.T) {
+	var limits []int
+	s := NewService(&mockRepo{
+		GetChangesFn: func(since int64, limit int) (*domain.SyncFeed, error) {
+			limits = append(limits, limit)
+			return &domain.SyncFeed{Sequence: since}, nil
+		},
+	}, nil, nil, time.Hour)
+
+	for _, limit := range []int{0, 20, 5000} {
+		if _, err := s.Changes(7, limit); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if limits[0] != defaultLimit || limits[1] != 20 || limits[2] != maxLimit {
+		t.Errorf("expected default, given and capped limits, got %v", limits)
+	}
+}
+
+func TestService_Push_AppliesInOrder(t *testing.T) {
+	s, tasks, lists := newTestService(map[string]*domain.Task{
+		"t1": {ID: "t1", Title: "Existing", Version: 3},
+	})
+
+	results, err := s.Push([]domain.SyncPush{
+		{Type: domain.SyncList, Op: domain.BulkCreate, ID: "l2", ListPatch: domain.TaskListPatch{Name: domain.PatchField[string]{Set: true, Value: "Offline"}}},
+		{Type: domain.SyncTask, Op: domain.BulkCreate, ID: "t2", TaskPatch: domain.TaskPatch{
+			ListID: domain.PatchField[string]{Set: true, Value: "l2"},
+			Title:  domain.PatchField[string]{Set: true, Value: "New"},
+			Status: domain.PatchField[string]{Set: true, Value: "completed"},
+		}},
+		{Type: domain.SyncTask, Op: domain.BulkUpdate, ID: "t1", BaseVersion: 3, TaskPatch: domain.TaskPatch{
+			Status: domain.PatchField[string]{Set: true, Value: "in-progress"},
+		}},
+		{Type: domain.SyncTask, Op: domain.BulkDelete, ID: "gone", BaseVersion: 1},
+	}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, result := range results {
+		if result.Status != domain.SyncApplied || result.Err != nil || result.Index != i {
+			t.Errorf("expected change %d to be applied, got %+v", i, result)
+		}
+	}
+	if lists.lists["l2"] == nil {
+		t.Error("expected the list to be created")
+	}
+	if created := tasks.tasks["t2"]; created == nil || created.Status != "completed" || created.Version != 1 || len(tasks.patched) != 1 {
+		t.Errorf("expected the created task to be completed, got %+v", created)
+	}
+	if tasks.tasks["t1"].Status != "in-progress" || results[2].Task.Version != 4 {
+		t.Errorf("expected the update to be applied, got %+v", results[2].Task)
+	}
+}
+
+func TestService_Push_ReportsConflicts(t *testing.T) {
+	s, tasks, _ := newTestService(map[string]*domain.Task{
+		"t1": {ID: "t1", Title: "Changed on the server", Version: 5},
+	})
+
+	results, err := s.Push([]domain.SyncPush{
+		{Type: domain.SyncTask, Op: domain.BulkUpdate, ID: "t1", BaseVersion: 4},
+		{Type: domain.SyncTask, Op: domain.BulkDelete, ID: "t1", BaseVersion: 4},
+		{Type: domain.SyncTask, Op: domain.BulkUpdate, ID: "missing", BaseVersion: 1},
+		{Type: domain.SyncTask, Op: domain.BulkCreate, ID: "t1", TaskPatch: domain.TaskPatch{Title: domain.PatchField[string]{Set: true, Value: "Dup"}}},
+		{Type: domain.SyncList, Op: domain.BulkUpdate, ID: "l1", BaseVersion: 1},
+	}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reasons := []string{"version conflict", "version conflict", "task not found", "task already exists", "version conflict"}
+	for i, result := range results {
+		if result.Status != domain.SyncConflict || result.Err == nil || result.Err.Error() != reasons[i] {
+			t.Errorf("change %d: expected conflict %q, got %+v", i, reasons[i], result)
+		}
+	}
+	if results[0].Task == nil || results[0].Task.Version != 5 || results[2].Task != nil || results[4].List.Version != 2 {
+		t.Error("expected conflicts to carry the server copy")
+	}
+	if len(tasks.patched) != 0 || len(tasks.deleted) != 0 {
+		t.Error("expected no conflicting change to be written")
+	}
+}
+
+func TestService_Push_RejectsInvalidChanges(t *testing.T) {
+	s, _, _ := newTestService(map[string]*domain.Task{})
+
+	results, err := s.Push([]domain.SyncPush{{Type: domain.SyncTask, Op: domain.BulkCreate, ID: "t1"}}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[0].Status != "" || results[0].Err == nil || results[0].Err.Error() != "title cannot be empty" {
+		t.Errorf("expected the change to fail validation, got %+v", results[0])
+	}
+
+	invalid := [][]domain.SyncPush{
+		nil,
+		{{Type: "sprint", Op: domain.BulkCreate, ID: "x"}},
+		{{Type: domain.SyncTask, Op: "move", ID: "x"}},
+		{{Type: domain.SyncTask, Op: domain.BulkUpdate, ID: "x"}},
+		make([]domain.SyncPush, domain.MaxBulkItems+1),
+	}
+	for _, changes := range invalid {
+		if _, err := s.Push(changes, ""); err == nil || !strings.HasPrefix(err.Error(), "invalid sync batch") {
+			t.Errorf("expected an invalid sync batch error, got %v", err)
+		}
+	}
+}
+
+func TestService_Purge(t *testing.T) {
+	var cutoff time.Time
+	s := NewService(&mockRepo{
+		PurgeTombstonesBeforeFn: func(before time.Time) (int64, error) {
+			cutoff = before
+			return 2, nil
+		},
+	}, nil, nil, 24*time.Hour)
+
+	if err := s.Purge(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if age := time.Since(cutoff); age < 24*time.Hour || age > 25*time.Hour {
+		t.Errorf("expected tombstones older than a day to be purged, got cutoff %v", cutoff)
+	}
+}
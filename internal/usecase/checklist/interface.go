@@ -0,0 +1,24 @@
+// Package checklist provides the ordered checklist items of tasks.
+package checklist
+
+import "github.com/G20-00/task-management-service-go/internal/domain"
+
+// Repository defines the interface for checklist item persistence operations.
+type Repository interface {
+	// Create appends an item at the end of the checklist and writes its position back into item.
+	Create(item *domain.ChecklistItem) error
+	GetByTaskID(taskID string) ([]*domain.ChecklistItem, error)
+	GetByID(taskID, id string) (*domain.ChecklistItem, error)
+	Update(item *domain.ChecklistItem) error
+	Delete(taskID, id string) error
+	// Reorder assigns positions following the order of ids in a single transaction.
+	Reorder(taskID string, ids []string) error
+	// ConvertToTask creates the task and removes the item in a single transaction.
+	ConvertToTask(item *domain.ChecklistItem, task *domain.Task, changedBy string) error
+}
+
+// TaskReader defines the task operations needed to manage checklists.
+type TaskReader interface {
+	GetByID(id string) (*domain.Task, error)
+	IsListArchived(listID string) (bool, error)
+}
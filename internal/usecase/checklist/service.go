@@ -0,0 +1,214 @@
+package checklist
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+// Service implements the checklist business logic operations.
+type Service struct {
+	repo  Repository
+	tasks TaskReader
+}
+
+// NewService creates and returns a new checklist Service instance.
+func NewService(repo Repository, tasks TaskReader) *Service {
+	return &Service{
+		repo:  repo,
+		tasks: tasks,
+	}
+}
+
+// GetItems retrieves the checklist of a task in order.
+func (s *Service) GetItems(taskID string) (items []*domain.ChecklistItem, err error) {
+	defer utils.RecoverPanic("service", "GetItems", &err)
+
+	if _, err := s.tasks.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByTaskID(taskID)
+}
+
+// AddItem appends a new unchecked item to the checklist of a task.
+func (s *Service) AddItem(taskID, title string) (item *domain.ChecklistItem, err error) {
+	defer utils.RecoverPanic("service", "AddItem", &err)
+
+	if strings.TrimSpace(title) == "" {
+		return nil, domain.NewInvalidError("title", "invalid_title", "title cannot be empty")
+	}
+
+	if _, err := s.writableTask(taskID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	item = &domain.ChecklistItem{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		Title:     title,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repo.Create(item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// UpdateItem renames an item and/or sets its done state. Nil arguments are left unchanged.
+func (s *Service) UpdateItem(taskID, id string, title *string, done *bool) (item *domain.ChecklistItem, err error) {
+	defer utils.RecoverPanic("service", "UpdateItem", &err)
+
+	if title != nil && strings.TrimSpace(*title) == "" {
+		return nil, domain.NewInvalidError("title", "invalid_title", "title cannot be empty")
+	}
+
+	if _, err := s.writableTask(taskID); err != nil {
+		return nil, err
+	}
+
+	item, err = s.repo.GetByID(taskID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if title != nil {
+		item.Title = *title
+	}
+	if done != nil {
+		item.Done = *done
+	}
+	item.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// ToggleItem flips the done state of an item.
+func (s *Service) ToggleItem(taskID, id string) (item *domain.ChecklistItem, err error) {
+	defer utils.RecoverPanic("service", "ToggleItem", &err)
+
+	item, err = s.repo.GetByID(taskID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	done := !item.Done
+	return s.UpdateItem(taskID, id, nil, &done)
+}
+
+// Reorder sets the order of the checklist. ids must contain every item of the task exactly once.
+func (s *Service) Reorder(taskID string, ids []string) (items []*domain.ChecklistItem, err error) {
+	defer utils.RecoverPanic("service", "Reorder", &err)
+
+	if _, err := s.writableTask(taskID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.GetByTaskID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) != len(current) {
+		return nil, domain.NewInvalidError("item_ids", "invalid_item_ids", "item ids must list every checklist item exactly once")
+	}
+	remaining := make(map[string]bool, len(current))
+	for _, item := range current {
+		remaining[item.ID] = true
+	}
+	for _, id := range ids {
+		if !remaining[id] {
+			return nil, domain.NewInvalidError("item_ids", "invalid_item_ids", "item ids must list every checklist item exactly once")
+		}
+		delete(remaining, id)
+	}
+
+	if err := s.repo.Reorder(taskID, ids); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByTaskID(taskID)
+}
+
+// DeleteItem removes an item from the checklist.
+func (s *Service) DeleteItem(taskID, id string) (err error) {
+	defer utils.RecoverPanic("service", "DeleteItem", &err)
+
+	if _, err := s.writableTask(taskID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(taskID, id)
+}
+
+// ConvertToTask turns a checklist item into a subtask of its task in the same list,
+// inheriting the priority of the task. The item is removed from the checklist.
+func (s *Service) ConvertToTask(taskID, id, changedBy string) (task *domain.Task, err error) {
+	defer utils.RecoverPanic("service", "ConvertToTask", &err)
+
+	parent, err := s.writableTask(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.repo.GetByID(taskID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	status := "pending"
+	if item.Done {
+		status = "completed"
+	}
+
+	now := time.Now()
+	task = &domain.Task{
+		ID:        uuid.New().String(),
+		ListID:    parent.ListID,
+		Title:     item.Title,
+		Status:    status,
+		Priority:  parent.Priority,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ParentID:  parent.ID,
+		Labels:    []string{},
+	}
+
+	if err := s.repo.ConvertToTask(item, task, changedBy); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// writableTask returns the task when its list can be modified.
+func (s *Service) writableTask(taskID string) (*domain.Task, error) {
+	task, err := s.tasks.GetByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.ListID != "" {
+		archived, err := s.tasks.IsListArchived(task.ListID)
+		if err != nil {
+			return nil, err
+		}
+		if archived {
+			return nil, domain.ErrTaskListArchived
+		}
+	}
+
+	return task, nil
+}
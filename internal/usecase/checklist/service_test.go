@@ -0,0 +1,111 @@
+package checklist
+
+import (
+	"testing"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockRepo struct {
+	items     []*domain.ChecklistItem
+	order     []string
+	converted *domain.Task
+}
+
+func (m *mockRepo) Create(item *domain.ChecklistItem) error {
+	item.Position = len(m.items)
+	m.items = append(m.items, item)
+	return nil
+}
+func (m *mockRepo) GetByTaskID(string) ([]*domain.ChecklistItem, error) { return m.items, nil }
+func (m *mockRepo) GetByID(_, id string) (*domain.ChecklistItem, error) {
+	for _, item := range m.items {
+		if item.ID == id {
+			return item, nil
+		}
+	}
+	return nil, domain.ErrChecklistItemNotFound
+}
+func (m *mockRepo) Update(*domain.ChecklistItem) error { return nil }
+func (m *mockRepo) Delete(string, string) error        { return nil }
+func (m *mockRepo) Reorder(_ string, ids []string) error {
+	m.order = ids
+	return nil
+}
+func (m *mockRepo) ConvertToTask(_ *domain.ChecklistItem, task *domain.Task, _ string) error {
+	m.converted = task
+	return nil
+}
+
+type mockTasks struct {
+	task     *domain.Task
+	archived bool
+}
+
+func (m *mockTasks) GetByID(string) (*domain.Task, error) {
+	if m.task == nil {
+		return nil, domain.ErrTaskNotFound
+	}
+	return m.task, nil
+}
+func (m *mockTasks) IsListArchived(string) (bool, error) { return m.archived, nil }
+
+func TestService_AddItem_AppendsItem(t *testing.T) {
+	repo := &mockRepo{}
+	s := NewService(repo, &mockTasks{task: &domain.Task{ID: "1", ListID: "l"}})
+	if _, err := s.AddItem("1", "first"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	item, err := s.AddItem("1", "second")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Position != 1 || item.Done {
+		t.Errorf("unexpected item: %+v", item)
+	}
+}
+
+func TestService_AddItem_ArchivedList(t *testing.T) {
+	s := NewService(&mockRepo{}, &mockTasks{task: &domain.Task{ID: "1", ListID: "l"}, archived: true})
+	if _, err := s.AddItem("1", "step"); err == nil || err.Error() != "task list is archived" {
+		t.Errorf("expected archived list error, got %v", err)
+	}
+}
+
+func TestService_ToggleItem(t *testing.T) {
+	repo := &mockRepo{items: []*domain.ChecklistItem{{ID: "a", TaskID: "1"}}}
+	s := NewService(repo, &mockTasks{task: &domain.Task{ID: "1"}})
+	item, err := s.ToggleItem("1", "a")
+	if err != nil || !item.Done {
+		t.Errorf("expected item to be done, got %+v, err: %v", item, err)
+	}
+}
+
+func TestService_Reorder_RequiresEveryItem(t *testing.T) {
+	repo := &mockRepo{items: []*domain.ChecklistItem{{ID: "a"}, {ID: "b"}}}
+	s := NewService(repo, &mockTasks{task: &domain.Task{ID: "1"}})
+	if _, err := s.Reorder("1", []string{"a", "a"}); err == nil {
+		t.Error("expected error for duplicated ids")
+	}
+	if _, err := s.Reorder("1", []string{"b", "a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.order) != 2 || repo.order[0] != "b" {
+		t.Errorf("unexpected order: %v", repo.order)
+	}
+}
+
+func TestService_ConvertToTask_CreatesSubtask(t *testing.T) {
+	repo := &mockRepo{items: []*domain.ChecklistItem{{ID: "a", TaskID: "1", Title: "Step", Done: true}}}
+	s := NewService(repo, &mockTasks{task: &domain.Task{ID: "1", ListID: "l", Priority: "high"}})
+	task, err := s.ConvertToTask("1", "a", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.ParentID != "1" || task.ListID != "l" || task.Priority != "high" || task.Status != "completed" || task.Title != "Step" {
+		t.Errorf("unexpected task: %+v", task)
+	}
+	if repo.converted != task {
+		t.Error("expected task to be stored")
+	}
+}
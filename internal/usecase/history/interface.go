@@ -0,0 +1,18 @@
+// Package history provides the immutable revision history of tasks.
+package history
+
+import "github.com/G20-00/task-management-service-go/internal/domain"
+
+// Repository defines the interface for task revision persistence operations. Revisions are
+// recorded, together with the user who made the change, by the task repositories in the
+// same transaction as each change.
+type Repository interface {
+	GetByRevision(taskID string, revision int) (*domain.TaskRevision, error)
+	GetByTaskID(taskID string) ([]*domain.TaskRevision, error)
+}
+
+// TaskUpdater defines the task operations needed to revert a task to a previous revision.
+type TaskUpdater interface {
+	GetByID(id string) (*domain.Task, error)
+	Update(id, listID, title, description, status, priority, changedBy string) (*domain.Task, error)
+}
@@ -0,0 +1,102 @@
+package history
+
+import (
+	"strconv"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+// Entry is a revision of a task together with the field-level changes it introduced.
+type Entry struct {
+	Revision *domain.TaskRevision
+	Changes  []domain.FieldChange
+}
+
+// Service implements the task history business logic operations.
+type Service struct {
+	repo  Repository
+	tasks TaskUpdater
+}
+
+// NewService creates and returns a new history Service instance.
+func NewService(repo Repository, tasks TaskUpdater) *Service {
+	return &Service{
+		repo:  repo,
+		tasks: tasks,
+	}
+}
+
+// GetHistory retrieves every revision of a task, oldest first, with the changes each one introduced.
+func (s *Service) GetHistory(taskID string) (entries []*Entry, err error) {
+	defer utils.RecoverPanic("service", "GetHistory", &err)
+
+	if _, err := s.tasks.GetByID(taskID); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.repo.GetByTaskID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries = make([]*Entry, len(revisions))
+	var previous *domain.TaskRevision
+	for i, rev := range revisions {
+		entries[i] = &Entry{Revision: rev, Changes: diff(previous, rev)}
+		previous = rev
+	}
+
+	return entries, nil
+}
+
+// Revert restores the list, title, description, status and priority of a task from a
+// previous revision. The restored state is recorded as a new revision, so the history
+// itself is never rewritten. The new revision is attributed to changedBy.
+func (s *Service) Revert(taskID string, revision int, changedBy string) (task *domain.Task, err error) {
+	defer utils.RecoverPanic("service", "Revert", &err)
+
+	if revision <= 0 {
+		return nil, domain.NewInvalidError("revision", "invalid_revision", "invalid revision")
+	}
+
+	target, err := s.repo.GetByRevision(taskID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err = s.tasks.Update(taskID, target.ListID, target.Title, target.Description, target.Status, target.Priority, changedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// diff lists the tracked fields that differ between two revisions. With no previous
+// revision every field is reported as changed from an empty value.
+func diff(previous, current *domain.TaskRevision) []domain.FieldChange {
+	if previous == nil {
+		previous = &domain.TaskRevision{}
+	}
+
+	fields := []domain.FieldChange{
+		{Field: "list_id", Old: previous.ListID, New: current.ListID},
+		{Field: "title", Old: previous.Title, New: current.Title},
+		{Field: "description", Old: previous.Description, New: current.Description},
+		{Field: "status", Old: previous.Status, New: current.Status},
+		{Field: "priority", Old: previous.Priority, New: current.Priority},
+		{Field: "sprint_id", Old: previous.SprintID, New: current.SprintID},
+		{Field: "archived", Old: strconv.FormatBool(previous.Archived), New: strconv.FormatBool(current.Archived)},
+		{Field: "deleted", Old: strconv.FormatBool(previous.Deleted), New: strconv.FormatBool(current.Deleted)},
+	}
+
+	changes := []domain.FieldChange{}
+	for _, f := range fields {
+		if f.Old != f.New {
+			changes = append(changes, f)
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,83 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// mockRepo records a revision on every task update, the way the revision trigger does.
+type mockRepo struct {
+	revisions []*domain.TaskRevision
+}
+
+func (m *mockRepo) record(task *domain.Task, changedBy string) {
+	m.revisions = append(m.revisions, &domain.TaskRevision{
+		TaskID: task.ID, Revision: len(m.revisions) + 1, TaskVersion: task.Version, ChangedBy: changedBy,
+		ListID: task.ListID, Title: task.Title, Description: task.Description, Status: task.Status, Priority: task.Priority,
+	})
+}
+
+func (m *mockRepo) GetByRevision(_ string, revision int) (*domain.TaskRevision, error) {
+	if revision > len(m.revisions) {
+		return nil, domain.ErrRevisionNotFound
+	}
+	return m.revisions[revision-1], nil
+}
+func (m *mockRepo) GetByTaskID(string) ([]*domain.TaskRevision, error) { return m.revisions, nil }
+
+type mockTasks struct {
+	repo *mockRepo
+	task *domain.Task
+}
+
+func (m *mockTasks) GetByID(string) (*domain.Task, error) { return m.task, nil }
+func (m *mockTasks) Update(id, listID, title, description, status, priority, changedBy string) (*domain.Task, error) {
+	m.task = &domain.Task{ID: id, ListID: listID, Title: title, Description: description, Status: status, Priority: priority, Version: m.task.Version + 1}
+	m.repo.record(m.task, changedBy)
+	return m.task, nil
+}
+
+func TestService_GetHistory_Diffs(t *testing.T) {
+	repo := &mockRepo{}
+	repo.record(&domain.Task{ID: "1", Title: "A", Status: "pending", Priority: "low", Version: 1}, "")
+	repo.record(&domain.Task{ID: "1", Title: "B", Status: "pending", Priority: "low", Version: 2}, "")
+	repo.revisions[1].Archived = true
+	s := NewService(repo, &mockTasks{task: &domain.Task{ID: "1"}})
+
+	entries, err := s.GetHistory("1")
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d, err: %v", len(entries), err)
+	}
+	changes := entries[1].Changes
+	if len(changes) != 2 || changes[0].Field != "title" || changes[0].Old != "A" || changes[0].New != "B" ||
+		changes[1].Field != "archived" || changes[1].New != "true" {
+		t.Errorf("unexpected changes: %+v", changes)
+	}
+}
+
+func TestService_Revert_CreatesNewRevision(t *testing.T) {
+	repo := &mockRepo{}
+	repo.record(&domain.Task{ID: "1", Title: "A", Status: "pending", Priority: "low", Version: 1}, "")
+	repo.record(&domain.Task{ID: "1", Title: "B", Status: "completed", Priority: "low", Version: 2}, "")
+	tasks := &mockTasks{repo: repo, task: &domain.Task{ID: "1", Version: 2}}
+	s := NewService(repo, tasks)
+
+	task, err := s.Revert("1", 1, "reverter")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Title != "A" || task.Status != "pending" {
+		t.Errorf("expected task reverted to revision 1, got %+v", task)
+	}
+	if len(repo.revisions) != 3 || repo.revisions[2].ChangedBy != "reverter" {
+		t.Errorf("expected a third revision by reverter, got %d revisions", len(repo.revisions))
+	}
+}
+
+func TestService_Revert_InvalidRevision(t *testing.T) {
+	s := NewService(&mockRepo{}, &mockTasks{})
+	if _, err := s.Revert("1", 0, "u"); err == nil {
+		t.Error("expected error for invalid revision")
+	}
+}
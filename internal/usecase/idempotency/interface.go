@@ -0,0 +1,20 @@
+// Package idempotency lets clients retry non-idempotent requests safely by replaying the
+// response of the first request made with the same Idempotency-Key.
+package idempotency
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// Repository defines the interface for idempotency key persistence operations.
+type Repository interface {
+	// Reserve stores the record unless the user has an unexpired record for the same key,
+	// which is returned instead. A record of the same request still in progress whose lock
+	// has passed is replaced. It returns nil when the key was reserved.
+	Reserve(record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error)
+	Complete(record *domain.IdempotencyRecord) error
+	Release(userID, key string) error
+	PurgeExpiredBefore(before time.Time) (int64, error)
+}
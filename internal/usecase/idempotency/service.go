@@ -0,0 +1,116 @@
+package idempotency
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+// MaxKeyLength is the longest Idempotency-Key accepted.
+const MaxKeyLength = 255
+
+// Service implements the idempotency key business logic operations.
+type Service struct {
+	repo  Repository
+	ttl   time.Duration
+	lease time.Duration
+}
+
+// NewService creates and returns a new idempotency Service instance.
+// Keys can be reused for a different request once ttl has passed since their first use. A
+// retry of a request still in progress after lease is assumed to follow a request that
+// failed without releasing its key, and takes the key over.
+func NewService(repo Repository, ttl, lease time.Duration) *Service {
+	return &Service{
+		repo:  repo,
+		ttl:   ttl,
+		lease: lease,
+	}
+}
+
+// Begin reserves a key for a request identified by fingerprint. It returns nil when the
+// request has to be processed, and the stored record when it already was and its response
+// has to be replayed. Reusing a key for a different request returns
+// domain.ErrIdempotencyKeyReused, and retrying before the first request finished returns
+// domain.ErrIdempotencyKeyInUse.
+func (s *Service) Begin(userID, key, fingerprint string) (replay *domain.IdempotencyRecord, err error) {
+	defer utils.RecoverPanic("service", "Begin", &err)
+
+	if key == "" {
+		return nil, domain.NewInvalidError("Idempotency-Key", "invalid_idempotency_key", "invalid idempotency key: cannot be empty")
+	}
+	if len(key) > MaxKeyLength {
+		return nil, domain.NewInvalidError("Idempotency-Key", "invalid_idempotency_key", fmt.Sprintf("invalid idempotency key: at most %d characters", MaxKeyLength))
+	}
+
+	now := time.Now()
+	existing, err := s.repo.Reserve(&domain.IdempotencyRecord{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+		LockedUntil: now.Add(s.lease),
+	})
+	if err != nil || existing == nil {
+		return nil, err
+	}
+
+	if existing.Fingerprint != fingerprint {
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+	if !existing.Completed() {
+		return nil, domain.ErrIdempotencyKeyInUse
+	}
+
+	return existing, nil
+}
+
+// Complete stores the response of the request a key was reserved for by Begin.
+func (s *Service) Complete(userID, key string, statusCode int, headers map[string]string, body []byte) (err error) {
+	defer utils.RecoverPanic("service", "Complete", &err)
+
+	return s.repo.Complete(&domain.IdempotencyRecord{
+		UserID:     userID,
+		Key:        key,
+		StatusCode: statusCode,
+		Headers:    headers,
+		Body:       body,
+	})
+}
+
+// Release frees a key reserved by Begin whose request failed, so that it can be retried.
+func (s *Service) Release(userID, key string) (err error) {
+	defer utils.RecoverPanic("service", "Release", &err)
+
+	return s.repo.Release(userID, key)
+}
+
+// Purge deletes the keys that expired.
+func (s *Service) Purge() (err error) {
+	defer utils.RecoverPanic("service", "Purge", &err)
+
+	purged, err := s.repo.PurgeExpiredBefore(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if purged > 0 {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"layer":  "service",
+			"method": "Purge",
+			"keys":   purged,
+		}).Info("Purged expired idempotency keys")
+	}
+
+	return nil
+}
+
+// StartPurgeJob runs Purge every interval in a background goroutine.
+// The returned function stops the job.
+func (s *Service) StartPurgeJob(interval time.Duration) func() {
+	return utils.StartJob("idempotency-purge", interval, s.Purge)
+}
@@ -0,0 +1,120 @@
+package idempotency
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockRepo struct {
+	ReserveFn            func(record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error)
+	CompleteFn           func(record *domain.IdempotencyRecord) error
+	ReleaseFn            func(userID, key string) error
+	PurgeExpiredBeforeFn func(before time.Time) (int64, error)
+}
+
+func (m *mockRepo) Reserve(record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+	return m.ReserveFn(record)
+}
+func (m *mockRepo) Complete(record *domain.IdempotencyRecord) error { return m.CompleteFn(record) }
+func (m *mockRepo) Release(userID, key string) error                { return m.ReleaseFn(userID, key) }
+func (m *mockRepo) PurgeExpiredBefore(before time.Time) (int64, error) {
+	return m.PurgeExpiredBeforeFn(before)
+}
+
+func TestService_Begin_NewKey(t *testing.T) {
+	var reserved *domain.IdempotencyRecord
+	s := NewService(&mockRepo{
+		ReserveFn: func(record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+			reserved = record
+			return nil, nil
+		},
+	}, 24*time.Hour, time.Minute)
+
+	replay, err := s.Begin("u1", "k1", "abc")
+	if err != nil || replay != nil {
+		t.Fatalf("expected the request to proceed, got %+v, %v", replay, err)
+	}
+	if reserved.UserID != "u1" || reserved.Key != "k1" || reserved.Fingerprint != "abc" {
+		t.Errorf("unexpected reservation: %+v", reserved)
+	}
+	if ttl := reserved.ExpiresAt.Sub(reserved.CreatedAt); ttl != 24*time.Hour {
+		t.Errorf("expected a 24h TTL, got %v", ttl)
+	}
+	if lease := reserved.LockedUntil.Sub(reserved.CreatedAt); lease != time.Minute {
+		t.Errorf("expected a 1m lease, got %v", lease)
+	}
+}
+
+func TestService_Begin_InvalidKey(t *testing.T) {
+	s := NewService(&mockRepo{}, time.Hour, time.Minute)
+	for _, key := range []string{"", strings.Repeat("k", MaxKeyLength+1)} {
+		if _, err := s.Begin("u1", key, "abc"); err == nil || !strings.HasPrefix(err.Error(), "invalid idempotency key") {
+			t.Errorf("expected invalid key error for %q, got %v", key, err)
+		}
+	}
+}
+
+func TestService_Begin_Replay(t *testing.T) {
+	stored := &domain.IdempotencyRecord{Fingerprint: "abc", StatusCode: 201, Body: []byte(`{"id":"t1"}`)}
+	s := NewService(&mockRepo{
+		ReserveFn: func(*domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) { return stored, nil },
+	}, time.Hour, time.Minute)
+
+	replay, err := s.Begin("u1", "k1", "abc")
+	if err != nil || replay != stored {
+		t.Errorf("expected the stored response, got %+v, %v", replay, err)
+	}
+}
+
+func TestService_Begin_DifferentRequest(t *testing.T) {
+	s := NewService(&mockRepo{
+		ReserveFn: func(*domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+			return &domain.IdempotencyRecord{Fingerprint: "other", StatusCode: 201}, nil
+		},
+	}, time.Hour, time.Minute)
+
+	_, err := s.Begin("u1", "k1", "abc")
+	if err == nil || err.Error() != "idempotency key reused with a different request" {
+		t.Errorf("expected key reuse error, got %v", err)
+	}
+}
+
+func TestService_Begin_InProgress(t *testing.T) {
+	s := NewService(&mockRepo{
+		ReserveFn: func(*domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+			return &domain.IdempotencyRecord{Fingerprint: "abc"}, nil
+		},
+	}, time.Hour, time.Minute)
+
+	_, err := s.Begin("u1", "k1", "abc")
+	if err == nil || err.Error() != "idempotency key in use by a request in progress" {
+		t.Errorf("expected in progress error, got %v", err)
+	}
+}
+
+func TestService_Complete(t *testing.T) {
+	var completed *domain.IdempotencyRecord
+	s := NewService(&mockRepo{
+		CompleteFn: func(record *domain.IdempotencyRecord) error { completed = record; return nil },
+	}, time.Hour, time.Minute)
+
+	if err := s.Complete("u1", "k1", 201, map[string]string{"Content-Type": "application/json"}, []byte("{}")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if completed.StatusCode != 201 || completed.Headers["Content-Type"] != "application/json" || string(completed.Body) != "{}" {
+		t.Errorf("unexpected record: %+v", completed)
+	}
+}
+
+func TestService_Purge_Error(t *testing.T) {
+	s := NewService(&mockRepo{
+		PurgeExpiredBeforeFn: func(time.Time) (int64, error) { return 0, errors.New("db error") },
+	}, time.Hour, time.Minute)
+	if err := s.Purge(); err == nil {
+		t.Error("expected error from Purge")
+	}
+}
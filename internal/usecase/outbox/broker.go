@@ -0,0 +1,43 @@
+package outbox
+
+import (
+	"encoding/json"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// Broker is a message broker that publishes to subjects, such as NATS. A *nats.Conn
+// satisfies it as is.
+type Broker interface {
+	Publish(subject string, data []byte) error
+}
+
+// BrokerSink is a sink that publishes every event as JSON to a broker, on the subject
+// formed by a prefix and the event type, for example "tasks.task.created".
+type BrokerSink struct {
+	broker Broker
+	prefix string
+}
+
+// NewBrokerSink creates and returns a new BrokerSink publishing under the given prefix.
+func NewBrokerSink(broker Broker, prefix string) *BrokerSink {
+	return &BrokerSink{
+		broker: broker,
+		prefix: prefix,
+	}
+}
+
+// Subject returns the subject an event of the given type is published on.
+func (s *BrokerSink) Subject(eventType string) string {
+	return s.prefix + "." + eventType
+}
+
+// Publish sends the event to the broker.
+func (s *BrokerSink) Publish(event *domain.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return s.broker.Publish(s.Subject(event.Type), data)
+}
@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"sync"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// Bus is a sink that hands every event to the handlers subscribed in the same process.
+// Handlers run synchronously in the relay goroutine and should return quickly.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[int]func(event *domain.Event)
+	nextID   int
+}
+
+// NewBus creates and returns a new Bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{
+		handlers: map[int]func(event *domain.Event){},
+	}
+}
+
+// Subscribe registers a handler for every event published from now on. The returned
+// function removes it.
+func (b *Bus) Subscribe(handler func(event *domain.Event)) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+	}
+}
+
+// Publish calls every subscribed handler with the event.
+func (b *Bus) Publish(event *domain.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, handler := range b.handlers {
+		handler(event)
+	}
+
+	return nil
+}
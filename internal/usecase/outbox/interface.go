@@ -0,0 +1,29 @@
+// Package outbox publishes the events recorded together with task and task list changes.
+// Events are stored in the same transaction as the change, so none is lost if the process
+// stops, and a relay hands them to the sinks at least once and in order per aggregate.
+package outbox
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// Repository defines the interface for outbox persistence operations.
+type Repository interface {
+	// ClaimPending retrieves up to limit unpublished events available at now, oldest first,
+	// and hides them until leaseUntil. Only the oldest unpublished event of each aggregate
+	// is returned.
+	ClaimPending(now, leaseUntil time.Time, limit int) ([]*domain.OutboxEntry, error)
+	MarkPublished(sequence int64, publishedAt time.Time) error
+	// MarkFailed records a failed attempt and keeps the event, and the later events of its
+	// aggregate, waiting until nextAttemptAt.
+	MarkFailed(sequence int64, lastError string, nextAttemptAt time.Time) error
+	PurgePublishedBefore(cutoff time.Time) (int64, error)
+}
+
+// Sink receives the published events. Publish may be called more than once with the same
+// event, so sinks should ignore events whose ID they already handled.
+type Sink interface {
+	Publish(event *domain.Event) error
+}
@@ -0,0 +1,142 @@
+package outbox
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+const (
+	// relayBatchSize is how many events are claimed at a time.
+	relayBatchSize = 100
+	// maxBatchesPerRun bounds the work of a single Relay call while events keep arriving.
+	maxBatchesPerRun = 20
+	// relayLease is how long claimed events are hidden from other relays.
+	relayLease = time.Minute
+	// firstRetryDelay is the wait after the first failed attempt to publish an event; it
+	// doubles after each following one up to maxRetryDelay.
+	firstRetryDelay = time.Second
+	maxRetryDelay   = 5 * time.Minute
+)
+
+type namedSink struct {
+	name string
+	sink Sink
+}
+
+// Service implements the outbox relay operations.
+type Service struct {
+	repo      Repository
+	sinks     []namedSink
+	retention time.Duration
+}
+
+// NewService creates and returns a new outbox Service instance.
+// Published events are kept for retention before they are purged.
+func NewService(repo Repository, retention time.Duration) *Service {
+	return &Service{
+		repo:      repo,
+		retention: retention,
+	}
+}
+
+// AddSink registers a sink that receives every published event. Sinks are called in the
+// order they were added. It must be called before the relay starts.
+func (s *Service) AddSink(name string, sink Sink) {
+	s.sinks = append(s.sinks, namedSink{name: name, sink: sink})
+}
+
+// retryDelay is the wait before the attempt that follows the given failed attempt.
+func retryDelay(attempt int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// Relay publishes the pending events to every sink and returns how many were published.
+// An event that a sink rejects is retried later with backoff, and the later events of the
+// same aggregate wait for it.
+func (s *Service) Relay() (published int, err error) {
+	defer utils.RecoverPanic("service", "Relay", &err)
+
+	for batch := 0; batch < maxBatchesPerRun; batch++ {
+		now := time.Now()
+		entries, err := s.repo.ClaimPending(now, now.Add(relayLease), relayBatchSize)
+		if err != nil {
+			return published, err
+		}
+		if len(entries) == 0 {
+			break
+		}
+
+		for _, entry := range entries {
+			if err := s.publish(entry); err != nil {
+				return published, err
+			}
+			if entry.LastError == "" {
+				published++
+			}
+		}
+	}
+
+	return published, nil
+}
+
+// publish hands an event to every sink and records the outcome. A sink error is kept as
+// the LastError of the entry.
+func (s *Service) publish(entry *domain.OutboxEntry) error {
+	entry.LastError = ""
+	for _, named := range s.sinks {
+		if err := named.sink.Publish(&entry.Event); err != nil {
+			entry.LastError = fmt.Sprintf("%s: %s", named.name, err.Error())
+			break
+		}
+	}
+
+	now := time.Now()
+	if entry.LastError == "" {
+		return s.repo.MarkPublished(entry.Event.Sequence, now)
+	}
+
+	entry.Attempts++
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"layer":    "service",
+		"method":   "publish",
+		"eventID":  entry.Event.ID,
+		"attempts": entry.Attempts,
+		"error":    entry.LastError,
+	}).Warn("Failed to publish outbox event")
+
+	return s.repo.MarkFailed(entry.Event.Sequence, entry.LastError, now.Add(retryDelay(entry.Attempts)))
+}
+
+// Purge deletes the events published longer than the retention period ago.
+func (s *Service) Purge() (err error) {
+	defer utils.RecoverPanic("service", "Purge", &err)
+
+	_, err = s.repo.PurgePublishedBefore(time.Now().Add(-s.retention))
+	return err
+}
+
+// StartRelay runs Relay every interval in a background goroutine.
+// The returned function stops the relay.
+func (s *Service) StartRelay(interval time.Duration) func() {
+	return utils.StartJob("outbox-relay", interval, func() error {
+		_, err := s.Relay()
+		return err
+	})
+}
+
+// StartPurgeJob runs Purge every interval in a background goroutine.
+// The returned function stops the job.
+func (s *Service) StartPurgeJob(interval time.Duration) func() {
+	return utils.StartJob("outbox-purge", interval, s.Purge)
+}
@@ -0,0 +1,200 @@
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockRepo struct {
+	ClaimPendingFn         func(now, leaseUntil time.Time, limit int) ([]*domain.OutboxEntry, error)
+	MarkPublishedFn        func(sequence int64, publishedAt time.Time) error
+	MarkFailedFn           func(sequence int64, lastError string, nextAttemptAt time.Time) error
+	PurgePublishedBeforeFn func(cutoff time.Time) (int64, error)
+}
+
+func (m *mockRepo) ClaimPending(now, leaseUntil time.Time, limit int) ([]*domain.OutboxEntry, error) {
+	return m.ClaimPendingFn(now, leaseUntil, limit)
+}
+func (m *mockRepo) MarkPublished(sequence int64, publishedAt time.Time) error {
+	return m.MarkPublishedFn(sequence, publishedAt)
+}
+func (m *mockRepo) MarkFailed(sequence int64, lastError string, nextAttemptAt time.Time) error {
+	return m.MarkFailedFn(sequence, lastError, nextAttemptAt)
+}
+func (m *mockRepo) PurgePublishedBefore(cutoff time.Time) (int64, error) {
+	return m.PurgePublishedBeforeFn(cutoff)
+}
+
+// recordingSink records the IDs of the events it receives and fails for the given ones.
+type recordingSink struct {
+	events []string
+	fail   map[string]bool
+}
+
+func (s *recordingSink) Publish(event *domain.Event) error {
+	if s.fail[event.ID] {
+		return errors.New("unavailable")
+	}
+	s.events = append(s.events, event.ID)
+	return nil
+}
+
+// claimOnce returns a ClaimPending that hands out the entries on the first call only.
+func claimOnce(entries ...*domain.OutboxEntry) func(time.Time, time.Time, int) ([]*domain.OutboxEntry, error) {
+	claimed := false
+	return func(now, leaseUntil time.Time, limit int) ([]*domain.OutboxEntry, error) {
+		if claimed || !leaseUntil.After(now) || limit <= 0 {
+			return nil, nil
+		}
+		claimed = true
+		return entries, nil
+	}
+}
+
+func entry(sequence int64, id string) *domain.OutboxEntry {
+	return &domain.OutboxEntry{Event: domain.Event{ID: id, Sequence: sequence, Type: domain.EventTaskUpdated}, AggregateType: domain.AggregateTask}
+}
+
+func TestService_Relay_PublishesToEverySink(t *testing.T) {
+	var published []int64
+	s := NewService(&mockRepo{
+		ClaimPendingFn:  claimOnce(entry(1, "e1"), entry(2, "e2")),
+		MarkPublishedFn: func(sequence int64, _ time.Time) error { published = append(published, sequence); return nil },
+	}, time.Hour)
+	first, second := &recordingSink{}, &recordingSink{}
+	s.AddSink("first", first)
+	s.AddSink("second", second)
+
+	count, err := s.Relay()
+	if err != nil || count != 2 {
+		t.Fatalf("expected 2 published events, got %d, %v", count, err)
+	}
+	for _, sink := range []*recordingSink{first, second} {
+		if strings.Join(sink.events, ",") != "e1,e2" {
+			t.Errorf("expected the events in order, got %v", sink.events)
+		}
+	}
+	if len(published) != 2 || published[0] != 1 || published[1] != 2 {
+		t.Errorf("expected both events marked as published, got %v", published)
+	}
+}
+
+func TestService_Relay_RetriesFailedEvents(t *testing.T) {
+	failed := entry(1, "e1")
+	failed.Attempts = 2
+	var lastError string
+	var wait time.Duration
+	s := NewService(&mockRepo{
+		ClaimPendingFn:  claimOnce(failed, entry(2, "e2")),
+		MarkPublishedFn: func(int64, time.Time) error { return nil },
+		MarkFailedFn: func(sequence int64, message string, nextAttemptAt time.Time) error {
+			if sequence != 1 {
+				t.Errorf("unexpected failed event %d", sequence)
+			}
+			lastError, wait = message, time.Until(nextAttemptAt)
+			return nil
+		},
+	}, time.Hour)
+	s.AddSink("bus", &recordingSink{})
+	s.AddSink("broker", &recordingSink{fail: map[string]bool{"e1": true}})
+
+	count, err := s.Relay()
+	if err != nil || count != 1 {
+		t.Fatalf("expected 1 published event, got %d, %v", count, err)
+	}
+	if lastError != "broker: unavailable" {
+		t.Errorf("expected the error of the failing sink, got %q", lastError)
+	}
+	if wait < 3*time.Second || wait > 4*time.Second {
+		t.Errorf("expected the third retry 4 seconds later, got %v", wait)
+	}
+}
+
+func TestService_Relay_ClaimError(t *testing.T) {
+	s := NewService(&mockRepo{
+		ClaimPendingFn: func(time.Time, time.Time, int) ([]*domain.OutboxEntry, error) { return nil, errors.New("db error") },
+	}, time.Hour)
+
+	if _, err := s.Relay(); err == nil || err.Error() != "db error" {
+		t.Errorf("expected db error, got %v", err)
+	}
+}
+
+func TestService_Purge(t *testing.T) {
+	var cutoff time.Time
+	s := NewService(&mockRepo{
+		PurgePublishedBeforeFn: func(c time.Time) (int64, error) { cutoff = c; return 3, nil },
+	}, 24*time.Hour)
+
+	if err := s.Purge(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if age := time.Since(cutoff); age < 24*time.Hour || age > 24*time.Hour+time.Minute {
+		t.Errorf("expected a cutoff 24 hours ago, got %v", cutoff)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  time.Second,
+		3:  4 * time.Second,
+		20: 5 * time.Minute,
+	}
+	for attempt, expected := range cases {
+		if delay := retryDelay(attempt); delay != expected {
+			t.Errorf("attempt %d: expected %v, got %v", attempt, expected, delay)
+		}
+	}
+}
+
+func TestBus_SubscribeAndUnsubscribe(t *testing.T) {
+	bus := NewBus()
+	var received []string
+	unsubscribe := bus.Subscribe(func(event *domain.Event) { received = append(received, event.ID) })
+
+	if err := bus.Publish(&domain.Event{ID: "e1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unsubscribe()
+	if err := bus.Publish(&domain.Event{ID: "e2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(received) != 1 || received[0] != "e1" {
+		t.Errorf("expected only the first event, got %v", received)
+	}
+}
+
+type recordingBroker struct {
+	subject string
+	data    []byte
+}
+
+func (b *recordingBroker) Publish(subject string, data []byte) error {
+	b.subject, b.data = subject, data
+	return nil
+}
+
+func TestBrokerSink_Publish(t *testing.T) {
+	broker := &recordingBroker{}
+	sink := NewBrokerSink(broker, "tasks")
+
+	if err := sink.Publish(&domain.Event{ID: "e1", Sequence: 4, Type: domain.EventListCreated, ListID: "l1", Data: json.RawMessage(`{}`)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if broker.subject != "tasks.list.created" {
+		t.Errorf("unexpected subject %q", broker.subject)
+	}
+	var event domain.Event
+	if err := json.Unmarshal(broker.data, &event); err != nil {
+		t.Fatalf("invalid message: %v", err)
+	}
+	if event.ID != "e1" || event.Sequence != 4 || event.ListID != "l1" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
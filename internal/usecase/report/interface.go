@@ -0,0 +1,29 @@
+// Package report provides burndown, burnup and cumulative flow series built from daily task status snapshots.
+package report
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// Repository defines the interface for status snapshot operations.
+type Repository interface {
+	// SnapshotStatuses records the current status of every live task for the given day,
+	// replacing any snapshot already taken that day. It returns how many tasks were recorded.
+	SnapshotStatuses(day time.Time) (int64, error)
+	// ListFlow counts the tasks of a list per status for every day in [from, to].
+	ListFlow(listID string, from, to time.Time) ([]*domain.FlowPoint, error)
+	// SprintFlow counts the tasks of a sprint per status for every day in [from, to].
+	SprintFlow(sprintID string, from, to time.Time) ([]*domain.FlowPoint, error)
+}
+
+// ListReader defines the task list operations needed to build list reports.
+type ListReader interface {
+	GetByID(id string) (*domain.TaskList, error)
+}
+
+// SprintReader defines the sprint operations needed to build sprint reports.
+type SprintReader interface {
+	GetByID(id string) (*domain.Sprint, error)
+}
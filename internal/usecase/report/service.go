@@ -0,0 +1,166 @@
+package report
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+const (
+	// defaultRangeDays is the range reported for a list when no dates are given.
+	defaultRangeDays = 14
+	// maxRangeDays bounds the number of points of a single series.
+	maxRangeDays = 366
+)
+
+// BurndownPoint is the remaining work on a day together with the ideal straight line to zero.
+type BurndownPoint struct {
+	Date      time.Time
+	Remaining int
+	Ideal     float64
+}
+
+// BurnupPoint is the completed work on a day against the total scope.
+type BurnupPoint struct {
+	Date      time.Time
+	Completed int
+	Scope     int
+}
+
+// Service implements the report business logic operations.
+type Service struct {
+	repo    Repository
+	lists   ListReader
+	sprints SprintReader
+}
+
+// NewService creates and returns a new report Service instance.
+func NewService(repo Repository, lists ListReader, sprints SprintReader) *Service {
+	return &Service{
+		repo:    repo,
+		lists:   lists,
+		sprints: sprints,
+	}
+}
+
+// ListFlow returns the daily status counts of a list. Without dates the last 14 days are reported.
+func (s *Service) ListFlow(listID string, from, to *time.Time) (points []*domain.FlowPoint, err error) {
+	defer utils.RecoverPanic("service", "ListFlow", &err)
+
+	if _, err := s.lists.GetByID(listID); err != nil {
+		return nil, err
+	}
+
+	today := truncateDay(time.Now())
+	start, end, err := dateRange(from, to, today.AddDate(0, 0, -(defaultRangeDays-1)), today)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListFlow(listID, start, end)
+}
+
+// SprintFlow returns the daily status counts of a sprint. Without dates the sprint's own
+// date range is reported, or the last 14 days when the sprint has no start date.
+func (s *Service) SprintFlow(sprintID string, from, to *time.Time) (points []*domain.FlowPoint, err error) {
+	defer utils.RecoverPanic("service", "SprintFlow", &err)
+
+	sprint, err := s.sprints.GetByID(sprintID)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultEnd := truncateDay(time.Now())
+	if sprint.EndsAt != nil {
+		defaultEnd = truncateDay(*sprint.EndsAt)
+	}
+	defaultStart := defaultEnd.AddDate(0, 0, -(defaultRangeDays - 1))
+	if sprint.StartsAt != nil {
+		defaultStart = truncateDay(*sprint.StartsAt)
+	}
+
+	start, end, err := dateRange(from, to, defaultStart, defaultEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.SprintFlow(sprintID, start, end)
+}
+
+// Snapshot records the current status of every task for today.
+func (s *Service) Snapshot() (err error) {
+	defer utils.RecoverPanic("service", "Snapshot", &err)
+
+	_, err = s.repo.SnapshotStatuses(truncateDay(time.Now()))
+	return err
+}
+
+// StartSnapshotJob takes a snapshot right away and then every interval in a background
+// goroutine, so that the snapshot of each day reflects the last run of that day.
+// The returned function stops the job.
+func (s *Service) StartSnapshotJob(interval time.Duration) func() {
+	go utils.RunJob("status-snapshot", s.Snapshot)
+	return utils.StartJob("status-snapshot", interval, s.Snapshot)
+}
+
+// Burndown derives the remaining work per day and an ideal line going from the
+// remaining work on the first day down to zero on the last.
+func Burndown(points []*domain.FlowPoint) []BurndownPoint {
+	result := make([]BurndownPoint, len(points))
+	if len(points) == 0 {
+		return result
+	}
+
+	start := float64(points[0].Total - points[0].Completed)
+	steps := float64(len(points) - 1)
+	for i, p := range points {
+		ideal := 0.0
+		if steps > 0 {
+			ideal = start - start*float64(i)/steps
+		}
+		result[i] = BurndownPoint{Date: p.Date, Remaining: p.Total - p.Completed, Ideal: ideal}
+	}
+
+	return result
+}
+
+// Burnup derives the completed work and the total scope per day.
+func Burnup(points []*domain.FlowPoint) []BurnupPoint {
+	result := make([]BurnupPoint, len(points))
+	for i, p := range points {
+		result[i] = BurnupPoint{Date: p.Date, Completed: p.Completed, Scope: p.Total}
+	}
+
+	return result
+}
+
+// dateRange resolves the requested range against the defaults and validates it.
+func dateRange(from, to *time.Time, defaultFrom, defaultTo time.Time) (start, end time.Time, err error) {
+	start, end = defaultFrom, defaultTo
+	if from != nil {
+		start = truncateDay(*from)
+	}
+	if to != nil {
+		end = truncateDay(*to)
+	}
+	if from != nil && to == nil && end.Before(start) {
+		end = start.AddDate(0, 0, defaultRangeDays-1)
+	}
+	if to != nil && from == nil && end.Before(start) {
+		start = end.AddDate(0, 0, -(defaultRangeDays - 1))
+	}
+
+	if end.Before(start) {
+		return start, end, domain.NewInvalidError("from", "invalid_date_range", "from must not be after to")
+	}
+	if end.Sub(start) > maxRangeDays*24*time.Hour {
+		return start, end, domain.NewInvalidError("to", "invalid_date_range", "date range cannot exceed 366 days")
+	}
+
+	return start, end, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,94 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockRepo struct {
+	from, to time.Time
+}
+
+func (m *mockRepo) SnapshotStatuses(time.Time) (int64, error) { return 0, nil }
+func (m *mockRepo) ListFlow(_ string, from, to time.Time) ([]*domain.FlowPoint, error) {
+	m.from, m.to = from, to
+	return nil, nil
+}
+func (m *mockRepo) SprintFlow(_ string, from, to time.Time) ([]*domain.FlowPoint, error) {
+	m.from, m.to = from, to
+	return nil, nil
+}
+
+type mockLists struct{}
+
+func (mockLists) GetByID(id string) (*domain.TaskList, error) {
+	if id == "missing" {
+		return nil, domain.ErrTaskListNotFound
+	}
+	return &domain.TaskList{ID: id}, nil
+}
+
+type mockSprints struct{ sprint *domain.Sprint }
+
+func (m mockSprints) GetByID(string) (*domain.Sprint, error) { return m.sprint, nil }
+
+func TestService_ListFlow_DefaultsToLastTwoWeeks(t *testing.T) {
+	repo := &mockRepo{}
+	s := NewService(repo, mockLists{}, mockSprints{})
+	if _, err := s.ListFlow("l", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if days := repo.to.Sub(repo.from).Hours() / 24; days != 13 {
+		t.Errorf("expected a 14 day range, got %v days", days+1)
+	}
+	if _, err := s.ListFlow("missing", nil, nil); err == nil {
+		t.Error("expected error for missing list")
+	}
+}
+
+func TestService_ListFlow_InvalidRange(t *testing.T) {
+	s := NewService(&mockRepo{}, mockLists{}, mockSprints{})
+	from := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, -1)
+	if _, err := s.ListFlow("l", &from, &to); err == nil || err.Error() != "from must not be after to" {
+		t.Errorf("expected range error, got %v", err)
+	}
+	to = from.AddDate(2, 0, 0)
+	if _, err := s.ListFlow("l", &from, &to); err == nil {
+		t.Error("expected error for a range longer than a year")
+	}
+}
+
+func TestService_SprintFlow_UsesSprintDates(t *testing.T) {
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 5, 14, 18, 0, 0, 0, time.UTC)
+	repo := &mockRepo{}
+	s := NewService(repo, mockLists{}, mockSprints{sprint: &domain.Sprint{ID: "s", StartsAt: &start, EndsAt: &end}})
+	if _, err := s.SprintFlow("s", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.from.Day() != 1 || repo.to.Day() != 14 || repo.from.Hour() != 0 {
+		t.Errorf("unexpected range %v - %v", repo.from, repo.to)
+	}
+}
+
+func TestBurndown_IdealLine(t *testing.T) {
+	points := []*domain.FlowPoint{
+		{Total: 10, Completed: 0},
+		{Total: 10, Completed: 4},
+		{Total: 12, Completed: 6},
+	}
+	series := Burndown(points)
+	if series[0].Ideal != 10 || series[1].Ideal != 5 || series[2].Ideal != 0 {
+		t.Errorf("unexpected ideal line: %+v", series)
+	}
+	if series[2].Remaining != 6 {
+		t.Errorf("expected 6 remaining, got %d", series[2].Remaining)
+	}
+	burnup := Burnup(points)
+	if burnup[2].Scope != 12 || burnup[2].Completed != 6 {
+		t.Errorf("unexpected burnup: %+v", burnup[2])
+	}
+}
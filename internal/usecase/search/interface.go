@@ -0,0 +1,13 @@
+// Package search provides full-text search over tasks.
+package search
+
+import "github.com/G20-00/task-management-service-go/internal/domain"
+
+// Repository defines the interface for full-text search operations.
+type Repository interface {
+	// Search returns the tasks matching the query and the filter, best matches first.
+	Search(query string, filter domain.TaskFilter, limit, offset int) ([]*domain.SearchResult, error)
+	// SetLanguage changes the text search configuration used to index and query tasks,
+	// reindexing every task when it differs from the current one.
+	SetLanguage(language string) error
+}
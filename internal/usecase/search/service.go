@@ -0,0 +1,79 @@
+package search
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+var validStatuses = map[string]bool{
+	"pending":     true,
+	"in-progress": true,
+	"completed":   true,
+}
+
+var validPriorities = map[string]bool{
+	"low":    true,
+	"medium": true,
+	"high":   true,
+}
+
+// Service implements the full-text search business logic.
+type Service struct {
+	repo Repository
+}
+
+// NewService creates and returns a new search Service instance.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Search finds the tasks whose title or description match the query, combined with the
+// status, priority and archive filters. The query accepts quoted phrases, "or" and a
+// leading "-" to exclude a word.
+func (s *Service) Search(query string, filter domain.TaskFilter, limit, offset int) (results []*domain.SearchResult, err error) {
+	defer utils.RecoverPanic("service", "Search", &err)
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, domain.NewInvalidError("q", "invalid_query", "search query cannot be empty")
+	}
+
+	if filter.Status != "" && !validStatuses[filter.Status] {
+		return nil, domain.NewInvalidError("status", "invalid_status", "invalid status")
+	}
+
+	if filter.Priority != "" && !validPriorities[filter.Priority] {
+		return nil, domain.NewInvalidError("priority", "invalid_priority", "invalid priority")
+	}
+
+	if offset < 0 {
+		return nil, domain.NewInvalidError("offset", "invalid_offset", "offset cannot be negative")
+	}
+
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	return s.repo.Search(query, filter, limit, offset)
+}
+
+// ConfigureLanguage sets the text search configuration (e.g. spanish, english, simple).
+func (s *Service) ConfigureLanguage(language string) error {
+	language = strings.TrimSpace(language)
+	if language == "" {
+		return errors.New("search language cannot be empty")
+	}
+
+	return s.repo.SetLanguage(language)
+}
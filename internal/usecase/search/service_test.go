@@ -0,0 +1,76 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockRepo struct {
+	query    string
+	filter   domain.TaskFilter
+	limit    int
+	offset   int
+	language string
+}
+
+func (m *mockRepo) Search(query string, filter domain.TaskFilter, limit, offset int) ([]*domain.SearchResult, error) {
+	m.query, m.filter, m.limit, m.offset = query, filter, limit, offset
+	return []*domain.SearchResult{{Task: &domain.Task{ID: "1"}, Rank: 0.5}}, nil
+}
+
+func (m *mockRepo) SetLanguage(language string) error {
+	m.language = language
+	return nil
+}
+
+func TestService_Search_EmptyQuery(t *testing.T) {
+	s := NewService(&mockRepo{})
+	_, err := s.Search("   ", domain.TaskFilter{}, 0, 0)
+	if err == nil || err.Error() != "search query cannot be empty" {
+		t.Errorf("expected empty query error, got %v", err)
+	}
+}
+
+func TestService_Search_InvalidFilters(t *testing.T) {
+	s := NewService(&mockRepo{})
+	if _, err := s.Search("deploy", domain.TaskFilter{Status: "done"}, 0, 0); err == nil || err.Error() != "invalid status" {
+		t.Errorf("expected invalid status error, got %v", err)
+	}
+	if _, err := s.Search("deploy", domain.TaskFilter{Priority: "urgent"}, 0, 0); err == nil || err.Error() != "invalid priority" {
+		t.Errorf("expected invalid priority error, got %v", err)
+	}
+	if _, err := s.Search("deploy", domain.TaskFilter{}, 0, -1); err == nil || err.Error() != "offset cannot be negative" {
+		t.Errorf("expected negative offset error, got %v", err)
+	}
+}
+
+func TestService_Search_NormalizesLimit(t *testing.T) {
+	repo := &mockRepo{}
+	s := NewService(repo)
+	results, err := s.Search("  canción ", domain.TaskFilter{Status: "pending"}, 500, 10)
+	if err != nil || len(results) != 1 {
+		t.Fatalf("expected one result, got %v, err: %v", results, err)
+	}
+	if repo.query != "canción" || repo.limit != maxLimit || repo.offset != 10 || repo.filter.Status != "pending" {
+		t.Errorf("unexpected repository call: %+v", repo)
+	}
+
+	if _, err := s.Search("x", domain.TaskFilter{}, 0, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.limit != defaultLimit {
+		t.Errorf("expected default limit %d, got %d", defaultLimit, repo.limit)
+	}
+}
+
+func TestService_ConfigureLanguage(t *testing.T) {
+	repo := &mockRepo{}
+	s := NewService(repo)
+	if err := s.ConfigureLanguage(""); err == nil {
+		t.Error("expected error for empty language")
+	}
+	if err := s.ConfigureLanguage("english"); err != nil || repo.language != "english" {
+		t.Errorf("expected english to be stored, got %q, err: %v", repo.language, err)
+	}
+}
@@ -0,0 +1,28 @@
+// Package sprint provides sprints and milestones that group tasks across lists.
+package sprint
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// Repository defines the interface for sprint persistence operations.
+type Repository interface {
+	Create(sprint *domain.Sprint) error
+	GetAll() ([]*domain.Sprint, error)
+	GetByID(id string) (*domain.Sprint, error)
+	Update(sprint *domain.Sprint) error
+	// Delete removes a sprint and unassigns its tasks.
+	Delete(id, changedBy string) error
+	// AssignTasks moves the given tasks into the sprint and returns how many were assigned.
+	AssignTasks(sprintID string, taskIDs []string, changedBy string) (int64, error)
+	UnassignTask(sprintID, taskID, changedBy string) error
+	GetTasks(sprintID string) ([]*domain.Task, error)
+	// Start marks a planned sprint as active and records its current tasks as committed.
+	Start(id string, at time.Time) error
+	// Close marks an active sprint as closed and moves its unfinished tasks to nextID,
+	// or unassigns them when nextID is empty. It returns how many tasks were rolled over.
+	Close(id, nextID string, at time.Time, changedBy string) (int64, error)
+	Summary(id string) (*domain.SprintSummary, error)
+}
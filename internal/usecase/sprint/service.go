@@ -0,0 +1,246 @@
+package sprint
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+var validKinds = map[string]bool{
+	"sprint":    true,
+	"milestone": true,
+}
+
+// Service implements the sprint business logic operations.
+type Service struct {
+	repo Repository
+}
+
+// NewService creates and returns a new sprint Service instance.
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// Create creates a planned sprint or milestone. Sprints need both dates; milestones only an end date.
+func (s *Service) Create(name, goal, kind string, startsAt, endsAt *time.Time) (sprint *domain.Sprint, err error) {
+	defer utils.RecoverPanic("service", "Create", &err)
+
+	if kind == "" {
+		kind = "sprint"
+	}
+
+	now := time.Now()
+	sprint = &domain.Sprint{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Goal:      goal,
+		Kind:      kind,
+		Status:    "planned",
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := validate(sprint); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(sprint); err != nil {
+		return nil, err
+	}
+
+	return sprint, nil
+}
+
+// GetAll retrieves all sprints.
+func (s *Service) GetAll() (sprints []*domain.Sprint, err error) {
+	defer utils.RecoverPanic("service", "GetAll", &err)
+
+	return s.repo.GetAll()
+}
+
+// GetByID retrieves a sprint by its ID.
+func (s *Service) GetByID(id string) (sprint *domain.Sprint, err error) {
+	defer utils.RecoverPanic("service", "GetByID", &err)
+
+	return s.repo.GetByID(id)
+}
+
+// Update changes the name, goal, kind and dates of a sprint that is not closed.
+func (s *Service) Update(id, name, goal, kind string, startsAt, endsAt *time.Time) (sprint *domain.Sprint, err error) {
+	defer utils.RecoverPanic("service", "Update", &err)
+
+	sprint, err = s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.Status == "closed" {
+		return nil, domain.ErrSprintClosed
+	}
+
+	if kind == "" {
+		kind = sprint.Kind
+	}
+	sprint.Name = name
+	sprint.Goal = goal
+	sprint.Kind = kind
+	sprint.StartsAt = startsAt
+	sprint.EndsAt = endsAt
+	sprint.UpdatedAt = time.Now()
+
+	if err := validate(sprint); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(sprint); err != nil {
+		return nil, err
+	}
+
+	return sprint, nil
+}
+
+// Delete removes a sprint. Its tasks are kept but no longer belong to any sprint.
+func (s *Service) Delete(id, changedBy string) (err error) {
+	defer utils.RecoverPanic("service", "Delete", &err)
+
+	return s.repo.Delete(id, changedBy)
+}
+
+// AssignTasks adds tasks to a sprint that is not closed and returns how many were assigned.
+func (s *Service) AssignTasks(sprintID string, taskIDs []string, changedBy string) (count int64, err error) {
+	defer utils.RecoverPanic("service", "AssignTasks", &err)
+
+	if len(taskIDs) == 0 {
+		return 0, domain.NewInvalidError("task_ids", "invalid_task_ids", "task ids cannot be empty")
+	}
+
+	if err := s.ensureOpen(sprintID); err != nil {
+		return 0, err
+	}
+
+	return s.repo.AssignTasks(sprintID, taskIDs, changedBy)
+}
+
+// UnassignTask removes a task from a sprint that is not closed.
+func (s *Service) UnassignTask(sprintID, taskID, changedBy string) (err error) {
+	defer utils.RecoverPanic("service", "UnassignTask", &err)
+
+	if err := s.ensureOpen(sprintID); err != nil {
+		return err
+	}
+
+	return s.repo.UnassignTask(sprintID, taskID, changedBy)
+}
+
+// GetTasks retrieves the tasks of a sprint.
+func (s *Service) GetTasks(sprintID string) (tasks []*domain.Task, err error) {
+	defer utils.RecoverPanic("service", "GetTasks", &err)
+
+	if _, err := s.repo.GetByID(sprintID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTasks(sprintID)
+}
+
+// Start activates a planned sprint, committing to the tasks it currently holds.
+func (s *Service) Start(id string) (sprint *domain.Sprint, err error) {
+	defer utils.RecoverPanic("service", "Start", &err)
+
+	sprint, err = s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.Status != "planned" {
+		return nil, domain.ErrSprintNotPlanned
+	}
+
+	if err := s.repo.Start(id, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(id)
+}
+
+// Close closes an active sprint, rolling its unfinished tasks into nextID. With an
+// empty nextID unfinished tasks go back to the backlog.
+func (s *Service) Close(id, nextID, changedBy string) (sprint *domain.Sprint, err error) {
+	defer utils.RecoverPanic("service", "Close", &err)
+
+	sprint, err = s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if sprint.Status != "active" {
+		return nil, domain.ErrSprintNotActive
+	}
+
+	if nextID != "" {
+		if nextID == id {
+			return nil, domain.NewInvalidError("next_sprint_id", "invalid_next_sprint_id", "next sprint must be a different sprint")
+		}
+		next, err := s.repo.GetByID(nextID)
+		if errors.Is(err, domain.ErrSprintNotFound) {
+			return nil, domain.ErrNextSprintNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if next.Status == "closed" {
+			return nil, domain.ErrSprintClosed
+		}
+	}
+
+	if _, err := s.repo.Close(id, nextID, time.Now(), changedBy); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(id)
+}
+
+// Summary compares committed and completed work of a sprint.
+func (s *Service) Summary(id string) (summary *domain.SprintSummary, err error) {
+	defer utils.RecoverPanic("service", "Summary", &err)
+
+	return s.repo.Summary(id)
+}
+
+func (s *Service) ensureOpen(sprintID string) error {
+	sprint, err := s.repo.GetByID(sprintID)
+	if err != nil {
+		return err
+	}
+	if sprint.Status == "closed" {
+		return domain.ErrSprintClosed
+	}
+
+	return nil
+}
+
+func validate(sprint *domain.Sprint) error {
+	if strings.TrimSpace(sprint.Name) == "" {
+		return domain.NewInvalidError("name", "invalid_name", "name cannot be empty")
+	}
+	if !validKinds[sprint.Kind] {
+		return domain.NewInvalidError("kind", "invalid_kind", "invalid kind: must be sprint or milestone")
+	}
+	if sprint.EndsAt == nil {
+		return domain.NewInvalidError("ends_at", "invalid_ends_at", "end date is required")
+	}
+	if sprint.Kind == "sprint" && sprint.StartsAt == nil {
+		return domain.NewInvalidError("starts_at", "invalid_starts_at", "start date is required for a sprint")
+	}
+	if sprint.StartsAt != nil && sprint.EndsAt.Before(*sprint.StartsAt) {
+		return domain.NewInvalidError("ends_at", "invalid_ends_at", "end date must not be before start date")
+	}
+
+	return nil
+}
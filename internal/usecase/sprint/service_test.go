@@ -0,0 +1,104 @@
+package sprint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockRepo struct {
+	sprints  map[string]*domain.Sprint
+	closedTo string
+}
+
+func (m *mockRepo) Create(s *domain.Sprint) error {
+	m.sprints[s.ID] = s
+	return nil
+}
+func (m *mockRepo) GetAll() ([]*domain.Sprint, error) { return nil, nil }
+func (m *mockRepo) GetByID(id string) (*domain.Sprint, error) {
+	s, ok := m.sprints[id]
+	if !ok {
+		return nil, domain.ErrSprintNotFound
+	}
+	return s, nil
+}
+func (m *mockRepo) Update(*domain.Sprint) error { return nil }
+func (m *mockRepo) Delete(string, string) error { return nil }
+func (m *mockRepo) AssignTasks(_ string, taskIDs []string, _ string) (int64, error) {
+	return int64(len(taskIDs)), nil
+}
+func (m *mockRepo) UnassignTask(string, string, string) error     { return nil }
+func (m *mockRepo) GetTasks(string) ([]*domain.Task, error)       { return nil, nil }
+func (m *mockRepo) Summary(string) (*domain.SprintSummary, error) { return nil, nil }
+func (m *mockRepo) Start(id string, at time.Time) error {
+	m.sprints[id].Status = "active"
+	m.sprints[id].StartedAt = &at
+	return nil
+}
+func (m *mockRepo) Close(id, nextID string, at time.Time, _ string) (int64, error) {
+	m.sprints[id].Status = "closed"
+	m.sprints[id].ClosedAt = &at
+	m.closedTo = nextID
+	return 0, nil
+}
+
+func TestService_Create_Validation(t *testing.T) {
+	s := NewService(&mockRepo{sprints: map[string]*domain.Sprint{}})
+	start := time.Now()
+	end := start.AddDate(0, 0, 14)
+
+	if _, err := s.Create("S1", "", "", nil, &end); err == nil {
+		t.Error("expected error for sprint without start date")
+	}
+	if _, err := s.Create("S1", "", "sprint", &end, &start); err == nil {
+		t.Error("expected error for end before start")
+	}
+	if _, err := s.Create("M1", "", "milestone", nil, &end); err != nil {
+		t.Errorf("expected milestone without start date to be valid, got %v", err)
+	}
+	sprint, err := s.Create("S1", "Ship it", "", &start, &end)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sprint.Kind != "sprint" || sprint.Status != "planned" {
+		t.Errorf("unexpected sprint: %+v", sprint)
+	}
+}
+
+func TestService_StartAndClose(t *testing.T) {
+	repo := &mockRepo{sprints: map[string]*domain.Sprint{
+		"s1": {ID: "s1", Status: "planned"},
+		"s2": {ID: "s2", Status: "planned"},
+	}}
+	s := NewService(repo)
+
+	if _, err := s.Close("s1", "s2", ""); err == nil || err.Error() != "sprint is not active" {
+		t.Errorf("expected not active error, got %v", err)
+	}
+	if _, err := s.Start("s1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Start("s1"); err == nil {
+		t.Error("expected error starting an active sprint")
+	}
+	closed, err := s.Close("s1", "s2", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if closed.Status != "closed" || repo.closedTo != "s2" {
+		t.Errorf("expected sprint closed into s2, got %+v, %q", closed, repo.closedTo)
+	}
+	if _, err := s.AssignTasks("s1", []string{"t"}, ""); err == nil || err.Error() != "sprint is closed" {
+		t.Errorf("expected closed sprint error, got %v", err)
+	}
+}
+
+func TestService_Close_UnknownNextSprint(t *testing.T) {
+	repo := &mockRepo{sprints: map[string]*domain.Sprint{"s1": {ID: "s1", Status: "active"}}}
+	s := NewService(repo)
+	if _, err := s.Close("s1", "missing", ""); err == nil || err.Error() != "next sprint not found" {
+		t.Errorf("expected next sprint not found, got %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+// Package stream delivers task and task list events to connected clients as they happen.
+// Events reach every instance through a PostgreSQL notification channel, and clients that
+// reconnect receive the events they missed.
+package stream
+
+import "github.com/G20-00/task-management-service-go/internal/domain"
+
+// Repository defines the event read operations needed to stream events.
+type Repository interface {
+	GetEvent(sequence int64) (*domain.Event, error)
+	// GetPublishedEventsAfter retrieves up to limit published events with a sequence greater
+	// than the given one, in order, optionally only those about the given list.
+	GetPublishedEventsAfter(sequence int64, listID string, limit int) ([]*domain.Event, error)
+}
+
+// ListReader defines the task list operations needed to stream the events of a list.
+type ListReader interface {
+	GetByID(id string) (*domain.TaskList, error)
+}
+
+// Bus fans out events to the subscribers of this instance.
+type Bus interface {
+	Subscribe(handler func(event *domain.Event)) func()
+	Publish(event *domain.Event) error
+}
@@ -0,0 +1,166 @@
+package stream
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+// NotifyChannel is the PostgreSQL channel on which published events are announced.
+const NotifyChannel = "task_events"
+
+const (
+	// streamBuffer is how many events a client can fall behind before its stream is closed.
+	streamBuffer = 256
+	// replayLimit bounds the events replayed to a client that reconnects.
+	replayLimit = 1000
+)
+
+// Service implements the event streaming operations.
+type Service struct {
+	repo  Repository
+	lists ListReader
+	bus   Bus
+
+	mu           sync.Mutex
+	lastSequence int64
+}
+
+// NewService creates and returns a new stream Service instance.
+func NewService(repo Repository, lists ListReader, bus Bus) *Service {
+	return &Service{
+		repo:  repo,
+		lists: lists,
+		bus:   bus,
+	}
+}
+
+// Subscribe opens a stream of the events about a list, or about every list when listID is
+// empty. The events published after lastEventID are replayed first; lastEventID is 0 for
+// a new client.
+func (s *Service) Subscribe(listID string, lastEventID int64) (stream *domain.EventStream, err error) {
+	defer utils.RecoverPanic("service", "Subscribe", &err)
+
+	if listID != "" {
+		if _, err := s.lists.GetByID(listID); err != nil {
+			return nil, err
+		}
+	}
+
+	sub := &subscription{events: make(chan *domain.Event, streamBuffer)}
+	unsubscribe := s.bus.Subscribe(func(event *domain.Event) {
+		if listID == "" || event.ListID == listID {
+			sub.send(event)
+		}
+	})
+	stream = &domain.EventStream{
+		Events: sub.events,
+		Close: func() {
+			unsubscribe()
+			sub.close()
+		},
+	}
+
+	// The replay is read after subscribing, so that no event falls between both; the
+	// caller skips the live events that were also replayed.
+	if lastEventID > 0 {
+		stream.Replay, err = s.repo.GetPublishedEventsAfter(lastEventID, listID, replayLimit)
+		if err != nil {
+			stream.Close()
+			return nil, err
+		}
+	}
+
+	return stream, nil
+}
+
+// Notify handles a notification received on NotifyChannel: it loads the announced event
+// and hands it to the subscribers of this instance. An empty payload means the listener
+// reconnected, so the events published meanwhile are loaded instead.
+func (s *Service) Notify(payload string) {
+	if err := s.notify(payload); err != nil {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"layer":   "service",
+			"method":  "Notify",
+			"payload": payload,
+			"error":   err.Error(),
+		}).Error("Failed to stream event")
+	}
+}
+
+func (s *Service) notify(payload string) (err error) {
+	defer utils.RecoverPanic("service", "Notify", &err)
+
+	var events []*domain.Event
+	if payload == "" {
+		s.mu.Lock()
+		after := s.lastSequence
+		s.mu.Unlock()
+		if after == 0 {
+			return nil
+		}
+		if events, err = s.repo.GetPublishedEventsAfter(after, "", replayLimit); err != nil {
+			return err
+		}
+	} else {
+		sequence, err := strconv.ParseInt(payload, 10, 64)
+		if err != nil {
+			return err
+		}
+		event, err := s.repo.GetEvent(sequence)
+		if err != nil {
+			return err
+		}
+		events = []*domain.Event{event}
+	}
+
+	for _, event := range events {
+		s.mu.Lock()
+		if event.Sequence > s.lastSequence {
+			s.lastSequence = event.Sequence
+		}
+		s.mu.Unlock()
+
+		if err := s.bus.Publish(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// subscription buffers the events of a stream. It never blocks the bus: a client that
+// falls behind has its stream closed and resumes from the last event it received.
+type subscription struct {
+	mu     sync.Mutex
+	events chan *domain.Event
+	closed bool
+}
+
+func (s *subscription) send(event *domain.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	select {
+	case s.events <- event:
+	default:
+		s.closed = true
+		close(s.events)
+	}
+}
+
+func (s *subscription) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.events)
+	}
+}
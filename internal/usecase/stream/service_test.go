@@ -0,0 +1,139 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/internal/usecase/outbox"
+)
+
+type mockRepo struct {
+	GetEventFn                func(sequence int64) (*domain.Event, error)
+	GetPublishedEventsAfterFn func(sequence int64, listID string, limit int) ([]*domain.Event, error)
+}
+
+func (m *mockRepo) GetEvent(sequence int64) (*domain.Event, error) { return m.GetEventFn(sequence) }
+func (m *mockRepo) GetPublishedEventsAfter(sequence int64, listID string, limit int) ([]*domain.Event, error) {
+	return m.GetPublishedEventsAfterFn(sequence, listID, limit)
+}
+
+type mockLists struct {
+	GetByIDFn func(id string) (*domain.TaskList, error)
+}
+
+func (m *mockLists) GetByID(id string) (*domain.TaskList, error) { return m.GetByIDFn(id) }
+
+func existingLists() *mockLists {
+	return &mockLists{GetByIDFn: func(id string) (*domain.TaskList, error) {
+		if id != "l1" {
+			return nil, domain.ErrTaskListNotFound
+		}
+		return &domain.TaskList{ID: id}, nil
+	}}
+}
+
+func TestService_Subscribe_FiltersByList(t *testing.T) {
+	bus := outbox.NewBus()
+	s := NewService(&mockRepo{}, existingLists(), bus)
+
+	stream, err := s.Subscribe("l1", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	for _, event := range []*domain.Event{{Sequence: 1, ListID: "l1"}, {Sequence: 2, ListID: "l2"}, {Sequence: 3, ListID: "l1"}} {
+		if err := bus.Publish(event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(stream.Replay) != 0 || len(stream.Events) != 2 {
+		t.Fatalf("expected 2 events of l1, got %d", len(stream.Events))
+	}
+	if first := <-stream.Events; first.Sequence != 1 {
+		t.Errorf("expected event 1 first, got %d", first.Sequence)
+	}
+}
+
+func TestService_Subscribe_UnknownList(t *testing.T) {
+	s := NewService(&mockRepo{}, existingLists(), outbox.NewBus())
+	if _, err := s.Subscribe("missing", 0); err == nil || err.Error() != "task list not found" {
+		t.Errorf("expected task list not found, got %v", err)
+	}
+}
+
+func TestService_Subscribe_ReplaysMissedEvents(t *testing.T) {
+	s := NewService(&mockRepo{
+		GetPublishedEventsAfterFn: func(sequence int64, listID string, _ int) ([]*domain.Event, error) {
+			if sequence != 41 || listID != "l1" {
+				t.Errorf("unexpected replay query: %d %s", sequence, listID)
+			}
+			return []*domain.Event{{Sequence: 42, ListID: "l1"}, {Sequence: 45, ListID: "l1"}}, nil
+		},
+	}, existingLists(), outbox.NewBus())
+
+	stream, err := s.Subscribe("l1", 41)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	if len(stream.Replay) != 2 || stream.Replay[1].Sequence != 45 {
+		t.Errorf("expected the missed events, got %+v", stream.Replay)
+	}
+}
+
+func TestService_Subscribe_ClosesSlowStreams(t *testing.T) {
+	bus := outbox.NewBus()
+	s := NewService(&mockRepo{}, existingLists(), bus)
+
+	stream, err := s.Subscribe("", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+
+	for i := 0; i <= streamBuffer; i++ {
+		if err := bus.Publish(&domain.Event{Sequence: int64(i + 1)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	received := 0
+	for range stream.Events {
+		received++
+	}
+	if received != streamBuffer {
+		t.Errorf("expected the stream to close after %d events, got %d", streamBuffer, received)
+	}
+}
+
+func TestService_Notify(t *testing.T) {
+	bus := outbox.NewBus()
+	var replayedAfter int64
+	s := NewService(&mockRepo{
+		GetEventFn: func(sequence int64) (*domain.Event, error) {
+			return &domain.Event{Sequence: sequence, ListID: "l1"}, nil
+		},
+		GetPublishedEventsAfterFn: func(sequence int64, _ string, _ int) ([]*domain.Event, error) {
+			replayedAfter = sequence
+			return []*domain.Event{{Sequence: sequence + 1, ListID: "l1"}}, nil
+		},
+	}, existingLists(), bus)
+	var received []int64
+	unsubscribe := bus.Subscribe(func(event *domain.Event) { received = append(received, event.Sequence) })
+	defer unsubscribe()
+
+	s.Notify("7")
+	s.Notify("not-a-sequence")
+	// After a reconnection the events published since the last one are loaded.
+	s.Notify("")
+
+	if replayedAfter != 7 {
+		t.Errorf("expected the events after 7 to be loaded, got %d", replayedAfter)
+	}
+	if len(received) != 2 || received[0] != 7 || received[1] != 8 {
+		t.Errorf("expected events 7 and 8, got %v", received)
+	}
+}
@@ -0,0 +1,259 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+// Bulk applies one action to many tasks and reports the outcome of each of them. Errors
+// about the request itself start with "invalid bulk request"; errors about a single task
+// are reported in its item and do not fail the others unless the operation is atomic.
+func (s *Service) Bulk(req domain.BulkRequest, changedBy string) (result *domain.BulkResult, err error) {
+	defer utils.RecoverPanic("service", "Bulk", &err)
+
+	var items []domain.BulkItemResult
+	var changes []domain.BulkChange
+	if req.Action == domain.BulkCreate {
+		items, changes, err = s.prepareBulkCreate(req)
+	} else {
+		items, changes, err = s.prepareBulkChanges(req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result = &domain.BulkResult{Items: items, Applied: true}
+	if req.Atomic && hasFailedItem(items) {
+		rollBack(result)
+		return result, nil
+	}
+	if len(changes) == 0 {
+		return result, nil
+	}
+
+	errs, err := s.repo.ApplyBulk(changes, req.Atomic, changedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Changes were built only for the items that passed validation, in item order.
+	c := 0
+	for i := range result.Items {
+		if result.Items[i].Err != nil {
+			continue
+		}
+		if errs[c] != nil {
+			result.Items[i].Err = errs[c]
+			result.Items[i].Task = nil
+		}
+		c++
+	}
+	if req.Atomic && hasFailedItem(result.Items) {
+		rollBack(result)
+	}
+
+	return result, nil
+}
+
+func (s *Service) prepareBulkCreate(req domain.BulkRequest) ([]domain.BulkItemResult, []domain.BulkChange, error) {
+	if len(req.IDs) > 0 || req.Filter != "" {
+		return nil, nil, invalidBulkRequest("action", "create does not take ids or filter")
+	}
+	if len(req.Tasks) == 0 {
+		return nil, nil, invalidBulkRequest("tasks", "no tasks to create")
+	}
+	if len(req.Tasks) > domain.MaxBulkItems {
+		return nil, nil, invalidBulkRequest("tasks", fmt.Sprintf("at most %d tasks per request", domain.MaxBulkItems))
+	}
+
+	items := make([]domain.BulkItemResult, len(req.Tasks))
+	changes := []domain.BulkChange{}
+	for i, input := range req.Tasks {
+		task, err := s.newTask(input)
+		items[i] = domain.BulkItemResult{Index: i, Task: task, Err: err}
+		if err == nil {
+			items[i].ID = task.ID
+			changes = append(changes, domain.BulkChange{Op: domain.BulkCreate, Task: task})
+		}
+	}
+
+	return items, changes, nil
+}
+
+func (s *Service) prepareBulkChanges(req domain.BulkRequest) ([]domain.BulkItemResult, []domain.BulkChange, error) {
+	patch, err := bulkPatch(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids, err := s.bulkTargets(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]domain.BulkItemResult, len(ids))
+	changes := []domain.BulkChange{}
+	for i, id := range ids {
+		items[i] = domain.BulkItemResult{Index: i, ID: id}
+
+		task, err := s.repo.GetByID(id)
+		if err == nil && task == nil {
+			err = domain.ErrTaskNotFound
+		}
+		if err == nil {
+			err = s.prepareBulkTask(req, task, patch)
+		}
+		if err != nil {
+			items[i].Err = err
+			continue
+		}
+
+		items[i].Task = task
+		op := domain.BulkUpdate
+		if req.Action == domain.BulkDelete {
+			op = domain.BulkDelete
+		}
+		changes = append(changes, domain.BulkChange{Op: op, Task: task})
+	}
+
+	return items, changes, nil
+}
+
+// bulkPatch validates the changes of an update, move or label action and returns the
+// patch applied to every task. Labels are computed per task in prepareBulkTask.
+func bulkPatch(req domain.BulkRequest) (domain.TaskPatch, error) {
+	switch req.Action {
+	case domain.BulkUpdate:
+		patch := req.Fields
+		if !patch.ListID.Set && !patch.Title.Set && !patch.Description.Set && !patch.Status.Set &&
+			!patch.Priority.Set && !patch.DueDate.Set && !patch.Labels.Set {
+			return patch, invalidBulkRequest("fields", "no fields to update")
+		}
+		if err := validatePatch(&patch); err != nil {
+			return patch, invalidBulkRequest("fields", err.Error())
+		}
+		return patch, nil
+
+	case domain.BulkMove:
+		if req.ListID == "" {
+			return domain.TaskPatch{}, invalidBulkRequest("list_id", "list_id is required to move tasks")
+		}
+		return domain.TaskPatch{ListID: domain.PatchField[string]{Set: true, Value: req.ListID}}, nil
+
+	case domain.BulkLabel:
+		add, err := normalizeLabels(req.AddLabels)
+		if err != nil {
+			return domain.TaskPatch{}, invalidBulkRequest("add_labels", err.Error())
+		}
+		remove, err := normalizeLabels(req.RemoveLabels)
+		if err != nil {
+			return domain.TaskPatch{}, invalidBulkRequest("remove_labels", err.Error())
+		}
+		if len(add) == 0 && len(remove) == 0 {
+			return domain.TaskPatch{}, invalidBulkRequest("add_labels", "no labels to add or remove")
+		}
+		return domain.TaskPatch{Labels: domain.PatchField[[]string]{Set: true, Value: add}}, nil
+
+	case domain.BulkDelete:
+		return domain.TaskPatch{}, nil
+	}
+
+	return domain.TaskPatch{}, invalidBulkRequest("action", "action must be create, update, move, label or delete")
+}
+
+// bulkTargets returns the IDs of the tasks an action applies to: the given IDs, or the
+// tasks matching the filter expression.
+func (s *Service) bulkTargets(req domain.BulkRequest) ([]string, error) {
+	if (len(req.IDs) == 0) == (req.Filter == "") {
+		return nil, invalidBulkRequest("ids", "either ids or filter is required")
+	}
+
+	if req.Filter == "" {
+		if len(req.IDs) > domain.MaxBulkItems {
+			return nil, invalidBulkRequest("ids", fmt.Sprintf("at most %d tasks per request", domain.MaxBulkItems))
+		}
+		seen := map[string]bool{}
+		for _, id := range req.IDs {
+			if id == "" || seen[id] {
+				return nil, invalidBulkRequest("ids", fmt.Sprintf("empty or duplicate id %q", id))
+			}
+			seen[id] = true
+		}
+		return req.IDs, nil
+	}
+
+	where, err := ParseFilter(req.Filter)
+	if err != nil {
+		return nil, invalidBulkRequest("filter", err.Error())
+	}
+
+	page, err := s.repo.List(domain.TaskFilter{Where: where}, domain.PageRequest{Limit: domain.MaxBulkItems, Sort: "created_at"})
+	if err != nil {
+		return nil, err
+	}
+	if page.Next != nil {
+		return nil, invalidBulkRequest("filter", fmt.Sprintf("the filter matches more than %d tasks", domain.MaxBulkItems))
+	}
+
+	ids := make([]string, len(page.Items))
+	for i, task := range page.Items {
+		ids[i] = task.ID
+	}
+	return ids, nil
+}
+
+// prepareBulkTask applies the action to a task read from the repository, without storing it.
+func (s *Service) prepareBulkTask(req domain.BulkRequest, task *domain.Task, patch domain.TaskPatch) error {
+	switch req.Action {
+	case domain.BulkDelete:
+		return s.ensureListWritable(task.ListID)
+
+	case domain.BulkLabel:
+		remove := map[string]bool{}
+		for _, label := range req.RemoveLabels {
+			remove[strings.TrimSpace(label)] = true
+		}
+		labels := []string{}
+		for _, label := range append(append([]string{}, task.Labels...), patch.Labels.Value...) {
+			if !remove[label] {
+				labels = append(labels, label)
+			}
+		}
+		var err error
+		if patch.Labels.Value, err = normalizeLabels(labels); err != nil {
+			return err
+		}
+	}
+
+	return s.applyPatch(task, patch)
+}
+
+func hasFailedItem(items []domain.BulkItemResult) bool {
+	for _, item := range items {
+		if item.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// rollBack marks an atomic operation as not applied: the valid items report that they
+// were rolled back because of the failed ones.
+func rollBack(result *domain.BulkResult) {
+	result.Applied = false
+	for i := range result.Items {
+		result.Items[i].Task = nil
+		if result.Items[i].Err == nil {
+			result.Items[i].Err = domain.ErrRolledBack
+		}
+	}
+}
+
+// invalidBulkRequest returns the error of a bulk request that is not valid because of the
+// given request field.
+func invalidBulkRequest(field, reason string) error {
+	return domain.NewInvalidError(field, "invalid_bulk_request", "invalid bulk request: "+reason)
+}
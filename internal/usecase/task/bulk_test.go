@@ -0,0 +1,123 @@
+package task
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func bulkTasks() []*domain.Task {
+	return []*domain.Task{
+		{ID: "1", ListID: "l1", Title: "A", Status: "pending", Priority: "low", Labels: []string{"x"}},
+		{ID: "2", ListID: "l1", Title: "B", Status: "pending", Priority: "low"},
+	}
+}
+
+func TestBulk_UpdateBestEffort(t *testing.T) {
+	repo := &MockRepository{tasks: bulkTasks(), bulkErrs: map[string]error{"2": domain.ErrVersionConflict}}
+	service := NewService(repo)
+
+	result, err := service.Bulk(domain.BulkRequest{
+		Action: domain.BulkUpdate,
+		IDs:    []string{"1", "2", "missing"},
+		Fields: domain.TaskPatch{Status: domain.PatchField[string]{Set: true, Value: "completed"}},
+	}, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.Applied || len(result.Items) != 3 {
+		t.Fatalf("Unexpected result: %+v", result)
+	}
+	if result.Items[0].Err != nil || result.Items[0].Task.Status != "completed" {
+		t.Errorf("Expected item 0 to be updated: %+v", result.Items[0])
+	}
+	if result.Items[1].Err == nil || result.Items[1].Err.Error() != "version conflict" {
+		t.Errorf("Expected version conflict on item 1, got %v", result.Items[1].Err)
+	}
+	if result.Items[2].Err == nil || result.Items[2].Err.Error() != "task not found" {
+		t.Errorf("Expected task not found on item 2, got %v", result.Items[2].Err)
+	}
+	if len(repo.applied) != 1 {
+		t.Errorf("Expected a single applied change, got %d", len(repo.applied))
+	}
+}
+
+func TestBulk_AtomicRollsBack(t *testing.T) {
+	repo := &MockRepository{tasks: bulkTasks()}
+	service := NewService(repo)
+
+	result, err := service.Bulk(domain.BulkRequest{Action: domain.BulkDelete, IDs: []string{"1", "missing"}, Atomic: true}, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Applied || len(repo.applied) != 0 {
+		t.Errorf("Expected nothing to be applied: %+v", result)
+	}
+	if result.Items[0].Err == nil || !strings.HasPrefix(result.Items[0].Err.Error(), "rolled back") {
+		t.Errorf("Expected item 0 to be rolled back, got %v", result.Items[0].Err)
+	}
+}
+
+func TestBulk_Label(t *testing.T) {
+	repo := &MockRepository{tasks: bulkTasks()}
+	service := NewService(repo)
+
+	result, err := service.Bulk(domain.BulkRequest{Action: domain.BulkLabel, IDs: []string{"1"}, AddLabels: []string{"y", "x"}, RemoveLabels: []string{" x "}}, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if labels := result.Items[0].Task.Labels; len(labels) != 1 || labels[0] != "y" {
+		t.Errorf("Expected labels [y], got %v", labels)
+	}
+}
+
+func TestBulk_CreateAndFilter(t *testing.T) {
+	repo := &MockRepository{tasks: bulkTasks()}
+	service := NewService(repo)
+
+	result, err := service.Bulk(domain.BulkRequest{Action: domain.BulkCreate, Tasks: []domain.NewTask{{Title: "New"}, {Title: " "}}}, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Items[0].Err != nil || result.Items[0].Task.Priority != "medium" || result.Items[1].Err == nil {
+		t.Errorf("Unexpected create result: %+v", result.Items)
+	}
+
+	result, err = service.Bulk(domain.BulkRequest{Action: domain.BulkMove, Filter: "status = pending", ListID: "l2"}, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Items) != 2 || result.Items[1].Task.ListID != "l2" {
+		t.Errorf("Expected every matching task to move: %+v", result.Items)
+	}
+	if repo.listedPage.Limit != domain.MaxBulkItems {
+		t.Errorf("Expected the filter to be resolved with limit %d, got %d", domain.MaxBulkItems, repo.listedPage.Limit)
+	}
+}
+
+func TestBulk_InvalidRequests(t *testing.T) {
+	service := NewService(&MockRepository{tasks: bulkTasks()})
+	ids := make([]string, domain.MaxBulkItems+1)
+	for i := range ids {
+		ids[i] = strings.Repeat("a", i+1)
+	}
+
+	cases := map[string]domain.BulkRequest{
+		"unknown action":    {Action: "archive", IDs: []string{"1"}},
+		"ids and filter":    {Action: domain.BulkDelete, IDs: []string{"1"}, Filter: "status = pending"},
+		"no targets":        {Action: domain.BulkDelete},
+		"duplicate ids":     {Action: domain.BulkDelete, IDs: []string{"1", "1"}},
+		"too many ids":      {Action: domain.BulkDelete, IDs: ids},
+		"no fields":         {Action: domain.BulkUpdate, IDs: []string{"1"}},
+		"invalid field":     {Action: domain.BulkUpdate, IDs: []string{"1"}, Fields: domain.TaskPatch{Status: domain.PatchField[string]{Set: true, Value: "done"}}},
+		"move without list": {Action: domain.BulkMove, IDs: []string{"1"}},
+		"no labels":         {Action: domain.BulkLabel, IDs: []string{"1"}},
+		"invalid filter":    {Action: domain.BulkDelete, Filter: "owner = me"},
+	}
+	for name, req := range cases {
+		if _, err := service.Bulk(req, ""); err == nil || !strings.HasPrefix(err.Error(), "invalid bulk request") {
+			t.Errorf("%s: expected invalid bulk request, got %v", name, err)
+		}
+	}
+}
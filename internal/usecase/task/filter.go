@@ -0,0 +1,364 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// FilterError reports an invalid filter expression and the token it was detected at.
+// Pos is the 1-based character position of the token in the expression.
+type FilterError struct {
+	Pos     int
+	Token   string
+	Message string
+}
+
+func (e *FilterError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("invalid filter at position %d: %s", e.Pos, e.Message)
+	}
+	return fmt.Sprintf("invalid filter at position %d near %q: %s", e.Pos, e.Token, e.Message)
+}
+
+// filterFieldKind is the type of a filterable field, which decides its operators and values.
+type filterFieldKind int
+
+const (
+	kindEnum filterFieldKind = iota
+	kindText
+	kindID
+	kindTime
+	kindLabels
+)
+
+type filterField struct {
+	kind     filterFieldKind
+	nullable bool
+	values   map[string]bool
+}
+
+// filterFields is the whitelist of fields a filter expression can reference.
+var filterFields = map[string]filterField{
+	"status":      {kind: kindEnum, values: validStatuses},
+	"priority":    {kind: kindEnum, values: validPriorities},
+	"title":       {kind: kindText},
+	"description": {kind: kindText},
+	"list_id":     {kind: kindID, nullable: true},
+	"parent_id":   {kind: kindID, nullable: true},
+	"sprint_id":   {kind: kindID, nullable: true},
+	"labels":      {kind: kindLabels},
+	"created_at":  {kind: kindTime},
+	"updated_at":  {kind: kindTime},
+	"due_date":    {kind: kindTime, nullable: true},
+	"archived_at": {kind: kindTime, nullable: true},
+}
+
+// filterOperators lists the operators allowed for each kind of field.
+var filterOperators = map[filterFieldKind]map[string]bool{
+	kindEnum:   {"=": true, "!=": true, "in": true},
+	kindText:   {"=": true, "!=": true, "~": true, "in": true},
+	kindID:     {"=": true, "!=": true, "in": true},
+	kindTime:   {"=": true, "!=": true, ">": true, ">=": true, "<": true, "<=": true},
+	kindLabels: {"=": true, "in": true},
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type filterToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// keyword reports whether the token is the given case insensitive keyword.
+func (t filterToken) keyword(word string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, word)
+}
+
+// ParseFilter parses a filter expression and validates it against the field whitelist.
+//
+//	expr       = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | "(" expr ")" | comparison
+//	comparison = field op value | field "in" "(" value { "," value } ")" | field "is" ["not"] "null"
+//
+// Values are bare words (pending, 2026-01-01) or double quoted strings.
+func ParseFilter(expression string) (domain.FilterNode, error) {
+	tokens, err := lexFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, &FilterError{Pos: tok.pos, Token: tok.text, Message: `expected "and", "or" or end of expression`}
+	}
+
+	return node, nil
+}
+
+func lexFilter(expression string) ([]filterToken, error) {
+	runes := []rune(expression)
+	tokens := []filterToken{}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: tokenLParen, text: "(", pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: tokenRParen, text: ")", pos: pos})
+			i++
+		case r == ',':
+			tokens = append(tokens, filterToken{kind: tokenComma, text: ",", pos: pos})
+			i++
+		case r == '=' || r == '~':
+			tokens = append(tokens, filterToken{kind: tokenOperator, text: string(r), pos: pos})
+			i++
+		case r == '!' || r == '<' || r == '>':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, filterToken{kind: tokenOperator, text: string(r) + "=", pos: pos})
+				i += 2
+				continue
+			}
+			if r == '!' {
+				return nil, &FilterError{Pos: pos, Token: "!", Message: `expected "!="`}
+			}
+			tokens = append(tokens, filterToken{kind: tokenOperator, text: string(r), pos: pos})
+			i++
+		case r == '"':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				sb.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, &FilterError{Pos: pos, Token: string(runes[i:]), Message: "unterminated string"}
+			}
+			tokens = append(tokens, filterToken{kind: tokenString, text: sb.String(), pos: pos})
+			i = j + 1
+		case isFilterWordRune(r):
+			j := i
+			for j < len(runes) && isFilterWordRune(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, filterToken{kind: tokenWord, text: string(runes[i:j]), pos: pos})
+			i = j
+		default:
+			return nil, &FilterError{Pos: pos, Token: string(r), Message: "unexpected character"}
+		}
+	}
+
+	return append(tokens, filterToken{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
+
+func isFilterWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_.:+", r)
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() filterToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *filterParser) parseOr() (domain.FilterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().keyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = domain.FilterLogical{Operator: "or", Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (domain.FilterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().keyword("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = domain.FilterLogical{Operator: "and", Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (domain.FilterNode, error) {
+	tok := p.peek()
+
+	if tok.keyword("not") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return domain.FilterNot{Operand: operand}, nil
+	}
+
+	if tok.kind == tokenLParen {
+		p.next()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, unexpected(closing, `expected ")"`)
+		}
+		return node, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (domain.FilterNode, error) {
+	fieldTok := p.next()
+	if fieldTok.kind != tokenWord {
+		return nil, unexpected(fieldTok, "expected a field name")
+	}
+
+	name := strings.ToLower(fieldTok.text)
+	field, ok := filterFields[name]
+	if !ok {
+		return nil, &FilterError{Pos: fieldTok.pos, Token: fieldTok.text, Message: "unknown field"}
+	}
+
+	opTok := p.next()
+	switch {
+	case opTok.keyword("is"):
+		operator := "is null"
+		if p.peek().keyword("not") {
+			p.next()
+			operator = "is not null"
+		}
+		if nullTok := p.next(); !nullTok.keyword("null") {
+			return nil, unexpected(nullTok, `expected "null"`)
+		}
+		if !field.nullable {
+			return nil, &FilterError{Pos: opTok.pos, Token: opTok.text, Message: "field " + name + " cannot be null"}
+		}
+		return domain.FilterComparison{Field: name, Operator: operator}, nil
+
+	case opTok.keyword("in"):
+		if !filterOperators[field.kind]["in"] {
+			return nil, &FilterError{Pos: opTok.pos, Token: opTok.text, Message: "operator not supported for field " + name}
+		}
+		if open := p.next(); open.kind != tokenLParen {
+			return nil, unexpected(open, `expected "("`)
+		}
+		comparison := domain.FilterComparison{Field: name, Operator: "in"}
+		for {
+			valueTok := p.next()
+			value, dateOnly, err := filterValue(name, field, valueTok)
+			if err != nil {
+				return nil, err
+			}
+			comparison.Values = append(comparison.Values, value)
+			comparison.DateOnly = dateOnly
+
+			sep := p.next()
+			if sep.kind == tokenRParen {
+				return comparison, nil
+			}
+			if sep.kind != tokenComma {
+				return nil, unexpected(sep, `expected "," or ")"`)
+			}
+		}
+
+	case opTok.kind == tokenOperator:
+		if !filterOperators[field.kind][opTok.text] {
+			return nil, &FilterError{Pos: opTok.pos, Token: opTok.text, Message: "operator not supported for field " + name}
+		}
+		value, dateOnly, err := filterValue(name, field, p.next())
+		if err != nil {
+			return nil, err
+		}
+		return domain.FilterComparison{Field: name, Operator: opTok.text, Values: []interface{}{value}, DateOnly: dateOnly}, nil
+	}
+
+	return nil, unexpected(opTok, "expected an operator")
+}
+
+// filterValue validates a value token against its field and converts dates to time.Time.
+func filterValue(name string, field filterField, tok filterToken) (interface{}, bool, error) {
+	if tok.kind != tokenWord && tok.kind != tokenString {
+		return nil, false, unexpected(tok, "expected a value")
+	}
+
+	switch field.kind {
+	case kindEnum:
+		if !field.values[tok.text] {
+			return nil, false, &FilterError{Pos: tok.pos, Token: tok.text, Message: "invalid value for field " + name}
+		}
+	case kindTime:
+		if day, err := time.Parse("2006-01-02", tok.text); err == nil {
+			return day, true, nil
+		}
+		instant, err := time.Parse(time.RFC3339, tok.text)
+		if err != nil {
+			return nil, false, &FilterError{Pos: tok.pos, Token: tok.text, Message: "expected a date (YYYY-MM-DD) or RFC 3339 time"}
+		}
+		return instant, false, nil
+	}
+
+	return tok.text, false, nil
+}
+
+func unexpected(tok filterToken, message string) *FilterError {
+	if tok.kind == tokenEOF {
+		return &FilterError{Pos: tok.pos, Message: "unexpected end of expression, " + message}
+	}
+	return &FilterError{Pos: tok.pos, Token: tok.text, Message: message}
+}
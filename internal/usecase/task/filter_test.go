@@ -0,0 +1,88 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+func TestParseFilter_Precedence(t *testing.T) {
+	node, err := ParseFilter(`status in (pending,in-progress) and priority = high or title ~ "deploy prod"`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	or, ok := node.(domain.FilterLogical)
+	if !ok || or.Operator != "or" {
+		t.Fatalf("Expected or at the root, got %#v", node)
+	}
+	and, ok := or.Left.(domain.FilterLogical)
+	if !ok || and.Operator != "and" {
+		t.Fatalf("Expected and on the left, got %#v", or.Left)
+	}
+	in, ok := and.Left.(domain.FilterComparison)
+	if !ok || in.Operator != "in" || len(in.Values) != 2 || in.Values[1] != "in-progress" {
+		t.Errorf("Unexpected in comparison: %#v", and.Left)
+	}
+	title, ok := or.Right.(domain.FilterComparison)
+	if !ok || title.Operator != "~" || title.Values[0] != "deploy prod" {
+		t.Errorf("Unexpected title comparison: %#v", or.Right)
+	}
+}
+
+func TestParseFilter_DatesAndNull(t *testing.T) {
+	node, err := ParseFilter(`NOT (updated_at > 2026-01-01) and due_date is not null`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	and := node.(domain.FilterLogical)
+	updated := and.Left.(domain.FilterNot).Operand.(domain.FilterComparison)
+	if day, ok := updated.Values[0].(time.Time); !ok || !updated.DateOnly || day.Year() != 2026 {
+		t.Errorf("Expected a date-only comparison, got %#v", updated)
+	}
+	due := and.Right.(domain.FilterComparison)
+	if due.Operator != "is not null" {
+		t.Errorf("Expected is not null, got %q", due.Operator)
+	}
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	cases := []struct {
+		expression string
+		pos        int
+		token      string
+	}{
+		{`owner = bob`, 1, "owner"},
+		{`status = done`, 10, "done"},
+		{`priority ~ high`, 10, "~"},
+		{`status = pending and`, 21, ""},
+		{`(status = pending`, 18, ""},
+		{`title = "open`, 9, `"open`},
+		{`updated_at > yesterday`, 14, "yesterday"},
+		{`status = pending priority = high`, 18, "priority"},
+		{`title is null`, 7, "is"},
+		{`title = a & b`, 11, "&"},
+	}
+
+	for _, c := range cases {
+		_, err := ParseFilter(c.expression)
+		var filterErr *FilterError
+		if !errors.As(err, &filterErr) {
+			t.Errorf("%s: expected a FilterError, got %v", c.expression, err)
+			continue
+		}
+		if filterErr.Pos != c.pos || filterErr.Token != c.token {
+			t.Errorf("%s: expected position %d near %q, got %d near %q (%v)", c.expression, c.pos, c.token, filterErr.Pos, filterErr.Token, err)
+		}
+	}
+}
+
+func TestListTasks_InvalidFilter(t *testing.T) {
+	service := NewService(&MockRepository{})
+	_, err := service.List(domain.TaskFilter{Expression: "owner = bob"}, domain.PageRequest{Sort: "created_at"})
+	if err == nil || err.Error() != `invalid filter at position 1 near "owner": unknown field` {
+		t.Errorf("Expected unknown field error, got %v", err)
+	}
+}
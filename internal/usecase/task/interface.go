@@ -4,12 +4,21 @@ package task
 import "github.com/G20-00/task-management-service-go/internal/domain"
 
 // Repository defines the interface for task data persistence operations.
+// Writes record task revisions attributed to the changedBy user they are given.
 type Repository interface {
-	Create(task *domain.Task) error
-	GetAll() ([]*domain.Task, error)
+	Create(task *domain.Task, changedBy string) error
+	GetAll(includeArchived bool) ([]*domain.Task, error)
 	GetByID(id string) (*domain.Task, error)
-	Update(task *domain.Task) error
-	Delete(id string) error
-	GetByFilters(status, priority string) ([]*domain.Task, error)
+	Update(task *domain.Task, changedBy string) error
+	Delete(id, changedBy string) error
+	GetByFilters(status, priority string, includeArchived bool) ([]*domain.Task, error)
+	// GetByListIDs retrieves the tasks of several lists at once, oldest first.
+	GetByListIDs(listIDs []string, includeArchived bool) ([]*domain.Task, error)
+	// List retrieves one page of the tasks matching the filter in the requested order.
+	List(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error)
 	CountByListIDAndStatus(listID, status string) (int, error)
+	IsListArchived(listID string) (bool, error)
+	// ApplyBulk writes the changes of a bulk operation, in a single transaction when atomic,
+	// and returns the error of each change.
+	ApplyBulk(changes []domain.BulkChange, atomic bool, changedBy string) ([]error, error)
 }
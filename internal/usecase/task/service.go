@@ -1,7 +1,6 @@
 package task
 
 import (
-	"errors"
 	"strings"
 	"time"
 
@@ -23,6 +22,19 @@ var validPriorities = map[string]bool{
 	"high":   true,
 }
 
+var validSortFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"priority":   true,
+	"due_date":   true,
+	"title":      true,
+}
+
+// IsSortField reports whether tasks can be sorted by the given field.
+func IsSortField(field string) bool {
+	return validSortFields[field]
+}
+
 // Service implements the task business logic operations.
 type Service struct {
 	repo Repository
@@ -36,60 +48,168 @@ func NewService(repo Repository) *Service {
 }
 
 // Create creates a new task with the provided details and returns the created task.
-func (s *Service) Create(listID, title, description, priority string) (task *domain.Task, err error) {
+func (s *Service) Create(listID, title, description, priority, changedBy string) (task *domain.Task, err error) {
 	defer utils.RecoverPanic("service", "Create", &err)
 
-	if strings.TrimSpace(title) == "" {
-		return nil, errors.New("title cannot be empty")
+	newTask, err := s.newTask(domain.NewTask{ListID: listID, Title: title, Description: description, Priority: priority})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(newTask, changedBy); err != nil {
+		return nil, err
+	}
+
+	return newTask, nil
+}
+
+// CreateWithID creates a task with the id an offline client gave it, which must be a UUID.
+// The status, due date and labels of the input are stored in the same write.
+func (s *Service) CreateWithID(input domain.NewTask, changedBy string) (task *domain.Task, err error) {
+	defer utils.RecoverPanic("service", "CreateWithID", &err)
+
+	if _, err := uuid.Parse(input.ID); err != nil {
+		return nil, domain.NewInvalidError("id", "invalid_id", "invalid id: must be a UUID")
+	}
+
+	newTask, err := s.newTask(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(newTask, changedBy); err != nil {
+		return nil, err
+	}
+
+	return newTask, nil
+}
+
+// newTask validates the fields of a task to create and builds it, without storing it.
+func (s *Service) newTask(input domain.NewTask) (*domain.Task, error) {
+	if strings.TrimSpace(input.Title) == "" {
+		return nil, domain.NewInvalidError("title", "invalid_title", "title cannot be empty")
 	}
 
+	priority := input.Priority
 	if priority == "" {
 		priority = "medium"
 	}
 
 	if !validPriorities[priority] {
-		return nil, errors.New("invalid priority: must be low, medium, or high")
+		return nil, domain.NewInvalidError("priority", "invalid_priority", "invalid priority: must be low, medium, or high")
 	}
 
-	now := time.Now()
-	newTask := &domain.Task{
-		ID:          uuid.New().String(),
-		ListID:      listID,
-		Title:       title,
-		Description: description,
-		Status:      "pending",
-		Priority:    priority,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+	status := input.Status
+	if status == "" {
+		status = "pending"
+	}
+	if !validStatuses[status] {
+		return nil, domain.NewInvalidError("status", "invalid_status", "invalid status: must be pending, in-progress, or completed")
 	}
 
-	if err := s.repo.Create(newTask); err != nil {
+	labels, err := normalizeLabels(input.Labels)
+	if err != nil {
 		return nil, err
 	}
 
-	return newTask, nil
+	if err := s.ensureListWritable(input.ListID); err != nil {
+		return nil, err
+	}
+
+	id := input.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+
+	now := time.Now()
+	return &domain.Task{
+		ID:          id,
+		ListID:      input.ListID,
+		Title:       input.Title,
+		Description: input.Description,
+		Status:      status,
+		Priority:    priority,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		DueDate:     input.DueDate,
+		Labels:      labels,
+		Version:     1,
+	}, nil
 }
 
-// GetAll retrieves all tasks from the repository.
-func (s *Service) GetAll() (tasks []*domain.Task, err error) {
+// GetAll retrieves all tasks from the repository, optionally including archived ones.
+func (s *Service) GetAll(includeArchived bool) (tasks []*domain.Task, err error) {
 	defer utils.RecoverPanic("service", "GetAll", &err)
 
-	return s.repo.GetAll()
+	return s.repo.GetAll(includeArchived)
 }
 
-// GetByFilters retrieves tasks filtered by status and/or priority.
-func (s *Service) GetByFilters(status, priority string) (tasks []*domain.Task, err error) {
+// GetByFilters retrieves tasks filtered by status and/or priority, optionally including archived ones.
+func (s *Service) GetByFilters(status, priority string, includeArchived bool) (tasks []*domain.Task, err error) {
 	defer utils.RecoverPanic("service", "GetByFilters", &err)
 
 	if status != "" && !validStatuses[status] {
-		return nil, errors.New("invalid status")
+		return nil, domain.NewInvalidError("status", "invalid_status", "invalid status")
 	}
 
 	if priority != "" && !validPriorities[priority] {
-		return nil, errors.New("invalid priority")
+		return nil, domain.NewInvalidError("priority", "invalid_priority", "invalid priority")
 	}
 
-	return s.repo.GetByFilters(status, priority)
+	return s.repo.GetByFilters(status, priority, includeArchived)
+}
+
+// List retrieves one page of the tasks matching the filter and its optional filter
+// expression, which is parsed with ParseFilter. The page size defaults to
+// domain.DefaultPageLimit and is capped to domain.MaxPageLimit.
+func (s *Service) List(filter domain.TaskFilter, page domain.PageRequest) (result *domain.Page[*domain.Task], err error) {
+	defer utils.RecoverPanic("service", "List", &err)
+
+	if filter.Status != "" && !validStatuses[filter.Status] {
+		return nil, domain.NewInvalidError("status", "invalid_status", "invalid status")
+	}
+
+	if filter.Priority != "" && !validPriorities[filter.Priority] {
+		return nil, domain.NewInvalidError("priority", "invalid_priority", "invalid priority")
+	}
+
+	if !validSortFields[page.Sort] {
+		return nil, domain.NewInvalidError("sort", "invalid_sort", "invalid sort field")
+	}
+
+	if strings.TrimSpace(filter.Expression) != "" {
+		where, err := ParseFilter(filter.Expression)
+		if err != nil {
+			return nil, domain.WrapInvalidError("filter", "invalid_filter", err)
+		}
+		filter.Where = where
+	}
+
+	page.NormalizeLimit()
+
+	return s.repo.List(filter, page)
+}
+
+// GetByListIDs retrieves the tasks of the given lists, grouped by list ID and oldest
+// first, optionally including archived ones.
+func (s *Service) GetByListIDs(listIDs []string, includeArchived bool) (tasks map[string][]*domain.Task, err error) {
+	defer utils.RecoverPanic("service", "GetByListIDs", &err)
+
+	tasks = make(map[string][]*domain.Task, len(listIDs))
+	if len(listIDs) == 0 {
+		return tasks, nil
+	}
+
+	found, err := s.repo.GetByListIDs(listIDs, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range found {
+		tasks[t.ListID] = append(tasks[t.ListID], t)
+	}
+
+	return tasks, nil
 }
 
 // GetByID retrieves a task by its ID.
@@ -100,25 +220,51 @@ func (s *Service) GetByID(id string) (task *domain.Task, err error) {
 }
 
 // Update updates an existing task with the provided details and returns the updated task.
-func (s *Service) Update(id, listID, title, description, status, priority string) (task *domain.Task, err error) {
+func (s *Service) Update(id, listID, title, description, status, priority, changedBy string) (task *domain.Task, err error) {
 	defer utils.RecoverPanic("service", "Update", &err)
 
+	return s.update(id, 0, listID, title, description, status, priority, changedBy)
+}
+
+// UpdateIfMatch updates a task only if it is still at the given version, as read by the
+// client, and returns domain.ErrVersionConflict otherwise.
+func (s *Service) UpdateIfMatch(id string, version int, listID, title, description, status, priority, changedBy string) (task *domain.Task, err error) {
+	defer utils.RecoverPanic("service", "UpdateIfMatch", &err)
+
+	return s.update(id, version, listID, title, description, status, priority, changedBy)
+}
+
+// update applies the changes to a task. A version of 0 accepts any current version; the
+// write is still guarded against concurrent changes made after the task was read.
+func (s *Service) update(id string, version int, listID, title, description, status, priority, changedBy string) (*domain.Task, error) {
 	if strings.TrimSpace(title) == "" {
-		return nil, errors.New("title cannot be empty")
+		return nil, domain.NewInvalidError("title", "invalid_title", "title cannot be empty")
 	}
 
 	if !validStatuses[status] {
-		return nil, errors.New("invalid status: must be pending, in-progress, or completed")
+		return nil, domain.NewInvalidError("status", "invalid_status", "invalid status: must be pending, in-progress, or completed")
 	}
 
 	if !validPriorities[priority] {
-		return nil, errors.New("invalid priority: must be low, medium, or high")
+		return nil, domain.NewInvalidError("priority", "invalid_priority", "invalid priority: must be low, medium, or high")
 	}
 
 	existingTask, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
+	if version != 0 && existingTask.Version != version {
+		return nil, domain.ErrVersionConflict
+	}
+
+	if err := s.ensureListWritable(existingTask.ListID); err != nil {
+		return nil, err
+	}
+	if listID != existingTask.ListID {
+		if err := s.ensureListWritable(listID); err != nil {
+			return nil, err
+		}
+	}
 
 	existingTask.ListID = listID
 	existingTask.Title = title
@@ -127,16 +273,183 @@ func (s *Service) Update(id, listID, title, description, status, priority string
 	existingTask.Priority = priority
 	existingTask.UpdatedAt = time.Now()
 
-	if err := s.repo.Update(existingTask); err != nil {
+	if err := s.repo.Update(existingTask, changedBy); err != nil {
 		return nil, err
 	}
 
 	return existingTask, nil
 }
 
+// Patch applies a partial update to a task. A version other than 0 makes the update
+// conditional on the task still being at that version. Title, status, priority and list
+// cannot be cleared; clearing the description, due date or labels empties them.
+func (s *Service) Patch(id string, version int, patch domain.TaskPatch, changedBy string) (task *domain.Task, err error) {
+	defer utils.RecoverPanic("service", "Patch", &err)
+
+	if err := validatePatch(&patch); err != nil {
+		return nil, err
+	}
+
+	existingTask, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && existingTask.Version != version {
+		return nil, domain.ErrVersionConflict
+	}
+
+	if err := s.applyPatch(existingTask, patch); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(existingTask, changedBy); err != nil {
+		return nil, err
+	}
+
+	return existingTask, nil
+}
+
+// validatePatch checks the values of a patch that do not depend on the task it applies
+// to, and normalizes its labels.
+func validatePatch(patch *domain.TaskPatch) error {
+	required := []struct {
+		name  string
+		field domain.PatchField[string]
+	}{{"list_id", patch.ListID}, {"title", patch.Title}, {"status", patch.Status}, {"priority", patch.Priority}}
+	for _, r := range required {
+		if r.field.Null {
+			return domain.NewInvalidError(r.name, "invalid_"+r.name, r.name+" cannot be null")
+		}
+	}
+	if patch.Title.Set && strings.TrimSpace(patch.Title.Value) == "" {
+		return domain.NewInvalidError("title", "invalid_title", "title cannot be empty")
+	}
+	if patch.Status.Set && !validStatuses[patch.Status.Value] {
+		return domain.NewInvalidError("status", "invalid_status", "invalid status: must be pending, in-progress, or completed")
+	}
+	if patch.Priority.Set && !validPriorities[patch.Priority.Value] {
+		return domain.NewInvalidError("priority", "invalid_priority", "invalid priority: must be low, medium, or high")
+	}
+
+	labels, err := normalizeLabels(patch.Labels.Value)
+	if err != nil {
+		return err
+	}
+	patch.Labels.Value = labels
+
+	return nil
+}
+
+// applyPatch applies a validated patch to a task, checking that neither its current nor
+// its new list is archived.
+func (s *Service) applyPatch(task *domain.Task, patch domain.TaskPatch) error {
+	if err := s.ensureListWritable(task.ListID); err != nil {
+		return err
+	}
+	if patch.ListID.Set && patch.ListID.Value != task.ListID {
+		if err := s.ensureListWritable(patch.ListID.Value); err != nil {
+			return err
+		}
+		task.ListID = patch.ListID.Value
+	}
+
+	if patch.Title.Set {
+		task.Title = patch.Title.Value
+	}
+	if patch.Description.Set {
+		task.Description = patch.Description.Value
+	}
+	if patch.Status.Set {
+		task.Status = patch.Status.Value
+	}
+	if patch.Priority.Set {
+		task.Priority = patch.Priority.Value
+	}
+	if patch.DueDate.Set {
+		task.DueDate = nil
+		if !patch.DueDate.Null {
+			due := patch.DueDate.Value
+			task.DueDate = &due
+		}
+	}
+	if patch.Labels.Set {
+		task.Labels = patch.Labels.Value
+	}
+	task.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// normalizeLabels trims labels and drops duplicates, keeping their order.
+func normalizeLabels(labels []string) ([]string, error) {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return nil, domain.NewInvalidError("labels", "invalid_labels", "labels cannot be empty")
+		}
+		if !seen[label] {
+			seen[label] = true
+			normalized = append(normalized, label)
+		}
+	}
+
+	return normalized, nil
+}
+
 // Delete removes a task by its ID from the repository.
-func (s *Service) Delete(id string) (err error) {
+func (s *Service) Delete(id, changedBy string) (err error) {
 	defer utils.RecoverPanic("service", "Delete", &err)
 
-	return s.repo.Delete(id)
+	existingTask, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ensureListWritable(existingTask.ListID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(id, changedBy)
+}
+
+// DeleteIfMatch removes a task only if it is still at the given version and returns
+// domain.ErrVersionConflict otherwise. The version is checked by the same write that deletes it.
+func (s *Service) DeleteIfMatch(id string, version int, changedBy string) (err error) {
+	defer utils.RecoverPanic("service", "DeleteIfMatch", &err)
+
+	existingTask, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ensureListWritable(existingTask.ListID); err != nil {
+		return err
+	}
+
+	errs, err := s.repo.ApplyBulk([]domain.BulkChange{{Op: domain.BulkDelete, Task: &domain.Task{ID: id, Version: version}}}, true, changedBy)
+	if err != nil {
+		return err
+	}
+
+	return errs[0]
+}
+
+// ensureListWritable rejects changes to tasks that belong to an archived list, and
+// domain.ErrTaskListNotFound for lists that do not exist or are in the trash.
+func (s *Service) ensureListWritable(listID string) error {
+	if listID == "" {
+		return nil
+	}
+
+	archived, err := s.repo.IsListArchived(listID)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return domain.ErrTaskListArchived
+	}
+
+	return nil
 }
@@ -1,22 +1,31 @@
 package task
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/G20-00/task-management-service-go/internal/domain"
 )
 
 // MockRepository is a mock implementation of the task repository
 type MockRepository struct {
-	tasks []*domain.Task
+	tasks      []*domain.Task
+	listedPage domain.PageRequest
+	// bulkErrs makes ApplyBulk fail the changes of these task IDs; applied records the rest.
+	bulkErrs map[string]error
+	applied  []domain.BulkChange
+	// changedBy records the user the last write was attributed to.
+	changedBy string
 }
 
-func (m *MockRepository) Create(task *domain.Task) error {
+func (m *MockRepository) Create(task *domain.Task, changedBy string) error {
+	m.changedBy = changedBy
 	m.tasks = append(m.tasks, task)
 	return nil
 }
 
-func (m *MockRepository) GetAll() ([]*domain.Task, error) {
+func (m *MockRepository) GetAll(includeArchived bool) ([]*domain.Task, error) {
 	return m.tasks, nil
 }
 
@@ -29,27 +38,58 @@ func (m *MockRepository) GetByID(id string) (*domain.Task, error) {
 	return nil, nil
 }
 
-func (m *MockRepository) Update(task *domain.Task) error {
+func (m *MockRepository) Update(task *domain.Task, changedBy string) error {
+	m.changedBy = changedBy
 	return nil
 }
 
-func (m *MockRepository) Delete(id string) error {
+func (m *MockRepository) Delete(id, changedBy string) error {
+	m.changedBy = changedBy
 	return nil
 }
 
-func (m *MockRepository) GetByFilters(status, priority string) ([]*domain.Task, error) {
+func (m *MockRepository) GetByFilters(status, priority string, includeArchived bool) ([]*domain.Task, error) {
 	return m.tasks, nil
 }
 
+func (m *MockRepository) GetByListIDs(listIDs []string, includeArchived bool) ([]*domain.Task, error) {
+	return m.tasks, nil
+}
+
+func (m *MockRepository) List(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error) {
+	m.listedPage = page
+	return &domain.Page[*domain.Task]{Items: m.tasks}, nil
+}
+
 func (m *MockRepository) CountByListIDAndStatus(listID, status string) (int, error) {
 	return 0, nil
 }
 
+func (m *MockRepository) IsListArchived(listID string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockRepository) ApplyBulk(changes []domain.BulkChange, atomic bool, changedBy string) ([]error, error) {
+	m.changedBy = changedBy
+	errs := make([]error, len(changes))
+	for i, change := range changes {
+		if errs[i] = m.bulkErrs[change.Task.ID]; errs[i] != nil {
+			if atomic {
+				m.applied = nil
+				return errs, nil
+			}
+			continue
+		}
+		m.applied = append(m.applied, change)
+	}
+	return errs, nil
+}
+
 func TestCreateTask_Success(t *testing.T) {
 	repo := &MockRepository{}
 	service := NewService(repo)
 
-	task, err := service.Create("list-123", "Test Task", "Description", "high")
+	task, err := service.Create("list-123", "Test Task", "Description", "high", "")
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -68,7 +108,7 @@ func TestCreateTask_EmptyTitle(t *testing.T) {
 	repo := &MockRepository{}
 	service := NewService(repo)
 
-	_, err := service.Create("list-123", "", "Description", "high")
+	_, err := service.Create("list-123", "", "Description", "high", "")
 
 	if err == nil {
 		t.Error("Expected error for empty title, got nil")
@@ -78,16 +118,53 @@ func TestCreateTask_EmptyTitle(t *testing.T) {
 func TestCreateTask_InvalidPriority(t *testing.T) {
 	repo := &MockRepository{}
 	service := NewService(repo)
-	_, err := service.Create("list-123", "Task", "Description", "urgent")
+	_, err := service.Create("list-123", "Task", "Description", "urgent", "")
 	if err == nil {
 		t.Error("Expected error for invalid priority, got nil")
 	}
 }
 
+func TestCreateTaskWithID(t *testing.T) {
+	repo := &MockRepository{}
+	service := NewService(repo)
+
+	id := "5f0c1d4e-8a52-4d7c-9a4b-1f2e3d4c5b6a"
+	due := time.Now()
+	task, err := service.CreateWithID(domain.NewTask{ID: id, ListID: "list-123", Title: "Task", Status: "completed", DueDate: &due, Labels: []string{" a ", "a"}}, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if task.ID != id || task.Priority != "medium" || len(repo.tasks) != 1 {
+		t.Errorf("Expected the task to keep the client id, got %+v", task)
+	}
+	if task.Status != "completed" || task.DueDate != &due || len(task.Labels) != 1 {
+		t.Errorf("Expected the status, due date and labels to be created with the task, got %+v", task)
+	}
+
+	if _, err := service.CreateWithID(domain.NewTask{ID: "not-a-uuid", ListID: "list-123", Title: "Task"}, ""); err == nil || err.Error() != "invalid id: must be a UUID" {
+		t.Errorf("Expected invalid id error, got %v", err)
+	}
+	if _, err := service.CreateWithID(domain.NewTask{ID: id, Title: "Task", Status: "done"}, ""); err == nil || err.Error() != "invalid status: must be pending, in-progress, or completed" {
+		t.Errorf("Expected invalid status error, got %v", err)
+	}
+}
+
+func TestDeleteTaskIfMatch(t *testing.T) {
+	repo := &MockRepository{tasks: []*domain.Task{{ID: "1", Title: "A", Version: 3}}}
+	service := NewService(repo)
+
+	if err := service.DeleteIfMatch("1", 3, ""); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(repo.applied) != 1 || repo.applied[0].Op != domain.BulkDelete || repo.applied[0].Task.Version != 3 {
+		t.Errorf("Expected a delete conditional on version 3, got %+v", repo.applied)
+	}
+}
+
 func TestGetAllTasks(t *testing.T) {
 	repo := &MockRepository{tasks: []*domain.Task{{ID: "1", Title: "A"}}}
 	service := NewService(repo)
-	tasks, err := service.GetAll()
+	tasks, err := service.GetAll(false)
 	if err != nil || len(tasks) != 1 {
 		t.Errorf("Expected 1 task, got %v, err: %v", tasks, err)
 	}
@@ -96,7 +173,7 @@ func TestGetAllTasks(t *testing.T) {
 func TestGetByFilters_Valid(t *testing.T) {
 	repo := &MockRepository{tasks: []*domain.Task{{ID: "1", Status: "pending", Priority: "high"}}}
 	service := NewService(repo)
-	tasks, err := service.GetByFilters("pending", "high")
+	tasks, err := service.GetByFilters("pending", "high", false)
 	if err != nil || len(tasks) != 1 {
 		t.Errorf("Expected 1 task, got %v, err: %v", tasks, err)
 	}
@@ -104,7 +181,7 @@ func TestGetByFilters_Valid(t *testing.T) {
 
 func TestGetByFilters_InvalidStatus(t *testing.T) {
 	service := NewService(&MockRepository{})
-	_, err := service.GetByFilters("invalid", "high")
+	_, err := service.GetByFilters("invalid", "high", false)
 	if err == nil {
 		t.Error("Expected error for invalid status")
 	}
@@ -112,7 +189,7 @@ func TestGetByFilters_InvalidStatus(t *testing.T) {
 
 func TestGetByFilters_InvalidPriority(t *testing.T) {
 	service := NewService(&MockRepository{})
-	_, err := service.GetByFilters("pending", "urgent")
+	_, err := service.GetByFilters("pending", "urgent", false)
 	if err == nil {
 		t.Error("Expected error for invalid priority")
 	}
@@ -127,18 +204,70 @@ func TestGetByID(t *testing.T) {
 	}
 }
 
+func TestGetByListIDs_GroupsByList(t *testing.T) {
+	repo := &MockRepository{tasks: []*domain.Task{{ID: "1", ListID: "a"}, {ID: "2", ListID: "b"}, {ID: "3", ListID: "a"}}}
+	service := NewService(repo)
+	tasks, err := service.GetByListIDs([]string{"a", "b", "c"}, false)
+	if err != nil || len(tasks["a"]) != 2 || tasks["a"][1].ID != "3" || len(tasks["b"]) != 1 || len(tasks["c"]) != 0 {
+		t.Errorf("Expected tasks grouped by list, got %v, err: %v", tasks, err)
+	}
+}
+
 func TestUpdateTask_Success(t *testing.T) {
 	repo := &MockRepository{tasks: []*domain.Task{{ID: "1", Title: "Old", Status: "pending", Priority: "medium"}}}
 	service := NewService(repo)
-	task, err := service.Update("1", "list-123", "New", "desc", "completed", "high")
-	if err != nil || task.Title != "New" || task.Status != "completed" || task.Priority != "high" {
+	task, err := service.Update("1", "list-123", "New", "desc", "completed", "high", "user-1")
+	if err != nil || task.Title != "New" || task.Status != "completed" || task.Priority != "high" || repo.changedBy != "user-1" {
 		t.Errorf("Unexpected result: %+v, err: %v", task, err)
 	}
 }
 
+func TestUpdateTaskIfMatch(t *testing.T) {
+	repo := &MockRepository{tasks: []*domain.Task{{ID: "1", Title: "Old", Status: "pending", Priority: "medium", Version: 2}}}
+	service := NewService(repo)
+	if _, err := service.UpdateIfMatch("1", 1, "", "New", "", "pending", "low", ""); err == nil || err.Error() != "version conflict" {
+		t.Errorf("Expected version conflict, got %v", err)
+	}
+	task, err := service.UpdateIfMatch("1", 2, "", "New", "", "pending", "low", "")
+	if err != nil || task.Title != "New" {
+		t.Errorf("Unexpected result: %+v, err: %v", task, err)
+	}
+}
+
+func TestPatchTask(t *testing.T) {
+	repo := &MockRepository{tasks: []*domain.Task{{ID: "1", ListID: "list-123", Title: "Old", Description: "desc", Status: "pending", Priority: "medium"}}}
+	service := NewService(repo)
+	task, err := service.Patch("1", 0, domain.TaskPatch{
+		Status:      domain.PatchField[string]{Set: true, Value: "completed"},
+		Description: domain.PatchField[string]{Set: true, Null: true},
+		Labels:      domain.PatchField[[]string]{Set: true, Value: []string{" a ", "b", "a"}},
+	}, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if task.Title != "Old" || task.Status != "completed" || task.Description != "" || len(task.Labels) != 2 {
+		t.Errorf("Unexpected result: %+v", task)
+	}
+}
+
+func TestPatchTask_Invalid(t *testing.T) {
+	service := NewService(&MockRepository{tasks: []*domain.Task{{ID: "1", Title: "Old", Status: "pending", Priority: "medium"}}})
+	cases := map[string]domain.TaskPatch{
+		"title cannot be null":  {Title: domain.PatchField[string]{Set: true, Null: true}},
+		"title cannot be empty": {Title: domain.PatchField[string]{Set: true, Value: " "}},
+		"invalid status: must be pending, in-progress, or completed": {Status: domain.PatchField[string]{Set: true, Value: "done"}},
+		"labels cannot be empty": {Labels: domain.PatchField[[]string]{Set: true, Value: []string{""}}},
+	}
+	for want, patch := range cases {
+		if _, err := service.Patch("1", 0, patch, ""); err == nil || err.Error() != want {
+			t.Errorf("Expected %q, got %v", want, err)
+		}
+	}
+}
+
 func TestUpdateTask_EmptyTitle(t *testing.T) {
 	service := NewService(&MockRepository{})
-	_, err := service.Update("1", "list-123", "", "desc", "pending", "high")
+	_, err := service.Update("1", "list-123", "", "desc", "pending", "high", "")
 	if err == nil {
 		t.Error("Expected error for empty title")
 	}
@@ -146,7 +275,7 @@ func TestUpdateTask_EmptyTitle(t *testing.T) {
 
 func TestUpdateTask_InvalidStatus(t *testing.T) {
 	service := NewService(&MockRepository{})
-	_, err := service.Update("1", "list-123", "title", "desc", "invalid", "high")
+	_, err := service.Update("1", "list-123", "title", "desc", "invalid", "high", "")
 	if err == nil {
 		t.Error("Expected error for invalid status")
 	}
@@ -154,17 +283,114 @@ func TestUpdateTask_InvalidStatus(t *testing.T) {
 
 func TestUpdateTask_InvalidPriority(t *testing.T) {
 	service := NewService(&MockRepository{})
-	_, err := service.Update("1", "list-123", "title", "desc", "pending", "urgent")
+	_, err := service.Update("1", "list-123", "title", "desc", "pending", "urgent", "")
 	if err == nil {
 		t.Error("Expected error for invalid priority")
 	}
 }
 
 func TestDeleteTask(t *testing.T) {
-	repo := &MockRepository{}
+	repo := &MockRepository{tasks: []*domain.Task{{ID: "1", ListID: "list-123"}}}
 	service := NewService(repo)
-	err := service.Delete("1")
+	err := service.Delete("1", "user-1")
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
+	if repo.changedBy != "user-1" {
+		t.Errorf("Expected the delete to be attributed to user-1, got %q", repo.changedBy)
+	}
+}
+
+type archivedListRepository struct {
+	MockRepository
+}
+
+func (m *archivedListRepository) IsListArchived(listID string) (bool, error) {
+	if listID == "trashed-list" {
+		return false, domain.ErrTaskListNotFound
+	}
+	return listID == "archived-list", nil
+}
+
+func TestCreateTask_ArchivedList(t *testing.T) {
+	service := NewService(&archivedListRepository{})
+	_, err := service.Create("archived-list", "Title", "", "low", "")
+	if err == nil || err.Error() != "task list is archived" {
+		t.Errorf("Expected archived list error, got %v", err)
+	}
+}
+
+func TestCreateTask_TrashedList(t *testing.T) {
+	repo := &archivedListRepository{}
+	service := NewService(repo)
+	_, err := service.Create("trashed-list", "Title", "", "low", "")
+	if !errors.Is(err, domain.ErrTaskListNotFound) {
+		t.Errorf("Expected task list not found, got %v", err)
+	}
+	if len(repo.tasks) != 0 {
+		t.Errorf("Expected no task to be created, got %d", len(repo.tasks))
+	}
+}
+
+func TestUpdateTask_MoveToArchivedList(t *testing.T) {
+	repo := &archivedListRepository{MockRepository{tasks: []*domain.Task{{ID: "1", ListID: "list-123", Title: "A", Status: "pending", Priority: "low"}}}}
+	service := NewService(repo)
+	_, err := service.Update("1", "archived-list", "A", "", "pending", "low", "")
+	if err == nil || err.Error() != "task list is archived" {
+		t.Errorf("Expected archived list error, got %v", err)
+	}
+}
+
+func TestListTasks_DefaultsAndCapsLimit(t *testing.T) {
+	repo := &MockRepository{}
+	service := NewService(repo)
+	if _, err := service.List(domain.TaskFilter{}, domain.PageRequest{Sort: "created_at"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if repo.listedPage.Limit != domain.DefaultPageLimit {
+		t.Errorf("Expected default limit %d, got %d", domain.DefaultPageLimit, repo.listedPage.Limit)
+	}
+	if _, err := service.List(domain.TaskFilter{}, domain.PageRequest{Sort: "title", Limit: 1000}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if repo.listedPage.Limit != domain.MaxPageLimit {
+		t.Errorf("Expected capped limit %d, got %d", domain.MaxPageLimit, repo.listedPage.Limit)
+	}
+}
+
+func TestListTasks_InvalidSort(t *testing.T) {
+	service := NewService(&MockRepository{})
+	_, err := service.List(domain.TaskFilter{}, domain.PageRequest{Sort: "description"})
+	if err == nil || err.Error() != "invalid sort field" {
+		t.Errorf("Expected invalid sort field error, got %v", err)
+	}
+}
+
+func TestListTasks_InvalidStatus(t *testing.T) {
+	service := NewService(&MockRepository{})
+	_, err := service.List(domain.TaskFilter{Status: "done"}, domain.PageRequest{Sort: "created_at"})
+	if err == nil || err.Error() != "invalid status" {
+		t.Errorf("Expected invalid status error, got %v", err)
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	repo := &MockRepository{tasks: []*domain.Task{{ID: "1", Title: "Old", Status: "pending", Priority: "medium", Version: 2}}}
+	service := NewService(repo)
+
+	_, err := service.UpdateIfMatch("1", 1, "", "New", "", "pending", "low", "")
+	if !errors.Is(err, domain.ErrVersionConflict) {
+		t.Errorf("Expected ErrVersionConflict, got %v", err)
+	}
+
+	_, err = service.Create("list-123", "Task", "", "urgent", "")
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) || domainErr.Kind != domain.ErrorInvalid || domainErr.Code != "invalid_priority" || domainErr.Field != "priority" {
+		t.Errorf("Expected an invalid priority error, got %#v", err)
+	}
+
+	_, err = service.List(domain.TaskFilter{Expression: "owner = bob"}, domain.PageRequest{Sort: "created_at"})
+	if !errors.As(err, &domainErr) || domainErr.Code != "invalid_filter" || errors.Unwrap(err) == nil {
+		t.Errorf("Expected an invalid filter error wrapping the parse error, got %#v", err)
+	}
 }
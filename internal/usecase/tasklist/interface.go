@@ -6,8 +6,16 @@ import "github.com/G20-00/task-management-service-go/internal/domain"
 // Repository defines the interface for task list data persistence operations.
 type Repository interface {
 	Create(list *domain.TaskList) error
-	GetAll() ([]*domain.TaskList, error)
+	GetAll(includeArchived bool) ([]*domain.TaskList, error)
+	// List retrieves one page of task lists in the requested order.
+	List(includeArchived bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error)
 	GetByID(id string) (*domain.TaskList, error)
+	// GetByIDs retrieves several task lists at once, omitting the IDs that match no list.
+	GetByIDs(ids []string) ([]*domain.TaskList, error)
 	Update(list *domain.TaskList) error
-	Delete(id string) error
+	// Delete soft deletes a task list. A version other than 0 makes it conditional on the
+	// list still being at that version.
+	Delete(id string, version int, changedBy string) error
+	// GetStats counts the live tasks per status of the given lists. Lists without tasks are omitted.
+	GetStats(listIDs []string) (map[string]*domain.ListStats, error)
 }
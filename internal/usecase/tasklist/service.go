@@ -1,7 +1,6 @@
 package tasklist
 
 import (
-	"errors"
 	"strings"
 	"time"
 
@@ -24,13 +23,26 @@ func NewService(repo Repository) *Service {
 
 // Create creates a new task list with the provided name and description.
 func (s *Service) Create(name, description string) (*domain.TaskList, error) {
+	return s.create(uuid.New().String(), name, description)
+}
+
+// CreateWithID creates a task list with the id an offline client gave it, which must be a UUID.
+func (s *Service) CreateWithID(id, name, description string) (*domain.TaskList, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, domain.NewInvalidError("id", "invalid_id", "invalid id: must be a UUID")
+	}
+
+	return s.create(id, name, description)
+}
+
+func (s *Service) create(id, name, description string) (*domain.TaskList, error) {
 	if strings.TrimSpace(name) == "" {
-		return nil, errors.New("name cannot be empty")
+		return nil, domain.NewInvalidError("name", "invalid_name", "name cannot be empty")
 	}
 
 	now := time.Now()
 	list := &domain.TaskList{
-		ID:          uuid.New().String(),
+		ID:          id,
 		Name:        name,
 		Description: description,
 		CreatedAt:   now,
@@ -44,30 +56,110 @@ func (s *Service) Create(name, description string) (*domain.TaskList, error) {
 	return list, nil
 }
 
-// GetAll retrieves all task lists from the repository.
-func (s *Service) GetAll() ([]*domain.TaskList, error) {
-	return s.repo.GetAll()
+// GetAll retrieves all task lists from the repository, optionally including archived ones.
+func (s *Service) GetAll(includeArchived bool) ([]*domain.TaskList, error) {
+	return s.repo.GetAll(includeArchived)
+}
+
+var validSortFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"name":       true,
+}
+
+// List retrieves one page of task lists. The page size defaults to domain.DefaultPageLimit
+// and is capped to domain.MaxPageLimit.
+func (s *Service) List(includeArchived bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error) {
+	if !validSortFields[page.Sort] {
+		return nil, domain.NewInvalidError("sort", "invalid_sort", "invalid sort field")
+	}
+
+	page.NormalizeLimit()
+
+	return s.repo.List(includeArchived, page)
+}
+
+// GetStats counts the tasks per status of each of the given lists. Every requested list
+// is present in the result, with zero counts when it has no tasks.
+func (s *Service) GetStats(listIDs []string) (map[string]*domain.ListStats, error) {
+	stats := make(map[string]*domain.ListStats, len(listIDs))
+	if len(listIDs) == 0 {
+		return stats, nil
+	}
+
+	found, err := s.repo.GetStats(listIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range listIDs {
+		if st, ok := found[id]; ok {
+			stats[id] = st
+		} else {
+			stats[id] = &domain.ListStats{ListID: id}
+		}
+	}
+
+	return stats, nil
 }
 
 // GetByID retrieves a task list by its ID.
 func (s *Service) GetByID(id string) (*domain.TaskList, error) {
 	if id == "" {
-		return nil, errors.New("id cannot be empty")
+		return nil, domain.NewInvalidError("id", "invalid_id", "id cannot be empty")
 	}
 
 	return s.repo.GetByID(id)
 }
 
+// GetByIDs retrieves the task lists with the given IDs, keyed by ID. IDs that match no
+// list are missing from the result.
+func (s *Service) GetByIDs(ids []string) (map[string]*domain.TaskList, error) {
+	lists := make(map[string]*domain.TaskList, len(ids))
+	if len(ids) == 0 {
+		return lists, nil
+	}
+
+	found, err := s.repo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, list := range found {
+		lists[list.ID] = list
+	}
+
+	return lists, nil
+}
+
 // Update updates an existing task list with the provided details.
 func (s *Service) Update(id, name, description string) (*domain.TaskList, error) {
+	return s.update(id, 0, name, description)
+}
+
+// UpdateIfMatch updates a task list only if it is still at the given version and returns
+// domain.ErrVersionConflict otherwise.
+func (s *Service) UpdateIfMatch(id string, version int, name, description string) (*domain.TaskList, error) {
+	return s.update(id, version, name, description)
+}
+
+// update applies the changes to a task list. A version of 0 accepts any current version.
+func (s *Service) update(id string, version int, name, description string) (*domain.TaskList, error) {
 	if id == "" {
-		return nil, errors.New("id cannot be empty")
+		return nil, domain.NewInvalidError("id", "invalid_id", "id cannot be empty")
 	}
 
 	existing, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
+	if version != 0 && existing.Version != version {
+		return nil, domain.ErrVersionConflict
+	}
+
+	if existing.ArchivedAt != nil {
+		return nil, domain.ErrTaskListArchived
+	}
 
 	if name != "" {
 		existing.Name = name
@@ -84,11 +176,61 @@ func (s *Service) Update(id, name, description string) (*domain.TaskList, error)
 	return existing, nil
 }
 
+// Patch applies a partial update to a task list. A version other than 0 makes the update
+// conditional on the list still being at that version. The name cannot be cleared; a null
+// description empties it.
+func (s *Service) Patch(id string, version int, patch domain.TaskListPatch) (*domain.TaskList, error) {
+	if id == "" {
+		return nil, domain.NewInvalidError("id", "invalid_id", "id cannot be empty")
+	}
+	if patch.Name.Null {
+		return nil, domain.NewInvalidError("name", "invalid_name", "name cannot be null")
+	}
+	if patch.Name.Set && strings.TrimSpace(patch.Name.Value) == "" {
+		return nil, domain.NewInvalidError("name", "invalid_name", "name cannot be empty")
+	}
+
+	existing, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && existing.Version != version {
+		return nil, domain.ErrVersionConflict
+	}
+	if existing.ArchivedAt != nil {
+		return nil, domain.ErrTaskListArchived
+	}
+
+	if patch.Name.Set {
+		existing.Name = patch.Name.Value
+	}
+	if patch.Description.Set {
+		existing.Description = patch.Description.Value
+	}
+	existing.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(existing); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
 // Delete removes a task list by its ID from the repository.
-func (s *Service) Delete(id string) error {
+func (s *Service) Delete(id, changedBy string) error {
+	if id == "" {
+		return domain.NewInvalidError("id", "invalid_id", "id cannot be empty")
+	}
+
+	return s.repo.Delete(id, 0, changedBy)
+}
+
+// DeleteIfMatch removes a task list only if it is still at the given version and returns
+// domain.ErrVersionConflict otherwise. The version is checked by the same write that deletes it.
+func (s *Service) DeleteIfMatch(id string, version int, changedBy string) error {
 	if id == "" {
-		return errors.New("id cannot be empty")
+		return domain.NewInvalidError("id", "invalid_id", "id cannot be empty")
 	}
 
-	return s.repo.Delete(id)
+	return s.repo.Delete(id, version, changedBy)
 }
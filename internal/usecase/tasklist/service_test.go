@@ -10,17 +10,29 @@ import (
 
 type mockRepo struct {
 	CreateFn  func(list *domain.TaskList) error
-	GetAllFn  func() ([]*domain.TaskList, error)
+	GetAllFn  func(includeArchived bool) ([]*domain.TaskList, error)
 	GetByIDFn func(id string) (*domain.TaskList, error)
+	ByIDsFn   func(ids []string) ([]*domain.TaskList, error)
 	UpdateFn  func(list *domain.TaskList) error
-	DeleteFn  func(id string) error
+	DeleteFn  func(id string, version int) error
+	StatsFn   func(listIDs []string) (map[string]*domain.ListStats, error)
+	ListFn    func(includeArchived bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error)
 }
 
-func (m *mockRepo) Create(list *domain.TaskList) error          { return m.CreateFn(list) }
-func (m *mockRepo) GetAll() ([]*domain.TaskList, error)         { return m.GetAllFn() }
-func (m *mockRepo) GetByID(id string) (*domain.TaskList, error) { return m.GetByIDFn(id) }
-func (m *mockRepo) Update(list *domain.TaskList) error          { return m.UpdateFn(list) }
-func (m *mockRepo) Delete(id string) error                      { return m.DeleteFn(id) }
+func (m *mockRepo) Create(list *domain.TaskList) error { return m.CreateFn(list) }
+func (m *mockRepo) GetAll(includeArchived bool) ([]*domain.TaskList, error) {
+	return m.GetAllFn(includeArchived)
+}
+func (m *mockRepo) List(includeArchived bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error) {
+	return m.ListFn(includeArchived, page)
+}
+func (m *mockRepo) GetByID(id string) (*domain.TaskList, error)       { return m.GetByIDFn(id) }
+func (m *mockRepo) GetByIDs(ids []string) ([]*domain.TaskList, error) { return m.ByIDsFn(ids) }
+func (m *mockRepo) Update(list *domain.TaskList) error                { return m.UpdateFn(list) }
+func (m *mockRepo) Delete(id string, version int, _ string) error     { return m.DeleteFn(id, version) }
+func (m *mockRepo) GetStats(listIDs []string) (map[string]*domain.ListStats, error) {
+	return m.StatsFn(listIDs)
+}
 
 func TestService_Create(t *testing.T) {
 	repo := &mockRepo{
@@ -44,14 +56,27 @@ func TestService_Create_EmptyName(t *testing.T) {
 	}
 }
 
+func TestService_CreateWithID(t *testing.T) {
+	s := NewService(&mockRepo{CreateFn: func(list *domain.TaskList) error { return nil }})
+
+	id := "5f0c1d4e-8a52-4d7c-9a4b-1f2e3d4c5b6a"
+	list, err := s.CreateWithID(id, "Offline", "")
+	if err != nil || list.ID != id {
+		t.Errorf("expected the list to keep the client id, got %+v, %v", list, err)
+	}
+	if _, err := s.CreateWithID("not-a-uuid", "Offline", ""); err == nil || err.Error() != "invalid id: must be a UUID" {
+		t.Errorf("expected invalid id error, got %v", err)
+	}
+}
+
 func TestService_GetAll(t *testing.T) {
 	repo := &mockRepo{
-		GetAllFn: func() ([]*domain.TaskList, error) {
+		GetAllFn: func(bool) ([]*domain.TaskList, error) {
 			return []*domain.TaskList{{ID: "1", Name: "L", Description: "D", CreatedAt: time.Now(), UpdatedAt: time.Now()}}, nil
 		},
 	}
 	s := NewService(repo)
-	lists, err := s.GetAll()
+	lists, err := s.GetAll(false)
 	if err != nil || len(lists) != 1 {
 		t.Errorf("expected 1 list, got %v, err: %v", lists, err)
 	}
@@ -119,17 +144,152 @@ func TestService_Update_RepoError(t *testing.T) {
 
 func TestService_Delete(t *testing.T) {
 	repo := &mockRepo{
-		DeleteFn: func(id string) error { return nil },
+		DeleteFn: func(id string, version int) error {
+			if version != 0 {
+				t.Errorf("expected an unconditional delete, got version %d", version)
+			}
+			return nil
+		},
+	}
+	s := NewService(repo)
+	if err := s.Delete("1", ""); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestService_DeleteIfMatch(t *testing.T) {
+	repo := &mockRepo{
+		DeleteFn: func(id string, version int) error {
+			if version != 2 {
+				return domain.ErrVersionConflict
+			}
+			return nil
+		},
 	}
 	s := NewService(repo)
-	if err := s.Delete("1"); err != nil {
+	if err := s.DeleteIfMatch("1", 2, ""); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
+	if err := s.DeleteIfMatch("1", 1, ""); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Errorf("expected version conflict, got %v", err)
+	}
 }
 
 func TestService_Delete_EmptyID(t *testing.T) {
 	s := NewService(&mockRepo{})
-	if err := s.Delete(""); err == nil {
+	if err := s.Delete("", ""); err == nil {
 		t.Error("expected error for empty id")
 	}
 }
+
+func TestService_Update_ArchivedList(t *testing.T) {
+	archivedAt := time.Now()
+	repo := &mockRepo{
+		GetByIDFn: func(id string) (*domain.TaskList, error) {
+			return &domain.TaskList{ID: id, Name: "Old", ArchivedAt: &archivedAt}, nil
+		},
+	}
+	s := NewService(repo)
+	_, err := s.Update("1", "New", "")
+	if err == nil || err.Error() != "task list is archived" {
+		t.Errorf("expected archived list error, got %v", err)
+	}
+}
+
+func TestService_GetStats_FillsListsWithoutTasks(t *testing.T) {
+	repo := &mockRepo{
+		StatsFn: func([]string) (map[string]*domain.ListStats, error) {
+			return map[string]*domain.ListStats{"a": {ListID: "a", Total: 2, Completed: 1}}, nil
+		},
+	}
+	s := NewService(repo)
+	stats, err := s.GetStats([]string{"a", "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats["a"].Total != 2 || stats["b"] == nil || stats["b"].Total != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestService_GetByIDs(t *testing.T) {
+	var queried []string
+	repo := &mockRepo{
+		ByIDsFn: func(ids []string) ([]*domain.TaskList, error) {
+			queried = ids
+			return []*domain.TaskList{{ID: "a", Name: "A"}}, nil
+		},
+	}
+	s := NewService(repo)
+	lists, err := s.GetByIDs([]string{"a", "missing"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queried) != 2 || lists["a"] == nil || lists["a"].Name != "A" || lists["missing"] != nil {
+		t.Errorf("unexpected lists: %+v", lists)
+	}
+
+	if lists, err := s.GetByIDs(nil); err != nil || len(lists) != 0 {
+		t.Errorf("expected no query for no IDs, got %v, %v", lists, err)
+	}
+}
+
+func TestService_List_InvalidSort(t *testing.T) {
+	s := NewService(&mockRepo{})
+	_, err := s.List(false, domain.PageRequest{Sort: "priority"})
+	if err == nil || err.Error() != "invalid sort field" {
+		t.Errorf("expected invalid sort field error, got %v", err)
+	}
+}
+
+func TestService_List_DefaultLimit(t *testing.T) {
+	var got domain.PageRequest
+	repo := &mockRepo{
+		ListFn: func(_ bool, page domain.PageRequest) (*domain.Page[*domain.TaskList], error) {
+			got = page
+			return &domain.Page[*domain.TaskList]{}, nil
+		},
+	}
+	s := NewService(repo)
+	if _, err := s.List(false, domain.PageRequest{Sort: "name"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Limit != domain.DefaultPageLimit {
+		t.Errorf("expected limit %d, got %d", domain.DefaultPageLimit, got.Limit)
+	}
+}
+
+func TestService_UpdateIfMatch_VersionConflict(t *testing.T) {
+	repo := &mockRepo{
+		GetByIDFn: func(id string) (*domain.TaskList, error) {
+			return &domain.TaskList{ID: id, Name: "Old", Version: 5}, nil
+		},
+		UpdateFn: func(*domain.TaskList) error {
+			t.Error("a stale version must not be written")
+			return nil
+		},
+	}
+	s := NewService(repo)
+	_, err := s.UpdateIfMatch("1", 4, "New", "")
+	if err == nil || err.Error() != "version conflict" {
+		t.Errorf("expected version conflict, got %v", err)
+	}
+}
+
+func TestService_Patch_ClearsDescription(t *testing.T) {
+	repo := &mockRepo{
+		GetByIDFn: func(id string) (*domain.TaskList, error) {
+			return &domain.TaskList{ID: id, Name: "Old", Description: "Old"}, nil
+		},
+		UpdateFn: func(*domain.TaskList) error { return nil },
+	}
+	s := NewService(repo)
+	list, err := s.Patch("1", 0, domain.TaskListPatch{Description: domain.PatchField[string]{Set: true, Null: true}})
+	if err != nil || list.Name != "Old" || list.Description != "" {
+		t.Errorf("unexpected result: %+v, err: %v", list, err)
+	}
+
+	if _, err := s.Patch("1", 0, domain.TaskListPatch{Name: domain.PatchField[string]{Set: true, Null: true}}); err == nil || err.Error() != "name cannot be null" {
+		t.Errorf("expected name cannot be null, got %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+// Package template provides reusable task list templates with variable substitution.
+package template
+
+import "github.com/G20-00/task-management-service-go/internal/domain"
+
+// Repository defines the interface for template persistence operations.
+type Repository interface {
+	Create(tpl *domain.TaskTemplate) error
+	GetAll() ([]*domain.TaskTemplate, error)
+	GetByID(id string) (*domain.TaskTemplate, error)
+	Delete(id string) error
+	// Instantiate stores a new task list together with all of its tasks in a single transaction.
+	// Parent tasks must appear before their subtasks.
+	Instantiate(list *domain.TaskList, tasks []*domain.Task, changedBy string) error
+}
+
+// ListReader defines the task list operations needed to capture a list as a template.
+type ListReader interface {
+	GetByID(id string) (*domain.TaskList, error)
+}
+
+// TaskReader defines the task operations needed to capture a list as a template.
+type TaskReader interface {
+	GetByListID(listID string) ([]*domain.Task, error)
+}
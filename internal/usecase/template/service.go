@@ -0,0 +1,241 @@
+package template
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+var variablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// Service implements the template business logic operations.
+type Service struct {
+	repo  Repository
+	lists ListReader
+	tasks TaskReader
+}
+
+// NewService creates and returns a new template Service instance.
+func NewService(repo Repository, lists ListReader, tasks TaskReader) *Service {
+	return &Service{
+		repo:  repo,
+		lists: lists,
+		tasks: tasks,
+	}
+}
+
+// SaveFromList captures an existing task list and its tasks as a new template.
+// Due dates are stored relative to the creation date of the list. When name is empty
+// the list name is used.
+func (s *Service) SaveFromList(listID, name, description string) (tpl *domain.TaskTemplate, err error) {
+	defer utils.RecoverPanic("service", "SaveFromList", &err)
+
+	list, err := s.lists.GetByID(listID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.tasks.GetByListID(listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(name) == "" {
+		name = list.Name
+	}
+	if description == "" {
+		description = list.Description
+	}
+
+	itemIDs := make(map[string]string, len(tasks))
+	for _, t := range tasks {
+		itemIDs[t.ID] = uuid.New().String()
+	}
+
+	now := time.Now()
+	tpl = &domain.TaskTemplate{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Items:       make([]domain.TemplateItem, len(tasks)),
+	}
+	for i, t := range tasks {
+		item := domain.TemplateItem{
+			ID:           itemIDs[t.ID],
+			ParentItemID: itemIDs[t.ParentID],
+			Position:     i,
+			Title:        t.Title,
+			Description:  t.Description,
+			Priority:     t.Priority,
+			Labels:       append([]string{}, t.Labels...),
+		}
+		if t.DueDate != nil {
+			days := int(math.Round(t.DueDate.Sub(list.CreatedAt).Hours() / 24))
+			item.DueOffsetDays = &days
+		}
+		tpl.Items[i] = item
+	}
+
+	if err := s.repo.Create(tpl); err != nil {
+		return nil, err
+	}
+
+	return tpl, nil
+}
+
+// GetAll retrieves all templates without their items.
+func (s *Service) GetAll() (templates []*domain.TaskTemplate, err error) {
+	defer utils.RecoverPanic("service", "GetAll", &err)
+
+	return s.repo.GetAll()
+}
+
+// GetByID retrieves a template with its items.
+func (s *Service) GetByID(id string) (tpl *domain.TaskTemplate, err error) {
+	defer utils.RecoverPanic("service", "GetByID", &err)
+
+	return s.repo.GetByID(id)
+}
+
+// Delete removes a template.
+func (s *Service) Delete(id string) (err error) {
+	defer utils.RecoverPanic("service", "Delete", &err)
+
+	return s.repo.Delete(id)
+}
+
+// Instantiate creates a new task list from a template, replacing {{variable}} placeholders
+// in names, titles and descriptions with the given values. Every placeholder must have a
+// value. Due dates are computed from the instantiation time and all tasks start as pending.
+func (s *Service) Instantiate(templateID string, variables map[string]string, changedBy string) (list *domain.TaskList, tasks []*domain.Task, err error) {
+	defer utils.RecoverPanic("service", "Instantiate", &err)
+
+	tpl, err := s.repo.GetByID(templateID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	texts := []string{tpl.Name, tpl.Description}
+	for _, item := range tpl.Items {
+		texts = append(texts, item.Title, item.Description)
+	}
+	if missing := missingVariables(texts, variables); len(missing) > 0 {
+		return nil, nil, domain.NewInvalidError("variables", "missing_template_variables", "missing template variables: "+strings.Join(missing, ", "))
+	}
+
+	now := time.Now()
+	list = &domain.TaskList{
+		ID:          uuid.New().String(),
+		Name:        substitute(tpl.Name, variables),
+		Description: substitute(tpl.Description, variables),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	taskIDs := make(map[string]string, len(tpl.Items))
+	for _, item := range tpl.Items {
+		taskIDs[item.ID] = uuid.New().String()
+	}
+
+	tasks = make([]*domain.Task, 0, len(tpl.Items))
+	for _, item := range orderParentsFirst(tpl.Items) {
+		t := &domain.Task{
+			ID:          taskIDs[item.ID],
+			ListID:      list.ID,
+			Title:       substitute(item.Title, variables),
+			Description: substitute(item.Description, variables),
+			Status:      "pending",
+			Priority:    item.Priority,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			ParentID:    taskIDs[item.ParentItemID],
+			Labels:      append([]string{}, item.Labels...),
+		}
+		if item.DueOffsetDays != nil {
+			due := now.AddDate(0, 0, *item.DueOffsetDays)
+			t.DueDate = &due
+		}
+		tasks = append(tasks, t)
+	}
+
+	if err := s.repo.Instantiate(list, tasks, changedBy); err != nil {
+		return nil, nil, err
+	}
+
+	return list, tasks, nil
+}
+
+// substitute replaces every {{variable}} placeholder with its value.
+func substitute(text string, variables map[string]string) string {
+	return variablePattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+		return variables[name]
+	})
+}
+
+// missingVariables returns, sorted, the placeholders used in texts that have no value.
+func missingVariables(texts []string, variables map[string]string) []string {
+	seen := map[string]bool{}
+	missing := []string{}
+	for _, text := range texts {
+		for _, match := range variablePattern.FindAllStringSubmatch(text, -1) {
+			name := match[1]
+			if _, ok := variables[name]; !ok && !seen[name] {
+				seen[name] = true
+				missing = append(missing, name)
+			}
+		}
+	}
+	sort.Strings(missing)
+
+	return missing
+}
+
+// orderParentsFirst sorts items so that every parent precedes its subtasks, keeping
+// the template order otherwise. Items whose parent is not in the template are treated as roots.
+func orderParentsFirst(items []domain.TemplateItem) []domain.TemplateItem {
+	known := make(map[string]bool, len(items))
+	for _, item := range items {
+		known[item.ID] = true
+	}
+
+	children := map[string][]domain.TemplateItem{}
+	roots := []domain.TemplateItem{}
+	for _, item := range items {
+		if item.ParentItemID != "" && known[item.ParentItemID] && item.ParentItemID != item.ID {
+			children[item.ParentItemID] = append(children[item.ParentItemID], item)
+		} else {
+			roots = append(roots, item)
+		}
+	}
+
+	ordered := make([]domain.TemplateItem, 0, len(items))
+	visited := make(map[string]bool, len(items))
+	queue := roots
+	for len(queue) > 0 {
+		item := queue[0]
+		queue = queue[1:]
+		ordered = append(ordered, item)
+		visited[item.ID] = true
+		queue = append(queue, children[item.ID]...)
+	}
+
+	// Items caught in a parent cycle are never reached from a root; keep them as roots.
+	for _, item := range items {
+		if !visited[item.ID] {
+			item.ParentItemID = ""
+			ordered = append(ordered, item)
+		}
+	}
+
+	return ordered
+}
@@ -0,0 +1,112 @@
+package template
+
+import (
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockRepo struct {
+	templates map[string]*domain.TaskTemplate
+	list      *domain.TaskList
+	tasks     []*domain.Task
+}
+
+func (m *mockRepo) Create(tpl *domain.TaskTemplate) error {
+	m.templates[tpl.ID] = tpl
+	return nil
+}
+func (m *mockRepo) GetAll() ([]*domain.TaskTemplate, error) { return nil, nil }
+func (m *mockRepo) GetByID(id string) (*domain.TaskTemplate, error) {
+	tpl, ok := m.templates[id]
+	if !ok {
+		return nil, domain.ErrTemplateNotFound
+	}
+	return tpl, nil
+}
+func (m *mockRepo) Delete(string) error { return nil }
+func (m *mockRepo) Instantiate(list *domain.TaskList, tasks []*domain.Task, _ string) error {
+	m.list, m.tasks = list, tasks
+	return nil
+}
+
+type mockLists struct{ list *domain.TaskList }
+
+func (m *mockLists) GetByID(string) (*domain.TaskList, error) { return m.list, nil }
+
+type mockTasks struct{ tasks []*domain.Task }
+
+func (m *mockTasks) GetByListID(string) ([]*domain.Task, error) { return m.tasks, nil }
+
+func TestService_SaveFromList_CapturesTasks(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	due := created.AddDate(0, 0, 3)
+	repo := &mockRepo{templates: map[string]*domain.TaskTemplate{}}
+	s := NewService(repo,
+		&mockLists{list: &domain.TaskList{ID: "l", Name: "Onboarding", CreatedAt: created}},
+		&mockTasks{tasks: []*domain.Task{
+			{ID: "a", Title: "Parent", Priority: "high", DueDate: &due, Labels: []string{"hr"}},
+			{ID: "b", Title: "Child", Priority: "low", ParentID: "a"},
+		}})
+
+	tpl, err := s.SaveFromList("l", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tpl.Name != "Onboarding" || len(tpl.Items) != 2 {
+		t.Fatalf("unexpected template: %+v", tpl)
+	}
+	if tpl.Items[0].DueOffsetDays == nil || *tpl.Items[0].DueOffsetDays != 3 {
+		t.Errorf("expected due offset of 3 days, got %v", tpl.Items[0].DueOffsetDays)
+	}
+	if tpl.Items[1].ParentItemID != tpl.Items[0].ID {
+		t.Errorf("expected subtask to reference parent item, got %q", tpl.Items[1].ParentItemID)
+	}
+}
+
+func TestService_Instantiate_SubstitutesVariables(t *testing.T) {
+	offset := 2
+	repo := &mockRepo{templates: map[string]*domain.TaskTemplate{
+		"t": {ID: "t", Name: "Onboarding {{ name }}", Items: []domain.TemplateItem{
+			{ID: "child", ParentItemID: "parent", Title: "Laptop for {{name}}", Priority: "low"},
+			{ID: "parent", Title: "Welcome {{name}}", Description: "Team {{team}}", Priority: "high", DueOffsetDays: &offset},
+		}},
+	}}
+	s := NewService(repo, &mockLists{}, &mockTasks{})
+
+	list, tasks, err := s.Instantiate("t", map[string]string{"name": "Ana", "team": "core"}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list.Name != "Onboarding Ana" {
+		t.Errorf("unexpected list name %q", list.Name)
+	}
+	if len(tasks) != 2 || tasks[0].Title != "Welcome Ana" || tasks[0].Description != "Team core" {
+		t.Fatalf("expected parent first with substituted fields, got %+v", tasks)
+	}
+	if tasks[1].ParentID != tasks[0].ID || tasks[1].ListID != list.ID || tasks[1].Status != "pending" {
+		t.Errorf("unexpected subtask: %+v", tasks[1])
+	}
+	if tasks[0].DueDate == nil || tasks[1].DueDate != nil {
+		t.Errorf("expected due date only on parent task")
+	}
+	if repo.list != list || len(repo.tasks) != 2 {
+		t.Errorf("expected list and tasks to be stored together")
+	}
+}
+
+func TestService_Instantiate_MissingVariables(t *testing.T) {
+	repo := &mockRepo{templates: map[string]*domain.TaskTemplate{
+		"t": {ID: "t", Name: "{{project}}", Items: []domain.TemplateItem{{ID: "1", Title: "{{owner}} review"}}},
+	}}
+	s := NewService(repo, &mockLists{}, &mockTasks{})
+
+	_, _, err := s.Instantiate("t", map[string]string{"project": "X"}, "")
+	if err == nil || err.Error() != "missing template variables: owner" {
+		t.Errorf("expected missing variable error, got %v", err)
+	}
+	if repo.list != nil {
+		t.Error("expected nothing to be stored")
+	}
+}
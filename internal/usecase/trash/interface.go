@@ -0,0 +1,17 @@
+// Package trash provides business logic for soft deleted tasks and task lists.
+package trash
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// Repository defines the interface for trash persistence operations.
+type Repository interface {
+	GetDeletedTasks() ([]*domain.Task, error)
+	GetDeletedLists() ([]*domain.TaskList, error)
+	RestoreTask(id, changedBy string) error
+	RestoreList(id, changedBy string) error
+	PurgeDeletedBefore(before time.Time) (tasks, lists int64, err error)
+}
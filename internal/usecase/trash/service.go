@@ -0,0 +1,87 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+// Service implements the trash business logic operations.
+type Service struct {
+	repo      Repository
+	retention time.Duration
+}
+
+// NewService creates and returns a new trash Service instance.
+// Items older than retention in the trash are removed by Purge.
+func NewService(repo Repository, retention time.Duration) *Service {
+	return &Service{
+		repo:      repo,
+		retention: retention,
+	}
+}
+
+// GetDeletedTasks retrieves all tasks currently in the trash.
+func (s *Service) GetDeletedTasks() (tasks []*domain.Task, err error) {
+	defer utils.RecoverPanic("service", "GetDeletedTasks", &err)
+
+	return s.repo.GetDeletedTasks()
+}
+
+// GetDeletedLists retrieves all task lists currently in the trash.
+func (s *Service) GetDeletedLists() (lists []*domain.TaskList, err error) {
+	defer utils.RecoverPanic("service", "GetDeletedLists", &err)
+
+	return s.repo.GetDeletedLists()
+}
+
+// RestoreTask restores a single task from the trash.
+func (s *Service) RestoreTask(id, changedBy string) (err error) {
+	defer utils.RecoverPanic("service", "RestoreTask", &err)
+
+	if id == "" {
+		return domain.NewInvalidError("id", "invalid_id", "id cannot be empty")
+	}
+
+	return s.repo.RestoreTask(id, changedBy)
+}
+
+// RestoreList restores a task list and the tasks deleted together with it.
+func (s *Service) RestoreList(id, changedBy string) (err error) {
+	defer utils.RecoverPanic("service", "RestoreList", &err)
+
+	if id == "" {
+		return domain.NewInvalidError("id", "invalid_id", "id cannot be empty")
+	}
+
+	return s.repo.RestoreList(id, changedBy)
+}
+
+// Purge permanently removes everything that has been in the trash longer than the retention period.
+func (s *Service) Purge() (err error) {
+	defer utils.RecoverPanic("service", "Purge", &err)
+
+	tasks, lists, err := s.repo.PurgeDeletedBefore(time.Now().Add(-s.retention))
+	if err != nil {
+		return err
+	}
+
+	if tasks > 0 || lists > 0 {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"layer":  "service",
+			"method": "Purge",
+			"tasks":  tasks,
+			"lists":  lists,
+		}).Info("Purged expired trash")
+	}
+
+	return nil
+}
+
+// StartRetentionJob runs Purge every interval in a background goroutine.
+// The returned function stops the job.
+func (s *Service) StartRetentionJob(interval time.Duration) func() {
+	return utils.StartJob("trash-retention", interval, s.Purge)
+}
@@ -0,0 +1,84 @@
+package trash
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockRepo struct {
+	GetDeletedTasksFn    func() ([]*domain.Task, error)
+	GetDeletedListsFn    func() ([]*domain.TaskList, error)
+	RestoreTaskFn        func(id string) error
+	RestoreListFn        func(id string) error
+	PurgeDeletedBeforeFn func(before time.Time) (int64, int64, error)
+}
+
+func (m *mockRepo) GetDeletedTasks() ([]*domain.Task, error)     { return m.GetDeletedTasksFn() }
+func (m *mockRepo) GetDeletedLists() ([]*domain.TaskList, error) { return m.GetDeletedListsFn() }
+func (m *mockRepo) RestoreTask(id, _ string) error               { return m.RestoreTaskFn(id) }
+func (m *mockRepo) RestoreList(id, _ string) error               { return m.RestoreListFn(id) }
+func (m *mockRepo) PurgeDeletedBefore(before time.Time) (tasks, lists int64, err error) {
+	return m.PurgeDeletedBeforeFn(before)
+}
+
+func TestService_GetDeletedTasks(t *testing.T) {
+	now := time.Now()
+	s := NewService(&mockRepo{
+		GetDeletedTasksFn: func() ([]*domain.Task, error) {
+			return []*domain.Task{{ID: "1", DeletedAt: &now}}, nil
+		},
+	}, time.Hour)
+	tasks, err := s.GetDeletedTasks()
+	if err != nil || len(tasks) != 1 {
+		t.Errorf("expected 1 task, got %v, err: %v", tasks, err)
+	}
+}
+
+func TestService_RestoreTask_EmptyID(t *testing.T) {
+	s := NewService(&mockRepo{}, time.Hour)
+	if err := s.RestoreTask("", ""); err == nil {
+		t.Error("expected error for empty id")
+	}
+}
+
+func TestService_RestoreList(t *testing.T) {
+	var restored string
+	s := NewService(&mockRepo{
+		RestoreListFn: func(id string) error { restored = id; return nil },
+	}, time.Hour)
+	if err := s.RestoreList("list-1", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restored != "list-1" {
+		t.Errorf("expected list-1 to be restored, got %q", restored)
+	}
+}
+
+func TestService_Purge_UsesRetention(t *testing.T) {
+	var cutoff time.Time
+	s := NewService(&mockRepo{
+		PurgeDeletedBeforeFn: func(before time.Time) (int64, int64, error) {
+			cutoff = before
+			return 2, 1, nil
+		},
+	}, 48*time.Hour)
+	if err := s.Purge(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := time.Now().Add(-48 * time.Hour)
+	if cutoff.Sub(expected).Abs() > time.Minute {
+		t.Errorf("expected cutoff near %v, got %v", expected, cutoff)
+	}
+}
+
+func TestService_Purge_Error(t *testing.T) {
+	s := NewService(&mockRepo{
+		PurgeDeletedBeforeFn: func(time.Time) (int64, int64, error) { return 0, 0, errors.New("db error") },
+	}, time.Hour)
+	if err := s.Purge(); err == nil {
+		t.Error("expected error from repository")
+	}
+}
@@ -0,0 +1,25 @@
+// Package view provides saved task views: named filters, sorts, groupings and field selections.
+package view
+
+import "github.com/G20-00/task-management-service-go/internal/domain"
+
+// Repository defines the interface for saved view persistence operations.
+type Repository interface {
+	Create(view *domain.SavedView) error
+	GetByID(id string) (*domain.SavedView, error)
+	// GetVisible retrieves the views owned by the user plus the views shared with a list,
+	// restricted to one list when listID is not empty.
+	GetVisible(userID, listID string) ([]*domain.SavedView, error)
+	Update(view *domain.SavedView) error
+	Delete(id string) error
+}
+
+// ListReader defines the task list operations needed to share a view with a list.
+type ListReader interface {
+	GetByID(id string) (*domain.TaskList, error)
+}
+
+// TaskLister defines the task operations needed to run a view.
+type TaskLister interface {
+	List(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error)
+}
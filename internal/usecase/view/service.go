@@ -0,0 +1,182 @@
+package view
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/internal/usecase/task"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+var validGroupBy = map[string]bool{
+	"":          true,
+	"status":    true,
+	"priority":  true,
+	"list_id":   true,
+	"sprint_id": true,
+}
+
+// Service implements the saved view business logic operations.
+type Service struct {
+	repo  Repository
+	lists ListReader
+	tasks TaskLister
+}
+
+// NewService creates and returns a new view Service instance.
+func NewService(repo Repository, lists ListReader, tasks TaskLister) *Service {
+	return &Service{
+		repo:  repo,
+		lists: lists,
+		tasks: tasks,
+	}
+}
+
+// Create saves a new view owned by the user. Fields are expected to be validated by the caller.
+func (s *Service) Create(userID string, input *domain.SavedView) (view *domain.SavedView, err error) {
+	defer utils.RecoverPanic("service", "Create", &err)
+
+	now := time.Now()
+	view = &domain.SavedView{
+		ID:        uuid.New().String(),
+		OwnerID:   userID,
+		ListID:    input.ListID,
+		Name:      strings.TrimSpace(input.Name),
+		Filter:    strings.TrimSpace(input.Filter),
+		Sort:      input.Sort,
+		GroupBy:   input.GroupBy,
+		Fields:    input.Fields,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.validate(view); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// GetVisible retrieves the views the user can use, optionally only those shared with a list.
+func (s *Service) GetVisible(userID, listID string) (views []*domain.SavedView, err error) {
+	defer utils.RecoverPanic("service", "GetVisible", &err)
+
+	return s.repo.GetVisible(userID, listID)
+}
+
+// GetByID retrieves a view the user owns or that is shared with a list. Private views of
+// other users are reported as not found.
+func (s *Service) GetByID(id, userID string) (view *domain.SavedView, err error) {
+	defer utils.RecoverPanic("service", "GetByID", &err)
+
+	view, err = s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if view.OwnerID != userID && view.ListID == "" {
+		return nil, domain.ErrViewNotFound
+	}
+
+	return view, nil
+}
+
+// Update replaces the definition of a view. Only its owner can change it.
+func (s *Service) Update(id, userID string, input *domain.SavedView) (view *domain.SavedView, err error) {
+	defer utils.RecoverPanic("service", "Update", &err)
+
+	view, err = s.owned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	view.ListID = input.ListID
+	view.Name = strings.TrimSpace(input.Name)
+	view.Filter = strings.TrimSpace(input.Filter)
+	view.Sort = input.Sort
+	view.GroupBy = input.GroupBy
+	view.Fields = input.Fields
+	view.UpdatedAt = time.Now()
+
+	if err := s.validate(view); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// Delete removes a view. Only its owner can delete it.
+func (s *Service) Delete(id, userID string) (err error) {
+	defer utils.RecoverPanic("service", "Delete", &err)
+
+	if _, err := s.owned(id, userID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(id)
+}
+
+// Run lists one page of the tasks matching a view. A view shared with a list only
+// shows the tasks of that list.
+func (s *Service) Run(view *domain.SavedView, page domain.PageRequest) (result *domain.Page[*domain.Task], err error) {
+	defer utils.RecoverPanic("service", "Run", &err)
+
+	filter := domain.TaskFilter{
+		ListID:     view.ListID,
+		Expression: view.Filter,
+	}
+
+	return s.tasks.List(filter, page)
+}
+
+func (s *Service) owned(id, userID string) (*domain.SavedView, error) {
+	view, err := s.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if view.OwnerID != userID {
+		return nil, domain.ErrViewNotOwned
+	}
+
+	return view, nil
+}
+
+func (s *Service) validate(view *domain.SavedView) error {
+	if view.Name == "" {
+		return domain.NewInvalidError("name", "invalid_name", "name cannot be empty")
+	}
+
+	if view.Filter != "" {
+		if _, err := task.ParseFilter(view.Filter); err != nil {
+			return domain.WrapInvalidError("filter", "invalid_filter", err)
+		}
+	}
+
+	if view.Sort != "" && !task.IsSortField(strings.TrimPrefix(view.Sort, "-")) {
+		return domain.NewInvalidError("sort", "invalid_sort", "invalid sort field")
+	}
+
+	if !validGroupBy[view.GroupBy] {
+		return domain.NewInvalidError("group_by", "invalid_group_by", "invalid group by field")
+	}
+
+	if view.ListID != "" {
+		if _, err := s.lists.GetByID(view.ListID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
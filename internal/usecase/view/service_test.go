@@ -0,0 +1,129 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockRepo struct {
+	views map[string]*domain.SavedView
+}
+
+func newMockRepo(views ...*domain.SavedView) *mockRepo {
+	m := &mockRepo{views: map[string]*domain.SavedView{}}
+	for _, v := range views {
+		m.views[v.ID] = v
+	}
+	return m
+}
+
+func (m *mockRepo) Create(v *domain.SavedView) error {
+	m.views[v.ID] = v
+	return nil
+}
+func (m *mockRepo) GetByID(id string) (*domain.SavedView, error) {
+	v, ok := m.views[id]
+	if !ok {
+		return nil, domain.ErrViewNotFound
+	}
+	return v, nil
+}
+func (m *mockRepo) GetVisible(string, string) ([]*domain.SavedView, error) { return nil, nil }
+func (m *mockRepo) Update(v *domain.SavedView) error {
+	m.views[v.ID] = v
+	return nil
+}
+func (m *mockRepo) Delete(id string) error {
+	delete(m.views, id)
+	return nil
+}
+
+type mockLists struct{}
+
+func (mockLists) GetByID(id string) (*domain.TaskList, error) {
+	if id != "list-1" {
+		return nil, domain.ErrTaskListNotFound
+	}
+	return &domain.TaskList{ID: id}, nil
+}
+
+type mockTasks struct {
+	filter domain.TaskFilter
+}
+
+func (m *mockTasks) List(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error) {
+	m.filter = filter
+	return &domain.Page[*domain.Task]{Items: []*domain.Task{{ID: "1"}}}, nil
+}
+
+func TestService_Create_Validates(t *testing.T) {
+	s := NewService(newMockRepo(), mockLists{}, &mockTasks{})
+	cases := []struct {
+		input *domain.SavedView
+		err   string
+	}{
+		{&domain.SavedView{Name: " "}, "name cannot be empty"},
+		{&domain.SavedView{Name: "v", Filter: "owner = me"}, `invalid filter at position 1 near "owner": unknown field`},
+		{&domain.SavedView{Name: "v", Sort: "-description"}, "invalid sort field"},
+		{&domain.SavedView{Name: "v", GroupBy: "title"}, "invalid group by field"},
+		{&domain.SavedView{Name: "v", ListID: "missing"}, "task list not found"},
+	}
+	for _, c := range cases {
+		if _, err := s.Create("u1", c.input); err == nil || err.Error() != c.err {
+			t.Errorf("expected %q, got %v", c.err, err)
+		}
+	}
+}
+
+func TestService_Create_Success(t *testing.T) {
+	repo := newMockRepo()
+	s := NewService(repo, mockLists{}, &mockTasks{})
+	v, err := s.Create("u1", &domain.SavedView{Name: "Urgent", Filter: "priority = high", Sort: "-due_date", GroupBy: "status", ListID: "list-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.OwnerID != "u1" || v.ID == "" || repo.views[v.ID] == nil {
+		t.Errorf("unexpected view: %+v", v)
+	}
+}
+
+func TestService_GetByID_PrivateViewOfOtherUser(t *testing.T) {
+	s := NewService(newMockRepo(&domain.SavedView{ID: "v1", OwnerID: "u1", Name: "Mine"}), mockLists{}, &mockTasks{})
+	if _, err := s.GetByID("v1", "u2"); err == nil || err.Error() != "view not found" {
+		t.Errorf("expected view not found, got %v", err)
+	}
+	if _, err := s.GetByID("v1", "u1"); err != nil {
+		t.Errorf("expected the owner to see the view, got %v", err)
+	}
+}
+
+func TestService_SharedViewOnlyEditableByOwner(t *testing.T) {
+	repo := newMockRepo(&domain.SavedView{ID: "v1", OwnerID: "u1", Name: "Team", ListID: "list-1"})
+	s := NewService(repo, mockLists{}, &mockTasks{})
+	if _, err := s.GetByID("v1", "u2"); err != nil {
+		t.Errorf("expected shared view to be visible, got %v", err)
+	}
+	if _, err := s.Update("v1", "u2", &domain.SavedView{Name: "Mine"}); err == nil || err.Error() != "view not owned" {
+		t.Errorf("expected view not owned, got %v", err)
+	}
+	if err := s.Delete("v1", "u2"); err == nil || err.Error() != "view not owned" {
+		t.Errorf("expected view not owned, got %v", err)
+	}
+	if err := s.Delete("v1", "u1"); err != nil || repo.views["v1"] != nil {
+		t.Errorf("expected owner to delete the view, got %v", err)
+	}
+}
+
+func TestService_Run_ScopesSharedViewToList(t *testing.T) {
+	tasks := &mockTasks{}
+	s := NewService(newMockRepo(), mockLists{}, tasks)
+	page, err := s.Run(&domain.SavedView{ID: "v1", ListID: "list-1", Filter: "status = pending"}, domain.PageRequest{Sort: "created_at"})
+	if err != nil || len(page.Items) != 1 {
+		t.Fatalf("expected one task, got %v, err: %v", page, err)
+	}
+	if tasks.filter.ListID != "list-1" || !strings.Contains(tasks.filter.Expression, "pending") {
+		t.Errorf("unexpected filter: %+v", tasks.filter)
+	}
+}
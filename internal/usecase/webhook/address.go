@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// errAddressNotPublic is returned when a webhook URL points at an address of the service's
+// own network, such as loopback, a private range or the cloud metadata endpoint.
+var errAddressNotPublic = domain.NewInvalidError("url", "invalid_url", "invalid url: host must resolve to a public address")
+
+// isPublicIP reports whether deliveries may be sent to ip. Loopback, link-local (which
+// includes 169.254.169.254), private, unspecified and multicast addresses are refused.
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsPrivate() && !ip.IsUnspecified() && !ip.IsMulticast()
+}
+
+// checkHost rejects a webhook host that is, or resolves to, an address that is not public.
+// A host that cannot be resolved yet is accepted; the dialer of NewHTTPClient checks the
+// address again when a delivery is sent.
+func checkHost(host string, lookupIP func(host string) ([]net.IP, error)) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if !isPublicIP(ip) {
+			return errAddressNotPublic
+		}
+		return nil
+	}
+
+	ips, err := lookupIP(host)
+	if err != nil {
+		return nil
+	}
+	for _, ip := range ips {
+		if !isPublicIP(ip) {
+			return errAddressNotPublic
+		}
+	}
+	return nil
+}
+
+// dialPublicOnly is the Control hook of the delivery dialer. It runs after DNS resolution
+// with the address actually dialed, so a hostname that resolves to an internal address,
+// or a redirect to one, is refused as well.
+func dialPublicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return errors.New("webhook address is not public: " + host)
+	}
+	return nil
+}
+
+// NewHTTPClient returns the client deliveries are sent with: it gives up after timeout, never
+// goes through a proxy and only connects to public addresses.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: dialPublicOnly}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+// Headers of a delivery request.
+const (
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+const (
+	// MaxAttempts is how many times a delivery is tried before it becomes a dead letter.
+	MaxAttempts = 8
+	// firstRetryDelay is the wait after the first failed attempt; it doubles after each
+	// following one up to maxRetryDelay.
+	firstRetryDelay = 30 * time.Second
+	maxRetryDelay   = 6 * time.Hour
+	// deliveryLease is how long a claimed delivery is hidden from other workers, on top
+	// of the timeout of the HTTP client.
+	deliveryLease = time.Minute
+	// deliveryBatchSize is how many deliveries are claimed at a time.
+	deliveryBatchSize = 50
+	// maxErrorLength bounds the response body kept as the error of a failed attempt.
+	maxErrorLength = 1024
+)
+
+// Sign computes the signature of a delivery: the hex HMAC-SHA256, keyed with the webhook
+// secret, of the timestamp, a dot and the body, prefixed with "sha256=". Receivers verify
+// it by computing the same value from the X-Webhook-Timestamp header and the raw body.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryDelay is the wait before the attempt that follows the given failed attempt.
+func retryDelay(attempt int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// DeliverDue sends the deliveries that are due and returns how many were sent successfully.
+func (s *Service) DeliverDue() (delivered int, err error) {
+	defer utils.RecoverPanic("service", "DeliverDue", &err)
+
+	now := time.Now()
+	lease := deliveryLease + s.client.Timeout
+	deliveries, err := s.repo.ClaimDueDeliveries(now, now.Add(lease), deliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// A delivery that fails here stays claimed until its lease passes and is then retried,
+	// so the others of the batch are still sent.
+	for _, delivery := range deliveries {
+		webhook, err := s.repo.GetSubscription(delivery.SubscriptionID)
+		if err != nil {
+			logDeliveryError(delivery, "Failed to get webhook subscription", err)
+			continue
+		}
+
+		if err := s.deliver(webhook, delivery); err != nil {
+			logDeliveryError(delivery, "Failed to record webhook attempt", err)
+			continue
+		}
+		if delivery.Status == domain.DeliveryDelivered {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+func logDeliveryError(delivery *domain.WebhookDelivery, message string, err error) {
+	logger.GetLogger().WithFields(map[string]interface{}{
+		"layer":      "service",
+		"method":     "DeliverDue",
+		"webhookID":  delivery.SubscriptionID,
+		"deliveryID": delivery.ID,
+		"error":      err.Error(),
+	}).Error(message)
+}
+
+// deliver makes one attempt to send a delivery and records its outcome: delivered on a
+// 2xx response, retried later with exponential backoff otherwise, and dead after MaxAttempts.
+func (s *Service) deliver(webhook *domain.WebhookSubscription, delivery *domain.WebhookDelivery) error {
+	started := time.Now()
+	statusCode, sendErr := s.send(webhook, delivery, started)
+	finished := time.Now()
+
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+	delivery.UpdatedAt = finished
+	if sendErr != nil {
+		delivery.LastError = sendErr.Error()
+	}
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = domain.DeliveryDelivered
+		delivery.DeliveredAt = &finished
+	case delivery.Attempts >= MaxAttempts:
+		delivery.Status = domain.DeliveryDead
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"layer":      "service",
+			"method":     "deliver",
+			"webhookID":  webhook.ID,
+			"deliveryID": delivery.ID,
+			"error":      delivery.LastError,
+		}).Warn("Webhook delivery moved to dead letters")
+	default:
+		delivery.NextAttemptAt = finished.Add(retryDelay(delivery.Attempts))
+	}
+
+	return s.repo.RecordAttempt(delivery, &domain.WebhookAttempt{
+		ID:         uuid.New().String(),
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts,
+		StatusCode: statusCode,
+		Error:      delivery.LastError,
+		Duration:   finished.Sub(started),
+		CreatedAt:  started,
+	})
+}
+
+// send posts the payload of a delivery to the webhook URL. It returns the status code of
+// the response, or 0 when there was none, and an error unless the status is 2xx.
+func (s *Service) send(webhook *domain.WebhookSubscription, delivery *domain.WebhookDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "task-management-service-webhooks")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = resp.Body.Close() //nolint:errcheck
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorLength))
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+}
+
+// StartDeliveryWorker runs DeliverDue every interval in a background goroutine.
+// The returned function stops the worker.
+func (s *Service) StartDeliveryWorker(interval time.Duration) func() {
+	return utils.StartJob("webhook-delivery", interval, func() error {
+		_, err := s.DeliverDue()
+		return err
+	})
+}
@@ -0,0 +1,185 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// newDeliveryService returns a service whose repository holds one due delivery to the
+// given URL and records the attempts made to send it.
+func newDeliveryService(url string, delivery *domain.WebhookDelivery, attempts *[]*domain.WebhookAttempt) *Service {
+	return NewService(&mockRepo{
+		ClaimDueDeliveriesFn: func(now, leaseUntil time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+			if !leaseUntil.After(now) || limit <= 0 {
+				return nil, nil
+			}
+			return []*domain.WebhookDelivery{delivery}, nil
+		},
+		GetSubscriptionFn: func(id string) (*domain.WebhookSubscription, error) {
+			return &domain.WebhookSubscription{ID: id, URL: url, Secret: testSecret, Active: true}, nil
+		},
+		RecordAttemptFn: func(_ *domain.WebhookDelivery, a *domain.WebhookAttempt) error {
+			*attempts = append(*attempts, a)
+			return nil
+		},
+	}, &mockLists{}, &http.Client{Timeout: 5 * time.Second})
+}
+
+func TestService_DeliverDue_SignedDelivery(t *testing.T) {
+	payload := []byte(`{"id":"e1","type":"task.created"}`)
+	var received *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	delivery := &domain.WebhookDelivery{ID: "d1", SubscriptionID: "w1", EventType: domain.EventTaskCreated, Payload: payload, Status: domain.DeliveryPending}
+	var attempts []*domain.WebhookAttempt
+	s := newDeliveryService(receiver.URL, delivery, &attempts)
+
+	delivered, err := s.DeliverDue()
+	if err != nil || delivered != 1 {
+		t.Fatalf("expected 1 delivery, got %d, %v", delivered, err)
+	}
+	if string(body) != string(payload) {
+		t.Errorf("expected the stored payload, got %s", body)
+	}
+
+	timestamp, err := strconv.ParseInt(received.Header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid timestamp header: %v", err)
+	}
+	if received.Header.Get(SignatureHeader) != Sign(testSecret, timestamp, body) {
+		t.Errorf("signature does not verify: %s", received.Header.Get(SignatureHeader))
+	}
+	if received.Header.Get(EventHeader) != domain.EventTaskCreated || received.Header.Get(DeliveryHeader) != "d1" {
+		t.Errorf("unexpected headers: %v", received.Header)
+	}
+
+	if delivery.Status != domain.DeliveryDelivered || delivery.DeliveredAt == nil || delivery.Attempts != 1 {
+		t.Errorf("expected the delivery to be delivered, got %+v", delivery)
+	}
+	if len(attempts) != 1 || attempts[0].StatusCode != http.StatusNoContent || attempts[0].Error != "" {
+		t.Errorf("unexpected attempt log: %+v", attempts)
+	}
+}
+
+func TestService_DeliverDue_RetriesWithBackoff(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	delivery := &domain.WebhookDelivery{ID: "d1", SubscriptionID: "w1", Payload: []byte("{}"), Status: domain.DeliveryPending, Attempts: 2}
+	var attempts []*domain.WebhookAttempt
+	s := newDeliveryService(receiver.URL, delivery, &attempts)
+
+	before := time.Now()
+	delivered, err := s.DeliverDue()
+	if err != nil || delivered != 0 {
+		t.Fatalf("expected no delivery, got %d, %v", delivered, err)
+	}
+	if delivery.Status != domain.DeliveryPending || delivery.Attempts != 3 || delivery.LastStatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected a pending delivery after 3 attempts, got %+v", delivery)
+	}
+	if wait := delivery.NextAttemptAt.Sub(before); wait < 2*time.Minute || wait > 2*time.Minute+time.Second*5 {
+		t.Errorf("expected the third retry 2 minutes later, got %v", wait)
+	}
+	if len(attempts) != 1 || attempts[0].Attempt != 3 || attempts[0].Error != "unexpected status 503: boom\n" {
+		t.Errorf("unexpected attempt log: %+v", attempts)
+	}
+}
+
+func TestService_DeliverDue_DeadLetter(t *testing.T) {
+	delivery := &domain.WebhookDelivery{ID: "d1", SubscriptionID: "w1", Payload: []byte("{}"), Status: domain.DeliveryPending, Attempts: MaxAttempts - 1}
+	var attempts []*domain.WebhookAttempt
+	// Nothing listens on this address, so the request fails without a response.
+	s := newDeliveryService("http://127.0.0.1:1/hook", delivery, &attempts)
+
+	if _, err := s.DeliverDue(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delivery.Status != domain.DeliveryDead || delivery.Attempts != MaxAttempts || delivery.LastError == "" {
+		t.Errorf("expected a dead letter, got %+v", delivery)
+	}
+	if len(attempts) != 1 || attempts[0].StatusCode != 0 {
+		t.Errorf("unexpected attempt log: %+v", attempts)
+	}
+}
+
+func TestService_DeliverDue_ContinuesAfterError(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	deliveries := []*domain.WebhookDelivery{
+		{ID: "d1", SubscriptionID: "gone", Payload: []byte("{}"), Status: domain.DeliveryPending},
+		{ID: "d2", SubscriptionID: "w1", Payload: []byte("{}"), Status: domain.DeliveryPending},
+	}
+	s := NewService(&mockRepo{
+		ClaimDueDeliveriesFn: func(time.Time, time.Time, int) ([]*domain.WebhookDelivery, error) { return deliveries, nil },
+		GetSubscriptionFn: func(id string) (*domain.WebhookSubscription, error) {
+			if id == "gone" {
+				return nil, domain.ErrWebhookNotFound
+			}
+			return &domain.WebhookSubscription{ID: id, URL: receiver.URL, Secret: testSecret, Active: true}, nil
+		},
+		RecordAttemptFn: func(*domain.WebhookDelivery, *domain.WebhookAttempt) error { return nil },
+	}, &mockLists{}, &http.Client{Timeout: 5 * time.Second})
+
+	delivered, err := s.DeliverDue()
+	if err != nil || delivered != 1 {
+		t.Fatalf("expected the second delivery to be sent, got %d, %v", delivered, err)
+	}
+	if deliveries[1].Status != domain.DeliveryDelivered || deliveries[0].Attempts != 0 {
+		t.Errorf("unexpected deliveries: %+v, %+v", deliveries[0], deliveries[1])
+	}
+}
+
+func TestNewHTTPClient_RefusesInternalAddresses(t *testing.T) {
+	reached := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	resp, err := NewHTTPClient(5*time.Second).Post(receiver.URL, "application/json", strings.NewReader("{}"))
+	if err == nil {
+		_ = resp.Body.Close() //nolint:errcheck
+	}
+	if err == nil || !strings.Contains(err.Error(), "webhook address is not public") || reached {
+		t.Errorf("expected the loopback receiver to be refused, got %v, reached %v", err, reached)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		5:  8 * time.Minute,
+		20: 6 * time.Hour,
+	}
+	for attempt, expected := range cases {
+		if delay := retryDelay(attempt); delay != expected {
+			t.Errorf("attempt %d: expected %v, got %v", attempt, expected, delay)
+		}
+	}
+}
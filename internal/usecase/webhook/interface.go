@@ -0,0 +1,37 @@
+// Package webhook notifies other systems of task and task list changes by sending signed
+// HTTP requests to the URLs they subscribe, retrying failed deliveries with backoff.
+package webhook
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+// Repository defines the interface for webhook persistence operations.
+type Repository interface {
+	CreateSubscription(webhook *domain.WebhookSubscription) error
+	GetSubscription(id string) (*domain.WebhookSubscription, error)
+	GetSubscriptionsByOwner(ownerID string) ([]*domain.WebhookSubscription, error)
+	// GetSubscriptionsForEvent retrieves the active subscriptions that receive an event of
+	// the given type about the given list.
+	GetSubscriptionsForEvent(eventType, listID string) ([]*domain.WebhookSubscription, error)
+	UpdateSubscription(webhook *domain.WebhookSubscription) error
+	DeleteSubscription(id string) error
+
+	CreateDeliveries(deliveries []*domain.WebhookDelivery) error
+	// ClaimDueDeliveries retrieves up to limit pending deliveries due at now and postpones
+	// them until leaseUntil, so that no other worker sends them at the same time.
+	ClaimDueDeliveries(now, leaseUntil time.Time, limit int) ([]*domain.WebhookDelivery, error)
+	GetDeliveries(subscriptionID, status string, limit int) ([]*domain.WebhookDelivery, error)
+	GetDelivery(id string) (*domain.WebhookDelivery, error)
+	GetAttempts(deliveryID string) ([]*domain.WebhookAttempt, error)
+	// RecordAttempt stores an attempt together with the resulting state of its delivery.
+	RecordAttempt(delivery *domain.WebhookDelivery, attempt *domain.WebhookAttempt) error
+	ResetDelivery(id string, nextAttemptAt time.Time) error
+}
+
+// ListReader defines the task list operations needed to subscribe to the events of a list.
+type ListReader interface {
+	GetByID(id string) (*domain.TaskList, error)
+}
@@ -0,0 +1,290 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/pkg/utils"
+)
+
+const (
+	// minSecretLength is the shortest secret accepted when the client chooses it.
+	minSecretLength = 16
+	// maxDeliveriesListed is how many deliveries GetDeliveries returns.
+	maxDeliveriesListed = 100
+)
+
+// Service implements the webhook business logic operations.
+type Service struct {
+	repo     Repository
+	lists    ListReader
+	client   *http.Client
+	lookupIP func(host string) ([]net.IP, error)
+}
+
+// NewService creates and returns a new webhook Service instance. Deliveries are sent with
+// client, which should be one returned by NewHTTPClient so that they only reach public addresses.
+func NewService(repo Repository, lists ListReader, client *http.Client) *Service {
+	return &Service{
+		repo:     repo,
+		lists:    lists,
+		client:   client,
+		lookupIP: net.LookupIP,
+	}
+}
+
+// CreateSubscription subscribes a URL to events on behalf of the user. A secret is
+// generated when none is given.
+func (s *Service) CreateSubscription(userID string, input *domain.WebhookSubscription) (webhook *domain.WebhookSubscription, err error) {
+	defer utils.RecoverPanic("service", "CreateSubscription", &err)
+
+	secret := input.Secret
+	if secret == "" {
+		if secret, err = newSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	webhook = &domain.WebhookSubscription{
+		ID:         uuid.New().String(),
+		OwnerID:    userID,
+		URL:        strings.TrimSpace(input.URL),
+		EventTypes: input.EventTypes,
+		ListID:     input.ListID,
+		Secret:     secret,
+		Active:     true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.validate(webhook); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateSubscription(webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// GetSubscriptions retrieves the webhooks of the user.
+func (s *Service) GetSubscriptions(userID string) (webhooks []*domain.WebhookSubscription, err error) {
+	defer utils.RecoverPanic("service", "GetSubscriptions", &err)
+
+	return s.repo.GetSubscriptionsByOwner(userID)
+}
+
+// GetSubscription retrieves a webhook of the user.
+func (s *Service) GetSubscription(id, userID string) (webhook *domain.WebhookSubscription, err error) {
+	defer utils.RecoverPanic("service", "GetSubscription", &err)
+
+	return s.owned(id, userID)
+}
+
+// UpdateSubscription replaces the URL, event types, list and active flag of a webhook of
+// the user. The secret is only replaced when a new one is given.
+func (s *Service) UpdateSubscription(id, userID string, input *domain.WebhookSubscription) (webhook *domain.WebhookSubscription, err error) {
+	defer utils.RecoverPanic("service", "UpdateSubscription", &err)
+
+	webhook, err = s.owned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.URL = strings.TrimSpace(input.URL)
+	webhook.EventTypes = input.EventTypes
+	webhook.ListID = input.ListID
+	webhook.Active = input.Active
+	if input.Secret != "" {
+		webhook.Secret = input.Secret
+	}
+	webhook.UpdatedAt = time.Now()
+
+	if err := s.validate(webhook); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateSubscription(webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// DeleteSubscription removes a webhook of the user together with its deliveries.
+func (s *Service) DeleteSubscription(id, userID string) (err error) {
+	defer utils.RecoverPanic("service", "DeleteSubscription", &err)
+
+	if _, err := s.owned(id, userID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteSubscription(id)
+}
+
+// GetDeliveries retrieves the most recent deliveries of a webhook of the user, optionally
+// only those with the given status.
+func (s *Service) GetDeliveries(id, userID, status string) (deliveries []*domain.WebhookDelivery, err error) {
+	defer utils.RecoverPanic("service", "GetDeliveries", &err)
+
+	if status != "" && status != domain.DeliveryPending && status != domain.DeliveryDelivered && status != domain.DeliveryDead {
+		return nil, domain.NewInvalidError("status", "invalid_delivery_status", "invalid delivery status: must be pending, delivered or dead")
+	}
+
+	if _, err := s.owned(id, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetDeliveries(id, status, maxDeliveriesListed)
+}
+
+// GetDelivery retrieves a delivery of a webhook of the user with the log of its attempts.
+func (s *Service) GetDelivery(id, userID, deliveryID string) (delivery *domain.WebhookDelivery, attempts []*domain.WebhookAttempt, err error) {
+	defer utils.RecoverPanic("service", "GetDelivery", &err)
+
+	delivery, err = s.ownedDelivery(id, userID, deliveryID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attempts, err = s.repo.GetAttempts(deliveryID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return delivery, attempts, nil
+}
+
+// Redeliver sends a delivery again, whatever its status, with a new series of attempts.
+// It is how dead letters are retried once the receiver is fixed.
+func (s *Service) Redeliver(id, userID, deliveryID string) (delivery *domain.WebhookDelivery, err error) {
+	defer utils.RecoverPanic("service", "Redeliver", &err)
+
+	if _, err := s.ownedDelivery(id, userID, deliveryID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ResetDelivery(deliveryID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetDelivery(deliveryID)
+}
+
+// Publish queues the delivery of an event to every webhook subscribed to it. Publishing
+// the same event again does not queue a second delivery to a webhook.
+func (s *Service) Publish(event *domain.Event) (err error) {
+	defer utils.RecoverPanic("service", "Publish", &err)
+
+	webhooks, err := s.repo.GetSubscriptionsForEvent(event.Type, event.ListID)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	deliveries := make([]*domain.WebhookDelivery, len(webhooks))
+	for i, webhook := range webhooks {
+		deliveries[i] = &domain.WebhookDelivery{
+			ID:             uuid.New().String(),
+			SubscriptionID: webhook.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+			Status:         domain.DeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+	}
+
+	return s.repo.CreateDeliveries(deliveries)
+}
+
+func (s *Service) owned(id, userID string) (*domain.WebhookSubscription, error) {
+	webhook, err := s.repo.GetSubscription(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.OwnerID != userID {
+		return nil, domain.ErrWebhookNotOwned
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) ownedDelivery(id, userID, deliveryID string) (*domain.WebhookDelivery, error) {
+	if _, err := s.owned(id, userID); err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.repo.GetDelivery(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.SubscriptionID != id {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+
+	return delivery, nil
+}
+
+func (s *Service) validate(webhook *domain.WebhookSubscription) error {
+	target, err := url.Parse(webhook.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return domain.NewInvalidError("url", "invalid_url", "invalid url: must be an absolute http or https URL")
+	}
+	if err := checkHost(target.Hostname(), s.lookupIP); err != nil {
+		return err
+	}
+
+	known := map[string]bool{}
+	for _, eventType := range domain.EventTypes {
+		known[eventType] = true
+	}
+	for _, eventType := range webhook.EventTypes {
+		if !known[eventType] {
+			return domain.NewInvalidError("events", "invalid_event_type", "invalid event type: "+eventType)
+		}
+	}
+	if webhook.EventTypes == nil {
+		webhook.EventTypes = []string{}
+	}
+
+	if len(webhook.Secret) < minSecretLength {
+		return domain.NewInvalidError("secret", "invalid_secret", "secret must be at least 16 characters")
+	}
+
+	if webhook.ListID != "" {
+		if _, err := s.lists.GetByID(webhook.ListID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newSecret generates a random signing secret.
+func newSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
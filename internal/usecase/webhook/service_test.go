@@ -0,0 +1,257 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+)
+
+type mockRepo struct {
+	CreateSubscriptionFn       func(webhook *domain.WebhookSubscription) error
+	GetSubscriptionFn          func(id string) (*domain.WebhookSubscription, error)
+	GetSubscriptionsByOwnerFn  func(ownerID string) ([]*domain.WebhookSubscription, error)
+	GetSubscriptionsForEventFn func(eventType, listID string) ([]*domain.WebhookSubscription, error)
+	UpdateSubscriptionFn       func(webhook *domain.WebhookSubscription) error
+	DeleteSubscriptionFn       func(id string) error
+	CreateDeliveriesFn         func(deliveries []*domain.WebhookDelivery) error
+	ClaimDueDeliveriesFn       func(now, leaseUntil time.Time, limit int) ([]*domain.WebhookDelivery, error)
+	GetDeliveriesFn            func(subscriptionID, status string, limit int) ([]*domain.WebhookDelivery, error)
+	GetDeliveryFn              func(id string) (*domain.WebhookDelivery, error)
+	GetAttemptsFn              func(deliveryID string) ([]*domain.WebhookAttempt, error)
+	RecordAttemptFn            func(delivery *domain.WebhookDelivery, attempt *domain.WebhookAttempt) error
+	ResetDeliveryFn            func(id string, nextAttemptAt time.Time) error
+}
+
+func (m *mockRepo) CreateSubscription(w *domain.WebhookSubscription) error {
+	return m.CreateSubscriptionFn(w)
+}
+func (m *mockRepo) GetSubscription(id string) (*domain.WebhookSubscription, error) {
+	return m.GetSubscriptionFn(id)
+}
+func (m *mockRepo) GetSubscriptionsByOwner(ownerID string) ([]*domain.WebhookSubscription, error) {
+	return m.GetSubscriptionsByOwnerFn(ownerID)
+}
+func (m *mockRepo) GetSubscriptionsForEvent(eventType, listID string) ([]*domain.WebhookSubscription, error) {
+	return m.GetSubscriptionsForEventFn(eventType, listID)
+}
+func (m *mockRepo) UpdateSubscription(w *domain.WebhookSubscription) error {
+	return m.UpdateSubscriptionFn(w)
+}
+func (m *mockRepo) DeleteSubscription(id string) error { return m.DeleteSubscriptionFn(id) }
+func (m *mockRepo) CreateDeliveries(deliveries []*domain.WebhookDelivery) error {
+	return m.CreateDeliveriesFn(deliveries)
+}
+func (m *mockRepo) ClaimDueDeliveries(now, leaseUntil time.Time, limit int) ([]*domain.WebhookDelivery, error) {
+	return m.ClaimDueDeliveriesFn(now, leaseUntil, limit)
+}
+func (m *mockRepo) GetDeliveries(subscriptionID, status string, limit int) ([]*domain.WebhookDelivery, error) {
+	return m.GetDeliveriesFn(subscriptionID, status, limit)
+}
+func (m *mockRepo) GetDelivery(id string) (*domain.WebhookDelivery, error) {
+	return m.GetDeliveryFn(id)
+}
+func (m *mockRepo) GetAttempts(deliveryID string) ([]*domain.WebhookAttempt, error) {
+	return m.GetAttemptsFn(deliveryID)
+}
+func (m *mockRepo) RecordAttempt(d *domain.WebhookDelivery, a *domain.WebhookAttempt) error {
+	return m.RecordAttemptFn(d, a)
+}
+func (m *mockRepo) ResetDelivery(id string, nextAttemptAt time.Time) error {
+	return m.ResetDeliveryFn(id, nextAttemptAt)
+}
+
+type mockLists struct {
+	GetByIDFn func(id string) (*domain.TaskList, error)
+}
+
+func (m *mockLists) GetByID(id string) (*domain.TaskList, error) { return m.GetByIDFn(id) }
+
+func TestService_CreateSubscription_GeneratesSecret(t *testing.T) {
+	var created *domain.WebhookSubscription
+	s := NewService(&mockRepo{
+		CreateSubscriptionFn: func(w *domain.WebhookSubscription) error { created = w; return nil },
+	}, &mockLists{}, http.DefaultClient)
+
+	w, err := s.CreateSubscription("u1", &domain.WebhookSubscription{URL: " https://example.com/hook ", EventTypes: []string{domain.EventTaskCreated}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created != w || w.OwnerID != "u1" || w.URL != "https://example.com/hook" || !w.Active {
+		t.Errorf("unexpected webhook: %+v", w)
+	}
+	if len(w.Secret) != 64 {
+		t.Errorf("expected a generated secret, got %q", w.Secret)
+	}
+}
+
+func TestService_CreateSubscription_Invalid(t *testing.T) {
+	s := NewService(&mockRepo{}, &mockLists{
+		GetByIDFn: func(string) (*domain.TaskList, error) { return nil, domain.ErrTaskListNotFound },
+	}, http.DefaultClient)
+
+	cases := map[string]*domain.WebhookSubscription{
+		"invalid url":         {URL: "ftp://example.com"},
+		"invalid event type":  {URL: "https://example.com", EventTypes: []string{"task.exploded"}},
+		"secret must be":      {URL: "https://example.com", Secret: "short"},
+		"task list not found": {URL: "https://example.com", ListID: "missing"},
+	}
+	for prefix, input := range cases {
+		if _, err := s.CreateSubscription("u1", input); err == nil || !strings.HasPrefix(err.Error(), prefix) {
+			t.Errorf("expected %q error, got %v", prefix, err)
+		}
+	}
+}
+
+func TestService_CreateSubscription_InternalAddress(t *testing.T) {
+	s := NewService(&mockRepo{}, &mockLists{}, http.DefaultClient)
+	resolved := map[string]string{"localhost": "127.0.0.1", "internal.example.com": "10.0.0.7"}
+	s.lookupIP = func(host string) ([]net.IP, error) {
+		return []net.IP{net.ParseIP(resolved[host])}, nil
+	}
+
+	for _, url := range []string{
+		"http://127.0.0.1/hook",
+		"http://localhost:8080/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://192.168.1.10/hook",
+		"http://[::1]/hook",
+		"https://internal.example.com/hook",
+	} {
+		_, err := s.CreateSubscription("u1", &domain.WebhookSubscription{URL: url})
+		if !errors.Is(err, errAddressNotPublic) {
+			t.Errorf("expected %s to be refused, got %v", url, err)
+		}
+	}
+}
+
+func TestService_UpdateSubscription_NotOwned(t *testing.T) {
+	s := NewService(&mockRepo{
+		GetSubscriptionFn: func(string) (*domain.WebhookSubscription, error) {
+			return &domain.WebhookSubscription{ID: "w1", OwnerID: "u2"}, nil
+		},
+	}, &mockLists{}, http.DefaultClient)
+
+	_, err := s.UpdateSubscription("w1", "u1", &domain.WebhookSubscription{URL: "https://example.com"})
+	if err == nil || err.Error() != "webhook not owned" {
+		t.Errorf("expected not owned error, got %v", err)
+	}
+}
+
+func TestService_UpdateSubscription_KeepsSecret(t *testing.T) {
+	s := NewService(&mockRepo{
+		GetSubscriptionFn: func(string) (*domain.WebhookSubscription, error) {
+			return &domain.WebhookSubscription{ID: "w1", OwnerID: "u1", Secret: "0123456789abcdef0123"}, nil
+		},
+		UpdateSubscriptionFn: func(*domain.WebhookSubscription) error { return nil },
+	}, &mockLists{}, http.DefaultClient)
+
+	w, err := s.UpdateSubscription("w1", "u1", &domain.WebhookSubscription{URL: "https://example.com/new", Active: false})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Secret != "0123456789abcdef0123" || w.Active || w.URL != "https://example.com/new" {
+		t.Errorf("unexpected webhook: %+v", w)
+	}
+}
+
+func TestService_Publish_QueuesDeliveries(t *testing.T) {
+	var deliveries []*domain.WebhookDelivery
+	s := NewService(&mockRepo{
+		GetSubscriptionsForEventFn: func(eventType, listID string) ([]*domain.WebhookSubscription, error) {
+			if eventType != domain.EventTaskCreated || listID != "l1" {
+				t.Errorf("unexpected event lookup: %s %s", eventType, listID)
+			}
+			return []*domain.WebhookSubscription{{ID: "w1"}, {ID: "w2"}}, nil
+		},
+		CreateDeliveriesFn: func(d []*domain.WebhookDelivery) error { deliveries = d; return nil },
+	}, &mockLists{}, http.DefaultClient)
+
+	err := s.Publish(&domain.Event{ID: "e1", Sequence: 7, Type: domain.EventTaskCreated, ListID: "l1", Data: json.RawMessage(`{"id":"t1"}`)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(deliveries) != 2 || deliveries[0].SubscriptionID != "w1" || deliveries[1].SubscriptionID != "w2" {
+		t.Fatalf("expected one delivery per webhook, got %+v", deliveries)
+	}
+	if deliveries[0].EventID != "e1" || deliveries[1].EventID != "e1" || deliveries[0].Status != domain.DeliveryPending {
+		t.Errorf("expected pending deliveries of the same event, got %+v", deliveries)
+	}
+
+	var event domain.Event
+	if err := json.Unmarshal(deliveries[0].Payload, &event); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	var task domain.Task
+	if err := json.Unmarshal(event.Data, &task); err != nil {
+		t.Fatalf("invalid event data: %v", err)
+	}
+	if event.Type != domain.EventTaskCreated || event.ListID != "l1" || event.Sequence != 7 || task.ID != "t1" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+}
+
+func TestService_Publish_NoSubscriptions(t *testing.T) {
+	s := NewService(&mockRepo{
+		GetSubscriptionsForEventFn: func(string, string) ([]*domain.WebhookSubscription, error) { return nil, nil },
+	}, &mockLists{}, http.DefaultClient)
+
+	// CreateDeliveries is not set: it would panic if called.
+	if err := s.Publish(&domain.Event{ID: "e1", Type: domain.EventListDeleted, ListID: "l1"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestService_GetDeliveries_InvalidStatus(t *testing.T) {
+	s := NewService(&mockRepo{}, &mockLists{}, http.DefaultClient)
+	if _, err := s.GetDeliveries("w1", "u1", "lost"); err == nil || !strings.HasPrefix(err.Error(), "invalid delivery status") {
+		t.Errorf("expected invalid status error, got %v", err)
+	}
+}
+
+func TestService_Redeliver_OtherWebhook(t *testing.T) {
+	s := NewService(&mockRepo{
+		GetSubscriptionFn: func(string) (*domain.WebhookSubscription, error) {
+			return &domain.WebhookSubscription{ID: "w1", OwnerID: "u1"}, nil
+		},
+		GetDeliveryFn: func(string) (*domain.WebhookDelivery, error) {
+			return &domain.WebhookDelivery{ID: "d1", SubscriptionID: "w2"}, nil
+		},
+	}, &mockLists{}, http.DefaultClient)
+
+	_, err := s.Redeliver("w1", "u1", "d1")
+	if err == nil || err.Error() != "delivery not found" {
+		t.Errorf("expected delivery not found, got %v", err)
+	}
+}
+
+func TestService_Redeliver_ResetsDelivery(t *testing.T) {
+	reset := ""
+	s := NewService(&mockRepo{
+		GetSubscriptionFn: func(string) (*domain.WebhookSubscription, error) {
+			return &domain.WebhookSubscription{ID: "w1", OwnerID: "u1"}, nil
+		},
+		GetDeliveryFn: func(id string) (*domain.WebhookDelivery, error) {
+			status := domain.DeliveryDead
+			if reset != "" {
+				status = domain.DeliveryPending
+			}
+			return &domain.WebhookDelivery{ID: id, SubscriptionID: "w1", Status: status}, nil
+		},
+		ResetDeliveryFn: func(id string, _ time.Time) error { reset = id; return nil },
+	}, &mockLists{}, http.DefaultClient)
+
+	d, err := s.Redeliver("w1", "u1", "d1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reset != "d1" || d.Status != domain.DeliveryPending {
+		t.Errorf("expected d1 to be pending again, got %+v", d)
+	}
+}
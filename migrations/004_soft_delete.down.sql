@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_task_lists_deleted_at;
+DROP INDEX IF EXISTS idx_tasks_deleted_at;
+
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+DELETE FROM task_lists WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_list_id_fkey;
+ALTER TABLE tasks ADD CONSTRAINT tasks_list_id_fkey FOREIGN KEY (list_id) REFERENCES task_lists(id) ON DELETE CASCADE;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE task_lists DROP COLUMN IF EXISTS deleted_at;
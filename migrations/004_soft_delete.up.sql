@@ -0,0 +1,10 @@
+-- Soft delete para listas y tareas: las filas se marcan con deleted_at y se purgan por retención
+ALTER TABLE task_lists ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP NULL;
+
+-- El borrado de una lista ya no arrastra sus tareas de forma irreversible
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_list_id_fkey;
+ALTER TABLE tasks ADD CONSTRAINT tasks_list_id_fkey FOREIGN KEY (list_id) REFERENCES task_lists(id);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_task_lists_deleted_at ON task_lists(deleted_at);
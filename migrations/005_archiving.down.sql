@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_task_lists_archived_at;
+DROP INDEX IF EXISTS idx_tasks_archived_at;
+
+ALTER TABLE task_lists DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS archived_at;
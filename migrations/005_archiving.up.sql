@@ -0,0 +1,10 @@
+-- Archivado independiente del estado: las tareas y listas archivadas se ocultan por defecto
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP NULL;
+ALTER TABLE task_lists ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP NULL;
+
+-- Las tareas ya completadas toman updated_at como fecha de completado para el auto-archivado
+UPDATE tasks SET completed_at = updated_at WHERE status = 'completed' AND completed_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_archived_at ON tasks(archived_at);
+CREATE INDEX IF NOT EXISTS idx_task_lists_archived_at ON task_lists(archived_at);
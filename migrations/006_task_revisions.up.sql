@@ -0,0 +1,15 @@
+-- Historial inmutable de cambios de tareas: una fila por revisión con el snapshot completo
+CREATE TABLE IF NOT EXISTS task_revisions (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    changed_by TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMP NOT NULL,
+    changed_fields TEXT[] NOT NULL DEFAULT '{}',
+    list_id VARCHAR(36),
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(50) NOT NULL,
+    priority VARCHAR(20) NOT NULL,
+    UNIQUE (task_id, revision)
+);
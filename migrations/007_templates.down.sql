@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS task_template_items;
+DROP TABLE IF EXISTS task_templates;
+
+DROP INDEX IF EXISTS idx_tasks_parent_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS labels;
+ALTER TABLE tasks DROP COLUMN IF EXISTS parent_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_date;
@@ -0,0 +1,29 @@
+-- Campos de tarea capturados por las plantillas: fecha límite, subtareas y etiquetas
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_date TIMESTAMP NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS parent_id VARCHAR(36) NULL REFERENCES tasks(id);
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS labels TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks(parent_id);
+
+-- Plantillas reutilizables de lista con sus tareas; la fecha límite se guarda como desplazamiento en días
+CREATE TABLE IF NOT EXISTS task_templates (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS task_template_items (
+    id VARCHAR(36) PRIMARY KEY,
+    template_id VARCHAR(36) NOT NULL REFERENCES task_templates(id) ON DELETE CASCADE,
+    parent_item_id VARCHAR(36) NULL,
+    position INTEGER NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    priority VARCHAR(20) NOT NULL,
+    due_offset_days INTEGER NULL,
+    labels TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_template_items_template_id ON task_template_items(template_id);
@@ -0,0 +1,25 @@
+-- Sprints e hitos que agrupan tareas de distintas listas
+CREATE TABLE IF NOT EXISTS sprints (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    goal TEXT NOT NULL DEFAULT '',
+    kind VARCHAR(20) NOT NULL DEFAULT 'sprint',
+    status VARCHAR(20) NOT NULL DEFAULT 'planned',
+    starts_at TIMESTAMP NULL,
+    ends_at TIMESTAMP NULL,
+    started_at TIMESTAMP NULL,
+    closed_at TIMESTAMP NULL,
+    rolled_over INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS sprint_id VARCHAR(36) NULL REFERENCES sprints(id);
+CREATE INDEX IF NOT EXISTS idx_tasks_sprint_id ON tasks(sprint_id);
+
+-- Tareas comprometidas al iniciar el sprint, para comparar comprometido vs completado
+CREATE TABLE IF NOT EXISTS sprint_commitments (
+    sprint_id VARCHAR(36) NOT NULL REFERENCES sprints(id) ON DELETE CASCADE,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    PRIMARY KEY (sprint_id, task_id)
+);
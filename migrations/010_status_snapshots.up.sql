@@ -0,0 +1,12 @@
+-- Estado diario de cada tarea para burndown, burnup y flujo acumulado
+CREATE TABLE IF NOT EXISTS task_status_snapshots (
+    snapshot_date DATE NOT NULL,
+    task_id VARCHAR(36) NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    list_id VARCHAR(36),
+    sprint_id VARCHAR(36),
+    status VARCHAR(50) NOT NULL,
+    PRIMARY KEY (snapshot_date, task_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_status_snapshots_list ON task_status_snapshots(list_id, snapshot_date);
+CREATE INDEX IF NOT EXISTS idx_task_status_snapshots_sprint ON task_status_snapshots(sprint_id, snapshot_date);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_tasks_list_id_status;
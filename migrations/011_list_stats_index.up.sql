@@ -0,0 +1,2 @@
+-- Índice para el conteo por lista y estado usado en el porcentaje de completado
+CREATE INDEX IF NOT EXISTS idx_tasks_list_id_status ON tasks(list_id, status) WHERE deleted_at IS NULL;
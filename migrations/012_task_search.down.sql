@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_tasks_search_vector;
+DROP TRIGGER IF EXISTS tasks_search_vector_trigger ON tasks;
+DROP FUNCTION IF EXISTS tasks_search_vector_update();
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS task_search_vector(REGCONFIG, TEXT, TEXT);
+DROP TABLE IF EXISTS search_settings;
+DROP FUNCTION IF EXISTS immutable_unaccent(TEXT);
+DROP EXTENSION IF EXISTS unaccent;
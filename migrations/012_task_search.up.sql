@@ -0,0 +1,39 @@
+-- Búsqueda de texto completo en títulos y descripciones de tareas, sin distinguir acentos
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent no es IMMUTABLE; este envoltorio permite usarla en columnas indexadas
+CREATE OR REPLACE FUNCTION immutable_unaccent(TEXT) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT
+    AS $$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$;
+
+-- Idioma de la búsqueda (una sola fila); la aplicación lo sincroniza con SEARCH_LANGUAGE al arrancar
+CREATE TABLE IF NOT EXISTS search_settings (
+    singleton BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (singleton),
+    language REGCONFIG NOT NULL DEFAULT 'spanish'
+);
+
+INSERT INTO search_settings (singleton) VALUES (TRUE) ON CONFLICT DO NOTHING;
+
+-- El título pesa más que la descripción en el ranking
+CREATE OR REPLACE FUNCTION task_search_vector(lang REGCONFIG, title TEXT, description TEXT) RETURNS TSVECTOR
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT setweight(to_tsvector(lang, immutable_unaccent(COALESCE(title, ''))), 'A') ||
+                 setweight(to_tsvector(lang, immutable_unaccent(COALESCE(description, ''))), 'B') $$;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+CREATE OR REPLACE FUNCTION tasks_search_vector_update() RETURNS TRIGGER
+    LANGUAGE plpgsql AS $$
+BEGIN
+    NEW.search_vector := task_search_vector((SELECT language FROM search_settings), NEW.title, NEW.description);
+    RETURN NEW;
+END
+$$;
+
+DROP TRIGGER IF EXISTS tasks_search_vector_trigger ON tasks;
+CREATE TRIGGER tasks_search_vector_trigger BEFORE INSERT OR UPDATE OF title, description ON tasks
+    FOR EACH ROW EXECUTE FUNCTION tasks_search_vector_update();
+
+UPDATE tasks SET search_vector = task_search_vector((SELECT language FROM search_settings), title, description);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_search_vector ON tasks USING GIN (search_vector);
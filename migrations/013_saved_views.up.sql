@@ -0,0 +1,16 @@
+-- Vistas guardadas: filtro, orden, agrupación y campos visibles, privadas o compartidas con una lista
+CREATE TABLE IF NOT EXISTS saved_views (
+    id VARCHAR(36) PRIMARY KEY,
+    owner_id TEXT NOT NULL,
+    list_id VARCHAR(36) NULL REFERENCES task_lists(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    filter TEXT NOT NULL DEFAULT '',
+    sort VARCHAR(50) NOT NULL DEFAULT '',
+    group_by VARCHAR(50) NOT NULL DEFAULT '',
+    fields TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_views_owner_id ON saved_views(owner_id);
+CREATE INDEX IF NOT EXISTS idx_saved_views_list_id ON saved_views(list_id);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS tasks_version_trigger ON tasks;
+DROP TRIGGER IF EXISTS task_lists_version_trigger ON task_lists;
+DROP FUNCTION IF EXISTS bump_version();
+ALTER TABLE tasks DROP COLUMN IF EXISTS version;
+ALTER TABLE task_lists DROP COLUMN IF EXISTS version;
@@ -0,0 +1,22 @@
+-- Número de versión de tareas y listas para control de concurrencia optimista (ETag / If-Match)
+ALTER TABLE task_lists ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- Cada modificación incrementa la versión, venga de la API o de procesos internos (archivado, papelera, sprints)
+CREATE OR REPLACE FUNCTION bump_version() RETURNS TRIGGER
+    LANGUAGE plpgsql AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END
+$$;
+
+DROP TRIGGER IF EXISTS task_lists_version_trigger ON task_lists;
+CREATE TRIGGER task_lists_version_trigger
+    BEFORE UPDATE OF name, description, archived_at, deleted_at ON task_lists
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
+
+DROP TRIGGER IF EXISTS tasks_version_trigger ON tasks;
+CREATE TRIGGER tasks_version_trigger
+    BEFORE UPDATE OF list_id, title, description, status, priority, archived_at, deleted_at, due_date, parent_id, labels, sprint_id ON tasks
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS tasks_tombstone_trigger ON tasks;
+DROP TRIGGER IF EXISTS task_lists_tombstone_trigger ON task_lists;
+DROP FUNCTION IF EXISTS record_sync_tombstone();
+DROP TABLE IF EXISTS sync_tombstones;
+DROP TRIGGER IF EXISTS tasks_change_seq_trigger ON tasks;
+DROP TRIGGER IF EXISTS task_lists_change_seq_trigger ON task_lists;
+DROP FUNCTION IF EXISTS bump_change_sequence();
+ALTER TABLE tasks DROP COLUMN IF EXISTS change_seq;
+ALTER TABLE task_lists DROP COLUMN IF EXISTS change_seq;
+DROP FUNCTION IF EXISTS next_change_sequence();
+DROP TABLE IF EXISTS sync_state;
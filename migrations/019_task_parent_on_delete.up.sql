@@ -0,0 +1,4 @@
+-- La purga de la papelera borra tareas que pueden ser padre de subtareas vivas o eliminadas
+-- más tarde; al borrar el padre la subtarea queda suelta en vez de hacer fallar la purga
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_parent_id_fkey;
+ALTER TABLE tasks ADD CONSTRAINT tasks_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES tasks(id) ON DELETE SET NULL;
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS tasks_revision_trigger ON tasks;
+DROP FUNCTION IF EXISTS record_task_revision();
+DROP INDEX IF EXISTS idx_task_revisions_task_version;
+ALTER TABLE task_revisions DROP COLUMN IF EXISTS deleted;
+ALTER TABLE task_revisions DROP COLUMN IF EXISTS archived;
+ALTER TABLE task_revisions DROP COLUMN IF EXISTS sprint_id;
+ALTER TABLE task_revisions DROP COLUMN IF EXISTS task_version;
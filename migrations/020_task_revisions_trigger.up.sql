@@ -0,0 +1,51 @@
+-- Las revisiones del historial se guardan con un trigger, en la misma transacción que el cambio
+-- de la tarea, venga de la API, de la sincronización o de procesos internos (archivado, papelera,
+-- sprints, plantillas, checklist). La capa de transporte solo atribuye después la revisión al
+-- usuario que hizo el cambio, buscándola por la versión de la tarea
+ALTER TABLE task_revisions ADD COLUMN IF NOT EXISTS task_version INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE task_revisions ADD COLUMN IF NOT EXISTS sprint_id VARCHAR(36);
+ALTER TABLE task_revisions ADD COLUMN IF NOT EXISTS archived BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE task_revisions ADD COLUMN IF NOT EXISTS deleted BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_task_revisions_task_version ON task_revisions(task_id, task_version);
+
+CREATE OR REPLACE FUNCTION record_task_revision() RETURNS TRIGGER
+    LANGUAGE plpgsql AS $$
+DECLARE
+    previous tasks%ROWTYPE;
+    changed TEXT[] := '{}';
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        previous := OLD;
+    END IF;
+
+    IF COALESCE(NEW.list_id, '') <> COALESCE(previous.list_id, '') THEN changed := changed || 'list_id'::TEXT; END IF;
+    IF NEW.title <> COALESCE(previous.title, '') THEN changed := changed || 'title'::TEXT; END IF;
+    IF NEW.description <> COALESCE(previous.description, '') THEN changed := changed || 'description'::TEXT; END IF;
+    IF NEW.status <> COALESCE(previous.status, '') THEN changed := changed || 'status'::TEXT; END IF;
+    IF NEW.priority <> COALESCE(previous.priority, '') THEN changed := changed || 'priority'::TEXT; END IF;
+    IF COALESCE(NEW.sprint_id, '') <> COALESCE(previous.sprint_id, '') THEN changed := changed || 'sprint_id'::TEXT; END IF;
+    IF (NEW.archived_at IS NOT NULL) <> (previous.archived_at IS NOT NULL) THEN changed := changed || 'archived'::TEXT; END IF;
+    IF (NEW.deleted_at IS NOT NULL) <> (previous.deleted_at IS NOT NULL) THEN changed := changed || 'deleted'::TEXT; END IF;
+
+    IF cardinality(changed) = 0 THEN
+        RETURN NULL;
+    END IF;
+
+    -- La escritura que dispara el trigger tiene bloqueada la fila de la tarea hasta el commit,
+    -- así que dos transacciones nunca calculan el mismo número de revisión para una tarea
+    INSERT INTO task_revisions (id, task_id, revision, task_version, changed_at, changed_fields,
+                                list_id, title, description, status, priority, sprint_id, archived, deleted)
+    SELECT gen_random_uuid()::TEXT, NEW.id, COALESCE(MAX(revision), 0) + 1, NEW.version, NOW(), changed,
+           NEW.list_id, NEW.title, NEW.description, NEW.status, NEW.priority, NEW.sprint_id,
+           NEW.archived_at IS NOT NULL, NEW.deleted_at IS NOT NULL
+    FROM task_revisions WHERE task_id = NEW.id;
+
+    RETURN NULL;
+END
+$$;
+
+DROP TRIGGER IF EXISTS tasks_revision_trigger ON tasks;
+CREATE TRIGGER tasks_revision_trigger
+    AFTER INSERT OR UPDATE OF list_id, title, description, status, priority, sprint_id, archived_at, deleted_at ON tasks
+    FOR EACH ROW EXECUTE FUNCTION record_task_revision();
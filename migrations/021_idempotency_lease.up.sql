@@ -0,0 +1,6 @@
+-- Plazo de la reserva de una clave en curso: si la petición que la reservó no termina ni la
+-- libera (por ejemplo porque el proceso murió), un reintento puede retomarla al vencer el plazo
+-- en vez de esperar a que caduque la clave
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP NULL;
+
+UPDATE idempotency_keys SET locked_until = created_at WHERE status_code IS NULL AND locked_until IS NULL;
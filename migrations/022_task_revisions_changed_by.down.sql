@@ -0,0 +1,35 @@
+CREATE OR REPLACE FUNCTION record_task_revision() RETURNS TRIGGER
+    LANGUAGE plpgsql AS $$
+DECLARE
+    previous tasks%ROWTYPE;
+    changed TEXT[] := '{}';
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        previous := OLD;
+    END IF;
+
+    IF COALESCE(NEW.list_id, '') <> COALESCE(previous.list_id, '') THEN changed := changed || 'list_id'::TEXT; END IF;
+    IF NEW.title <> COALESCE(previous.title, '') THEN changed := changed || 'title'::TEXT; END IF;
+    IF NEW.description <> COALESCE(previous.description, '') THEN changed := changed || 'description'::TEXT; END IF;
+    IF NEW.status <> COALESCE(previous.status, '') THEN changed := changed || 'status'::TEXT; END IF;
+    IF NEW.priority <> COALESCE(previous.priority, '') THEN changed := changed || 'priority'::TEXT; END IF;
+    IF COALESCE(NEW.sprint_id, '') <> COALESCE(previous.sprint_id, '') THEN changed := changed || 'sprint_id'::TEXT; END IF;
+    IF (NEW.archived_at IS NOT NULL) <> (previous.archived_at IS NOT NULL) THEN changed := changed || 'archived'::TEXT; END IF;
+    IF (NEW.deleted_at IS NOT NULL) <> (previous.deleted_at IS NOT NULL) THEN changed := changed || 'deleted'::TEXT; END IF;
+
+    IF cardinality(changed) = 0 THEN
+        RETURN NULL;
+    END IF;
+
+    -- La escritura que dispara el trigger tiene bloqueada la fila de la tarea hasta el commit,
+    -- así que dos transacciones nunca calculan el mismo número de revisión para una tarea
+    INSERT INTO task_revisions (id, task_id, revision, task_version, changed_at, changed_fields,
+                                list_id, title, description, status, priority, sprint_id, archived, deleted)
+    SELECT gen_random_uuid()::TEXT, NEW.id, COALESCE(MAX(revision), 0) + 1, NEW.version, NOW(), changed,
+           NEW.list_id, NEW.title, NEW.description, NEW.status, NEW.priority, NEW.sprint_id,
+           NEW.archived_at IS NOT NULL, NEW.deleted_at IS NOT NULL
+    FROM task_revisions WHERE task_id = NEW.id;
+
+    RETURN NULL;
+END
+$$;
@@ -0,0 +1,72 @@
+// Package dataloader batches and caches the loads of a single request, so that resolving
+// a field for many objects costs one query instead of one per object.
+package dataloader
+
+// BatchFunc loads the values of several keys at once. Keys missing from the result have
+// no value; the error, if any, is returned to every load of the batch.
+type BatchFunc[K comparable, V any] func(keys []K) (map[K]V, error)
+
+// Loader collects the keys requested with Load and fetches them in one batch when the
+// first returned thunk is called. Results are cached for the lifetime of the loader, which
+// is meant to be one request. A Loader is not safe for concurrent use.
+type Loader[K comparable, V any] struct {
+	fetch   BatchFunc[K, V]
+	pending []K
+	queued  map[K]bool
+	values  map[K]V
+	errs    map[K]error
+}
+
+// New creates a Loader that fetches its batches with fetch.
+func New[K comparable, V any](fetch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:  fetch,
+		queued: map[K]bool{},
+		values: map[K]V{},
+		errs:   map[K]error{},
+	}
+}
+
+// Load queues key and returns a thunk returning its value. The value is the zero value
+// of V and ok is false when the batch returned nothing for the key.
+func (l *Loader[K, V]) Load(key K) func() (value V, ok bool, err error) {
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+
+	return func() (V, bool, error) {
+		if len(l.pending) > 0 {
+			l.dispatch()
+		}
+		if err := l.errs[key]; err != nil {
+			var zero V
+			return zero, false, err
+		}
+		value, ok := l.values[key]
+		return value, ok, nil
+	}
+}
+
+// Prime stores the value of a key that was loaded some other way, so that loading it does
+// not fetch it again.
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.queued[key] = true
+	l.values[key] = value
+}
+
+func (l *Loader[K, V]) dispatch() {
+	keys := l.pending
+	l.pending = nil
+
+	values, err := l.fetch(keys)
+	for _, key := range keys {
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		if value, ok := values[key]; ok {
+			l.values[key] = value
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package dataloader
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLoader_BatchesQueuedKeys(t *testing.T) {
+	var batches [][]string
+	loader := New(func(keys []string) (map[string]int, error) {
+		batches = append(batches, keys)
+		values := map[string]int{}
+		for _, key := range keys {
+			if key != "missing" {
+				values[key] = len(key)
+			}
+		}
+		return values, nil
+	})
+
+	a := loader.Load("a")
+	bb := loader.Load("bb")
+	again := loader.Load("a")
+	missing := loader.Load("missing")
+
+	if v, ok, err := bb(); v != 2 || !ok || err != nil {
+		t.Errorf("expected 2, got %d %v %v", v, ok, err)
+	}
+	if v, ok, _ := a(); v != 1 || !ok {
+		t.Errorf("expected 1, got %d", v)
+	}
+	if v, _, _ := again(); v != 1 {
+		t.Errorf("expected the cached value, got %d", v)
+	}
+	if _, ok, err := missing(); ok || err != nil {
+		t.Errorf("expected no value for a missing key, got %v %v", ok, err)
+	}
+
+	loader.Prime("primed", 7)
+	if v, _, _ := loader.Load("primed")(); v != 7 {
+		t.Errorf("expected the primed value, got %d", v)
+	}
+	if v, _, _ := loader.Load("ccc")(); v != 3 {
+		t.Errorf("expected 3, got %d", v)
+	}
+
+	want := [][]string{{"a", "bb", "missing"}, {"ccc"}}
+	if !reflect.DeepEqual(batches, want) {
+		t.Errorf("expected batches %v, got %v", want, batches)
+	}
+}
+
+func TestLoader_Error(t *testing.T) {
+	loader := New(func([]int) (map[int]string, error) {
+		return nil, errors.New("db down")
+	})
+
+	first, second := loader.Load(1), loader.Load(2)
+	for _, thunk := range []func() (string, bool, error){first, second} {
+		if _, _, err := thunk(); err == nil || err.Error() != "db down" {
+			t.Errorf("expected the batch error, got %v", err)
+		}
+	}
+}
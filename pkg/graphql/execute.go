@@ -0,0 +1,772 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Params is a request to execute against a schema.
+type Params struct {
+	Schema        *Schema
+	Query         string
+	OperationName string
+	Variables     map[string]interface{}
+	Context       context.Context
+	// MaxDepth rejects operations whose fields are nested deeper, 0 disables the limit.
+	MaxDepth int
+	// MaxComplexity rejects operations whose estimated cost is higher, 0 disables the limit.
+	MaxComplexity int
+	// QueryOnly rejects mutations, for requests that must not have side effects.
+	QueryOnly bool
+}
+
+// Result is the result of a request. Executed is false when the request was rejected
+// before execution, in which case the response has no data member.
+type Result struct {
+	Data     interface{}
+	Errors   []*Error
+	Executed bool
+}
+
+// MarshalJSON encodes the result as a GraphQL response.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	response := map[string]interface{}{}
+	if r.Executed {
+		response["data"] = r.Data
+	}
+	if len(r.Errors) > 0 {
+		response["errors"] = r.Errors
+	}
+	return json.Marshal(response)
+}
+
+// Execute parses, validates and executes a request. Request errors (syntax, validation,
+// variables and limits) are returned without executing anything; field errors null the
+// field and are reported alongside the data.
+func Execute(p Params) *Result {
+	doc, err := Parse(p.Query)
+	if err != nil {
+		return &Result{Errors: []*Error{toError(err)}}
+	}
+
+	op, err := selectOperation(doc, p.OperationName)
+	if err != nil {
+		return &Result{Errors: []*Error{toError(err)}}
+	}
+
+	var root *Object
+	switch {
+	case op.Type == "query":
+		root = p.Schema.Query
+	case op.Type == "mutation" && p.QueryOnly:
+		return &Result{Errors: []*Error{{Message: "mutations are not allowed in this request", Locations: []Location{op.Location}}}}
+	case op.Type == "mutation" && p.Schema.Mutation != nil:
+		root = p.Schema.Mutation
+	default:
+		return &Result{Errors: []*Error{{Message: fmt.Sprintf("%s operations are not supported", op.Type), Locations: []Location{op.Location}}}}
+	}
+
+	vars, defined, errs := coerceVariables(p.Schema, op, p.Variables)
+	if len(errs) > 0 {
+		return &Result{Errors: errs}
+	}
+
+	a := &analyzer{
+		doc: doc, vars: vars, defined: defined, maxComplexity: p.MaxComplexity,
+		spreading: map[string]bool{}, fragments: map[string]fragmentCost{},
+	}
+	depth, _ := a.selectionSet(root, op.SelectionSet)
+	if len(a.errors) > 0 {
+		return &Result{Errors: a.errors}
+	}
+	if p.MaxDepth > 0 && depth > p.MaxDepth {
+		return &Result{Errors: []*Error{{Message: fmt.Sprintf("query depth %d exceeds the maximum of %d", depth, p.MaxDepth)}}}
+	}
+	if a.tooComplex {
+		return &Result{Errors: []*Error{{Message: fmt.Sprintf("query complexity exceeds the maximum of %d", p.MaxComplexity)}}}
+	}
+
+	ctx := p.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	e := &executor{ctx: ctx, doc: doc, vars: vars, defined: defined}
+	result := &Result{Executed: true}
+	data := newOrderedMap()
+	result.Data = data
+	nullify := func() { result.Data = nil }
+
+	if op.Type == "mutation" {
+		// Mutation fields run one after the other, each completed before the next starts.
+		keys, fields := e.collectFields(root, op.SelectionSet)
+		for _, key := range keys {
+			selections := make([]Selection, len(fields[key]))
+			for i, f := range fields[key] {
+				selections[i] = f
+			}
+			e.execute([]*pendingObject{{typ: root, selections: selections, out: data, nullify: nullify}})
+		}
+	} else {
+		e.execute([]*pendingObject{{typ: root, selections: op.SelectionSet, out: data, nullify: nullify}})
+	}
+
+	result.Errors = e.errors
+	return result
+}
+
+func toError(err error) *Error {
+	if gqlErr, ok := err.(*Error); ok {
+		return gqlErr
+	}
+	return &Error{Message: err.Error()}
+}
+
+func selectOperation(doc *Document, name string) (*Operation, error) {
+	if name != "" {
+		for _, op := range doc.Operations {
+			if op.Name == name {
+				return op, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown operation %q", name)
+	}
+
+	if len(doc.Operations) > 1 {
+		return nil, fmt.Errorf("an operation name is required when the document has several operations")
+	}
+	return doc.Operations[0], nil
+}
+
+// coerceVariables validates the provided variables against their definitions and applies
+// the defaults. It also returns the set of defined variables.
+func coerceVariables(schema *Schema, op *Operation, provided map[string]interface{}) (map[string]interface{}, map[string]bool, []*Error) {
+	types := schema.types()
+	vars := map[string]interface{}{}
+	defined := map[string]bool{}
+	var errs []*Error
+
+	for _, def := range op.Variables {
+		fail := func(format string, args ...interface{}) {
+			errs = append(errs, &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{def.Location}})
+		}
+
+		if defined[def.Name] {
+			fail("variable \"$%s\" is defined more than once", def.Name)
+			continue
+		}
+		defined[def.Name] = true
+
+		t, err := inputType(types, def.Type)
+		if err != nil {
+			fail("variable \"$%s\": %v", def.Name, err)
+			continue
+		}
+
+		raw, ok := provided[def.Name]
+		if !ok && def.Default != nil {
+			raw, _, err = valueFromAST(def.Default, nil, nil)
+			if err != nil {
+				fail("variable \"$%s\": %v", def.Name, err)
+				continue
+			}
+			ok = true
+		}
+		if !ok {
+			if _, required := t.(*NonNull); required {
+				fail("variable \"$%s\" of required type %q was not provided", def.Name, t.String())
+			}
+			continue
+		}
+
+		value, err := coerceInput(t, raw)
+		if err != nil {
+			fail("variable \"$%s\": %v", def.Name, err)
+			continue
+		}
+		vars[def.Name] = value
+	}
+
+	return vars, defined, errs
+}
+
+func inputType(types map[string]Type, ref *TypeRef) (Type, error) {
+	var t Type
+	if ref.Elem != nil {
+		elem, err := inputType(types, ref.Elem)
+		if err != nil {
+			return nil, err
+		}
+		t = NewList(elem)
+	} else {
+		named, ok := types[ref.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %q", ref.Name)
+		}
+		if _, ok := named.(*Scalar); !ok {
+			return nil, fmt.Errorf("type %q is not an input type", ref.Name)
+		}
+		t = named
+	}
+
+	if ref.NonNull {
+		return NewNonNull(t), nil
+	}
+	return t, nil
+}
+
+// valueFromAST converts a literal to a JSON-like value, replacing variables with their
+// coerced values. It reports false when the value is a variable that was not provided.
+func valueFromAST(v Value, vars map[string]interface{}, defined map[string]bool) (interface{}, bool, error) {
+	switch v := v.(type) {
+	case *Variable:
+		if !defined[v.Name] {
+			return nil, false, fmt.Errorf("variable \"$%s\" is not defined", v.Name)
+		}
+		value, ok := vars[v.Name]
+		return value, ok, nil
+	case *IntValue:
+		return v.Value, true, nil
+	case *FloatValue:
+		return v.Value, true, nil
+	case *StringValue:
+		return v.Value, true, nil
+	case *BooleanValue:
+		return v.Value, true, nil
+	case *EnumValue:
+		return v.Value, true, nil
+	case *ListValue:
+		list := make([]interface{}, len(v.Values))
+		for i, item := range v.Values {
+			value, _, err := valueFromAST(item, vars, defined)
+			if err != nil {
+				return nil, false, err
+			}
+			list[i] = value
+		}
+		return list, true, nil
+	case *ObjectValue:
+		object := make(map[string]interface{}, len(v.Fields))
+		for _, field := range v.Fields {
+			value, ok, err := valueFromAST(field.Value, vars, defined)
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				object[field.Name] = value
+			}
+		}
+		return object, true, nil
+	}
+	return nil, true, nil
+}
+
+// coerceInput validates an input value against its type. A single value is accepted where
+// a list is expected and wrapped in a list.
+func coerceInput(t Type, value interface{}) (interface{}, error) {
+	if nonNull, ok := t.(*NonNull); ok {
+		if value == nil {
+			return nil, fmt.Errorf("expected a non-null value of type %q", t.String())
+		}
+		return coerceInput(nonNull.OfType, value)
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	switch t := t.(type) {
+	case *List:
+		items, ok := value.([]interface{})
+		if !ok {
+			items = []interface{}{value}
+		}
+		coerced := make([]interface{}, len(items))
+		for i, item := range items {
+			v, err := coerceInput(t.OfType, item)
+			if err != nil {
+				return nil, err
+			}
+			coerced[i] = v
+		}
+		return coerced, nil
+	case *Scalar:
+		return t.ParseValue(value)
+	}
+	return nil, fmt.Errorf("type %q is not an input type", t.String())
+}
+
+// coerceArguments returns the arguments of a field with their defaults applied.
+func coerceArguments(parent *Object, field *Field, nodes []*ArgumentNode, vars map[string]interface{}, defined map[string]bool) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	given := map[string]Value{}
+	for _, node := range nodes {
+		if argument(field.Args, node.Name) == nil {
+			return nil, fmt.Errorf("unknown argument %q on field \"%s.%s\"", node.Name, parent.Name, field.Name)
+		}
+		given[node.Name] = node.Value
+	}
+
+	for _, arg := range field.Args {
+		var value interface{}
+		present := false
+		if node, ok := given[arg.Name]; ok {
+			var err error
+			if value, present, err = valueFromAST(node, vars, defined); err != nil {
+				return nil, err
+			}
+		}
+
+		if !present {
+			if arg.Default != nil {
+				args[arg.Name] = arg.Default
+			} else if _, required := arg.Type.(*NonNull); required {
+				return nil, fmt.Errorf("argument %q of type %q is required on field \"%s.%s\"", arg.Name, arg.Type.String(), parent.Name, field.Name)
+			}
+			continue
+		}
+
+		coerced, err := coerceInput(arg.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("argument %q on field \"%s.%s\": %v", arg.Name, parent.Name, field.Name, err)
+		}
+		args[arg.Name] = coerced
+	}
+
+	return args, nil
+}
+
+func argument(args []*Argument, name string) *Argument {
+	for _, arg := range args {
+		if arg.Name == name {
+			return arg
+		}
+	}
+	return nil
+}
+
+// skipped evaluates the @include and @skip directives of a selection.
+func skipped(directives []*Directive, vars map[string]interface{}, defined map[string]bool) (bool, error) {
+	for _, d := range directives {
+		if d.Name != "include" && d.Name != "skip" {
+			return false, fmt.Errorf("unknown directive \"@%s\"", d.Name)
+		}
+		if len(d.Arguments) != 1 || d.Arguments[0].Name != "if" {
+			return false, fmt.Errorf("directive \"@%s\" requires a single \"if\" argument", d.Name)
+		}
+		value, _, err := valueFromAST(d.Arguments[0].Value, vars, defined)
+		if err != nil {
+			return false, err
+		}
+		condition, ok := value.(bool)
+		if !ok {
+			return false, fmt.Errorf("argument \"if\" of directive \"@%s\" must be a Boolean", d.Name)
+		}
+		if condition == (d.Name == "skip") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// analyzer validates the selections of an operation and measures its depth and complexity.
+// The cost of each fragment is measured once, however many times it is spread, and the
+// analysis stops as soon as a selection set costs more than maxComplexity.
+type analyzer struct {
+	doc           *Document
+	vars          map[string]interface{}
+	defined       map[string]bool
+	maxComplexity int
+	spreading     map[string]bool
+	fragments     map[string]fragmentCost
+	tooComplex    bool
+	errors        []*Error
+}
+
+// fragmentCost is the depth and complexity of the selections of a fragment.
+type fragmentCost struct {
+	depth, complexity int
+}
+
+func (a *analyzer) fail(loc Location, format string, args ...interface{}) {
+	a.errors = append(a.errors, &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{loc}})
+}
+
+func (a *analyzer) directives(loc Location, directives []*Directive) {
+	if _, err := skipped(directives, a.vars, a.defined); err != nil {
+		a.fail(loc, "%v", err)
+	}
+}
+
+// selectionSet returns the depth and the complexity of the selections. Skipped selections
+// are counted too.
+func (a *analyzer) selectionSet(parent *Object, selections []Selection) (depth, complexity int) {
+	for _, selection := range selections {
+		if a.tooComplex {
+			return depth, complexity
+		}
+
+		var d, c int
+		switch s := selection.(type) {
+		case *FieldNode:
+			a.directives(s.Location, s.Directives)
+			d, c = a.field(parent, s)
+		case *FragmentSpread:
+			a.directives(s.Location, s.Directives)
+			fragment, ok := a.doc.Fragments[s.Name]
+			switch {
+			case !ok:
+				a.fail(s.Location, "unknown fragment %q", s.Name)
+				continue
+			case a.spreading[s.Name]:
+				a.fail(s.Location, "fragment %q spreads itself", s.Name)
+				continue
+			case fragment.TypeCondition != parent.Name:
+				a.fail(s.Location, "fragment %q cannot be spread on type %q", s.Name, parent.Name)
+				continue
+			}
+			d, c = a.fragment(parent, fragment)
+		case *InlineFragment:
+			a.directives(s.Location, s.Directives)
+			if s.TypeCondition != "" && s.TypeCondition != parent.Name {
+				a.fail(s.Location, "fragment on type %q cannot be spread on type %q", s.TypeCondition, parent.Name)
+				continue
+			}
+			d, c = a.selectionSet(parent, s.SelectionSet)
+		}
+
+		if d > depth {
+			depth = d
+		}
+		complexity += c
+		if a.maxComplexity > 0 && complexity > a.maxComplexity {
+			a.tooComplex = true
+		}
+	}
+
+	return depth, complexity
+}
+
+// fragment returns the depth and the complexity of the selections of a fragment, measured
+// the first time it is spread.
+func (a *analyzer) fragment(parent *Object, fragment *Fragment) (depth, complexity int) {
+	if cost, ok := a.fragments[fragment.Name]; ok {
+		return cost.depth, cost.complexity
+	}
+
+	a.spreading[fragment.Name] = true
+	depth, complexity = a.selectionSet(parent, fragment.SelectionSet)
+	delete(a.spreading, fragment.Name)
+	a.fragments[fragment.Name] = fragmentCost{depth: depth, complexity: complexity}
+	return depth, complexity
+}
+
+func (a *analyzer) field(parent *Object, node *FieldNode) (depth, complexity int) {
+	if node.Name == "__typename" {
+		return 1, 0
+	}
+
+	def := parent.Field(node.Name)
+	if def == nil {
+		a.fail(node.Location, "cannot query field %q on type %q", node.Name, parent.Name)
+		return 0, 0
+	}
+
+	args, err := coerceArguments(parent, def, node.Arguments, a.vars, a.defined)
+	if err != nil {
+		a.fail(node.Location, "%v", err)
+		args = map[string]interface{}{}
+	}
+
+	var childDepth, childComplexity int
+	switch named := namedType(def.Type).(type) {
+	case *Object:
+		if len(node.SelectionSet) == 0 {
+			a.fail(node.Location, "field %q of type %q must have a selection of subfields", node.Name, def.Type.String())
+			break
+		}
+		childDepth, childComplexity = a.selectionSet(named, node.SelectionSet)
+	default:
+		if len(node.SelectionSet) > 0 {
+			a.fail(node.Location, "field %q must not have a selection since type %q has no subfields", node.Name, def.Type.String())
+		}
+	}
+
+	if def.Complexity != nil {
+		return 1 + childDepth, def.Complexity(args, childComplexity)
+	}
+	return 1 + childDepth, 1 + childComplexity
+}
+
+// pendingObject is an object whose selections are still to be executed. nullify sets the
+// position of the object to null, or the closest nullable position above it.
+type pendingObject struct {
+	typ        *Object
+	value      interface{}
+	selections []Selection
+	out        *orderedMap
+	path       []interface{}
+	nullify    func()
+}
+
+// pendingField is a field of a pendingObject being resolved.
+type pendingField struct {
+	obj   *pendingObject
+	def   *Field
+	nodes []*FieldNode
+	key   string
+	value interface{}
+	err   error
+}
+
+type executor struct {
+	ctx     context.Context
+	doc     *Document
+	vars    map[string]interface{}
+	defined map[string]bool
+	errors  []*Error
+}
+
+// execute resolves the objects level by level. Every field of a level is resolved before
+// any Thunk of the level is called, so that loaders can fetch their keys in one batch.
+func (e *executor) execute(objects []*pendingObject) {
+	for len(objects) > 0 {
+		var fields []*pendingField
+		for _, obj := range objects {
+			keys, nodes := e.collectFields(obj.typ, obj.selections)
+			for _, key := range keys {
+				node := nodes[key][0]
+				if node.Name == "__typename" {
+					obj.out.set(key, obj.typ.Name)
+					continue
+				}
+
+				obj.out.set(key, nil)
+				f := &pendingField{obj: obj, def: obj.typ.Field(node.Name), nodes: nodes[key], key: key}
+				args, err := coerceArguments(obj.typ, f.def, node.Arguments, e.vars, e.defined)
+				if err != nil {
+					f.err = err
+				} else {
+					f.value, f.err = e.resolve(f.def, obj.value, args)
+				}
+				fields = append(fields, f)
+			}
+		}
+
+		for _, f := range fields {
+			if thunk, ok := f.value.(Thunk); ok && f.err == nil {
+				f.value, f.err = e.force(thunk)
+			}
+		}
+
+		var next []*pendingObject
+		for _, f := range fields {
+			f := f
+			path := appendPath(f.obj.path, f.key)
+			set := func(v interface{}) { f.obj.out.set(f.key, v) }
+			nullify := func() { f.obj.out.set(f.key, nil) }
+			if _, ok := f.def.Type.(*NonNull); ok {
+				nullify = f.obj.nullify
+			}
+
+			if f.err != nil {
+				e.fail(f.nodes[0].Location, path, f.err.Error())
+				nullify()
+				continue
+			}
+
+			var selections []Selection
+			for _, node := range f.nodes {
+				selections = append(selections, node.SelectionSet...)
+			}
+			next = e.complete(next, f.def.Type, f.value, selections, path, f.nodes[0].Location, set, nullify)
+		}
+
+		objects = next
+	}
+}
+
+func (e *executor) fail(loc Location, path []interface{}, message string) {
+	e.errors = append(e.errors, &Error{Message: message, Locations: []Location{loc}, Path: path})
+}
+
+func (e *executor) resolve(def *Field, source interface{}, args map[string]interface{}) (value interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			value, err = nil, fmt.Errorf("internal error")
+		}
+	}()
+
+	if def.Resolve == nil {
+		if object, ok := source.(map[string]interface{}); ok {
+			return object[def.Name], nil
+		}
+		return nil, fmt.Errorf("field %q has no resolver", def.Name)
+	}
+	return def.Resolve(ResolveParams{Context: e.ctx, Source: source, Args: args})
+}
+
+func (e *executor) force(thunk Thunk) (value interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			value, err = nil, fmt.Errorf("internal error")
+		}
+	}()
+
+	return thunk()
+}
+
+// complete converts a resolved value to its response value according to its type. Objects
+// are set as empty maps and returned in next, to be executed with the next level.
+func (e *executor) complete(next []*pendingObject, t Type, value interface{}, selections []Selection, path []interface{}, loc Location, set func(interface{}), nullify func()) []*pendingObject {
+	if nonNull, ok := t.(*NonNull); ok {
+		if isNil(value) {
+			e.fail(loc, path, "cannot return null for non-null field")
+			nullify()
+			return next
+		}
+		t = nonNull.OfType
+	}
+	if isNil(value) {
+		set(nil)
+		return next
+	}
+
+	switch t := t.(type) {
+	case *List:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			e.fail(loc, path, "expected a list")
+			nullify()
+			return next
+		}
+
+		items := make([]interface{}, rv.Len())
+		set(items)
+		_, itemNonNull := t.OfType.(*NonNull)
+		for i := range items {
+			i := i
+			itemNullify := func() { items[i] = nil }
+			if itemNonNull {
+				itemNullify = nullify
+			}
+			next = e.complete(next, t.OfType, rv.Index(i).Interface(), selections, appendPath(path, i), loc,
+				func(v interface{}) { items[i] = v }, itemNullify)
+		}
+	case *Object:
+		out := newOrderedMap()
+		set(out)
+		next = append(next, &pendingObject{typ: t, value: value, selections: selections, out: out, path: path, nullify: nullify})
+	case *Scalar:
+		serialized, err := t.Serialize(value)
+		if err != nil {
+			e.fail(loc, path, err.Error())
+			nullify()
+			return next
+		}
+		set(serialized)
+	}
+
+	return next
+}
+
+// collectFields groups the fields of the selections that apply to the object type by
+// response key, in order of first appearance. A fragment is collected once, however many
+// times it is spread.
+func (e *executor) collectFields(typ *Object, selections []Selection) ([]string, map[string][]*FieldNode) {
+	var keys []string
+	fields := map[string][]*FieldNode{}
+	visited := map[string]bool{}
+
+	var collect func(selections []Selection)
+	collect = func(selections []Selection) {
+		for _, selection := range selections {
+			switch s := selection.(type) {
+			case *FieldNode:
+				if skip, _ := skipped(s.Directives, e.vars, e.defined); skip {
+					continue
+				}
+				key := s.ResponseKey()
+				if _, ok := fields[key]; !ok {
+					keys = append(keys, key)
+				}
+				fields[key] = append(fields[key], s)
+			case *FragmentSpread:
+				fragment := e.doc.Fragments[s.Name]
+				if skip, _ := skipped(s.Directives, e.vars, e.defined); skip || visited[s.Name] || fragment.TypeCondition != typ.Name {
+					continue
+				}
+				visited[s.Name] = true
+				collect(fragment.SelectionSet)
+			case *InlineFragment:
+				if skip, _ := skipped(s.Directives, e.vars, e.defined); skip || (s.TypeCondition != "" && s.TypeCondition != typ.Name) {
+					continue
+				}
+				collect(s.SelectionSet)
+			}
+		}
+	}
+	collect(selections)
+
+	return keys, fields
+}
+
+func appendPath(path []interface{}, elem interface{}) []interface{} {
+	extended := make([]interface{}, len(path), len(path)+1)
+	copy(extended, path)
+	return append(extended, elem)
+}
+
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(value); rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// orderedMap is a JSON object that keeps the order its keys were first set in, so that
+// response members follow the order of the query.
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: map[string]interface{}{}}
+}
+
+func (m *orderedMap) set(key string, value interface{}) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// MarshalJSON encodes the members in order.
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
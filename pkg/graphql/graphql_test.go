@@ -0,0 +1,303 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testUser struct {
+	ID      string
+	Name    string
+	Friends []string
+}
+
+var testUsers = map[string]*testUser{
+	"1": {ID: "1", Name: "Ana", Friends: []string{"2", "3"}},
+	"2": {ID: "2", Name: "Luis", Friends: []string{"1"}},
+	"3": {ID: "3", Name: "Eva"},
+}
+
+// newTestSchema returns a schema whose User.friends field loads the friends of every user
+// of a level in one batch, counting the batches in loads.
+func newTestSchema(loads *int, counter *int) *Schema {
+	user := &Object{Name: "User", Description: "A person."}
+
+	var pending []string
+	loaded := map[string]*testUser{}
+	load := func(id string) Thunk {
+		pending = append(pending, id)
+		return func() (interface{}, error) {
+			if len(pending) > 0 {
+				*loads++
+				for _, key := range pending {
+					loaded[key] = testUsers[key]
+				}
+				pending = nil
+			}
+			return loaded[id], nil
+		}
+	}
+
+	user.Fields = []*Field{
+		{Name: "id", Type: NewNonNull(ID), Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*testUser).ID, nil
+		}},
+		{Name: "name", Type: NewNonNull(String), Resolve: func(p ResolveParams) (interface{}, error) {
+			return p.Source.(*testUser).Name, nil
+		}},
+		{Name: "friends", Type: NewNonNull(NewList(NewNonNull(user))), Resolve: func(p ResolveParams) (interface{}, error) {
+			thunks := []Thunk{}
+			for _, id := range p.Source.(*testUser).Friends {
+				thunks = append(thunks, load(id))
+			}
+			return Thunk(func() (interface{}, error) {
+				friends := []*testUser{}
+				for _, thunk := range thunks {
+					friend, _ := thunk()
+					friends = append(friends, friend.(*testUser))
+				}
+				return friends, nil
+			}), nil
+		}, Complexity: func(_ map[string]interface{}, child int) int { return 1 + 10*child }},
+		{Name: "secret", Type: String, Resolve: func(ResolveParams) (interface{}, error) {
+			return nil, errors.New("forbidden")
+		}},
+		{Name: "required", Type: NewNonNull(String), Resolve: func(ResolveParams) (interface{}, error) {
+			return nil, nil
+		}},
+	}
+
+	return &Schema{
+		Query: &Object{Name: "Query", Fields: []*Field{
+			{Name: "hello", Type: NewNonNull(String), Args: []*Argument{{Name: "name", Type: String, Default: "world"}},
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					return "hello " + p.Args["name"].(string), nil
+				}},
+			{Name: "user", Type: user, Args: []*Argument{{Name: "id", Type: NewNonNull(ID)}},
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					return testUsers[p.Args["id"].(string)], nil
+				}},
+			{Name: "users", Type: NewNonNull(NewList(NewNonNull(user))),
+				Resolve: func(ResolveParams) (interface{}, error) {
+					return []*testUser{testUsers["1"], testUsers["2"], testUsers["3"]}, nil
+				}},
+			{Name: "sum", Type: Int, Args: []*Argument{{Name: "values", Type: NewNonNull(NewList(NewNonNull(Int)))}},
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					total := 0
+					for _, v := range p.Args["values"].([]interface{}) {
+						total += v.(int)
+					}
+					return total, nil
+				}},
+		}},
+		Mutation: &Object{Name: "Mutation", Fields: []*Field{
+			{Name: "increment", Type: NewNonNull(Int), Args: []*Argument{{Name: "by", Type: NewNonNull(Int)}},
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					*counter += p.Args["by"].(int)
+					return *counter, nil
+				}},
+		}},
+	}
+}
+
+func run(t *testing.T, params Params) (*Result, string) {
+	t.Helper()
+	result := Execute(params)
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return result, string(encoded)
+}
+
+func TestExecute_Query(t *testing.T) {
+	var loads, counter int
+	schema := newTestSchema(&loads, &counter)
+
+	cases := []struct {
+		name      string
+		query     string
+		variables map[string]interface{}
+		want      string
+	}{
+		{"defaults and arguments", `{ hello greeting: hello(name: "Ana") }`, nil,
+			`{"data":{"hello":"hello world","greeting":"hello Ana"}}`},
+		{"variables", `query Q($id: ID!, $values: [Int!]!) { user(id: $id) { name } sum(values: $values) }`,
+			map[string]interface{}{"id": "2", "values": []interface{}{1.0, 2.0}},
+			`{"data":{"user":{"name":"Luis"},"sum":3}}`},
+		{"single value as list", `{ sum(values: 4) }`, nil, `{"data":{"sum":4}}`},
+		{"fragments and directives", `query($skip: Boolean = true) {
+				user(id: "1") { ...names friends @skip(if: $skip) { id } ... on User { __typename } }
+			}
+			fragment names on User { id name }`, nil,
+			`{"data":{"user":{"id":"1","name":"Ana","__typename":"User"}}}`},
+		{"null object", `{ user(id: "9") { name } }`, nil, `{"data":{"user":null}}`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, got := run(t, Params{Schema: schema, Query: tc.query, Variables: tc.variables})
+			if got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestExecute_BatchesLevels(t *testing.T) {
+	var loads, counter int
+	schema := newTestSchema(&loads, &counter)
+
+	_, got := run(t, Params{Schema: schema, Query: `{ users { name friends { name friends { id } } } }`})
+	want := `{"data":{"users":[` +
+		`{"name":"Ana","friends":[{"name":"Luis","friends":[{"id":"1"}]},{"name":"Eva","friends":[]}]},` +
+		`{"name":"Luis","friends":[{"name":"Ana","friends":[{"id":"2"},{"id":"3"}]}]},` +
+		`{"name":"Eva","friends":[]}]}}`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if loads != 2 {
+		t.Errorf("expected one load per level, got %d", loads)
+	}
+}
+
+func TestExecute_FieldErrors(t *testing.T) {
+	var loads, counter int
+	schema := newTestSchema(&loads, &counter)
+
+	result, got := run(t, Params{Schema: schema, Query: `{ user(id: "1") { name secret } users { required } }`})
+	want := `{"data":null,"errors":[` +
+		`{"message":"forbidden","locations":[{"line":1,"column":24}],"path":["user","secret"]},` +
+		`{"message":"cannot return null for non-null field","locations":[{"line":1,"column":41}],"path":["users",0,"required"]},` +
+		`{"message":"cannot return null for non-null field","locations":[{"line":1,"column":41}],"path":["users",1,"required"]},` +
+		`{"message":"cannot return null for non-null field","locations":[{"line":1,"column":41}],"path":["users",2,"required"]}]}`
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if !result.Executed {
+		t.Error("expected the request to be executed")
+	}
+}
+
+func TestExecute_Mutation(t *testing.T) {
+	var loads, counter int
+	schema := newTestSchema(&loads, &counter)
+
+	_, got := run(t, Params{Schema: schema, Query: `mutation { first: increment(by: 2) second: increment(by: 3) }`})
+	if want := `{"data":{"first":2,"second":5}}`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	result, _ := run(t, Params{Schema: schema, Query: `mutation { increment(by: 1) }`, QueryOnly: true})
+	if result.Executed || counter != 5 {
+		t.Error("expected the mutation to be rejected")
+	}
+}
+
+func TestExecute_RequestErrors(t *testing.T) {
+	var loads, counter int
+	schema := newTestSchema(&loads, &counter)
+
+	cases := []struct {
+		name    string
+		params  Params
+		message string
+	}{
+		{"syntax", Params{Query: `{ hello(`}, "syntax error: unexpected end of document"},
+		{"unterminated string", Params{Query: `{ hello(name: "x) }`}, "syntax error: unterminated string"},
+		{"unknown field", Params{Query: `{ nope }`}, `cannot query field "nope" on type "Query"`},
+		{"missing argument", Params{Query: `{ user { id } }`}, `argument "id" of type "ID!" is required on field "Query.user"`},
+		{"unknown argument", Params{Query: `{ hello(x: 1) }`}, `unknown argument "x" on field "Query.hello"`},
+		{"wrong argument type", Params{Query: `{ hello(name: 3) }`}, `argument "name" on field "Query.hello": String cannot represent 3`},
+		{"missing selection", Params{Query: `{ user(id: "1") }`}, `field "user" of type "User" must have a selection of subfields`},
+		{"selection on scalar", Params{Query: `{ hello { x } }`}, `field "hello" must not have a selection since type "String!" has no subfields`},
+		{"undefined variable", Params{Query: `{ user(id: $id) { id } }`}, `variable "$id" is not defined`},
+		{"missing variable", Params{Query: `query($id: ID!) { user(id: $id) { id } }`}, `variable "$id" of required type "ID!" was not provided`},
+		{"invalid variable", Params{Query: `query($n: Int) { sum(values: [$n]) }`, Variables: map[string]interface{}{"n": 1.5}}, `variable "$n": Int cannot represent 1.5`},
+		{"fragment cycle", Params{Query: `{ user(id: "1") { ...a } } fragment a on User { friends { ...a } }`}, `fragment "a" spreads itself`},
+		{"unknown directive", Params{Query: `{ hello @cached }`}, `unknown directive "@cached"`},
+		{"operation name", Params{Query: `query A { hello } query B { hello }`}, "an operation name is required when the document has several operations"},
+		{"subscription", Params{Query: `subscription { hello }`}, "subscription operations are not supported"},
+		{"depth", Params{Query: `{ users { friends { friends { id } } } }`, MaxDepth: 3}, "query depth 4 exceeds the maximum of 3"},
+		{"complexity", Params{Query: `{ users { friends { friends { id } } } }`, MaxComplexity: 100}, "query complexity exceeds the maximum of 100"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.params.Schema = schema
+			result := Execute(tc.params)
+			if result.Executed || len(result.Errors) == 0 || result.Errors[0].Message != tc.message {
+				t.Fatalf("expected %q, got %+v", tc.message, result.Errors)
+			}
+		})
+	}
+}
+
+func TestExecute_NestedFragments(t *testing.T) {
+	var loads, counter int
+	schema := newTestSchema(&loads, &counter)
+
+	// Every fragment spreads the next one twice, so expanding the spreads would visit the
+	// last fragment 2^24 times.
+	var query strings.Builder
+	query.WriteString(`{ user(id: "1") { ...f0 } }`)
+	for i := 0; i < 24; i++ {
+		fmt.Fprintf(&query, " fragment f%d on User { ...f%d ...f%d }", i, i+1, i+1)
+	}
+	query.WriteString(" fragment f24 on User { id name }")
+
+	start := time.Now()
+	result := Execute(Params{Schema: schema, Query: query.String(), MaxComplexity: 5000})
+	if result.Executed || len(result.Errors) == 0 || result.Errors[0].Message != "query complexity exceeds the maximum of 5000" {
+		t.Fatalf("expected the query to be too complex, got %+v", result.Errors)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the query to be rejected quickly, took %s", elapsed)
+	}
+
+	start = time.Now()
+	if _, got := run(t, Params{Schema: schema, Query: query.String()}); got != `{"data":{"user":{"id":"1","name":"Ana"}}}` {
+		t.Errorf("unexpected result %s", got)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the query to run quickly, took %s", elapsed)
+	}
+}
+
+func TestParse_FragmentLimits(t *testing.T) {
+	var fragments strings.Builder
+	fragments.WriteString(`{ user(id: "1") { id } }`)
+	for i := 0; i <= maxFragments; i++ {
+		fmt.Fprintf(&fragments, " fragment f%d on User { id }", i)
+	}
+	spreads := `{ user(id: "1") { ` + strings.Repeat("...f ", maxFragmentSpreads+1) + `} } fragment f on User { id }`
+
+	for query, want := range map[string]string{
+		fragments.String(): "document has more than 100 fragments",
+		spreads:            "document has more than 1000 fragment spreads",
+	} {
+		if _, err := Parse(query); err == nil || err.Error() != want {
+			t.Errorf("expected %q, got %v", want, err)
+		}
+	}
+}
+
+func TestSchema_SDL(t *testing.T) {
+	var loads, counter int
+	sdl := newTestSchema(&loads, &counter).SDL()
+
+	for _, want := range []string{
+		"schema {\n  query: Query\n  mutation: Mutation\n}",
+		`hello(name: String = "world"): String!`,
+		`"""A person."""` + "\ntype User {",
+		"friends: [User!]!",
+	} {
+		if !strings.Contains(sdl, want) {
+			t.Errorf("expected the SDL to contain %q, got:\n%s", want, sdl)
+		}
+	}
+}
@@ -0,0 +1,780 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Document is a parsed GraphQL request document.
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation is a query or mutation of a document.
+type Operation struct {
+	Type         string
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []Selection
+	Location     Location
+}
+
+// VariableDefinition declares a variable of an operation.
+type VariableDefinition struct {
+	Name     string
+	Type     *TypeRef
+	Default  Value
+	Location Location
+}
+
+// TypeRef is a type as written in a variable definition, e.g. [String!]!.
+type TypeRef struct {
+	Name    string
+	Elem    *TypeRef
+	NonNull bool
+}
+
+func (t *TypeRef) String() string {
+	s := t.Name
+	if t.Elem != nil {
+		s = "[" + t.Elem.String() + "]"
+	}
+	if t.NonNull {
+		s += "!"
+	}
+	return s
+}
+
+// Fragment is a named fragment definition.
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Location      Location
+}
+
+// Selection is a *FieldNode, a *FragmentSpread or an *InlineFragment.
+type Selection interface {
+	selection()
+}
+
+// FieldNode is a field of a selection set.
+type FieldNode struct {
+	Alias        string
+	Name         string
+	Arguments    []*ArgumentNode
+	Directives   []*Directive
+	SelectionSet []Selection
+	Location     Location
+}
+
+// ResponseKey is the key of the field in the response: its alias or its name.
+func (f *FieldNode) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// FragmentSpread includes a named fragment in a selection set.
+type FragmentSpread struct {
+	Name       string
+	Directives []*Directive
+	Location   Location
+}
+
+// InlineFragment includes a selection set, optionally conditioned on the object type.
+type InlineFragment struct {
+	TypeCondition string
+	Directives    []*Directive
+	SelectionSet  []Selection
+	Location      Location
+}
+
+func (*FieldNode) selection()      {}
+func (*FragmentSpread) selection() {}
+func (*InlineFragment) selection() {}
+
+// ArgumentNode is an argument of a field or a directive.
+type ArgumentNode struct {
+	Name  string
+	Value Value
+}
+
+// Directive is a directive applied to a selection, e.g. @include(if: $withTasks).
+type Directive struct {
+	Name      string
+	Arguments []*ArgumentNode
+	Location  Location
+}
+
+// Value is an input value literal: a *Variable, *IntValue, *FloatValue, *StringValue,
+// *BooleanValue, *NullValue, *EnumValue, *ListValue or *ObjectValue.
+type Value interface {
+	value()
+}
+
+// Variable references a variable of the operation.
+type Variable struct{ Name string }
+
+// IntValue is an integer literal.
+type IntValue struct{ Value int }
+
+// FloatValue is a float literal.
+type FloatValue struct{ Value float64 }
+
+// StringValue is a string literal.
+type StringValue struct{ Value string }
+
+// BooleanValue is true or false.
+type BooleanValue struct{ Value bool }
+
+// NullValue is null.
+type NullValue struct{}
+
+// EnumValue is a bare name other than true, false and null.
+type EnumValue struct{ Value string }
+
+// ListValue is a list literal.
+type ListValue struct{ Values []Value }
+
+// ObjectValue is an input object literal.
+type ObjectValue struct{ Fields []*ArgumentNode }
+
+func (*Variable) value()     {}
+func (*IntValue) value()     {}
+func (*FloatValue) value()   {}
+func (*StringValue) value()  {}
+func (*BooleanValue) value() {}
+func (*NullValue) value()    {}
+func (*EnumValue) value()    {}
+func (*ListValue) value()    {}
+func (*ObjectValue) value()  {}
+
+// Location is the 1-based line and column of a node in the document.
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunct
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	loc  Location
+}
+
+// Limits on the fragments of a document, so that analyzing a document cannot take much
+// longer than reading it.
+const (
+	maxFragments       = 100
+	maxFragmentSpreads = 1000
+)
+
+// Parse parses a GraphQL request document. Errors start with "syntax error", except for
+// documents with more than maxFragments fragments or maxFragmentSpreads fragment spreads.
+func Parse(source string) (*Document, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	doc := &Document{Fragments: map[string]*Fragment{}}
+	for p.peek().kind != tokenEOF {
+		tok := p.peek()
+		switch {
+		case tok.kind == tokenPunct && tok.text == "{":
+			selections, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", SelectionSet: selections, Location: tok.loc})
+		case tok.kind == tokenName && (tok.text == "query" || tok.text == "mutation" || tok.text == "subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case tok.kind == tokenName && tok.text == "fragment":
+			fragment, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.Fragments[fragment.Name]; ok {
+				return nil, syntaxError(fragment.Location, fmt.Sprintf("fragment %q is defined more than once", fragment.Name))
+			}
+			if len(doc.Fragments) == maxFragments {
+				return nil, &Error{Message: fmt.Sprintf("document has more than %d fragments", maxFragments), Locations: []Location{fragment.Location}}
+			}
+			doc.Fragments[fragment.Name] = fragment
+		default:
+			return nil, p.unexpected(tok)
+		}
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, syntaxError(Location{Line: 1, Column: 1}, "document has no operation")
+	}
+
+	return doc, nil
+}
+
+// Error is an error of a GraphQL request, reported in the errors member of the result.
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func syntaxError(loc Location, message string) *Error {
+	return &Error{Message: "syntax error: " + message, Locations: []Location{loc}}
+}
+
+type parser struct {
+	tokens  []token
+	pos     int
+	spreads int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) unexpected(tok token) error {
+	if tok.kind == tokenEOF {
+		return syntaxError(tok.loc, "unexpected end of document")
+	}
+	return syntaxError(tok.loc, fmt.Sprintf("unexpected %q", tok.text))
+}
+
+// skip consumes the punctuator if it is next and reports whether it did.
+func (p *parser) skip(punct string) bool {
+	if tok := p.peek(); tok.kind == tokenPunct && tok.text == punct {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(punct string) error {
+	if !p.skip(punct) {
+		return p.unexpected(p.peek())
+	}
+	return nil
+}
+
+func (p *parser) expectName() (token, error) {
+	tok := p.next()
+	if tok.kind != tokenName {
+		return tok, p.unexpected(tok)
+	}
+	return tok, nil
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	tok := p.next()
+	op := &Operation{Type: tok.text, Location: tok.loc}
+
+	if p.peek().kind == tokenName {
+		op.Name = p.next().text
+	}
+
+	if p.skip("(") {
+		for !p.skip(")") {
+			def, err := p.parseVariableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			op.Variables = append(op.Variables, def)
+		}
+	}
+
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.SelectionSet = selections
+
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinition() (*VariableDefinition, error) {
+	loc := p.peek().loc
+	if err := p.expect("$"); err != nil {
+		return nil, err
+	}
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	typ, err := p.parseTypeRef()
+	if err != nil {
+		return nil, err
+	}
+
+	def := &VariableDefinition{Name: name.text, Type: typ, Location: loc}
+	if p.skip("=") {
+		if def.Default, err = p.parseValue(true); err != nil {
+			return nil, err
+		}
+	}
+
+	return def, nil
+}
+
+func (p *parser) parseTypeRef() (*TypeRef, error) {
+	typ := &TypeRef{}
+	if p.skip("[") {
+		elem, err := p.parseTypeRef()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		typ.Elem = elem
+	} else {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		typ.Name = name.text
+	}
+
+	typ.NonNull = p.skip("!")
+	return typ, nil
+}
+
+func (p *parser) parseFragment() (*Fragment, error) {
+	loc := p.next().loc
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	if name.text == "on" {
+		return nil, p.unexpected(name)
+	}
+	if on, err := p.expectName(); err != nil || on.text != "on" {
+		return nil, p.unexpected(on)
+	}
+	condition, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	fragment := &Fragment{Name: name.text, TypeCondition: condition.text, Location: loc}
+	if fragment.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if fragment.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+
+	return fragment, nil
+}
+
+func (p *parser) parseSelectionSet() ([]Selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	selections := []Selection{}
+	for !p.skip("}") {
+		selection, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, selection)
+	}
+
+	if len(selections) == 0 {
+		return nil, syntaxError(p.tokens[p.pos-1].loc, "selection set cannot be empty")
+	}
+
+	return selections, nil
+}
+
+func (p *parser) parseSelection() (Selection, error) {
+	tok := p.peek()
+	if !p.skip("...") {
+		return p.parseField()
+	}
+
+	if next := p.peek(); next.kind == tokenName && next.text != "on" {
+		p.next()
+		if p.spreads++; p.spreads > maxFragmentSpreads {
+			return nil, &Error{Message: fmt.Sprintf("document has more than %d fragment spreads", maxFragmentSpreads), Locations: []Location{tok.loc}}
+		}
+		directives, err := p.parseDirectives()
+		if err != nil {
+			return nil, err
+		}
+		return &FragmentSpread{Name: next.text, Directives: directives, Location: tok.loc}, nil
+	}
+
+	fragment := &InlineFragment{Location: tok.loc}
+	if next := p.peek(); next.kind == tokenName && next.text == "on" {
+		p.next()
+		condition, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		fragment.TypeCondition = condition.text
+	}
+
+	var err error
+	if fragment.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if fragment.SelectionSet, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+
+	return fragment, nil
+}
+
+func (p *parser) parseField() (*FieldNode, error) {
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+
+	field := &FieldNode{Name: name.text, Location: name.loc}
+	if p.skip(":") {
+		actual, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		field.Alias, field.Name = name.text, actual.text
+	}
+
+	if field.Arguments, err = p.parseArguments(false); err != nil {
+		return nil, err
+	}
+	if field.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind == tokenPunct && tok.text == "{" {
+		if field.SelectionSet, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+
+	return field, nil
+}
+
+func (p *parser) parseArguments(constant bool) ([]*ArgumentNode, error) {
+	if !p.skip("(") {
+		return nil, nil
+	}
+
+	arguments := []*ArgumentNode{}
+	for !p.skip(")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue(constant)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, &ArgumentNode{Name: name.text, Value: value})
+	}
+
+	return arguments, nil
+}
+
+func (p *parser) parseDirectives() ([]*Directive, error) {
+	var directives []*Directive
+	for {
+		tok := p.peek()
+		if !p.skip("@") {
+			return directives, nil
+		}
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		arguments, err := p.parseArguments(false)
+		if err != nil {
+			return nil, err
+		}
+		directives = append(directives, &Directive{Name: name.text, Arguments: arguments, Location: tok.loc})
+	}
+}
+
+// parseValue parses a value literal. Variables are not allowed in constant values, such
+// as the default value of a variable.
+func (p *parser) parseValue(constant bool) (Value, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenInt:
+		n, err := strconv.Atoi(tok.text)
+		if err != nil {
+			return nil, syntaxError(tok.loc, fmt.Sprintf("integer %s is out of range", tok.text))
+		}
+		return &IntValue{Value: n}, nil
+	case tokenFloat:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, syntaxError(tok.loc, fmt.Sprintf("invalid float %s", tok.text))
+		}
+		return &FloatValue{Value: f}, nil
+	case tokenString:
+		return &StringValue{Value: tok.text}, nil
+	case tokenName:
+		switch tok.text {
+		case "true", "false":
+			return &BooleanValue{Value: tok.text == "true"}, nil
+		case "null":
+			return &NullValue{}, nil
+		}
+		return &EnumValue{Value: tok.text}, nil
+	case tokenPunct:
+		switch tok.text {
+		case "$":
+			if constant {
+				return nil, p.unexpected(tok)
+			}
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			return &Variable{Name: name.text}, nil
+		case "[":
+			list := &ListValue{Values: []Value{}}
+			for !p.skip("]") {
+				item, err := p.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+				list.Values = append(list.Values, item)
+			}
+			return list, nil
+		case "{":
+			object := &ObjectValue{Fields: []*ArgumentNode{}}
+			for !p.skip("}") {
+				name, err := p.expectName()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				value, err := p.parseValue(constant)
+				if err != nil {
+					return nil, err
+				}
+				object.Fields = append(object.Fields, &ArgumentNode{Name: name.text, Value: value})
+			}
+			return object, nil
+		}
+	}
+
+	return nil, p.unexpected(tok)
+}
+
+// lex splits a document into tokens. Commas, white space and comments are ignored.
+func lex(source string) ([]token, error) {
+	tokens := []token{}
+	line, lineStart := 1, 0
+
+	for i := 0; i < len(source); {
+		c := source[i]
+		loc := Location{Line: line, Column: utf8.RuneCountInString(source[lineStart:i]) + 1}
+
+		switch {
+		case c == '\n':
+			i++
+			line, lineStart = line+1, i
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(source[i:], "\uFEFF"):
+			i += len("\uFEFF")
+		case strings.HasPrefix(source[i:], "..."):
+			tokens = append(tokens, token{kind: tokenPunct, text: "...", loc: loc})
+			i += 3
+		case strings.IndexByte("!$():=@[]{}|", c) >= 0:
+			tokens = append(tokens, token{kind: tokenPunct, text: string(c), loc: loc})
+			i++
+		case c == '_' || isLetter(c):
+			start := i
+			for i < len(source) && (source[i] == '_' || isLetter(source[i]) || isDigit(source[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenName, text: source[start:i], loc: loc})
+		case c == '-' || isDigit(c):
+			tok, end, err := lexNumber(source, i, loc)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			i = end
+		case c == '"':
+			if strings.HasPrefix(source[i:], `"""`) {
+				end := strings.Index(source[i+3:], `"""`)
+				if end < 0 {
+					return nil, syntaxError(loc, "unterminated string")
+				}
+				text := source[i+3 : i+3+end]
+				tokens = append(tokens, token{kind: tokenString, text: strings.TrimSpace(text), loc: loc})
+				if nl := strings.LastIndexByte(text, '\n'); nl >= 0 {
+					line, lineStart = line+strings.Count(text, "\n"), i+3+nl+1
+				}
+				i += end + 6
+			}
+			text, end, err := lexString(source, i, loc)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, loc: loc})
+			i = end
+		default:
+			r, _ := utf8.DecodeRuneInString(source[i:])
+			return nil, syntaxError(loc, fmt.Sprintf("unexpected character %q", r))
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, loc: Location{Line: line, Column: utf8.RuneCountInString(source[lineStart:]) + 1}})
+	return tokens, nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func lexNumber(source string, i int, loc Location) (token, int, error) {
+	start := i
+	kind := tokenInt
+	if source[i] == '-' {
+		i++
+	}
+
+	digits := func() int {
+		from := i
+		for i < len(source) && isDigit(source[i]) {
+			i++
+		}
+		return i - from
+	}
+
+	if digits() == 0 {
+		return token{}, 0, syntaxError(loc, "invalid number")
+	}
+	if i < len(source) && source[i] == '.' {
+		i++
+		kind = tokenFloat
+		if digits() == 0 {
+			return token{}, 0, syntaxError(loc, "invalid number")
+		}
+	}
+	if i < len(source) && (source[i] == 'e' || source[i] == 'E') {
+		i++
+		kind = tokenFloat
+		if i < len(source) && (source[i] == '+' || source[i] == '-') {
+			i++
+		}
+		if digits() == 0 {
+			return token{}, 0, syntaxError(loc, "invalid number")
+		}
+	}
+	if i < len(source) && (source[i] == '_' || isLetter(source[i]) || source[i] == '.') {
+		return token{}, 0, syntaxError(loc, "invalid number")
+	}
+
+	return token{kind: kind, text: source[start:i], loc: loc}, i, nil
+}
+
+func lexString(source string, i int, loc Location) (string, int, error) {
+	var b strings.Builder
+	for i++; i < len(source); {
+		c := source[i]
+		switch {
+		case c == '"':
+			return b.String(), i + 1, nil
+		case c == '\n':
+			return "", 0, syntaxError(loc, "unterminated string")
+		case c == '\\':
+			if i+1 >= len(source) {
+				return "", 0, syntaxError(loc, "unterminated string")
+			}
+			switch esc := source[i+1]; esc {
+			case '"', '\\', '/':
+				b.WriteByte(esc)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if i+6 > len(source) {
+					return "", 0, syntaxError(loc, "invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(source[i+2:i+6], 16, 32)
+				if err != nil {
+					return "", 0, syntaxError(loc, "invalid unicode escape")
+				}
+				b.WriteRune(rune(code))
+				i += 4
+			default:
+				return "", 0, syntaxError(loc, fmt.Sprintf("invalid escape \\%c", esc))
+			}
+			i += 2
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	return "", 0, syntaxError(loc, "unterminated string")
+}
@@ -0,0 +1,328 @@
+// Package graphql parses and executes GraphQL requests against a schema defined in code.
+//
+// It supports queries and mutations with arguments, variables, aliases, fragments and the
+// @include and @skip directives. Fields of a level are resolved for every object of the
+// level before the next level starts, so that resolvers can return a Thunk and load the
+// values of all the objects of a level in a single batch. Introspection is limited to
+// __typename; the schema is published with SDL.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Type is a GraphQL type: a *Scalar, an *Object, a *List or a *NonNull.
+type Type interface {
+	String() string
+}
+
+// Scalar is a leaf type.
+type Scalar struct {
+	Name        string
+	Description string
+	// Serialize converts a resolved value to its JSON representation.
+	Serialize func(value interface{}) (interface{}, error)
+	// ParseValue converts an input value, from a literal or decoded from JSON variables,
+	// to the value passed to resolvers.
+	ParseValue func(value interface{}) (interface{}, error)
+}
+
+func (s *Scalar) String() string { return s.Name }
+
+// Object is a type with fields.
+type Object struct {
+	Name        string
+	Description string
+	Fields      []*Field
+}
+
+func (o *Object) String() string { return o.Name }
+
+// Field returns the field with the given name, nil when there is none.
+func (o *Object) Field(name string) *Field {
+	for _, f := range o.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// List is a list of values of a type.
+type List struct {
+	OfType Type
+}
+
+func (l *List) String() string { return "[" + l.OfType.String() + "]" }
+
+// NonNull is a type whose values cannot be null.
+type NonNull struct {
+	OfType Type
+}
+
+func (n *NonNull) String() string { return n.OfType.String() + "!" }
+
+// NewList returns a list of t.
+func NewList(t Type) *List { return &List{OfType: t} }
+
+// NewNonNull returns the non-null variant of t.
+func NewNonNull(t Type) *NonNull { return &NonNull{OfType: t} }
+
+// Field is a field of an object type.
+type Field struct {
+	Name        string
+	Description string
+	Type        Type
+	Args        []*Argument
+	// Resolve returns the value of the field for its parent object, or a Thunk returning it.
+	Resolve ResolveFunc
+	// Complexity estimates the cost of the field from its arguments and the cost of its
+	// selection set. It defaults to 1 + childComplexity.
+	Complexity func(args map[string]interface{}, childComplexity int) int
+}
+
+// Argument is an argument of a field. Default is used when the argument is omitted.
+type Argument struct {
+	Name        string
+	Description string
+	Type        Type
+	Default     interface{}
+}
+
+// ResolveParams holds what a resolver needs: the request context, the parent object and
+// the coerced arguments, with their defaults applied.
+type ResolveParams struct {
+	Context context.Context
+	Source  interface{}
+	Args    map[string]interface{}
+}
+
+// ResolveFunc resolves the value of a field.
+type ResolveFunc func(p ResolveParams) (interface{}, error)
+
+// Thunk is returned by a resolver to defer loading its value until every field of the
+// level has been resolved.
+type Thunk func() (interface{}, error)
+
+// Schema is the root of a GraphQL API.
+type Schema struct {
+	Query    *Object
+	Mutation *Object
+}
+
+// Built-in scalars.
+var (
+	String = &Scalar{
+		Name:       "String",
+		Serialize:  serializeString,
+		ParseValue: parseString,
+	}
+	ID = &Scalar{
+		Name:        "ID",
+		Description: "A unique identifier, serialized as a string.",
+		Serialize:   serializeString,
+		ParseValue: func(value interface{}) (interface{}, error) {
+			if n, ok := value.(int); ok {
+				return fmt.Sprint(n), nil
+			}
+			return parseString(value)
+		},
+	}
+	Int = &Scalar{
+		Name: "Int",
+		Serialize: func(value interface{}) (interface{}, error) {
+			switch v := value.(type) {
+			case int:
+				return v, nil
+			case int64:
+				return v, nil
+			case int32:
+				return v, nil
+			}
+			return nil, fmt.Errorf("Int cannot represent %v", value)
+		},
+		ParseValue: func(value interface{}) (interface{}, error) {
+			switch v := value.(type) {
+			case int:
+				return v, nil
+			case float64:
+				if v == math.Trunc(v) && math.Abs(v) <= math.MaxInt32 {
+					return int(v), nil
+				}
+			}
+			return nil, fmt.Errorf("Int cannot represent %s", describe(value))
+		},
+	}
+	Float = &Scalar{
+		Name: "Float",
+		Serialize: func(value interface{}) (interface{}, error) {
+			switch v := value.(type) {
+			case float64:
+				return v, nil
+			case float32:
+				return v, nil
+			case int:
+				return float64(v), nil
+			}
+			return nil, fmt.Errorf("Float cannot represent %v", value)
+		},
+		ParseValue: func(value interface{}) (interface{}, error) {
+			switch v := value.(type) {
+			case float64:
+				return v, nil
+			case int:
+				return float64(v), nil
+			}
+			return nil, fmt.Errorf("Float cannot represent %s", describe(value))
+		},
+	}
+	Boolean = &Scalar{
+		Name: "Boolean",
+		Serialize: func(value interface{}) (interface{}, error) {
+			if v, ok := value.(bool); ok {
+				return v, nil
+			}
+			return nil, fmt.Errorf("Boolean cannot represent %v", value)
+		},
+		ParseValue: func(value interface{}) (interface{}, error) {
+			if v, ok := value.(bool); ok {
+				return v, nil
+			}
+			return nil, fmt.Errorf("Boolean cannot represent %s", describe(value))
+		},
+	}
+)
+
+func serializeString(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	return nil, fmt.Errorf("String cannot represent %v", value)
+}
+
+func parseString(value interface{}) (interface{}, error) {
+	if v, ok := value.(string); ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("String cannot represent %s", describe(value))
+}
+
+// describe formats an input value for error messages.
+func describe(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// namedType strips the list and non-null wrappers of a type.
+func namedType(t Type) Type {
+	for {
+		switch w := t.(type) {
+		case *List:
+			t = w.OfType
+		case *NonNull:
+			t = w.OfType
+		default:
+			return t
+		}
+	}
+}
+
+// types returns the named types reachable from the root types, by name.
+func (s *Schema) types() map[string]Type {
+	found := map[string]Type{}
+	var visit func(t Type)
+	visit = func(t Type) {
+		t = namedType(t)
+		if _, ok := found[t.String()]; ok {
+			return
+		}
+		found[t.String()] = t
+		if object, ok := t.(*Object); ok {
+			for _, f := range object.Fields {
+				visit(f.Type)
+				for _, arg := range f.Args {
+					visit(arg.Type)
+				}
+			}
+		}
+	}
+
+	for _, scalar := range []*Scalar{String, ID, Int, Float, Boolean} {
+		visit(scalar)
+	}
+	visit(s.Query)
+	if s.Mutation != nil {
+		visit(s.Mutation)
+	}
+
+	return found
+}
+
+// SDL returns the schema in the GraphQL schema definition language.
+func (s *Schema) SDL() string {
+	var b strings.Builder
+
+	b.WriteString("schema {\n  query: " + s.Query.Name + "\n")
+	if s.Mutation != nil {
+		b.WriteString("  mutation: " + s.Mutation.Name + "\n")
+	}
+	b.WriteString("}\n")
+
+	types := s.types()
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		switch t := types[name].(type) {
+		case *Scalar:
+			if t == String || t == ID || t == Int || t == Float || t == Boolean {
+				continue
+			}
+			b.WriteString("\n")
+			writeDescription(&b, "", t.Description)
+			b.WriteString("scalar " + t.Name + "\n")
+		case *Object:
+			b.WriteString("\n")
+			writeDescription(&b, "", t.Description)
+			b.WriteString("type " + t.Name + " {\n")
+			for _, f := range t.Fields {
+				writeDescription(&b, "  ", f.Description)
+				b.WriteString("  " + f.Name)
+				if len(f.Args) > 0 {
+					args := make([]string, len(f.Args))
+					for i, arg := range f.Args {
+						args[i] = arg.Name + ": " + arg.Type.String()
+						if arg.Default != nil {
+							args[i] += " = " + describe(arg.Default)
+						}
+					}
+					b.WriteString("(" + strings.Join(args, ", ") + ")")
+				}
+				b.WriteString(": " + f.Type.String() + "\n")
+			}
+			b.WriteString("}\n")
+		}
+	}
+
+	return b.String()
+}
+
+func writeDescription(b *strings.Builder, indent, description string) {
+	if description != "" {
+		b.WriteString(indent + `"""` + description + `"""` + "\n")
+	}
+}
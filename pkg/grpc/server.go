@@ -0,0 +1,321 @@
+// Package grpc serves gRPC services with the standard library HTTP/2 server. It implements
+// the gRPC over HTTP/2 protocol for unary and server streaming methods, with messages
+// encoded by package protobuf. Compression, client streaming and reflection are not
+// supported.
+package grpc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/pkg/protobuf"
+)
+
+// DefaultMaxMessageSize caps the size of a request message, as gRPC does by default.
+const DefaultMaxMessageSize = 4 << 20
+
+// MethodInfo describes the method a call is made to.
+type MethodInfo struct {
+	// FullMethod is the path of the method, "/package.Service/Method".
+	FullMethod string
+	// ServerStream is set for methods that send a stream of responses.
+	ServerStream bool
+}
+
+// UnaryHandler handles a call to a unary method.
+type UnaryHandler func(ctx context.Context, req protobuf.Message) (protobuf.Message, error)
+
+// UnaryInterceptor runs around every unary call; it calls handler to continue the call.
+type UnaryInterceptor func(ctx context.Context, req protobuf.Message, info *MethodInfo, handler UnaryHandler) (protobuf.Message, error)
+
+// ServerStream sends the responses of a server streaming call.
+type ServerStream interface {
+	Context() context.Context
+	Send(m protobuf.Message) error
+}
+
+// StreamHandler handles a call to a server streaming method, sending its responses on
+// stream until it returns.
+type StreamHandler func(req protobuf.Message, stream ServerStream) error
+
+// StreamInterceptor runs around every server streaming call; it calls handler to continue
+// the call.
+type StreamInterceptor func(req protobuf.Message, stream ServerStream, info *MethodInfo, handler StreamHandler) error
+
+// ServiceDesc describes a service: its full name, "package.Service", and its methods.
+type ServiceDesc struct {
+	Name    string
+	Methods []MethodDesc
+}
+
+// MethodDesc describes a method of a service. Exactly one of Unary and Stream is set.
+type MethodDesc struct {
+	Name string
+	// NewRequest returns an empty request message to decode the request into.
+	NewRequest func() protobuf.Message
+	Unary      UnaryHandler
+	Stream     StreamHandler
+}
+
+// Server routes gRPC calls to the methods of the registered services. It is an
+// http.Handler that must be served over HTTP/2.
+type Server struct {
+	methods        map[string]MethodDesc
+	unary          []UnaryInterceptor
+	stream         []StreamInterceptor
+	maxMessageSize int
+}
+
+// NewServer creates a Server without services.
+func NewServer() *Server {
+	return &Server{
+		methods:        map[string]MethodDesc{},
+		maxMessageSize: DefaultMaxMessageSize,
+	}
+}
+
+// RegisterService adds the methods of a service to the server.
+func (s *Server) RegisterService(desc ServiceDesc) {
+	for _, method := range desc.Methods {
+		s.methods["/"+desc.Name+"/"+method.Name] = method
+	}
+}
+
+// UseUnary adds interceptors to the unary calls. The first interceptor added is the
+// outermost one.
+func (s *Server) UseUnary(interceptors ...UnaryInterceptor) {
+	s.unary = append(s.unary, interceptors...)
+}
+
+// UseStream adds interceptors to the server streaming calls. The first interceptor added
+// is the outermost one.
+func (s *Server) UseStream(interceptors ...StreamInterceptor) {
+	s.stream = append(s.stream, interceptors...)
+}
+
+// NewHTTPServer returns an HTTP server for the gRPC server on addr. It speaks HTTP/2
+// without TLS, which gRPC clients use when dialing with insecure credentials.
+func (s *Server) NewHTTPServer(addr string) *http.Server {
+	protocols := &http.Protocols{}
+	protocols.SetUnencryptedHTTP2(true)
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		Protocols:         protocols,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// ServeHTTP handles a gRPC call. Once the request is recognized as gRPC the response is
+// always 200; the outcome of the call is sent in the grpc-status and grpc-message trailers.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.ProtoMajor != 2 {
+		http.Error(w, "gRPC requires HTTP/2", http.StatusHTTPVersionNotSupported)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "gRPC requires POST", http.StatusMethodNotAllowed)
+		return
+	}
+	if contentType := r.Header.Get("Content-Type"); contentType != "application/grpc" && !strings.HasPrefix(contentType, "application/grpc+proto") {
+		http.Error(w, "unsupported content type "+contentType, http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Add("Trailer", "Grpc-Status")
+	w.Header().Add("Trailer", "Grpc-Message")
+	w.WriteHeader(http.StatusOK)
+
+	status := StatusOf(s.serve(w, r))
+	w.Header().Set("Grpc-Status", strconv.Itoa(int(status.Code)))
+	if status.Message != "" {
+		w.Header().Set("Grpc-Message", encodeMessage(status.Message))
+	}
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = Errorf(Internal, "internal error")
+		}
+	}()
+
+	method, ok := s.methods[r.URL.Path]
+	if !ok {
+		return Errorf(Unimplemented, "unknown method %s", r.URL.Path)
+	}
+
+	ctx := context.WithValue(r.Context(), metadataKey{}, r.Header)
+	if raw := r.Header.Get("Grpc-Timeout"); raw != "" {
+		timeout, err := parseTimeout(raw)
+		if err != nil {
+			return Errorf(InvalidArgument, "invalid grpc-timeout %q", raw)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	data, err := s.readRequest(r.Body)
+	if err != nil {
+		return err
+	}
+	req := method.NewRequest()
+	if err := req.UnmarshalProto(data); err != nil {
+		return Errorf(InvalidArgument, "invalid request message: %v", err)
+	}
+
+	info := &MethodInfo{FullMethod: r.URL.Path, ServerStream: method.Stream != nil}
+	stream := &serverStream{ctx: ctx, w: w}
+
+	if method.Stream != nil {
+		return s.chainStream(method.Stream, info)(req, stream)
+	}
+
+	resp, err := s.chainUnary(method.Unary, info)(ctx, req)
+	if err != nil {
+		return err
+	}
+	return stream.Send(resp)
+}
+
+// readRequest reads the single request message of a unary or server streaming call.
+func (s *Server) readRequest(body io.Reader) ([]byte, error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(body, header); err != nil {
+		return nil, Errorf(InvalidArgument, "missing request message")
+	}
+	if header[0] != 0 {
+		return nil, Errorf(Unimplemented, "compressed messages are not supported")
+	}
+
+	length := binary.BigEndian.Uint32(header[1:])
+	if int64(length) > int64(s.maxMessageSize) {
+		return nil, Errorf(ResourceExhausted, "request message of %d bytes exceeds the maximum of %d", length, s.maxMessageSize)
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(body, data); err != nil {
+		return nil, Errorf(InvalidArgument, "truncated request message")
+	}
+	if n, _ := body.Read(make([]byte, 1)); n > 0 { //nolint:errcheck
+		return nil, Errorf(InvalidArgument, "expected a single request message")
+	}
+	return data, nil
+}
+
+func (s *Server) chainUnary(handler UnaryHandler, info *MethodInfo) UnaryHandler {
+	for i := len(s.unary) - 1; i >= 0; i-- {
+		interceptor, next := s.unary[i], handler
+		handler = func(ctx context.Context, req protobuf.Message) (protobuf.Message, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+	return handler
+}
+
+func (s *Server) chainStream(handler StreamHandler, info *MethodInfo) StreamHandler {
+	for i := len(s.stream) - 1; i >= 0; i-- {
+		interceptor, next := s.stream[i], handler
+		handler = func(req protobuf.Message, stream ServerStream) error {
+			return interceptor(req, stream, info, next)
+		}
+	}
+	return handler
+}
+
+// serverStream writes response messages as length-prefixed frames, flushing each one so
+// that the client receives it right away.
+type serverStream struct {
+	ctx context.Context
+	w   http.ResponseWriter
+	mu  sync.Mutex
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) Send(m protobuf.Message) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	data := protobuf.Marshal(m)
+	frame := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	frame = append(frame, data...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(frame); err != nil {
+		return err
+	}
+	return http.NewResponseController(s.w).Flush()
+}
+
+// WrapServerStream returns a stream that sends on stream with ctx as its context, for
+// interceptors that add values to the context of a streaming call.
+func WrapServerStream(stream ServerStream, ctx context.Context) ServerStream {
+	return &wrappedStream{ServerStream: stream, ctx: ctx}
+}
+
+type wrappedStream struct {
+	ServerStream
+	ctx context.Context
+}
+
+// Context returns the context the stream was wrapped with.
+func (s *wrappedStream) Context() context.Context {
+	return s.ctx
+}
+
+type metadataKey struct{}
+
+// IncomingMetadata returns the metadata the client sent with a call: the headers of the
+// request.
+func IncomingMetadata(ctx context.Context) http.Header {
+	md, _ := ctx.Value(metadataKey{}).(http.Header) //nolint:errcheck
+	if md == nil {
+		return http.Header{}
+	}
+	return md
+}
+
+var timeoutUnits = map[byte]time.Duration{
+	'H': time.Hour,
+	'M': time.Minute,
+	'S': time.Second,
+	'm': time.Millisecond,
+	'u': time.Microsecond,
+	'n': time.Nanosecond,
+}
+
+// parseTimeout reads a grpc-timeout header: at most eight digits followed by a unit.
+func parseTimeout(raw string) (time.Duration, error) {
+	if len(raw) < 2 || len(raw) > 9 {
+		return 0, errors.New("invalid timeout")
+	}
+	unit, ok := timeoutUnits[raw[len(raw)-1]]
+	if !ok {
+		return 0, errors.New("invalid timeout unit")
+	}
+	value, err := strconv.ParseInt(raw[:len(raw)-1], 10, 64)
+	if err != nil || value < 0 {
+		return 0, errors.New("invalid timeout value")
+	}
+	if value > int64(math.MaxInt64/unit) {
+		return math.MaxInt64, nil
+	}
+	return time.Duration(value) * unit, nil
+}
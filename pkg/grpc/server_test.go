@@ -0,0 +1,274 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/G20-00/task-management-service-go/pkg/protobuf"
+)
+
+type echo struct {
+	text string
+}
+
+func (m *echo) MarshalProto(e *protobuf.Encoder) {
+	e.String(1, m.text)
+}
+
+func (m *echo) UnmarshalProto(data []byte) error {
+	return protobuf.Decode(data, func(f protobuf.Field) (err error) {
+		if f.Number == 1 {
+			m.text, err = f.String()
+		}
+		return err
+	})
+}
+
+func newTestServer() *Server {
+	s := NewServer()
+	s.RegisterService(ServiceDesc{Name: "test.Echo", Methods: []MethodDesc{
+		{Name: "Say", NewRequest: func() protobuf.Message { return &echo{} },
+			Unary: func(ctx context.Context, req protobuf.Message) (protobuf.Message, error) {
+				text := req.(*echo).text
+				switch text {
+				case "fail":
+					return nil, Errorf(NotFound, "nada: ñ 100%%")
+				case "panic":
+					panic("boom")
+				case "user":
+					text = ctx.Value(userKey{}).(string)
+				case "deadline":
+					<-ctx.Done()
+					return nil, ctx.Err()
+				}
+				return &echo{text: text}, nil
+			}},
+		{Name: "Repeat", NewRequest: func() protobuf.Message { return &echo{} },
+			Stream: func(req protobuf.Message, stream ServerStream) error {
+				for _, word := range strings.Fields(req.(*echo).text) {
+					if err := stream.Send(&echo{text: word + stream.Context().Value(userKey{}).(string)}); err != nil {
+						return err
+					}
+				}
+				return nil
+			}},
+	}})
+	return s
+}
+
+type userKey struct{}
+
+func frame(m protobuf.Message) []byte {
+	data := protobuf.Marshal(m)
+	header := make([]byte, 5)
+	binary.BigEndian.PutUint32(header[1:], uint32(len(data)))
+	return append(header, data...)
+}
+
+func readFrames(t *testing.T, body []byte) []string {
+	t.Helper()
+	var texts []string
+	for len(body) > 0 {
+		length := binary.BigEndian.Uint32(body[1:5])
+		m := &echo{}
+		if err := m.UnmarshalProto(body[5 : 5+length]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		texts = append(texts, m.text)
+		body = body[5+length:]
+	}
+	return texts
+}
+
+// call makes a gRPC call through ServeHTTP and returns the responses and the trailers.
+func call(t *testing.T, s *Server, method string, body []byte, headers map[string]string) ([]string, http.Header) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, method, bytes.NewReader(body))
+	req.ProtoMajor = 2
+	req.Header.Set("Content-Type", "application/grpc")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	resp := rec.Result()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	return readFrames(t, rec.Body.Bytes()), resp.Trailer
+}
+
+func TestServer_Unary(t *testing.T) {
+	s := newTestServer()
+	s.UseUnary(func(ctx context.Context, req protobuf.Message, info *MethodInfo, handler UnaryHandler) (protobuf.Message, error) {
+		user := IncomingMetadata(ctx).Get("x-user") + "@" + info.FullMethod
+		return handler(context.WithValue(ctx, userKey{}, user), req)
+	})
+
+	cases := []struct {
+		name    string
+		text    string
+		want    []string
+		status  string
+		message string
+	}{
+		{"ok", "hola", []string{"hola"}, "0", ""},
+		{"interceptor", "user", []string{"ana@/test.Echo/Say"}, "0", ""},
+		{"status error", "fail", nil, "5", "nada: %C3%B1 100%25"},
+		{"panic", "panic", nil, "13", "internal error"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, trailer := call(t, s, "/test.Echo/Say", frame(&echo{text: tc.text}), map[string]string{"X-User": "ana"})
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+			if trailer.Get("Grpc-Status") != tc.status || trailer.Get("Grpc-Message") != tc.message {
+				t.Errorf("expected status %s %q, got %s %q", tc.status, tc.message, trailer.Get("Grpc-Status"), trailer.Get("Grpc-Message"))
+			}
+		})
+	}
+}
+
+func TestServer_Stream(t *testing.T) {
+	s := newTestServer()
+	s.UseStream(func(req protobuf.Message, stream ServerStream, info *MethodInfo, handler StreamHandler) error {
+		if !info.ServerStream {
+			t.Error("expected a server streaming method")
+		}
+		return handler(req, WrapServerStream(stream, context.WithValue(stream.Context(), userKey{}, "!")))
+	})
+
+	got, trailer := call(t, s, "/test.Echo/Repeat", frame(&echo{text: "uno dos tres"}), nil)
+	if strings.Join(got, ",") != "uno!,dos!,tres!" {
+		t.Errorf("expected three messages, got %v", got)
+	}
+	if trailer.Get("Grpc-Status") != "0" {
+		t.Errorf("expected status 0, got %s", trailer.Get("Grpc-Status"))
+	}
+}
+
+func TestServer_RequestErrors(t *testing.T) {
+	s := newTestServer()
+	compressed := frame(&echo{text: "x"})
+	compressed[0] = 1
+
+	cases := []struct {
+		name    string
+		method  string
+		body    []byte
+		headers map[string]string
+		status  string
+		message string
+	}{
+		{"unknown method", "/test.Echo/Nope", frame(&echo{}), nil, "12", "unknown method /test.Echo/Nope"},
+		{"missing message", "/test.Echo/Say", nil, nil, "3", "missing request message"},
+		{"truncated message", "/test.Echo/Say", frame(&echo{text: "hola"})[:7], nil, "3", "truncated request message"},
+		{"two messages", "/test.Echo/Say", append(frame(&echo{}), frame(&echo{})...), nil, "3", "expected a single request message"},
+		{"compressed", "/test.Echo/Say", compressed, nil, "12", "compressed messages are not supported"},
+		{"invalid message", "/test.Echo/Say", []byte{0, 0, 0, 0, 2, 0x08, 0x01}, nil, "3", "invalid request message: field 1 has wire type 0, expected 2"},
+		{"invalid timeout", "/test.Echo/Say", frame(&echo{}), map[string]string{"Grpc-Timeout": "5x"}, "3", `invalid grpc-timeout "5x"`},
+		{"deadline", "/test.Echo/Say", frame(&echo{text: "deadline"}), map[string]string{"Grpc-Timeout": "1m"}, "4", "context deadline exceeded"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, trailer := call(t, s, tc.method, tc.body, tc.headers)
+			if trailer.Get("Grpc-Status") != tc.status || trailer.Get("Grpc-Message") != tc.message {
+				t.Errorf("expected status %s %q, got %s %q", tc.status, tc.message, trailer.Get("Grpc-Status"), trailer.Get("Grpc-Message"))
+			}
+		})
+	}
+}
+
+func TestServer_RejectsNonGRPCRequests(t *testing.T) {
+	s := newTestServer()
+
+	req := httptest.NewRequest(http.MethodPost, "/test.Echo/Say", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusHTTPVersionNotSupported {
+		t.Errorf("expected status 505 for HTTP/1.1, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/test.Echo/Say", nil)
+	req.ProtoMajor = 2
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected status 415, got %d", rec.Code)
+	}
+}
+
+func TestServer_NewHTTPServerSpeaksCleartextHTTP2(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	server := newTestServer().NewHTTPServer(listener.Addr().String())
+	go server.Serve(listener) //nolint:errcheck
+	defer server.Close()
+
+	protocols := &http.Protocols{}
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{
+		Transport: &http.Transport{Protocols: protocols},
+		Timeout:   5 * time.Second,
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "http://"+listener.Addr().String()+"/test.Echo/Say", bytes.NewReader(frame(&echo{text: "hola"})))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.ProtoMajor != 2 {
+		t.Errorf("expected HTTP/2, got %s", resp.Proto)
+	}
+	if got := readFrames(t, body); len(got) != 1 || got[0] != "hola" {
+		t.Errorf("expected hola, got %v", got)
+	}
+	if resp.Trailer.Get("Grpc-Status") != "0" {
+		t.Errorf("expected status 0, got %q", resp.Trailer.Get("Grpc-Status"))
+	}
+}
+
+func TestStatusOf(t *testing.T) {
+	cases := []struct {
+		err  error
+		want Code
+	}{
+		{nil, OK},
+		{Errorf(NotFound, "x"), NotFound},
+		{context.Canceled, Canceled},
+		{errors.New("x"), Unknown},
+	}
+
+	for _, tc := range cases {
+		if got := StatusOf(tc.err).Code; got != tc.want {
+			t.Errorf("expected %d for %v, got %d", tc.want, tc.err, got)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	cases := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{"add member", `{"a":1}`, `[{"op":"add","path":"/b","value":[1]}]`, `{"a":1,"b":[1]}`},
+		{"add to array end", `{"a":[1,2]}`, `[{"op":"add","path":"/a/-","value":3}]`, `{"a":[1,2,3]}`},
+		{"insert in array", `{"a":[1,3]}`, `[{"op":"add","path":"/a/1","value":2}]`, `{"a":[1,2,3]}`},
+		{"remove", `{"a":1,"b":2}`, `[{"op":"remove","path":"/a"}]`, `{"b":2}`},
+		{"remove from array", `{"a":[1,2,3]}`, `[{"op":"remove","path":"/a/0"}]`, `{"a":[2,3]}`},
+		{"replace with null", `{"a":"x"}`, `[{"op":"replace","path":"/a","value":null}]`, `{"a":null}`},
+		{"move", `{"a":{"b":1},"c":{}}`, `[{"op":"move","from":"/a/b","path":"/c/d"}]`, `{"a":{},"c":{"d":1}}`},
+		{"copy", `{"a":[1]}`, `[{"op":"copy","from":"/a","path":"/b"},{"op":"add","path":"/b/-","value":2}]`, `{"a":[1],"b":[1,2]}`},
+		{"test then replace", `{"v":3,"a":"x"}`, `[{"op":"test","path":"/v","value":3},{"op":"replace","path":"/a","value":"y"}]`, `{"v":3,"a":"y"}`},
+		{"escaped path", `{"a/b":1,"c~d":2}`, `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/c~0d"}]`, `{"a/b":3}`},
+		{"replace document", `{"a":1}`, `[{"op":"replace","path":"","value":{"b":2}}]`, `{"b":2}`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var doc, want interface{}
+			if err := json.Unmarshal([]byte(tc.doc), &doc); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tc.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			ops, err := Decode([]byte(tc.patch))
+			if err != nil {
+				t.Fatalf("unexpected decode error: %v", err)
+			}
+
+			got, err := Apply(doc, ops)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			gotJSON, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantJSON, err := json.Marshal(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("expected %s, got %s", wantJSON, gotJSON)
+			}
+		})
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		patch  string
+		prefix string
+	}{
+		{"test mismatch", `[{"op":"test","path":"/a","value":2}]`, "patch test failed"},
+		{"missing path", `[{"op":"replace","path":"/missing","value":1}]`, "invalid patch"},
+		{"missing value", `[{"op":"add","path":"/b"}]`, "invalid patch"},
+		{"index out of range", `[{"op":"add","path":"/list/5","value":1}]`, "invalid patch"},
+		{"leading zero index", `[{"op":"remove","path":"/list/01"}]`, "invalid patch"},
+		{"unknown op", `[{"op":"merge","path":"/a"}]`, "invalid patch"},
+		{"move into child", `[{"op":"move","from":"/obj","path":"/obj/x"}]`, "invalid patch"},
+		{"relative path", `[{"op":"remove","path":"a"}]`, "invalid patch"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := map[string]interface{}{"a": 1, "list": []interface{}{1, 2}, "obj": map[string]interface{}{}}
+			ops, err := Decode([]byte(tc.patch))
+			if err != nil {
+				t.Fatalf("unexpected decode error: %v", err)
+			}
+			if _, err := Apply(doc, ops); err == nil || !strings.HasPrefix(err.Error(), tc.prefix) {
+				t.Errorf("expected error starting with %q, got %v", tc.prefix, err)
+			}
+			if doc["a"] != 1 {
+				t.Error("the original document must not change")
+			}
+		})
+	}
+}
+
+func TestDecode_NotAnArray(t *testing.T) {
+	if _, err := Decode([]byte(`{"op":"add"}`)); err == nil {
+		t.Error("expected error for a patch that is not an array")
+	}
+}
@@ -0,0 +1,166 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testItem struct {
+	ID      string            `json:"id" openapi:"format=uuid"`
+	Title   string            `json:"title" openapi:"required,minLength=1,maxLength=10"`
+	Status  string            `json:"status,omitempty" openapi:"enum=open|closed"`
+	Due     *time.Time        `json:"due,omitempty" openapi:"nullable"`
+	Count   int               `json:"count" openapi:"minimum=0,maximum=5"`
+	Tags    []string          `json:"tags" openapi:"maxItems=2"`
+	Labels  map[string]string `json:"labels"`
+	Child   *testItem         `json:"child,omitempty"`
+	Ignored string            `json:"-"`
+}
+
+func newTestDocument() (*Document, *Schema) {
+	doc := New(Info{Title: "Test", Version: "1"})
+	schema := doc.Components.SchemaOf(testItem{})
+	doc.Add("POST", "/items", &Operation{
+		OperationID: "createItem",
+		RequestBody: &RequestBody{Required: true, Content: map[string]*MediaType{"application/json": {Schema: schema}}},
+		Responses:   map[string]*Response{"201": {Description: "Created"}},
+	})
+	doc.Add("GET", "/items/{id}", &Operation{OperationID: "getItem"})
+	doc.Add("GET", "/items/search", &Operation{OperationID: "searchItems"})
+	return doc, schema
+}
+
+func TestSchemaOf(t *testing.T) {
+	doc, schema := newTestDocument()
+
+	if schema.Ref != "#/components/schemas/TestItem" {
+		t.Fatalf("expected a reference to TestItem, got %q", schema.Ref)
+	}
+	item := doc.Components.Schemas["TestItem"]
+	if item == nil {
+		t.Fatal("expected TestItem to be registered")
+	}
+	if !reflect.DeepEqual(item.Required, []string{"title"}) {
+		t.Errorf("expected title to be required, got %v", item.Required)
+	}
+	if _, ok := item.Properties["Ignored"]; ok {
+		t.Error("expected fields tagged json:\"-\" to be skipped")
+	}
+	if len(item.Properties) != 8 {
+		t.Errorf("expected 8 properties, got %d", len(item.Properties))
+	}
+
+	due := item.Properties["due"]
+	if !reflect.DeepEqual(due.Type, Types{"string", "null"}) || due.Format != "date-time" {
+		t.Errorf("unexpected due schema: %+v", due)
+	}
+	if got := item.Properties["status"].Enum; !reflect.DeepEqual(got, []string{"open", "closed"}) {
+		t.Errorf("unexpected status enum: %v", got)
+	}
+	if got := item.Properties["child"].Ref; got != "#/components/schemas/TestItem" {
+		t.Errorf("expected the recursive field to refer to TestItem, got %q", got)
+	}
+	if got := item.Properties["labels"].AdditionalProperties; got == nil || got.Type[0] != "string" {
+		t.Errorf("unexpected labels schema: %+v", item.Properties["labels"])
+	}
+
+	data, err := json.Marshal(due)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"type":["string","null"],"format":"date-time"}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+type testDetail struct {
+	testItem
+	Notes string `json:"notes"`
+}
+
+func TestSchemaOf_Embedded(t *testing.T) {
+	doc := New(Info{Title: "Test", Version: "1"})
+	doc.Components.SchemaOf(testDetail{})
+
+	detail := doc.Components.Schemas["TestDetail"]
+	if detail == nil || detail.Properties["title"] == nil || detail.Properties["notes"] == nil {
+		t.Fatalf("expected the embedded fields next to notes, got %+v", detail)
+	}
+	if _, ok := detail.Properties["testItem"]; ok {
+		t.Error("expected the embedded struct not to be a property")
+	}
+	if !reflect.DeepEqual(detail.Required, []string{"title"}) {
+		t.Errorf("expected the embedded required fields, got %v", detail.Required)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	doc, _ := newTestDocument()
+
+	cases := []struct {
+		method, path, want string
+	}{
+		{"GET", "/items/123", "getItem"},
+		{"GET", "/items/search", "searchItems"},
+		{"POST", "/items/", "createItem"},
+		{"DELETE", "/items/123", ""},
+		{"GET", "/items/123/extra", ""},
+	}
+	for _, tc := range cases {
+		op, ok := doc.Match(tc.method, tc.path)
+		got := ""
+		if ok {
+			got = op.OperationID
+		}
+		if got != tc.want {
+			t.Errorf("%s %s: expected %q, got %q", tc.method, tc.path, tc.want, got)
+		}
+	}
+}
+
+func TestValidateBody(t *testing.T) {
+	doc, _ := newTestDocument()
+	op, _ := doc.Match("POST", "/items")
+
+	cases := []struct {
+		name        string
+		contentType string
+		body        string
+		want        []FieldError
+	}{
+		{"valid", "application/json", `{"title":"a","status":"open","due":null,"count":2,"tags":["x"],"child":{"title":"b"}}`, nil},
+		{"media type parameters", "application/json; charset=utf-8", `{"title":"a"}`, nil},
+		{"undescribed media type", "text/plain", `not json`, nil},
+		{"empty body", "application/json", ``, []FieldError{{"", "is required"}}},
+		{"invalid JSON", "application/json", `{"title":`, []FieldError{{"", "must be valid JSON"}}},
+		{"not an object", "application/json", `[1]`, []FieldError{{"", "must be an object"}}},
+		{"missing required", "application/json", `{}`, []FieldError{{"/title", "is required"}}},
+		{"wrong type", "application/json", `{"title":1}`, []FieldError{{"/title", "must be a string"}}},
+		{"empty string", "application/json", `{"title":""}`, []FieldError{{"/title", "cannot be empty"}}},
+		{"too long", "application/json", `{"title":"abcdefghijk"}`, []FieldError{{"/title", "must be at most 10 characters long"}}},
+		{"enum", "application/json", `{"title":"a","status":"done"}`, []FieldError{{"/status", "must be one of: open, closed"}}},
+		{"format", "application/json", `{"title":"a","id":"x","due":"tomorrow"}`, []FieldError{
+			{"/due", "must be an RFC 3339 date-time"},
+			{"/id", "must be a UUID"},
+		}},
+		{"integer", "application/json", `{"title":"a","count":1.5}`, []FieldError{{"/count", "must be an integer"}}},
+		{"maximum", "application/json", `{"title":"a","count":6}`, []FieldError{{"/count", "must be at most 5"}}},
+		{"items", "application/json", `{"title":"a","tags":["x",2,"z"]}`, []FieldError{
+			{"/tags", "must have at most 2 items"},
+			{"/tags/1", "must be a string"},
+		}},
+		{"nested", "application/json", `{"title":"a","child":{"title":null}}`, []FieldError{{"/child/title", "must be a string"}}},
+		{"map values", "application/json", `{"title":"a","labels":{"a/b":true}}`, []FieldError{{"/labels/a~1b", "must be a string"}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := doc.ValidateBody(op, tc.contentType, []byte(tc.body))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Schema is a JSON Schema, the subset of the 2020-12 draft used by OpenAPI 3.1 that this
+// package generates and validates.
+type Schema struct {
+	Ref         string             `json:"$ref,omitempty"`
+	Type        Types              `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	// AdditionalProperties is the schema of the values of a map.
+	AdditionalProperties *Schema  `json:"additionalProperties,omitempty"`
+	Items                *Schema  `json:"items,omitempty"`
+	Enum                 []string `json:"enum,omitempty"`
+	MinLength            *int     `json:"minLength,omitempty"`
+	MaxLength            *int     `json:"maxLength,omitempty"`
+	MinItems             *int     `json:"minItems,omitempty"`
+	MaxItems             *int     `json:"maxItems,omitempty"`
+	Minimum              *float64 `json:"minimum,omitempty"`
+	Maximum              *float64 `json:"maximum,omitempty"`
+	Examples             []any    `json:"examples,omitempty"`
+	Default              any      `json:"default,omitempty"`
+}
+
+// Types is the type keyword of a schema: one JSON type, or several when the value can
+// also be null.
+type Types []string
+
+// MarshalJSON writes a single type as a string and several as an array.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON reads a type written as a string or as an array.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	var several []string
+	if err := json.Unmarshal(data, &several); err != nil {
+		return err
+	}
+	*t = several
+	return nil
+}
+
+// Ref returns a schema that refers to a component schema.
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// String returns a string schema with an optional format.
+func String(format string) *Schema {
+	return &Schema{Type: Types{"string"}, Format: format}
+}
+
+// Integer returns an integer schema.
+func Integer() *Schema {
+	return &Schema{Type: Types{"integer"}}
+}
+
+// Boolean returns a boolean schema.
+func Boolean() *Schema {
+	return &Schema{Type: Types{"boolean"}}
+}
+
+// ArrayOf returns an array schema.
+func ArrayOf(items *Schema) *Schema {
+	return &Schema{Type: Types{"array"}, Items: items}
+}
+
+// Nullable returns a copy of the schema that also accepts null.
+func (s *Schema) Nullable() *Schema {
+	nullable := *s
+	nullable.Type = append(append(Types{}, s.Type...), "null")
+	return &nullable
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// SchemaOf returns the schema of the type of v, registering the schemas of the structs it
+// involves as components named after their types. Struct fields are described by their
+// json tag and by an openapi tag with comma separated options:
+//
+//	required           the field must be present
+//	nullable           the field can be null
+//	enum=a|b|c         the allowed values
+//	format=uuid        the format of a string
+//	minLength=1, maxLength=255, minItems=1, maxItems=100, minimum=0, maximum=10
+func (c *Components) SchemaOf(v any) *Schema {
+	return c.schemaOf(reflect.TypeOf(v))
+}
+
+func (c *Components) schemaOf(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return String("date-time")
+	case t == reflect.TypeOf(json.RawMessage{}):
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return c.schemaOf(t.Elem())
+	case reflect.String:
+		return String("")
+	case reflect.Bool:
+		return Boolean()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Integer()
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}
+	case reflect.Slice, reflect.Array:
+		return ArrayOf(c.schemaOf(t.Elem()))
+	case reflect.Map:
+		return &Schema{Type: Types{"object"}, AdditionalProperties: c.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return c.structSchema(t)
+		}
+		name := componentName(t)
+		if _, ok := c.Schemas[name]; !ok {
+			// Registered before its fields so that recursive types refer to it.
+			c.Schemas[name] = &Schema{}
+			*c.Schemas[name] = *c.structSchema(t)
+		}
+		return Ref(name)
+	}
+	return &Schema{}
+}
+
+func componentName(t reflect.Type) string {
+	runes := []rune(t.Name())
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func (c *Components) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: Types{"object"}, Properties: map[string]*Schema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if embedded := embeddedStruct(field); embedded != nil {
+			// Fields of an embedded struct are encoded as fields of the outer one.
+			inner := c.structSchema(embedded)
+			for name, property := range inner.Properties {
+				schema.Properties[name] = property
+			}
+			schema.Required = append(schema.Required, inner.Required...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := c.schemaOf(field.Type)
+		required, err := applyTag(property, field.Tag.Get("openapi"))
+		if err != nil {
+			panic(fmt.Sprintf("openapi: field %s.%s: %v", t.Name(), field.Name, err))
+		}
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
+
+// embeddedStruct returns the type of an embedded struct field without a json name, whose
+// fields encoding/json promotes to the outer struct, or nil for any other field.
+func embeddedStruct(field reflect.StructField) reflect.Type {
+	if !field.Anonymous || field.Tag.Get("json") != "" {
+		return nil
+	}
+	t := field.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType {
+		return nil
+	}
+	return t
+}
+
+// applyTag applies the options of an openapi tag to the schema of a field, and reports
+// whether the field is required.
+func applyTag(s *Schema, tag string) (bool, error) {
+	required := false
+	if tag == "" {
+		return required, nil
+	}
+
+	for _, option := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(option, "=")
+		var err error
+		switch key {
+		case "required":
+			required = true
+		case "nullable":
+			*s = *s.Nullable()
+		case "enum":
+			s.Enum = strings.Split(value, "|")
+		case "format":
+			s.Format = value
+		case "minLength":
+			s.MinLength, err = intOption(value)
+		case "maxLength":
+			s.MaxLength, err = intOption(value)
+		case "minItems":
+			s.MinItems, err = intOption(value)
+		case "maxItems":
+			s.MaxItems, err = intOption(value)
+		case "minimum":
+			s.Minimum, err = floatOption(value)
+		case "maximum":
+			s.Maximum, err = floatOption(value)
+		default:
+			err = fmt.Errorf("unknown option %q", key)
+		}
+		if err != nil {
+			return required, err
+		}
+	}
+	return required, nil
+}
+
+func intOption(value string) (*int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func floatOption(value string) (*float64, error) {
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
@@ -0,0 +1,223 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FieldError describes why a value of a body does not match its schema. Field is the JSON
+// pointer of the value, e.g. /title, or empty for the body itself.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ValidateBody validates a request body against the request body of an operation, choosing
+// the schema by the media type of contentType. A body of a media type the operation does
+// not describe is not validated: the handler decides how to reject it.
+func (d *Document) ValidateBody(op *Operation, contentType string, body []byte) []FieldError {
+	if op.RequestBody == nil {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil
+	}
+	media, ok := op.RequestBody.Content[mediaType]
+	if !ok || media.Schema == nil {
+		return nil
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			return []FieldError{{Field: "", Message: "is required"}}
+		}
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return []FieldError{{Field: "", Message: "must be valid JSON"}}
+	}
+	return d.Validate(media.Schema, value)
+}
+
+// Validate validates a value decoded from JSON, with numbers decoded as json.Number or
+// float64, against a schema whose references point to the components of the document.
+func (d *Document) Validate(schema *Schema, value any) []FieldError {
+	var errs []FieldError
+	d.validate(schema, value, "", &errs)
+	return errs
+}
+
+func (d *Document) validate(schema *Schema, value any, pointer string, errs *[]FieldError) {
+	add := func(format string, args ...any) {
+		*errs = append(*errs, FieldError{Field: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if schema.Ref != "" {
+		resolved := d.resolve(schema.Ref)
+		if resolved == nil {
+			add("refers to an unknown schema %s", schema.Ref)
+			return
+		}
+		if value == nil && slices.Contains(schema.Type, "null") {
+			return
+		}
+		d.validate(resolved, value, pointer, errs)
+		return
+	}
+
+	if len(schema.Type) > 0 && !slices.ContainsFunc(schema.Type, func(t string) bool { return hasType(value, t) }) {
+		add("must be %s", typeNames(schema.Type))
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, v) {
+			add("must be one of: %s", strings.Join(schema.Enum, ", "))
+			return
+		}
+		length := utf8.RuneCountInString(v)
+		if schema.MinLength != nil && length < *schema.MinLength {
+			if *schema.MinLength == 1 {
+				add("cannot be empty")
+			} else {
+				add("must be at least %d characters long", *schema.MinLength)
+			}
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			add("must be at most %d characters long", *schema.MaxLength)
+		}
+		if message := checkFormat(schema.Format, v); message != "" {
+			add("%s", message)
+		}
+	case json.Number, float64:
+		n := number(v)
+		if schema.Minimum != nil && n < *schema.Minimum {
+			add("must be at least %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			add("must be at most %v", *schema.Maximum)
+		}
+	case []any:
+		if schema.MinItems != nil && len(v) < *schema.MinItems {
+			add("must have at least %d items", *schema.MinItems)
+		}
+		if schema.MaxItems != nil && len(v) > *schema.MaxItems {
+			add("must have at most %d items", *schema.MaxItems)
+		}
+		if schema.Items != nil {
+			for i, item := range v {
+				d.validate(schema.Items, item, fmt.Sprintf("%s/%d", pointer, i), errs)
+			}
+		}
+	case map[string]any:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, FieldError{Field: pointer + "/" + escapePointer(name), Message: "is required"})
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := schema.Properties[name]
+			if !ok {
+				property = schema.AdditionalProperties
+			}
+			if property != nil {
+				d.validate(property, v[name], pointer+"/"+escapePointer(name), errs)
+			}
+		}
+	}
+}
+
+func (d *Document) resolve(ref string) *Schema {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok || d.Components == nil {
+		return nil
+	}
+	return d.Components.Schemas[name]
+}
+
+func hasType(value any, t string) bool {
+	switch v := value.(type) {
+	case nil:
+		return t == "null"
+	case string:
+		return t == "string"
+	case bool:
+		return t == "boolean"
+	case json.Number, float64:
+		n := number(v)
+		return t == "number" || (t == "integer" && n == math.Trunc(n))
+	case []any:
+		return t == "array"
+	case map[string]any:
+		return t == "object"
+	}
+	return false
+}
+
+func number(v any) float64 {
+	if n, ok := v.(json.Number); ok {
+		f, _ := n.Float64() //nolint:errcheck // json.Number holds a valid number
+		return f
+	}
+	f, _ := v.(float64) //nolint:errcheck // called only with json.Number or float64
+	return f
+}
+
+func typeNames(types Types) string {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		switch t {
+		case "array", "object", "integer":
+			names = append(names, "an "+t)
+		case "null":
+			names = append(names, "null")
+		default:
+			names = append(names, "a "+t)
+		}
+	}
+	return strings.Join(names, " or ")
+}
+
+func checkFormat(format, value string) string {
+	switch format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return "must be an RFC 3339 date-time"
+		}
+	case "date":
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return "must be a date in the form YYYY-MM-DD"
+		}
+	case "uuid":
+		if !uuidPattern.MatchString(value) {
+			return "must be a UUID"
+		}
+	}
+	return ""
+}
+
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
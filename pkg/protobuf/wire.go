@@ -0,0 +1,271 @@
+// Package protobuf encodes and decodes messages in the protocol buffers binary wire format.
+// There is no code generation: a message implements Message by hand, writing its fields
+// with an Encoder and reading them back with Decode, using the field numbers of its .proto
+// definition.
+package protobuf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// WireType is the encoding of a field value on the wire.
+type WireType int
+
+// The wire types of the protocol buffers format. Groups are deprecated and not supported.
+const (
+	Varint  WireType = 0
+	Fixed64 WireType = 1
+	Bytes   WireType = 2
+	Fixed32 WireType = 5
+)
+
+// maxFieldNumber is the highest field number a message can use.
+const maxFieldNumber = 1<<29 - 1
+
+var errTruncated = errors.New("truncated message")
+
+// Message is a protocol buffers message.
+type Message interface {
+	// MarshalProto writes the fields of the message.
+	MarshalProto(e *Encoder)
+	// UnmarshalProto reads the fields of an encoded message into the message.
+	UnmarshalProto(data []byte) error
+}
+
+// Marshal encodes a message.
+func Marshal(m Message) []byte {
+	e := &Encoder{}
+	m.MarshalProto(e)
+	return e.buf
+}
+
+// Encoder writes the fields of a message. Following proto3, the methods of scalar fields
+// leave out zero values, which the decoder reads back as the default.
+type Encoder struct {
+	buf []byte
+}
+
+func (e *Encoder) tag(num int, t WireType) {
+	e.buf = binary.AppendUvarint(e.buf, uint64(num)<<3|uint64(t))
+}
+
+func (e *Encoder) bytes(num int, v []byte) {
+	e.tag(num, Bytes)
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+// String writes a string field.
+func (e *Encoder) String(num int, v string) {
+	if v != "" {
+		e.bytes(num, []byte(v))
+	}
+}
+
+// Bytes writes a bytes field.
+func (e *Encoder) Bytes(num int, v []byte) {
+	if len(v) > 0 {
+		e.bytes(num, v)
+	}
+}
+
+// Int64 writes an int64 field.
+func (e *Encoder) Int64(num int, v int64) {
+	if v != 0 {
+		e.tag(num, Varint)
+		e.buf = binary.AppendUvarint(e.buf, uint64(v))
+	}
+}
+
+// Int32 writes an int32 field. Negative values take ten bytes, as for int64.
+func (e *Encoder) Int32(num int, v int32) {
+	e.Int64(num, int64(v))
+}
+
+// Bool writes a bool field.
+func (e *Encoder) Bool(num int, v bool) {
+	if v {
+		e.tag(num, Varint)
+		e.buf = append(e.buf, 1)
+	}
+}
+
+// Strings writes a repeated string field, one element at a time.
+func (e *Encoder) Strings(num int, v []string) {
+	for _, s := range v {
+		e.bytes(num, []byte(s))
+	}
+}
+
+// Message writes an embedded message field. It is written even when empty, since the
+// presence of a message field is meaningful.
+func (e *Encoder) Message(num int, m Message) {
+	e.bytes(num, Marshal(m))
+}
+
+// Timestamp writes a google.protobuf.Timestamp field. A nil time leaves the field unset.
+func (e *Encoder) Timestamp(num int, t *time.Time) {
+	if t == nil {
+		return
+	}
+	e.Message(num, &timestamp{seconds: t.Unix(), nanos: int32(t.Nanosecond())})
+}
+
+// Field is one field read from an encoded message.
+type Field struct {
+	Number int
+	Type   WireType
+	value  uint64
+	data   []byte
+}
+
+// Decode calls fn with every field of an encoded message, in the order they were written.
+// Unknown fields can be ignored by fn, as proto3 requires.
+func Decode(data []byte, fn func(f Field) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errTruncated
+		}
+		data = data[n:]
+
+		f := Field{Number: int(key >> 3), Type: WireType(key & 7)}
+		if key>>3 == 0 || key>>3 > maxFieldNumber {
+			return fmt.Errorf("invalid field number %d", key>>3)
+		}
+
+		switch f.Type {
+		case Varint:
+			if f.value, n = binary.Uvarint(data); n <= 0 {
+				return errTruncated
+			}
+			data = data[n:]
+		case Fixed64:
+			if len(data) < 8 {
+				return errTruncated
+			}
+			f.value = binary.LittleEndian.Uint64(data)
+			data = data[8:]
+		case Fixed32:
+			if len(data) < 4 {
+				return errTruncated
+			}
+			f.value = uint64(binary.LittleEndian.Uint32(data))
+			data = data[4:]
+		case Bytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return errTruncated
+			}
+			f.data = data[n : n+int(length)]
+			data = data[n+int(length):]
+		default:
+			return fmt.Errorf("unsupported wire type %d of field %d", f.Type, f.Number)
+		}
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f Field) expect(t WireType) error {
+	if f.Type != t {
+		return fmt.Errorf("field %d has wire type %d, expected %d", f.Number, f.Type, t)
+	}
+	return nil
+}
+
+// String reads a string field.
+func (f Field) String() (string, error) {
+	if err := f.expect(Bytes); err != nil {
+		return "", err
+	}
+	return string(f.data), nil
+}
+
+// Bytes reads a bytes field. The returned slice is a copy.
+func (f Field) Bytes() ([]byte, error) {
+	if err := f.expect(Bytes); err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), f.data...), nil
+}
+
+// Int64 reads an int64 field.
+func (f Field) Int64() (int64, error) {
+	if err := f.expect(Varint); err != nil {
+		return 0, err
+	}
+	return int64(f.value), nil
+}
+
+// Int32 reads an int32 field.
+func (f Field) Int32() (int32, error) {
+	v, err := f.Int64()
+	if err != nil {
+		return 0, err
+	}
+	if v < math.MinInt32 || v > math.MaxInt32 {
+		return 0, fmt.Errorf("field %d overflows int32", f.Number)
+	}
+	return int32(v), nil
+}
+
+// Bool reads a bool field.
+func (f Field) Bool() (bool, error) {
+	if err := f.expect(Varint); err != nil {
+		return false, err
+	}
+	return f.value != 0, nil
+}
+
+// Message reads an embedded message field into m. When the field is repeated in the
+// encoded message, each occurrence is merged into m by calling Message again.
+func (f Field) Message(m Message) error {
+	if err := f.expect(Bytes); err != nil {
+		return err
+	}
+	return m.UnmarshalProto(f.data)
+}
+
+// Timestamp reads a google.protobuf.Timestamp field.
+func (f Field) Timestamp() (*time.Time, error) {
+	ts := &timestamp{}
+	if err := f.Message(ts); err != nil {
+		return nil, err
+	}
+	if ts.nanos < 0 || ts.nanos > 999999999 {
+		return nil, fmt.Errorf("field %d is not a valid timestamp", f.Number)
+	}
+	t := time.Unix(ts.seconds, int64(ts.nanos)).UTC()
+	return &t, nil
+}
+
+// timestamp is the google.protobuf.Timestamp well-known type.
+type timestamp struct {
+	seconds int64
+	nanos   int32
+}
+
+func (ts *timestamp) MarshalProto(e *Encoder) {
+	e.Int64(1, ts.seconds)
+	e.Int32(2, ts.nanos)
+}
+
+func (ts *timestamp) UnmarshalProto(data []byte) error {
+	return Decode(data, func(f Field) (err error) {
+		switch f.Number {
+		case 1:
+			ts.seconds, err = f.Int64()
+		case 2:
+			ts.nanos, err = f.Int32()
+		}
+		return err
+	})
+}
@@ -0,0 +1,131 @@
+package protobuf
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type testMessage struct {
+	id      int64
+	name    string
+	tags    []string
+	enabled bool
+	delta   int32
+	at      *time.Time
+	child   *testMessage
+}
+
+func (m *testMessage) MarshalProto(e *Encoder) {
+	e.Int64(1, m.id)
+	e.String(2, m.name)
+	e.Strings(3, m.tags)
+	e.Bool(4, m.enabled)
+	e.Int32(5, m.delta)
+	e.Timestamp(6, m.at)
+	if m.child != nil {
+		e.Message(7, m.child)
+	}
+}
+
+func (m *testMessage) UnmarshalProto(data []byte) error {
+	return Decode(data, func(f Field) (err error) {
+		switch f.Number {
+		case 1:
+			m.id, err = f.Int64()
+		case 2:
+			m.name, err = f.String()
+		case 3:
+			var tag string
+			tag, err = f.String()
+			m.tags = append(m.tags, tag)
+		case 4:
+			m.enabled, err = f.Bool()
+		case 5:
+			m.delta, err = f.Int32()
+		case 6:
+			m.at, err = f.Timestamp()
+		case 7:
+			m.child = &testMessage{}
+			err = f.Message(m.child)
+		}
+		return err
+	})
+}
+
+func TestMarshal_WireFormat(t *testing.T) {
+	cases := []struct {
+		name string
+		msg  *testMessage
+		want []byte
+	}{
+		{"varint", &testMessage{id: 150}, []byte{0x08, 0x96, 0x01}},
+		{"string", &testMessage{name: "testing"}, []byte{0x12, 0x07, 't', 'e', 's', 't', 'i', 'n', 'g'}},
+		{"repeated", &testMessage{tags: []string{"a", ""}}, []byte{0x1a, 0x01, 'a', 0x1a, 0x00}},
+		{"negative int32", &testMessage{delta: -1}, []byte{0x28, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+		{"zero values", &testMessage{}, nil},
+		{"empty message", &testMessage{child: &testMessage{}}, []byte{0x3a, 0x00}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Marshal(tc.msg); !bytes.Equal(got, tc.want) {
+				t.Errorf("expected % x, got % x", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDecode_RoundTrip(t *testing.T) {
+	at := time.Date(2024, 5, 1, 10, 30, 0, 500, time.UTC)
+	msg := &testMessage{
+		id: 1 << 40, name: "tarea", tags: []string{"x", "y"}, enabled: true, delta: -7, at: &at,
+		child: &testMessage{name: "hija"},
+	}
+
+	got := &testMessage{}
+	if err := got.UnmarshalProto(Marshal(msg)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.id != msg.id || got.name != msg.name || len(got.tags) != 2 || got.tags[1] != "y" ||
+		!got.enabled || got.delta != -7 || !got.at.Equal(at) || got.child.name != "hija" {
+		t.Errorf("expected %+v, got %+v", msg, got)
+	}
+}
+
+func TestDecode_SkipsUnknownFields(t *testing.T) {
+	// Field 9 as a fixed64, field 10 as a fixed32 and field 11 as bytes, then field 2.
+	data := []byte{0x49, 1, 2, 3, 4, 5, 6, 7, 8, 0x55, 1, 2, 3, 4, 0x5a, 0x01, 'z', 0x12, 0x01, 'a'}
+
+	got := &testMessage{}
+	if err := got.UnmarshalProto(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.name != "a" {
+		t.Errorf("expected name a, got %q", got.name)
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"truncated varint", []byte{0x08, 0x96}, "truncated message"},
+		{"truncated bytes", []byte{0x12, 0x05, 'a'}, "truncated message"},
+		{"field number zero", []byte{0x00, 0x01}, "invalid field number 0"},
+		{"group", []byte{0x0b}, "unsupported wire type 3 of field 1"},
+		{"wrong wire type", []byte{0x10, 0x01}, "field 2 has wire type 0, expected 2"},
+		{"int32 overflow", []byte{0x28, 0x80, 0x80, 0x80, 0x80, 0x10}, "field 5 overflows int32"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&testMessage{}).UnmarshalProto(tc.data)
+			if err == nil || err.Error() != tc.want {
+				t.Errorf("expected %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
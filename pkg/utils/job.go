@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/G20-00/task-management-service-go/pkg/logger"
+)
+
+// StartJob runs fn every interval in a background goroutine until the returned function is
+// called. Errors are logged with the name of the job and do not stop it.
+func StartJob(name string, interval time.Duration, fn func() error) func() {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				RunJob(name, fn)
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// RunJob runs fn once and logs its error with the name of the job.
+func RunJob(name string, fn func() error) {
+	if err := fn(); err != nil {
+		logger.GetLogger().WithFields(map[string]interface{}{
+			"layer": "job",
+			"job":   name,
+			"error": err.Error(),
+		}).Error("Background job failed")
+	}
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStartJob_RunsUntilStopped(t *testing.T) {
+	var runs atomic.Int32
+	stop := StartJob("test", time.Millisecond, func() error {
+		runs.Add(1)
+		return errors.New("failed")
+	})
+
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if runs.Load() < 3 {
+		t.Fatalf("expected the job to keep running after an error, ran %d times", runs.Load())
+	}
+
+	stop()
+	time.Sleep(5 * time.Millisecond)
+	stopped := runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	if runs.Load() != stopped {
+		t.Errorf("expected the job to stop, ran %d more times", runs.Load()-stopped)
+	}
+}
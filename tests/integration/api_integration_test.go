@@ -55,14 +55,14 @@ func getTestDB(t *testing.T) *sql.DB {
 }
 
 func setupApp(t *testing.T) *fiber.App {
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: httpdelivery.ErrorHandler})
 	db := getTestDB(t)
 	taskRepo := repo.NewPostgresTaskRepository(db)
 	taskListRepo := repo.NewPostgresTaskListRepository(db)
 	taskService := taskusecase.NewService(taskRepo)
 	taskListService := tasklistusecase.NewService(taskListRepo)
 	taskHandler := httpdelivery.NewTaskHandler(taskService)
-	taskListHandler := httpdelivery.NewTaskListHandler(taskListService, taskService)
+	taskListHandler := httpdelivery.NewTaskListHandler(taskListService)
 
 	app.Post("/api/lists", httpdelivery.JWTMiddleware, taskListHandler.CreateTaskList)
 	app.Get("/api/lists/:id", httpdelivery.JWTMiddleware, taskListHandler.GetTaskList)
@@ -58,7 +58,7 @@ func TestPostgresTaskRepository_Create_Integration(t *testing.T) {
 		UpdatedAt:   now,
 	}
 
-	err = repo.Create(task)
+	err = repo.Create(task, "")
 	if err != nil {
 		t.Fatalf("Failed to create task: %v", err)
 	}
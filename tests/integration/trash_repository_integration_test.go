@@ -0,0 +1,93 @@
+package integration_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G20-00/task-management-service-go/internal/domain"
+	"github.com/G20-00/task-management-service-go/internal/infrastructure/repository"
+	"github.com/G20-00/task-management-service-go/internal/usecase/task"
+)
+
+func TestPostgresTrashRepository_PurgeParentWithLiveSubtask_Integration(t *testing.T) {
+	db := getTestDB(t)
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("Failed to close db: %v", err)
+		}
+	}()
+
+	cleanupTasks(t, db)
+
+	listID := "test-list-id"
+	now := time.Now()
+	if _, err := db.Exec(`INSERT INTO task_lists (id, name, created_at, updated_at) VALUES ($1, $2, $3, $4) ON CONFLICT (id) DO NOTHING`, listID, "Test List", now, now); err != nil {
+		t.Fatalf("Failed to create test list: %v", err)
+	}
+
+	parentID, childID := uuid.New().String(), uuid.New().String()
+	insert := `INSERT INTO tasks (id, list_id, title, description, status, priority, created_at, updated_at, parent_id, deleted_at)
+	           VALUES ($1, $2, $3, '', 'pending', 'medium', $4, $4, $5, $6)`
+	if _, err := db.Exec(insert, parentID, listID, "Parent", now, nil, now.Add(-48*time.Hour)); err != nil {
+		t.Fatalf("Failed to create parent task: %v", err)
+	}
+	if _, err := db.Exec(insert, childID, listID, "Child", now, parentID, nil); err != nil {
+		t.Fatalf("Failed to create child task: %v", err)
+	}
+
+	tasks, _, err := repository.NewPostgresTrashRepository(db).PurgeDeletedBefore(now.Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to purge: %v", err)
+	}
+	if tasks != 1 {
+		t.Errorf("Expected 1 purged task, got %d", tasks)
+	}
+
+	var parent sql.NullString
+	if err := db.QueryRow(`SELECT parent_id FROM tasks WHERE id = $1`, childID).Scan(&parent); err != nil {
+		t.Fatalf("Expected the child task to survive the purge: %v", err)
+	}
+	if parent.Valid {
+		t.Errorf("Expected the child task to be detached, got parent %s", parent.String)
+	}
+
+	cleanupTasks(t, db)
+}
+
+func TestCreateTask_InTrashedList_Integration(t *testing.T) {
+	db := getTestDB(t)
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("Failed to close db: %v", err)
+		}
+	}()
+
+	cleanupTasks(t, db)
+
+	listID := uuid.New().String()
+	now := time.Now()
+	if _, err := db.Exec(`INSERT INTO task_lists (id, name, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $3, $3)`, listID, "Trashed List", now); err != nil {
+		t.Fatalf("Failed to create trashed list: %v", err)
+	}
+
+	service := task.NewService(repository.NewPostgresTaskRepository(db))
+	if _, err := service.Create(listID, "Task", "", "medium", ""); !errors.Is(err, domain.ErrTaskListNotFound) {
+		t.Errorf("Expected task list not found, got %v", err)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM tasks WHERE list_id = $1`, listID).Scan(&count); err != nil {
+		t.Fatalf("Failed to count tasks: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected no task in the trashed list, got %d", count)
+	}
+
+	if _, err := db.Exec(`DELETE FROM task_lists WHERE id = $1`, listID); err != nil {
+		t.Errorf("Failed to delete trashed list: %v", err)
+	}
+}
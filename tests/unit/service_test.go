@@ -13,12 +13,12 @@ type MockRepository struct {
 	tasks []*domain.Task
 }
 
-func (m *MockRepository) Create(task *domain.Task) error {
+func (m *MockRepository) Create(task *domain.Task, _ string) error {
 	m.tasks = append(m.tasks, task)
 	return nil
 }
 
-func (m *MockRepository) GetAll() ([]*domain.Task, error) {
+func (m *MockRepository) GetAll(includeArchived bool) ([]*domain.Task, error) {
 	return m.tasks, nil
 }
 
@@ -31,27 +31,43 @@ func (m *MockRepository) GetByID(id string) (*domain.Task, error) {
 	return nil, nil
 }
 
-func (m *MockRepository) Update(task *domain.Task) error {
+func (m *MockRepository) Update(task *domain.Task, _ string) error {
 	return nil
 }
 
-func (m *MockRepository) Delete(id string) error {
+func (m *MockRepository) Delete(id, _ string) error {
 	return nil
 }
 
-func (m *MockRepository) GetByFilters(status, priority string) ([]*domain.Task, error) {
+func (m *MockRepository) GetByFilters(status, priority string, includeArchived bool) ([]*domain.Task, error) {
 	return m.tasks, nil
 }
 
+func (m *MockRepository) GetByListIDs(listIDs []string, includeArchived bool) ([]*domain.Task, error) {
+	return m.tasks, nil
+}
+
+func (m *MockRepository) List(filter domain.TaskFilter, page domain.PageRequest) (*domain.Page[*domain.Task], error) {
+	return &domain.Page[*domain.Task]{Items: m.tasks}, nil
+}
+
 func (m *MockRepository) CountByListIDAndStatus(listID, status string) (int, error) {
 	return 0, nil
 }
 
+func (m *MockRepository) IsListArchived(listID string) (bool, error) {
+	return false, nil
+}
+
+func (m *MockRepository) ApplyBulk(changes []domain.BulkChange, atomic bool, _ string) ([]error, error) {
+	return make([]error, len(changes)), nil
+}
+
 func TestCreateTask_Success(t *testing.T) {
 	repo := &MockRepository{}
 	service := taskusecase.NewService(repo)
 
-	task, err := service.Create("list-123", "Test Task", "Description", "high")
+	task, err := service.Create("list-123", "Test Task", "Description", "high", "")
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -70,7 +86,7 @@ func TestCreateTask_EmptyTitle(t *testing.T) {
 	repo := &MockRepository{}
 	service := taskusecase.NewService(repo)
 
-	_, err := service.Create("list-123", "", "Description", "high")
+	_, err := service.Create("list-123", "", "Description", "high", "")
 
 	if err == nil {
 		t.Error("Expected error for empty title, got nil")
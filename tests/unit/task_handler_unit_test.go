@@ -17,7 +17,7 @@ import (
 func TestTaskHandler_CreateTask_BadRequest(t *testing.T) {
 	svc := taskusecase.NewService(&mockRepo{})
 	h := httpdelivery.NewTaskHandler(svc)
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: httpdelivery.ErrorHandler})
 	app.Post("/tasks", h.CreateTask)
 	// Enviar body inválido
 	req := httptest.NewRequest("POST", "/tasks", strings.NewReader("{"))
@@ -34,7 +34,7 @@ func TestTaskHandler_CreateTask_BadRequest(t *testing.T) {
 func TestTaskHandler_CreateTask_TitleRequired(t *testing.T) {
 	svc := taskusecase.NewService(&mockRepo{})
 	h := httpdelivery.NewTaskHandler(svc)
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: httpdelivery.ErrorHandler})
 	app.Post("/tasks", h.CreateTask)
 	body := `{"list_id": "1"}`
 	req := httptest.NewRequest("POST", "/tasks", strings.NewReader(body))
@@ -53,7 +53,7 @@ func TestTaskHandler_CreateTask_ServiceError(t *testing.T) {
 		CreateFn: func(t *domain.Task) error { return errors.New("fail") },
 	})
 	h := httpdelivery.NewTaskHandler(svc)
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: httpdelivery.ErrorHandler})
 	app.Post("/tasks", h.CreateTask)
 	body := `{"list_id": "1", "title": "t"}`
 	req := httptest.NewRequest("POST", "/tasks", strings.NewReader(body))
@@ -19,7 +19,7 @@ func TestPostgresTaskRepository_Create_Error(t *testing.T) {
 	r := repo.NewPostgresTaskRepository(db)
 	mock.ExpectExec("INSERT INTO tasks").WillReturnError(errors.New("fail"))
 	task := &domain.Task{ID: "1", ListID: "1", Title: "t", Status: "pending", Priority: "medium"}
-	err = r.Create(task)
+	err = r.Create(task, "")
 	if err == nil {
 		t.Error("esperado error en Create")
 	}
@@ -31,7 +31,7 @@ func TestPostgresTaskRepository_GetByID_NotFound(t *testing.T) {
 		t.Fatalf("error creating sqlmock: %v", err)
 	}
 	r := repo.NewPostgresTaskRepository(db)
-	mock.ExpectQuery("SELECT id, list_id, title, description, status, priority, created_at, updated_at FROM tasks WHERE id = \\$1").
+	mock.ExpectQuery("SELECT id, list_id, title, description, status, priority, created_at, updated_at, archived_at FROM tasks WHERE id = \\$1").
 		WithArgs("no-task").WillReturnError(sql.ErrNoRows)
 	_, err = r.GetByID("no-task")
 	if err == nil {
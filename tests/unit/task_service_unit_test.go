@@ -16,12 +16,20 @@ type mockRepo struct {
 	DeleteFn                 func(string) error
 }
 
-func (m *mockRepo) Create(t *domain.Task) error                         { return m.CreateFn(t) }
-func (m *mockRepo) GetByID(id string) (*domain.Task, error)             { return m.GetByIDFn(id) }
-func (m *mockRepo) Update(t *domain.Task) error                         { return m.UpdateFn(t) }
-func (m *mockRepo) Delete(id string) error                              { return m.DeleteFn(id) }
-func (m *mockRepo) GetAll() ([]*domain.Task, error)                     { return nil, nil }
-func (m *mockRepo) GetByFilters(string, string) ([]*domain.Task, error) { return nil, nil }
+func (m *mockRepo) Create(t *domain.Task, _ string) error                     { return m.CreateFn(t) }
+func (m *mockRepo) GetByID(id string) (*domain.Task, error)                   { return m.GetByIDFn(id) }
+func (m *mockRepo) Update(t *domain.Task, _ string) error                     { return m.UpdateFn(t) }
+func (m *mockRepo) Delete(id, _ string) error                                 { return m.DeleteFn(id) }
+func (m *mockRepo) GetAll(bool) ([]*domain.Task, error)                       { return nil, nil }
+func (m *mockRepo) GetByFilters(string, string, bool) ([]*domain.Task, error) { return nil, nil }
+func (m *mockRepo) IsListArchived(string) (bool, error)                       { return false, nil }
+func (m *mockRepo) GetByListIDs([]string, bool) ([]*domain.Task, error)       { return nil, nil }
+func (m *mockRepo) ApplyBulk(changes []domain.BulkChange, _ bool, _ string) ([]error, error) {
+	return make([]error, len(changes)), nil
+}
+func (m *mockRepo) List(domain.TaskFilter, domain.PageRequest) (*domain.Page[*domain.Task], error) {
+	return &domain.Page[*domain.Task]{}, nil
+}
 func (m *mockRepo) CountByListIDAndStatus(listID, status string) (int, error) {
 	if m.CountByListIDAndStatusFn != nil {
 		return m.CountByListIDAndStatusFn(listID, status)
@@ -34,7 +42,7 @@ func TestService_Create_Success(t *testing.T) {
 		CreateFn: func(tk *domain.Task) error { return nil },
 	}
 	svc := taskusecase.NewService(repo)
-	task, err := svc.Create("list-1", "titulo", "desc", "medium")
+	task, err := svc.Create("list-1", "titulo", "desc", "medium", "")
 	if err != nil || task == nil {
 		t.Fatalf("esperado crear tarea sin error, obtuve %v", err)
 	}
@@ -43,7 +51,7 @@ func TestService_Create_Success(t *testing.T) {
 func TestService_Create_InvalidPriority(t *testing.T) {
 	repo := &mockRepo{CreateFn: func(tk *domain.Task) error { return nil }}
 	svc := taskusecase.NewService(repo)
-	_, err := svc.Create("list-1", "titulo", "desc", "super")
+	_, err := svc.Create("list-1", "titulo", "desc", "super", "")
 	if err == nil {
 		t.Error("esperado error por prioridad inválida")
 	}
@@ -55,7 +63,7 @@ func TestService_Update_NotFound(t *testing.T) {
 		UpdateFn:  func(tk *domain.Task) error { return nil },
 	}
 	svc := taskusecase.NewService(repo)
-	_, err := svc.Update("id", "list-1", "titulo", "desc", "pending", "medium")
+	_, err := svc.Update("id", "list-1", "titulo", "desc", "pending", "medium", "")
 	if err == nil {
 		t.Error("esperado error por tarea no encontrada")
 	}
@@ -67,7 +75,7 @@ func TestService_Update_InvalidStatus(t *testing.T) {
 		UpdateFn:  func(tk *domain.Task) error { return nil },
 	}
 	svc := taskusecase.NewService(repo)
-	_, err := svc.Update("id", "list-1", "titulo", "desc", "hecho", "medium")
+	_, err := svc.Update("id", "list-1", "titulo", "desc", "hecho", "medium", "")
 	if err == nil {
 		t.Error("esperado error por status inválido")
 	}